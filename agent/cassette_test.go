@@ -0,0 +1,123 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aschepis/backscratcher/staff/config"
+	"github.com/aschepis/backscratcher/staff/llm"
+	"github.com/aschepis/backscratcher/staff/llm/cassette"
+	"github.com/rs/zerolog"
+)
+
+// countingToolExecutor counts tool calls and returns a fixed result.
+type countingToolExecutor struct {
+	calls int
+}
+
+func (e *countingToolExecutor) Handle(ctx context.Context, toolName, agentID string, inputJSON []byte) (any, error) {
+	e.calls++
+	return map[string]any{"deadline": "Friday"}, nil
+}
+
+// fakeOpenAIServer answers chat completions with a tool call, then with text once the
+// tool result has been sent.
+func fakeOpenAIServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Messages []struct {
+				Role string `json:"role"`
+			} `json:"messages"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		message := `{"role":"assistant","content":null,"tool_calls":[{"id":"call_1","type":"function","function":{"name":"lookup","arguments":"{\"q\":\"deadline\"}"}}]}`
+		finish := "tool_calls"
+		if last := body.Messages[len(body.Messages)-1]; last.Role == "tool" {
+			message = `{"role":"assistant","content":"The deadline is Friday."}`
+			finish = "stop"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"chatcmpl-1","object":"chat.completion","created":1,"model":"gpt-4o-mini",` +
+			`"choices":[{"index":0,"message":` + message + `,"finish_reason":"` + finish + `"}],` +
+			`"usage":{"prompt_tokens":10,"completion_tokens":5,"total_tokens":15}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// cassetteClient resolves an OpenAI client through a crew that sends its traffic through transport.
+func cassetteClient(t *testing.T, providerConfig *llm.ProviderConfig, transport http.RoundTripper) llm.Client {
+	t.Helper()
+	registry := llm.NewProviderRegistry(providerConfig, []string{llm.ProviderOpenAI})
+	key, err := registry.ResolveAgentLLMConfig("tester", llm.AgentLLMConfig{
+		LLMPreferences: []llm.LLMPreference{{Provider: llm.ProviderOpenAI, Model: "gpt-4o-mini"}},
+	})
+	if err != nil {
+		t.Fatalf("ResolveAgentLLMConfig: %v", err)
+	}
+	crew := &Crew{
+		clientCache:  make(map[string]llm.Client),
+		llmTransport: transport,
+		logger:       zerolog.Nop(),
+	}
+	client, err := crew.getOrCreateClient(key, "tester", &config.AgentConfig{ID: "tester"})
+	if err != nil {
+		t.Fatalf("getOrCreateClient: %v", err)
+	}
+	return client
+}
+
+func TestToolLoop_ReplaysCassetteWithoutCredentials(t *testing.T) {
+	for _, name := range []string{"OPENAI_API_KEY", "OPENAI_BASE_URL", "OPENAI_MODEL", "OPENAI_ORG_ID"} {
+		t.Setenv(name, "")
+	}
+	dir := t.TempDir()
+	ctx := context.Background()
+	newRequest := func() *llm.Request {
+		return &llm.Request{Model: "gpt-4o-mini", Messages: []llm.Message{llm.NewTextMessage(llm.RoleUser, "When is the deadline?")}}
+	}
+
+	// Record a tool-loop turn against a fake provider
+	server := fakeOpenAIServer(t)
+	recorder, err := cassette.New(cassette.Config{Dir: dir, Mode: cassette.ModeRecord})
+	if err != nil {
+		t.Fatalf("cassette.New(record): %v", err)
+	}
+	recordClient := cassetteClient(t, &llm.ProviderConfig{OpenAIAPIKey: "test-key", OpenAIBaseURL: server.URL + "/v1"}, recorder)
+	recorded, err := executeToolLoop(ctx, recordClient, newRequest(), "tester", "thread", &countingToolExecutor{}, nil, nil, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	server.Close()
+
+	// Replay through the crew with no provider credentials or endpoint configured
+	player, err := cassette.New(cassette.Config{Dir: dir, Mode: cassette.ModeReplay})
+	if err != nil {
+		t.Fatalf("cassette.New(replay): %v", err)
+	}
+	client := cassetteClient(t, &llm.ProviderConfig{Replay: true}, player)
+
+	tools := &countingToolExecutor{}
+	replayed, err := executeToolLoop(ctx, client, newRequest(), "tester", "thread", tools, nil, nil, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if replayed != recorded || replayed != "The deadline is Friday." {
+		t.Errorf("replayed %q, recorded %q", replayed, recorded)
+	}
+	if tools.calls != 1 {
+		t.Errorf("expected the replayed tool call to run once, got %d", tools.calls)
+	}
+
+	// A request that was never recorded fails instead of reaching a provider
+	unrecorded := &llm.Request{Model: "gpt-4o-mini", Messages: []llm.Message{llm.NewTextMessage(llm.RoleUser, "Something else")}}
+	if _, err := executeToolLoop(ctx, client, unrecorded, "tester", "thread", tools, nil, nil, zerolog.Nop()); err == nil {
+		t.Error("expected an unrecorded request to fail")
+	}
+}
@@ -4,8 +4,21 @@ import (
 	"context"
 
 	ctxpkg "github.com/aschepis/backscratcher/staff/context"
+	"github.com/aschepis/backscratcher/staff/llm"
 )
 
+// attachmentsKey is the context key for media attached to the next user message.
+type attachmentsKey struct{}
+
+// reasoningCallbackKey is the context key for the ReasoningCallback.
+type reasoningCallbackKey struct{}
+
+// turnEventCallbackKey is the context key for the TurnEventCallback.
+type turnEventCallbackKey struct{}
+
+// chatTurnKey is the context key marking a turn the user started from chat.
+type chatTurnKey struct{}
+
 // WithDebugCallback adds a DebugCallback to the context
 func WithDebugCallback(ctx context.Context, cb DebugCallback) context.Context {
 	return ctxpkg.WithDebugCallback(ctx, cb)
@@ -17,3 +30,52 @@ func GetDebugCallback(ctx context.Context) (DebugCallback, bool) {
 	cb, ok := ctxpkg.GetDebugCallback(ctx)
 	return DebugCallback(cb), ok
 }
+
+// WithAttachments adds images or documents to the context. The runner attaches them to the
+// user message of the turn started with this context.
+func WithAttachments(ctx context.Context, attachments []llm.MediaBlock) context.Context {
+	return context.WithValue(ctx, attachmentsKey{}, attachments)
+}
+
+// GetAttachments retrieves attachments from the context.
+// Returns the attachments and a bool indicating if any were set.
+func GetAttachments(ctx context.Context) ([]llm.MediaBlock, bool) {
+	attachments, ok := ctx.Value(attachmentsKey{}).([]llm.MediaBlock)
+	return attachments, ok && len(attachments) > 0
+}
+
+// WithChatTurn marks the context as a turn the user started from chat. Turn hooks only run
+// for these turns, not for scheduled or system-initiated runs.
+func WithChatTurn(ctx context.Context) context.Context {
+	return context.WithValue(ctx, chatTurnKey{}, true)
+}
+
+// IsChatTurn reports whether the context was marked with WithChatTurn.
+func IsChatTurn(ctx context.Context) bool {
+	chat, _ := ctx.Value(chatTurnKey{}).(bool)
+	return chat
+}
+
+// WithReasoningCallback adds a ReasoningCallback to the context
+func WithReasoningCallback(ctx context.Context, cb ReasoningCallback) context.Context {
+	return context.WithValue(ctx, reasoningCallbackKey{}, cb)
+}
+
+// GetReasoningCallback retrieves a ReasoningCallback from the context.
+// Returns the callback and a bool indicating if it was set.
+func GetReasoningCallback(ctx context.Context) (ReasoningCallback, bool) {
+	cb, ok := ctx.Value(reasoningCallbackKey{}).(ReasoningCallback)
+	return cb, ok && cb != nil
+}
+
+// WithTurnEventCallback adds a TurnEventCallback to the context
+func WithTurnEventCallback(ctx context.Context, cb TurnEventCallback) context.Context {
+	return context.WithValue(ctx, turnEventCallbackKey{}, cb)
+}
+
+// GetTurnEventCallback retrieves a TurnEventCallback from the context.
+// Returns the callback and a bool indicating if it was set.
+func GetTurnEventCallback(ctx context.Context) (TurnEventCallback, bool) {
+	cb, ok := ctx.Value(turnEventCallbackKey{}).(TurnEventCallback)
+	return cb, ok && cb != nil
+}
@@ -6,10 +6,41 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/aschepis/backscratcher/staff/config"
 	"github.com/aschepis/backscratcher/staff/llm"
 	"github.com/rs/zerolog"
 )
 
+// Context compression strategies. An agent selects one via config.ContextCompressionConfig.
+const (
+	// CompressionStrategySummarize replaces the whole conversation with a single summary.
+	CompressionStrategySummarize = "summarize"
+	// CompressionStrategySlidingWindow summarizes only the older prefix of the conversation and
+	// keeps the most recent turns, unanswered tool calls and pinned messages verbatim.
+	CompressionStrategySlidingWindow = "sliding_window"
+)
+
+// DefaultKeepRecentTurns is the number of turns kept verbatim by the sliding window strategy
+// when the agent config does not specify one.
+const DefaultKeepRecentTurns = 4
+
+// CompressionSettings resolves the compression strategy and retained turn count for an agent.
+// Agents without a compression config use the summarize strategy.
+func CompressionSettings(cfg *config.AgentConfig) (strategy string, keepTurns int) {
+	if cfg == nil || cfg.Compression == nil || cfg.Compression.Strategy == "" {
+		return CompressionStrategySummarize, 0
+	}
+	strategy = cfg.Compression.Strategy
+	if strategy != CompressionStrategySlidingWindow {
+		return strategy, 0
+	}
+	keepTurns = cfg.Compression.KeepRecentTurns
+	if keepTurns <= 0 {
+		keepTurns = DefaultKeepRecentTurns
+	}
+	return strategy, keepTurns
+}
+
 // ContextManager handles context management operations like reset and compression.
 type ContextManager struct {
 	messagePersister MessagePersister
@@ -120,34 +151,241 @@ func (cm *ContextManager) CompressContext(
 	// Create system message content
 	systemMsg := map[string]interface{}{
 		"type":            "compress",
+		"strategy":        CompressionStrategySummarize,
 		"message":         fmt.Sprintf("Context compressed: %s", summary),
+		"summary":         summary,
 		"timestamp":       time.Now().Unix(),
 		"original_size":   originalSize,
 		"compressed_size": compressedSize,
 	}
 
-	contentJSON, err := json.Marshal(systemMsg)
+	if err := cm.appendCompressMarker(ctx, agentID, threadID, systemMsg); err != nil {
+		return "", err
+	}
+
+	cm.logger.Info().
+		Str("agent_id", agentID).
+		Str("thread_id", threadID).
+		Int("original_size", originalSize).
+		Int("compressed_size", compressedSize).
+		Msg("Context compressed")
+
+	return summary, nil
+}
+
+// CompressContextWindow summarizes everything except the last keepTurns turns and returns the
+// compressed message list. Pinned messages and tool calls whose results fall inside the retained
+// window (or that were never answered) are kept verbatim. The summary is prepended to the first
+// retained message. If there is nothing old enough to summarize, messages are returned unchanged
+// and no compression marker is written.
+func (cm *ContextManager) CompressContextWindow(
+	ctx context.Context,
+	agentID, threadID string,
+	systemPrompt string,
+	messages []llm.Message,
+	keepTurns int,
+	summarizer *MessageSummarizer,
+) ([]llm.Message, error) {
+	if keepTurns <= 0 {
+		keepTurns = DefaultKeepRecentTurns
+	}
+
+	older, pinned, recent := splitSlidingWindow(messages, keepTurns)
+	if len(older) == 0 {
+		cm.logger.Debug().
+			Str("agent_id", agentID).
+			Int("keep_turns", keepTurns).
+			Msg("Nothing older than the retained window, skipping compression")
+		return messages, nil
+	}
+
+	originalSize := GetContextSize(systemPrompt, messages)
+
+	summary, err := summarizer.SummarizeContext(ctx, systemPrompt, older)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal system message: %w", err)
+		return nil, fmt.Errorf("failed to summarize context: %w", err)
 	}
 
-	// Save the system message
-	if systemPersister, ok := cm.messagePersister.(interface {
-		AppendSystemMessage(ctx context.Context, agentID, threadID, content string, breakType string) error
-	}); ok {
-		if err := systemPersister.AppendSystemMessage(ctx, agentID, threadID, string(contentJSON), "compress"); err != nil {
-			return "", fmt.Errorf("failed to save system message: %w", err)
-		}
-	} else {
-		return "", fmt.Errorf("message persister does not support system messages")
+	compressed := buildWindowedContext(summary, pinned, recent)
+	compressedSize := GetContextSize(systemPrompt, compressed)
+
+	systemMsg := map[string]interface{}{
+		"type":            "compress",
+		"strategy":        CompressionStrategySlidingWindow,
+		"message":         fmt.Sprintf("Context compressed: %s", summary),
+		"summary":         summary,
+		"retained_turns":  keepTurns,
+		"timestamp":       time.Now().Unix(),
+		"original_size":   originalSize,
+		"compressed_size": compressedSize,
+	}
+
+	if err := cm.appendCompressMarker(ctx, agentID, threadID, systemMsg); err != nil {
+		return nil, err
 	}
 
 	cm.logger.Info().
 		Str("agent_id", agentID).
 		Str("thread_id", threadID).
+		Int("summarized_messages", len(older)).
+		Int("pinned_messages", len(pinned)).
+		Int("retained_messages", len(recent)).
 		Int("original_size", originalSize).
 		Int("compressed_size", compressedSize).
-		Msg("Context compressed")
+		Msg("Context compressed with sliding window")
 
-	return summary, nil
+	return compressed, nil
+}
+
+// appendCompressMarker persists a "compress" system message so that later history loads
+// start from the compressed context.
+func (cm *ContextManager) appendCompressMarker(ctx context.Context, agentID, threadID string, systemMsg map[string]interface{}) error {
+	contentJSON, err := json.Marshal(systemMsg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal system message: %w", err)
+	}
+
+	systemPersister, ok := cm.messagePersister.(interface {
+		AppendSystemMessage(ctx context.Context, agentID, threadID, content string, breakType string) error
+	})
+	if !ok {
+		return fmt.Errorf("message persister does not support system messages")
+	}
+	if err := systemPersister.AppendSystemMessage(ctx, agentID, threadID, string(contentJSON), "compress"); err != nil {
+		return fmt.Errorf("failed to save system message: %w", err)
+	}
+	return nil
+}
+
+// splitSlidingWindow partitions messages into the older prefix to summarize, pinned messages
+// from that prefix, and the recent window to keep verbatim.
+//
+// A turn starts at a user message carrying text (tool result messages continue the current turn).
+// The window is widened so that a tool_use block is never separated from its tool_result, and
+// tool_use blocks that were never answered stay in the window.
+func splitSlidingWindow(messages []llm.Message, keepTurns int) (older, pinned, recent []llm.Message) {
+	cut := 0
+	turns := 0
+	for i := len(messages) - 1; i >= 0; i-- {
+		if isTurnStart(messages[i]) {
+			turns++
+			if turns == keepTurns {
+				cut = i
+				break
+			}
+		}
+	}
+	if turns < keepTurns {
+		return nil, nil, messages
+	}
+
+	answered := make(map[string]bool)
+	for _, msg := range messages {
+		for _, block := range msg.Content {
+			if block.Type == llm.ContentBlockTypeToolResult && block.ToolResult != nil {
+				answered[block.ToolResult.ID] = true
+			}
+		}
+	}
+
+	for cut > 0 {
+		inWindow := make(map[string]bool)
+		for _, msg := range messages[cut:] {
+			for _, block := range msg.Content {
+				if block.Type == llm.ContentBlockTypeToolResult && block.ToolResult != nil {
+					inWindow[block.ToolResult.ID] = true
+				}
+			}
+		}
+
+		newCut := cut
+		for i := cut - 1; i >= 0; i-- {
+			for _, block := range messages[i].Content {
+				if block.Type != llm.ContentBlockTypeToolUse || block.ToolUse == nil {
+					continue
+				}
+				if inWindow[block.ToolUse.ID] || !answered[block.ToolUse.ID] {
+					newCut = i
+				}
+			}
+		}
+		if newCut == cut {
+			break
+		}
+		cut = newCut
+	}
+
+	for _, msg := range messages[:cut] {
+		if msg.Pinned {
+			pinned = append(pinned, msg)
+		} else {
+			older = append(older, msg)
+		}
+	}
+	return older, pinned, messages[cut:]
+}
+
+// isTurnStart reports whether msg is a user message that begins a new turn.
+func isTurnStart(msg llm.Message) bool {
+	if msg.Role != llm.RoleUser {
+		return false
+	}
+	hasText := false
+	for _, block := range msg.Content {
+		if block.Type == llm.ContentBlockTypeToolResult {
+			return false
+		}
+		if block.Type == llm.ContentBlockTypeText {
+			hasText = true
+		}
+	}
+	return hasText
+}
+
+// buildWindowedContext assembles the compressed context: the summary, then pinned messages, then
+// the retained window. The summary is merged into the first user message and adjacent user
+// messages are coalesced so roles keep alternating.
+func buildWindowedContext(summary string, pinned, recent []llm.Message) []llm.Message {
+	summaryBlock := llm.ContentBlock{
+		Type: llm.ContentBlockTypeText,
+		Text: fmt.Sprintf("Previous conversation summary: %s", summary),
+	}
+
+	kept := make([]llm.Message, 0, len(pinned)+len(recent)+1)
+	kept = append(kept, llm.Message{Role: llm.RoleUser, Content: []llm.ContentBlock{summaryBlock}})
+	for _, msg := range append(append([]llm.Message{}, pinned...), recent...) {
+		last := &kept[len(kept)-1]
+		if msg.Role == llm.RoleUser && last.Role == llm.RoleUser {
+			content := make([]llm.ContentBlock, 0, len(last.Content)+len(msg.Content))
+			// Tool results must lead a user message, so they go ahead of the merged text.
+			content = append(content, toolResultBlocks(msg.Content)...)
+			content = append(content, last.Content...)
+			content = append(content, nonToolResultBlocks(msg.Content)...)
+			last.Content = content
+			last.Pinned = last.Pinned || msg.Pinned
+			continue
+		}
+		kept = append(kept, msg)
+	}
+	return kept
+}
+
+func toolResultBlocks(blocks []llm.ContentBlock) []llm.ContentBlock {
+	var out []llm.ContentBlock
+	for _, block := range blocks {
+		if block.Type == llm.ContentBlockTypeToolResult {
+			out = append(out, block)
+		}
+	}
+	return out
+}
+
+func nonToolResultBlocks(blocks []llm.ContentBlock) []llm.ContentBlock {
+	var out []llm.ContentBlock
+	for _, block := range blocks {
+		if block.Type != llm.ContentBlockTypeToolResult {
+			out = append(out, block)
+		}
+	}
+	return out
 }
@@ -0,0 +1,192 @@
+package agent
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aschepis/backscratcher/staff/config"
+	"github.com/aschepis/backscratcher/staff/llm"
+)
+
+func toolUseMessage(id, name string) llm.Message {
+	return llm.Message{
+		Role: llm.RoleAssistant,
+		Content: []llm.ContentBlock{{
+			Type:    llm.ContentBlockTypeToolUse,
+			ToolUse: &llm.ToolUseBlock{ID: id, Name: name, Input: map[string]interface{}{}},
+		}},
+	}
+}
+
+func toolResultMessage(id, content string) llm.Message {
+	return llm.Message{
+		Role: llm.RoleUser,
+		Content: []llm.ContentBlock{{
+			Type:       llm.ContentBlockTypeToolResult,
+			ToolResult: &llm.ToolResultBlock{ID: id, Content: content},
+		}},
+	}
+}
+
+func TestCompressionSettings(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           *config.AgentConfig
+		wantStrategy  string
+		wantKeepTurns int
+	}{
+		{
+			name:         "nil config defaults to summarize",
+			cfg:          nil,
+			wantStrategy: CompressionStrategySummarize,
+		},
+		{
+			name:         "no compression block defaults to summarize",
+			cfg:          &config.AgentConfig{},
+			wantStrategy: CompressionStrategySummarize,
+		},
+		{
+			name: "sliding window uses default turn count",
+			cfg: &config.AgentConfig{
+				Compression: &config.ContextCompressionConfig{Strategy: CompressionStrategySlidingWindow},
+			},
+			wantStrategy:  CompressionStrategySlidingWindow,
+			wantKeepTurns: DefaultKeepRecentTurns,
+		},
+		{
+			name: "sliding window with explicit turn count",
+			cfg: &config.AgentConfig{
+				Compression: &config.ContextCompressionConfig{Strategy: CompressionStrategySlidingWindow, KeepRecentTurns: 2},
+			},
+			wantStrategy:  CompressionStrategySlidingWindow,
+			wantKeepTurns: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy, keepTurns := CompressionSettings(tt.cfg)
+			if strategy != tt.wantStrategy {
+				t.Errorf("strategy = %q, want %q", strategy, tt.wantStrategy)
+			}
+			if keepTurns != tt.wantKeepTurns {
+				t.Errorf("keepTurns = %d, want %d", keepTurns, tt.wantKeepTurns)
+			}
+		})
+	}
+}
+
+func TestSplitSlidingWindow_KeepsRecentTurns(t *testing.T) {
+	messages := []llm.Message{
+		llm.NewTextMessage(llm.RoleUser, "turn 1"),
+		llm.NewTextMessage(llm.RoleAssistant, "answer 1"),
+		llm.NewTextMessage(llm.RoleUser, "turn 2"),
+		toolUseMessage("tool-1", "read_file"),
+		toolResultMessage("tool-1", "contents"),
+		llm.NewTextMessage(llm.RoleAssistant, "answer 2"),
+		llm.NewTextMessage(llm.RoleUser, "turn 3"),
+		llm.NewTextMessage(llm.RoleAssistant, "answer 3"),
+	}
+
+	older, pinned, recent := splitSlidingWindow(messages, 2)
+
+	if len(older) != 2 {
+		t.Fatalf("expected 2 older messages, got %d", len(older))
+	}
+	if len(pinned) != 0 {
+		t.Fatalf("expected no pinned messages, got %d", len(pinned))
+	}
+	if len(recent) != 6 {
+		t.Fatalf("expected 6 recent messages, got %d", len(recent))
+	}
+	if recent[0].Content[0].Text != "turn 2" {
+		t.Errorf("expected window to start at turn 2, got %q", recent[0].Content[0].Text)
+	}
+}
+
+func TestSplitSlidingWindow_NotEnoughTurns(t *testing.T) {
+	messages := []llm.Message{
+		llm.NewTextMessage(llm.RoleUser, "only turn"),
+		llm.NewTextMessage(llm.RoleAssistant, "answer"),
+	}
+
+	older, _, recent := splitSlidingWindow(messages, 4)
+	if len(older) != 0 {
+		t.Errorf("expected nothing to summarize, got %d messages", len(older))
+	}
+	if len(recent) != len(messages) {
+		t.Errorf("expected all %d messages retained, got %d", len(messages), len(recent))
+	}
+}
+
+func TestSplitSlidingWindow_KeepsUnansweredToolUse(t *testing.T) {
+	messages := []llm.Message{
+		llm.NewTextMessage(llm.RoleUser, "turn 1"),
+		toolUseMessage("tool-orphan", "send_email"),
+		llm.NewTextMessage(llm.RoleUser, "turn 2"),
+		llm.NewTextMessage(llm.RoleAssistant, "answer 2"),
+	}
+
+	older, _, recent := splitSlidingWindow(messages, 1)
+
+	if len(older) != 1 {
+		t.Fatalf("expected only the first turn to be summarized, got %d messages", len(older))
+	}
+	if recent[0].Content[0].Type != llm.ContentBlockTypeToolUse {
+		t.Errorf("expected unanswered tool_use to be retained at the start of the window")
+	}
+}
+
+func TestSplitSlidingWindow_PreservesPinned(t *testing.T) {
+	pinnedMsg := llm.NewTextMessage(llm.RoleUser, "my account number is 42")
+	pinnedMsg.Pinned = true
+
+	messages := []llm.Message{
+		pinnedMsg,
+		llm.NewTextMessage(llm.RoleAssistant, "noted"),
+		llm.NewTextMessage(llm.RoleUser, "turn 2"),
+		llm.NewTextMessage(llm.RoleAssistant, "answer 2"),
+	}
+
+	older, pinned, recent := splitSlidingWindow(messages, 1)
+
+	if len(pinned) != 1 || pinned[0].Content[0].Text != "my account number is 42" {
+		t.Fatalf("expected pinned message to be preserved, got %+v", pinned)
+	}
+	if len(older) != 1 || older[0].Content[0].Text != "noted" {
+		t.Fatalf("expected only unpinned prefix to be summarized, got %+v", older)
+	}
+	if len(recent) != 2 {
+		t.Fatalf("expected 2 recent messages, got %d", len(recent))
+	}
+}
+
+func TestBuildWindowedContext_MergesSummaryIntoFirstUserMessage(t *testing.T) {
+	pinnedMsg := llm.NewTextMessage(llm.RoleUser, "pinned fact")
+	pinnedMsg.Pinned = true
+	recent := []llm.Message{
+		llm.NewTextMessage(llm.RoleUser, "turn 2"),
+		llm.NewTextMessage(llm.RoleAssistant, "answer 2"),
+	}
+
+	got := buildWindowedContext("earlier stuff", []llm.Message{pinnedMsg}, recent)
+
+	if len(got) != 2 {
+		t.Fatalf("expected 2 messages after merging, got %d", len(got))
+	}
+	if got[0].Role != llm.RoleUser || got[1].Role != llm.RoleAssistant {
+		t.Fatalf("expected user/assistant alternation, got %s/%s", got[0].Role, got[1].Role)
+	}
+	if !got[0].Pinned {
+		t.Errorf("expected merged message to stay pinned")
+	}
+
+	var texts []string
+	for _, block := range got[0].Content {
+		texts = append(texts, block.Text)
+	}
+	joined := strings.Join(texts, "|")
+	if joined != "Previous conversation summary: earlier stuff|pinned fact|turn 2" {
+		t.Errorf("unexpected merged content: %q", joined)
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net/http"
 	"sync"
 	"time"
 
 	"github.com/aschepis/backscratcher/staff/config"
 	"github.com/aschepis/backscratcher/staff/llm"
 	llmanthropic "github.com/aschepis/backscratcher/staff/llm/anthropic"
+	llmgemini "github.com/aschepis/backscratcher/staff/llm/gemini"
+	llmmock "github.com/aschepis/backscratcher/staff/llm/mock"
 	llmollama "github.com/aschepis/backscratcher/staff/llm/ollama"
 	llmopenai "github.com/aschepis/backscratcher/staff/llm/openai"
 	"github.com/aschepis/backscratcher/staff/mcp"
@@ -27,6 +30,9 @@ type Crew struct {
 	StatsManager      *StatsManager
 	messagePersister  MessagePersister   // Optional message persister
 	messageSummarizer *MessageSummarizer // Optional message summarizer
+	llmTransport      http.RoundTripper  // Optional transport for provider HTTP traffic
+	turnHooks         []TurnHook         // Called after every agent's completed turns
+	profileProvider   ProfileProvider    // Supplies the user profile to agents that include it
 
 	MCPServers map[string]*config.MCPServerConfig
 	MCPClients map[string]mcp.MCPClient
@@ -55,6 +61,28 @@ func WithMessageSummarizer(summarizer *MessageSummarizer) CrewOption {
 	}
 }
 
+// WithLLMTransport sends every provider client's HTTP requests through transport,
+// such as an LLM cassette that records or replays them.
+func WithLLMTransport(transport http.RoundTripper) CrewOption {
+	return func(c *Crew) {
+		c.llmTransport = transport
+	}
+}
+
+// WithTurnHook adds a hook that is called after any agent completes a chat turn.
+func WithTurnHook(hook TurnHook) CrewOption {
+	return func(c *Crew) {
+		c.turnHooks = append(c.turnHooks, hook)
+	}
+}
+
+// WithProfileProvider sets where agents configured with include_profile get the user profile.
+func WithProfileProvider(provider ProfileProvider) CrewOption {
+	return func(c *Crew) {
+		c.profileProvider = provider
+	}
+}
+
 func NewCrew(logger zerolog.Logger, apiKey string, db *sql.DB, opts ...CrewOption) *Crew {
 	if db == nil {
 		panic("database connection is required for Crew")
@@ -135,10 +163,11 @@ func (c *Crew) InitializeAgents(registry *llm.ProviderRegistry) error {
 		}
 		for i, pref := range cfg.LLM {
 			agentLLMConfig.LLMPreferences[i] = llm.LLMPreference{
-				Provider:    pref.Provider,
-				Model:       pref.Model,
-				Temperature: pref.Temperature,
-				APIKeyRef:   pref.APIKeyRef,
+				Provider:       pref.Provider,
+				Model:          pref.Model,
+				Temperature:    pref.Temperature,
+				APIKeyRef:      pref.APIKeyRef,
+				ThinkingBudget: pref.ThinkingBudget,
 			}
 		}
 
@@ -161,6 +190,10 @@ func (c *Crew) InitializeAgents(registry *llm.ProviderRegistry) error {
 		if err != nil {
 			return fmt.Errorf("failed to create runner for agent %s: %w", id, err)
 		}
+		for _, hook := range c.turnHooks {
+			runner.AddTurnHook(hook)
+		}
+		runner.SetProfileProvider(c.profileProvider)
 
 		// Now acquire lock only to store the runner
 		c.mu.Lock()
@@ -294,6 +327,10 @@ type StreamCallback func(text string) error
 // DebugCallback is called for debug information (tool invocations, API calls, etc.)
 type DebugCallback func(message string)
 
+// ReasoningCallback is called for each reasoning (extended thinking) delta received
+// from the streaming API. Reasoning is delivered separately from the answer text.
+type ReasoningCallback func(text string)
+
 // RunStream executes a single turn for an agent with streaming support.
 // debugCallback should be added to context using WithDebugCallback if needed.
 func (c *Crew) RunStream(
@@ -480,7 +517,7 @@ func (c *Crew) buildAgentInfoLocked(ag *Agent) *AgentInfo {
 // Clients are cached by ClientKey string representation to avoid creating duplicate clients.
 func (c *Crew) getOrCreateClient(key *llm.ClientKey, agentID string, agentConfig *config.AgentConfig) (llm.Client, error) {
 	// Create cache key from ClientKey
-	keyStr := fmt.Sprintf("%s:%s:%s:%s:%s:%s", key.Provider, key.Model, key.APIKey, key.Host, key.BaseURL, key.Organization)
+	keyStr := fmt.Sprintf("%s:%s:%s:%s:%s:%s:%s:%s:%t", key.Provider, key.Model, key.APIKey, key.Host, key.BaseURL, key.Organization, key.ScriptPath, key.AgentID, key.Replay)
 
 	// Check cache first with read lock
 	c.logger.Info().Msgf("Checking cache for client %s", keyStr)
@@ -493,51 +530,93 @@ func (c *Crew) getOrCreateClient(key *llm.ClientKey, agentID string, agentConfig
 	c.mu.RUnlock()
 
 	// Not in cache - create new base client (no lock held during creation)
+	var httpClient *http.Client
+	if c.llmTransport != nil {
+		httpClient = &http.Client{Transport: c.llmTransport}
+	}
+	baseClient, err := newProviderClient(key, httpClient, c.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the base client using double-checked locking pattern
+	c.mu.Lock()
+	// Double-check: another goroutine might have created it while we were creating
+	if existingClient, ok := c.clientCache[keyStr]; ok {
+		c.mu.Unlock()
+		// Use the existing client instead
+		return c.wrapClientWithMiddleware(existingClient, agentID, agentConfig), nil
+	}
+	c.clientCache[keyStr] = baseClient
+	c.mu.Unlock()
+
+	// Wrap with agent-specific middleware
+	return c.wrapClientWithMiddleware(baseClient, agentID, agentConfig), nil
+}
+
+// replayAPIKey stands in for provider credentials when replaying LLM cassettes, since
+// recorded requests are matched without their credentials.
+const replayAPIKey = "replay"
+
+// newProviderClient creates the LLM client for the provider in key. A nil httpClient
+// uses each provider's default.
+func newProviderClient(key *llm.ClientKey, httpClient *http.Client, logger zerolog.Logger) (llm.Client, error) {
 	var baseClient llm.Client
 	var err error
 
+	apiKey := key.APIKey
+	if apiKey == "" && key.Replay {
+		apiKey = replayAPIKey
+	}
+
 	switch key.Provider {
 	case llm.ProviderAnthropic:
-		if key.APIKey == "" {
+		if apiKey == "" {
 			return nil, fmt.Errorf("anthropic API key is required")
 		}
-		baseClient, err = llmanthropic.NewAnthropicClient(key.APIKey, c.logger)
+		baseClient, err = llmanthropic.NewAnthropicClient(apiKey, httpClient, logger)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create anthropic client: %w", err)
 		}
 
 	case llm.ProviderOllama:
-		baseClient, err = llmollama.NewOllamaClient(key.Host, key.Model)
+		baseClient, err = llmollama.NewOllamaClient(key.Host, key.Model, httpClient)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create ollama client: %w", err)
 		}
 
 	case llm.ProviderOpenAI:
-		if key.APIKey == "" {
+		if apiKey == "" {
 			return nil, fmt.Errorf("openai API key is required")
 		}
-		baseClient, err = llmopenai.NewOpenAIClient(key.APIKey, key.BaseURL, key.Model, key.Organization)
+		baseClient, err = llmopenai.NewOpenAIClient(apiKey, key.BaseURL, key.Model, key.Organization, httpClient)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create openai client: %w", err)
 		}
 
+	case llm.ProviderGemini:
+		if apiKey == "" {
+			return nil, fmt.Errorf("gemini API key is required")
+		}
+		baseClient, err = llmgemini.NewGeminiClient(apiKey, key.BaseURL, key.Model, httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gemini client: %w", err)
+		}
+
+	case llm.ProviderMock:
+		script, err := llmmock.LoadScript(key.ScriptPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load mock script: %w", err)
+		}
+		baseClient, err = llmmock.NewMockClient(script, key.AgentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create mock client: %w", err)
+		}
+
 	default:
 		return nil, fmt.Errorf("unknown provider: %s", key.Provider)
 	}
-
-	// Cache the base client using double-checked locking pattern
-	c.mu.Lock()
-	// Double-check: another goroutine might have created it while we were creating
-	if existingClient, ok := c.clientCache[keyStr]; ok {
-		c.mu.Unlock()
-		// Use the existing client instead
-		return c.wrapClientWithMiddleware(existingClient, agentID, agentConfig), nil
-	}
-	c.clientCache[keyStr] = baseClient
-	c.mu.Unlock()
-
-	// Wrap with agent-specific middleware
-	return c.wrapClientWithMiddleware(baseClient, agentID, agentConfig), nil
+	return baseClient, nil
 }
 
 // wrapClientWithMiddleware wraps a base client with agent-specific middleware.
@@ -560,7 +639,7 @@ func (c *Crew) wrapClientWithMiddleware(baseClient llm.Client, agentID string, a
 			c.messagePersister,
 			c.messageSummarizer,
 			agentID,
-			agentConfig.System,
+			agentConfig,
 		)
 		middleware = append(middleware, compressionMw)
 	}
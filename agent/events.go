@@ -0,0 +1,38 @@
+package agent
+
+import "github.com/aschepis/backscratcher/staff/llm"
+
+// TurnEventType identifies a structured event emitted while a streaming turn runs.
+type TurnEventType string
+
+const (
+	// TurnEventToolUse is emitted when the model starts a tool call.
+	TurnEventToolUse TurnEventType = "tool_use"
+	// TurnEventToolInput carries a fragment of a tool call's JSON input as it streams.
+	TurnEventToolInput TurnEventType = "tool_input"
+	// TurnEventToolResult is emitted after a tool has run.
+	TurnEventToolResult TurnEventType = "tool_result"
+	// TurnEventUsage reports token usage and the stop reason of one LLM call in the tool loop.
+	TurnEventUsage TurnEventType = "usage"
+)
+
+// TurnEvent is a structured event from the tool loop. Which fields are set depends on Type.
+type TurnEvent struct {
+	Type      TurnEventType
+	Iteration int // 1-based LLM call within the turn
+
+	// Tool events
+	ToolID    string
+	ToolName  string
+	ToolInput string // JSON fragment for tool_input events
+	Result    string // JSON-encoded result for tool_result events
+	IsError   bool
+
+	// Usage events
+	Usage      *llm.Usage
+	StopReason string
+}
+
+// TurnEventCallback is called for each structured event of a streaming turn, alongside
+// the text deltas delivered to StreamCallback.
+type TurnEventCallback func(event TurnEvent)
@@ -108,12 +108,15 @@ func (m *RateLimitMiddleware) OnStreamError(ctx context.Context, req *llm.Reques
 }
 
 // CompressionMiddleware handles automatic context compression.
+// The compression strategy comes from the agent's config (see CompressionSettings).
 type CompressionMiddleware struct {
 	logger            zerolog.Logger
 	messagePersister  MessagePersister
 	messageSummarizer *MessageSummarizer
 	agentID           string
 	systemPrompt      string
+	strategy          string
+	keepTurns         int
 }
 
 // NewCompressionMiddleware creates a new CompressionMiddleware.
@@ -122,14 +125,21 @@ func NewCompressionMiddleware(
 	messagePersister MessagePersister,
 	messageSummarizer *MessageSummarizer,
 	agentID string,
-	systemPrompt string,
+	agentConfig *config.AgentConfig,
 ) *CompressionMiddleware {
+	strategy, keepTurns := CompressionSettings(agentConfig)
+	var systemPrompt string
+	if agentConfig != nil {
+		systemPrompt = agentConfig.System
+	}
 	return &CompressionMiddleware{
 		logger:            logger.With().Str("component", "compressionMiddleware").Logger(),
 		messagePersister:  messagePersister,
 		messageSummarizer: messageSummarizer,
 		agentID:           agentID,
 		systemPrompt:      systemPrompt,
+		strategy:          strategy,
+		keepTurns:         keepTurns,
 	}
 }
 
@@ -202,6 +212,14 @@ func (m *CompressionMiddleware) compressContext(ctx context.Context, msgs []llm.
 
 	// Use ContextManager to compress
 	cm := NewContextManager(m.logger, m.messagePersister)
+	if m.strategy == CompressionStrategySlidingWindow {
+		compressed, err := cm.CompressContextWindow(ctx, m.agentID, threadID, m.systemPrompt, msgs, m.keepTurns, m.messageSummarizer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compress context: %w", err)
+		}
+		return compressed, nil
+	}
+
 	summary, err := cm.CompressContext(ctx, m.agentID, threadID, m.systemPrompt, msgs, m.messageSummarizer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to compress context: %w", err)
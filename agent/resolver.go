@@ -44,3 +44,21 @@ func ResolveLLMFromConfig(cfg *config.AgentConfig) ResolvedLLMInfo {
 		Model:    "",
 	}
 }
+
+// ThinkingBudgetFor returns the thinking budget of the LLM preference that the given
+// provider and model were resolved from, or 0 if no matching preference enables thinking.
+func ThinkingBudgetFor(cfg *config.AgentConfig, provider, model string) int64 {
+	if cfg == nil {
+		return 0
+	}
+	for _, pref := range cfg.LLM {
+		if pref.Provider != provider {
+			continue
+		}
+		// A preference without a model resolves to the provider default
+		if pref.Model == "" || pref.Model == model {
+			return pref.ThinkingBudget
+		}
+	}
+	return 0
+}
@@ -142,3 +142,64 @@ func TestResolveLLMFromConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestThinkingBudgetFor(t *testing.T) {
+	cfg := &config.AgentConfig{
+		LLM: []config.LLMPreference{
+			{Provider: llm.ProviderAnthropic, Model: "claude-sonnet-4-20250514", ThinkingBudget: 8000},
+			{Provider: llm.ProviderOllama, ThinkingBudget: 2048},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		cfg      *config.AgentConfig
+		provider string
+		model    string
+		expected int64
+	}{
+		{
+			name:     "matching provider and model",
+			cfg:      cfg,
+			provider: llm.ProviderAnthropic,
+			model:    "claude-sonnet-4-20250514",
+			expected: 8000,
+		},
+		{
+			name:     "preference without model matches any model",
+			cfg:      cfg,
+			provider: llm.ProviderOllama,
+			model:    "qwen3:8b",
+			expected: 2048,
+		},
+		{
+			name:     "model mismatch",
+			cfg:      cfg,
+			provider: llm.ProviderAnthropic,
+			model:    "claude-haiku-4-5",
+			expected: 0,
+		},
+		{
+			name:     "provider not in preferences",
+			cfg:      cfg,
+			provider: llm.ProviderOpenAI,
+			model:    "gpt-4",
+			expected: 0,
+		},
+		{
+			name:     "nil config",
+			cfg:      nil,
+			provider: llm.ProviderAnthropic,
+			model:    "claude-sonnet-4-20250514",
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ThinkingBudgetFor(tt.cfg, tt.provider, tt.model); got != tt.expected {
+				t.Errorf("ThinkingBudgetFor: got %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
@@ -26,6 +26,10 @@ type MessagePersister interface {
 	// AppendAssistantMessage saves an assistant text-only message to the conversation history.
 	AppendAssistantMessage(ctx context.Context, agentID, threadID, content string) error
 
+	// AppendReasoning saves the assistant's reasoning, kept separate from its answer text,
+	// with the provider signature if the reasoning was signed.
+	AppendReasoning(ctx context.Context, agentID, threadID, content, signature string) error
+
 	// AppendToolCall saves an assistant message with tool use blocks to the conversation history.
 	AppendToolCall(ctx context.Context, agentID, threadID, toolID, toolName string, toolInput any) error
 
@@ -45,9 +49,27 @@ type AgentRunner struct {
 	messagePersister  MessagePersister   // Optional message persister
 	messageSummarizer *MessageSummarizer // Optional message summarizer
 	rateLimitHandler  *RateLimitHandler  // Rate limit handler
+	turnHooks         []TurnHook         // Called after each completed turn
+	profileProvider   ProfileProvider    // Supplies the user profile for agents that include it
 	logger            zerolog.Logger
 }
 
+// ProfileProvider returns the user profile to append to system prompts, or "" if there is none.
+type ProfileProvider func(ctx context.Context) (string, error)
+
+// Turn is a completed exchange between the user and an agent.
+type Turn struct {
+	AgentID     string
+	ThreadID    string
+	UserMessage string
+	Response    string
+}
+
+// TurnHook is called after an agent completes a turn the user started from chat (see
+// WithChatTurn). Hooks run on the agent's goroutine before the turn returns, so slow work
+// should be handed off.
+type TurnHook func(ctx context.Context, turn Turn)
+
 // NewAgentRunner creates a new AgentRunner with all required dependencies.
 func NewAgentRunner(
 	logger zerolog.Logger,
@@ -98,6 +120,46 @@ func NewAgentRunner(
 	}, nil
 }
 
+// AddTurnHook registers a hook to call after each completed turn.
+func (r *AgentRunner) AddTurnHook(hook TurnHook) {
+	r.turnHooks = append(r.turnHooks, hook)
+}
+
+// SetProfileProvider sets where the user profile comes from for agents configured to
+// include it in their system prompt.
+func (r *AgentRunner) SetProfileProvider(provider ProfileProvider) {
+	r.profileProvider = provider
+}
+
+// systemPrompt returns the agent's system prompt, followed by the user profile if the
+// agent includes it.
+func (r *AgentRunner) systemPrompt(ctx context.Context) string {
+	system := r.agent.Config.System
+	if !r.agent.Config.IncludeProfile || r.profileProvider == nil {
+		return system
+	}
+	profile, err := r.profileProvider(ctx)
+	if err != nil {
+		r.logger.Warn().Err(err).Str("agent_id", r.agent.ID).Msg("Failed to load user profile for system prompt")
+		return system
+	}
+	if strings.TrimSpace(profile) == "" {
+		return system
+	}
+	return system + "\n\n<user_profile>\n" + strings.TrimSpace(profile) + "\n</user_profile>"
+}
+
+// runTurnHooks calls the turn hooks for a completed chat turn.
+func (r *AgentRunner) runTurnHooks(ctx context.Context, threadID, userMsg, response string) {
+	if !IsChatTurn(ctx) {
+		return
+	}
+	turn := Turn{AgentID: r.agent.ID, ThreadID: threadID, UserMessage: userMsg, Response: response}
+	for _, hook := range r.turnHooks {
+		hook(ctx, turn)
+	}
+}
+
 // GetResolvedModel returns the model resolved from LLM preferences.
 func (r *AgentRunner) GetResolvedModel() string {
 	return r.resolvedModel
@@ -158,7 +220,7 @@ func (r *AgentRunner) updateAgentStateAfterExecution(executionSuccessful bool, e
 }
 
 // RunAgent executes a single turn for an agent, with optional history.
-// debugCallback is retrieved from context if available.
+// debugCallback and attachments are retrieved from context if available.
 // History is provided as provider-neutral llm.Message types.
 func (r *AgentRunner) RunAgent(
 	ctx context.Context,
@@ -189,7 +251,9 @@ func (r *AgentRunner) RunAgent(
 	}()
 
 	// Prepare LLM request (history is already in llm.Message format)
-	req := prepareLLMRequest(r.agent, r.resolvedModel, userMsg, history, r.toolProvider)
+	attachments, _ := GetAttachments(ctx)
+	req := prepareLLMRequest(r.agent, r.resolvedModel, r.resolvedProvider, userMsg, attachments, history, r.toolProvider)
+	req.System = r.systemPrompt(ctx)
 
 	// Execute tool loop
 	result, err := executeToolLoop(
@@ -216,12 +280,13 @@ func (r *AgentRunner) RunAgent(
 	}
 
 	executionSuccessful = true
+	r.runTurnHooks(ctx, threadID, userMsg, result)
 	return result, nil
 }
 
 // RunAgentStream executes a single turn for an agent with streaming support.
 // It calls the callback function for each text delta received.
-// debugCallback is retrieved from context if available.
+// debugCallback, reasoningCallback and attachments are retrieved from context if available.
 func (r *AgentRunner) RunAgentStream(
 	ctx context.Context,
 	threadID string,
@@ -252,7 +317,9 @@ func (r *AgentRunner) RunAgentStream(
 	}()
 
 	// Prepare LLM request (history is already in llm.Message format)
-	req := prepareLLMRequest(r.agent, r.resolvedModel, userMsg, history, r.toolProvider)
+	attachments, _ := GetAttachments(ctx)
+	req := prepareLLMRequest(r.agent, r.resolvedModel, r.resolvedProvider, userMsg, attachments, history, r.toolProvider)
+	req.System = r.systemPrompt(ctx)
 
 	// Execute tool loop with streaming
 	result, err := executeToolLoopStream(
@@ -280,6 +347,7 @@ func (r *AgentRunner) RunAgentStream(
 	}
 
 	executionSuccessful = true
+	r.runTurnHooks(ctx, threadID, userMsg, result)
 	return result, nil
 }
 
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	ctxpkg "github.com/aschepis/backscratcher/staff/context"
 	"github.com/aschepis/backscratcher/staff/llm"
 	"github.com/aschepis/backscratcher/staff/ui/tui/debug"
 	"github.com/rs/zerolog"
@@ -31,7 +32,8 @@ type toolExecutionResult struct {
 	Result          any    // Original result for persistence
 	SummarizedJSON  string // JSON-serialized summarized result for LLM
 	IsError         bool
-	RepeatedFailure bool // True if this tool has failed too many times
+	RepeatedFailure bool             // True if this tool has failed too many times
+	Media           []llm.MediaBlock // Images or documents returned by the tool
 }
 
 // toolLoopContext holds shared context for tool loop execution.
@@ -84,8 +86,14 @@ func (tlc *toolLoopContext) executeSingleTool(toolUse *llm.ToolUseBlock) (*toolE
 	// Output debug info about tool call
 	debug.ChatMessage(tlc.ctx, fmt.Sprintf("🔧 Tool call detected: %s\nArguments: %s", toolUse.Name, string(raw)))
 
-	// Execute tool
-	result, callErr := tlc.toolExec.Handle(tlc.ctx, toolUse.Name, tlc.agentID, raw)
+	// Execute tool, telling it which call it serves so memories it stores can record their source
+	toolCtx := ctxpkg.WithToolCall(tlc.ctx, ctxpkg.ToolCall{
+		AgentID:  tlc.agentID,
+		ThreadID: tlc.threadID,
+		ToolID:   toolUse.ID,
+		ToolName: toolUse.Name,
+	})
+	result, callErr := tlc.toolExec.Handle(toolCtx, toolUse.Name, tlc.agentID, raw)
 
 	callKey := toolCallKey{
 		toolName: toolUse.Name,
@@ -115,11 +123,20 @@ func (tlc *toolLoopContext) executeSingleTool(toolUse *llm.ToolUseBlock) (*toolE
 		delete(tlc.repeatedFailures, callKey)
 	}
 
-	// Summarize result if needed (before marshaling to JSON)
-	summarizedResult, summarizeErr := summarizeToolResult(tlc.ctx, tlc.messageSummarizer, result)
-	if summarizeErr != nil {
-		tlc.logger.Warn().Err(summarizeErr).Msg("Failed to summarize tool result, using original")
-		summarizedResult = result
+	// Tools that return images or documents hand them over as media; the rest of the
+	// result is small metadata and is never summarized.
+	var media []llm.MediaBlock
+	summarizedResult := result
+	if mediaResult, ok := result.(llm.MediaToolResult); ok {
+		media = mediaResult.ToolResultMedia()
+	} else {
+		// Summarize result if needed (before marshaling to JSON)
+		var summarizeErr error
+		summarizedResult, summarizeErr = summarizeToolResult(tlc.ctx, tlc.messageSummarizer, result)
+		if summarizeErr != nil {
+			tlc.logger.Warn().Err(summarizeErr).Msg("Failed to summarize tool result, using original")
+			summarizedResult = result
+		}
 	}
 
 	// Marshal result to JSON string
@@ -143,9 +160,31 @@ func (tlc *toolLoopContext) executeSingleTool(toolUse *llm.ToolUseBlock) (*toolE
 		Result:         result,
 		SummarizedJSON: string(summarizedJSON),
 		IsError:        isError,
+		Media:          media,
 	}, nil
 }
 
+// persistReasoning persists reasoning blocks to storage, separately from the answer text.
+// Redacted reasoning is not stored. A signature without text is stored so it can be replayed.
+func (tlc *toolLoopContext) persistReasoning(contentBlocks []llm.ContentBlock) {
+	if tlc.messagePersister == nil {
+		return
+	}
+
+	for _, block := range contentBlocks {
+		if block.Type != llm.ContentBlockTypeReasoning || block.Reasoning == nil {
+			continue
+		}
+		if block.Reasoning.Text == "" && block.Reasoning.Signature == "" {
+			continue
+		}
+		if err := tlc.messagePersister.AppendReasoning(tlc.ctx, tlc.agentID, tlc.threadID,
+			block.Reasoning.Text, block.Reasoning.Signature); err != nil {
+			tlc.logger.Warn().Err(err).Msg("failed to persist reasoning")
+		}
+	}
+}
+
 // persistToolCalls persists tool calls to storage.
 func (tlc *toolLoopContext) persistToolCalls(contentBlocks []llm.ContentBlock) {
 	if tlc.messagePersister == nil {
@@ -202,6 +241,7 @@ func buildToolResultBlocks(results []*toolExecutionResult) []llm.ContentBlock {
 				ID:      result.ToolID,
 				Content: result.SummarizedJSON,
 				IsError: result.IsError,
+				Media:   result.Media,
 			},
 		}, true
 	})
@@ -230,25 +270,35 @@ func summarizeFinalText(ctx context.Context, summarizer *MessageSummarizer, text
 }
 
 // prepareLLMRequest converts agent config, history, and tools to an llm.Request.
+// Attachments (images or documents) are added to the user message after its text.
+// The thinking budget comes from the LLM preference the runner resolved to.
 func prepareLLMRequest(
 	agent *Agent,
 	resolvedModel string,
+	resolvedProvider string,
 	userMsg string,
+	attachments []llm.MediaBlock,
 	history []llm.Message,
 	toolProvider ToolProvider,
 ) *llm.Request {
 	toolSpecs := toolProvider.SpecsFor(agent.Config)
 
+	userMessage := llm.NewTextMessage(llm.RoleUser, userMsg)
+	for _, attachment := range attachments {
+		userMessage.Content = append(userMessage.Content, llm.NewMediaBlock(attachment))
+	}
+
 	messages := make([]llm.Message, 0, len(history)+1)
 	messages = append(messages, history...)
-	messages = append(messages, llm.NewTextMessage(llm.RoleUser, userMsg))
+	messages = append(messages, userMessage)
 
 	return &llm.Request{
-		Model:     resolvedModel,
-		Messages:  messages,
-		System:    agent.Config.System,
-		Tools:     toolSpecs,
-		MaxTokens: agent.Config.MaxTokens,
+		Model:          resolvedModel,
+		Messages:       messages,
+		System:         agent.Config.System,
+		Tools:          toolSpecs,
+		MaxTokens:      agent.Config.MaxTokens,
+		ThinkingBudget: ThinkingBudgetFor(agent.Config, resolvedProvider, resolvedModel),
 	}
 }
 
@@ -269,11 +319,12 @@ func executeToolLoop(
 
 	for iterationCount := 1; iterationCount <= maxIterations; iterationCount++ {
 		currentReq := &llm.Request{
-			Model:     req.Model,
-			Messages:  conversationHistory,
-			System:    req.System,
-			Tools:     req.Tools,
-			MaxTokens: req.MaxTokens,
+			Model:          req.Model,
+			Messages:       conversationHistory,
+			System:         req.System,
+			Tools:          req.Tools,
+			MaxTokens:      req.MaxTokens,
+			ThinkingBudget: req.ThinkingBudget,
 		}
 
 		debug.ChatMessage(ctx, fmt.Sprintf("🤖 Calling LLM (model: %s, messages: %d, tools: %d)",
@@ -308,11 +359,13 @@ func executeToolLoop(
 			}
 		}
 
-		// Add assistant message to conversation history
+		// Add assistant message to conversation history. Reasoning blocks stay in the
+		// message because some providers require them to be sent back with tool results.
 		conversationHistory = append(conversationHistory, llm.Message{
 			Role:    llm.RoleAssistant,
 			Content: resp.Content,
 		})
+		tlc.persistReasoning(resp.Content)
 
 		// If no tool calls, we're done
 		if len(toolResults) == 0 {
@@ -348,14 +401,16 @@ func executeToolLoopStream(
 ) (string, error) {
 	tlc := newToolLoopContext(ctx, agentID, threadID, toolExec, messagePersister, messageSummarizer, logger)
 	conversationHistory := req.Messages
+	turnEventCallback, _ := GetTurnEventCallback(ctx)
 
 	for iterationCount := 1; iterationCount <= maxIterations; iterationCount++ {
 		currentReq := &llm.Request{
-			Model:     req.Model,
-			Messages:  conversationHistory,
-			System:    req.System,
-			Tools:     req.Tools,
-			MaxTokens: req.MaxTokens,
+			Model:          req.Model,
+			Messages:       conversationHistory,
+			System:         req.System,
+			Tools:          req.Tools,
+			MaxTokens:      req.MaxTokens,
+			ThinkingBudget: req.ThinkingBudget,
 		}
 
 		debug.ChatMessage(ctx, fmt.Sprintf("🤖 Calling LLM stream (model: %s, messages: %d, tools: %d)",
@@ -371,6 +426,16 @@ func executeToolLoopStream(
 		toolUses := make(map[string]*llm.ToolUseBlock)         // Deduplicated by ID
 		toolInputBuilders := make(map[string]*strings.Builder) // Accumulate JSON input per tool ID
 		var currentToolID string                               // Track which tool is currently receiving input
+		var reasoningBlocks []*llm.ReasoningBlock              // Reasoning in the order it was received
+		var usage *llm.Usage
+		var stopReason string
+		reasoningCallback, _ := GetReasoningCallback(ctx)
+		emit := func(event TurnEvent) {
+			if turnEventCallback != nil {
+				event.Iteration = iterationCount
+				turnEventCallback(event)
+			}
+		}
 
 		// Process stream events
 		for stream.Next() {
@@ -404,20 +469,51 @@ func executeToolLoopStream(
 							toolUses[tu.ID] = &toolCopy
 							// Initialize input builder for this tool
 							toolInputBuilders[tu.ID] = &strings.Builder{}
+							emit(TurnEvent{Type: TurnEventToolUse, ToolID: tu.ID, ToolName: tu.Name})
 						}
 						// Track current tool for subsequent input deltas
 						currentToolID = tu.ID
 					}
+				case llm.StreamDeltaTypeReasoning:
+					if r := event.Delta.Reasoning; r != nil {
+						// A content_block event starts a new reasoning block; deltas extend the last one
+						if event.Type == llm.StreamEventTypeContentBlock || len(reasoningBlocks) == 0 {
+							reasoningBlocks = append(reasoningBlocks, &llm.ReasoningBlock{})
+						}
+						current := reasoningBlocks[len(reasoningBlocks)-1]
+						current.Text += r.Text
+						current.Signature += r.Signature
+						current.RedactedData += r.RedactedData
+						if reasoningCallback != nil && r.Text != "" {
+							reasoningCallback(r.Text)
+						}
+					}
 				case llm.StreamDeltaTypeToolInput:
 					// Accumulate tool input JSON delta
 					if currentToolID != "" {
 						if builder, ok := toolInputBuilders[currentToolID]; ok {
 							builder.WriteString(event.Delta.ToolInput)
+							emit(TurnEvent{
+								Type:      TurnEventToolInput,
+								ToolID:    currentToolID,
+								ToolName:  toolUses[currentToolID].Name,
+								ToolInput: event.Delta.ToolInput,
+							})
 						}
 					}
 				}
 
+			case llm.StreamEventTypeMessageDelta:
+				usage = event.Usage
+				stopReason = event.StopReason
+
 			case llm.StreamEventTypeStop:
+				if event.Usage != nil {
+					usage = event.Usage
+				}
+				if event.StopReason != "" {
+					stopReason = event.StopReason
+				}
 				goto streamDone
 			}
 		}
@@ -438,6 +534,7 @@ func executeToolLoopStream(
 			return "", err
 		}
 		_ = stream.Close()
+		emit(TurnEvent{Type: TurnEventUsage, Usage: usage, StopReason: stopReason})
 
 		// Execute collected tools
 		var toolResults []*toolExecutionResult
@@ -447,13 +544,26 @@ func executeToolLoopStream(
 			toolUsesSlice = append(toolUsesSlice, tu)
 			result, err := tlc.executeSingleTool(tu)
 			if err != nil && result != nil && result.RepeatedFailure {
+				emit(TurnEvent{Type: TurnEventToolResult, ToolID: tu.ID, ToolName: tu.Name, Result: err.Error(), IsError: true})
 				return "", err
 			}
 			if result != nil {
+				emit(TurnEvent{
+					Type:     TurnEventToolResult,
+					ToolID:   result.ToolID,
+					ToolName: result.ToolName,
+					Result:   result.SummarizedJSON,
+					IsError:  result.IsError,
+				})
 				toolResults = append(toolResults, result)
 			}
 		}
 
+		reasoningContent := lo.Map(reasoningBlocks, func(r *llm.ReasoningBlock, _ int) llm.ContentBlock {
+			return llm.NewReasoningContentBlock(*r)
+		})
+		tlc.persistReasoning(reasoningContent)
+
 		// If no tool calls, we're done
 		if len(toolResults) == 0 {
 			text := strings.TrimSpace(finalText.String())
@@ -465,7 +575,7 @@ func executeToolLoopStream(
 		}
 
 		// Build and persist assistant message with tool uses
-		assistantBlocks := lo.Map(toolUsesSlice, func(tu *llm.ToolUseBlock, _ int) llm.ContentBlock {
+		toolUseBlocks := lo.Map(toolUsesSlice, func(tu *llm.ToolUseBlock, _ int) llm.ContentBlock {
 			return llm.ContentBlock{
 				Type:    llm.ContentBlockTypeToolUse,
 				ToolUse: tu,
@@ -473,9 +583,11 @@ func executeToolLoopStream(
 		})
 
 		// Persist tool calls using the content blocks
-		tlc.persistToolCalls(assistantBlocks)
+		tlc.persistToolCalls(toolUseBlocks)
 		tlc.persistToolResults(toolResults)
 
+		// Reasoning must come before the tool uses it led to
+		assistantBlocks := append(reasoningContent, toolUseBlocks...)
 		conversationHistory = append(conversationHistory,
 			llm.Message{Role: llm.RoleAssistant, Content: assistantBlocks},
 			buildToolResultMessage(toolResults),
@@ -0,0 +1,92 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aschepis/backscratcher/staff/llm"
+	"github.com/aschepis/backscratcher/staff/llm/mock"
+	"github.com/rs/zerolog"
+)
+
+// stubToolExecutor returns a fixed result, or an error for the tool named "broken".
+type stubToolExecutor struct{}
+
+func (stubToolExecutor) Handle(ctx context.Context, toolName, agentID string, inputJSON []byte) (any, error) {
+	if toolName == "broken" {
+		return nil, errors.New("tool failed")
+	}
+	return map[string]any{"ok": true}, nil
+}
+
+func TestExecuteToolLoopStream_EmitsTurnEvents(t *testing.T) {
+	script, err := mock.ParseScript([]byte(`
+agents:
+  default:
+    - tool_calls:
+        - name: lookup
+          input: {q: "x"}
+    - tool_calls:
+        - name: broken
+    - text: "All done."
+`))
+	if err != nil {
+		t.Fatalf("ParseScript: %v", err)
+	}
+	client, err := mock.NewMockClient(script, "tester")
+	if err != nil {
+		t.Fatalf("NewMockClient: %v", err)
+	}
+
+	var events []TurnEvent
+	ctx := WithTurnEventCallback(context.Background(), func(event TurnEvent) {
+		events = append(events, event)
+	})
+	req := &llm.Request{Model: "mock", Messages: []llm.Message{llm.NewTextMessage(llm.RoleUser, "go")}}
+
+	result, err := executeToolLoopStream(ctx, client, req, "tester", "thread", stubToolExecutor{}, nil, nil, nil, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("executeToolLoopStream: %v", err)
+	}
+	if result != "All done." {
+		t.Errorf("result: got %q", result)
+	}
+
+	var types []TurnEventType
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	want := []TurnEventType{
+		TurnEventToolUse, TurnEventToolInput, TurnEventUsage, TurnEventToolResult,
+		TurnEventToolUse, TurnEventToolInput, TurnEventUsage, TurnEventToolResult,
+		TurnEventUsage,
+	}
+	if len(types) != len(want) {
+		t.Fatalf("event types: got %v, want %v", types, want)
+	}
+	for i := range want {
+		if types[i] != want[i] {
+			t.Fatalf("event types: got %v, want %v", types, want)
+		}
+	}
+
+	if events[0].ToolName != "lookup" || events[0].Iteration != 1 {
+		t.Errorf("tool use event: got %+v", events[0])
+	}
+	if events[1].ToolInput != `{"q":"x"}` {
+		t.Errorf("tool input event: got %q", events[1].ToolInput)
+	}
+	if events[2].StopReason != "tool_calls" || events[2].Usage == nil {
+		t.Errorf("usage event: got %+v", events[2])
+	}
+	if events[3].IsError || events[3].Result != `{"ok":true}` {
+		t.Errorf("tool result event: got %+v", events[3])
+	}
+	if !events[7].IsError || events[7].ToolName != "broken" {
+		t.Errorf("failed tool result event: got %+v", events[7])
+	}
+	if last := events[8]; last.StopReason != "stop" || last.Iteration != 3 {
+		t.Errorf("final usage event: got %+v", last)
+	}
+}
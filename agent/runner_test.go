@@ -0,0 +1,74 @@
+package agent
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/aschepis/backscratcher/staff/config"
+	"github.com/aschepis/backscratcher/staff/llm/mock"
+	"github.com/aschepis/backscratcher/staff/migrations"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/rs/zerolog"
+)
+
+func TestTurnHooks_OnlyRunForChatTurns(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close() //nolint:errcheck // Test cleanup
+	if err := migrations.RunMigrations(db, filepath.Join("..", "migrations"), zerolog.Nop()); err != nil {
+		t.Fatalf("RunMigrations: %v", err)
+	}
+
+	script, err := mock.ParseScript([]byte(`
+loop: true
+agents:
+  default:
+    - text: "Noted."
+`))
+	if err != nil {
+		t.Fatalf("ParseScript: %v", err)
+	}
+	client, err := mock.NewMockClient(script, "tester")
+	if err != nil {
+		t.Fatalf("NewMockClient: %v", err)
+	}
+
+	var turns []Turn
+	crew := NewCrew(zerolog.Nop(), "", db, WithTurnHook(func(ctx context.Context, turn Turn) {
+		turns = append(turns, turn)
+	}))
+	agentConfig := &config.AgentConfig{ID: "tester", Schedule: "@every 1h"}
+	runner := &AgentRunner{
+		llmClient:     client,
+		agent:         &Agent{ID: "tester", Config: agentConfig},
+		resolvedModel: "mock",
+		toolProvider:  crew.ToolProvider,
+		stateManager:  crew.StateManager,
+		statsManager:  crew.StatsManager,
+		logger:        zerolog.Nop(),
+	}
+	for _, hook := range crew.turnHooks {
+		runner.AddTurnHook(hook)
+	}
+	crew.Agents["tester"] = agentConfig
+	crew.Runners["tester"] = runner
+
+	// A scheduled wake-up runs the agent the way the scheduler does, without a chat turn
+	if _, err := crew.Run(context.Background(), "tester", "scheduled-1", "continue", nil); err != nil {
+		t.Fatalf("scheduled run: %v", err)
+	}
+	if len(turns) != 0 {
+		t.Fatalf("expected no turn hooks for a scheduled run, got %+v", turns)
+	}
+
+	if _, err := crew.Run(WithChatTurn(context.Background()), "tester", "thread-1", "I prefer tea", nil); err != nil {
+		t.Fatalf("chat run: %v", err)
+	}
+	if len(turns) != 1 || turns[0].ThreadID != "thread-1" || turns[0].UserMessage != "I prefer tea" || turns[0].Response != "Noted." {
+		t.Errorf("expected one hook call for the chat turn, got %+v", turns)
+	}
+}
@@ -2,6 +2,7 @@ package agent
 
 import (
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/aschepis/backscratcher/staff/config"
@@ -192,9 +193,12 @@ func (p *ToolProviderFromRegistry) GetAllSchemas() map[string]ToolSchema {
 	return result
 }
 
-// getAllToolNames returns all registered tool names
+// getAllToolNames returns all registered tool names, sorted so that pattern matches
+// (and the requests they end up in) come out in the same order every time
 func (p *ToolProviderFromRegistry) getAllToolNames() []string {
-	return lo.Keys(p.schemas)
+	names := lo.Keys(p.schemas)
+	slices.Sort(names)
+	return names
 }
 
 // expandToolPattern expands a tool pattern (with optional MCP server prefix) into matching tool names using regexp
@@ -28,6 +28,7 @@ type ChatRequest struct {
 	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
 	ThreadId      string                 `protobuf:"bytes,2,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"`
 	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Attachments   []*Attachment          `protobuf:"bytes,4,rep,name=attachments,proto3" json:"attachments,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -83,6 +84,74 @@ func (x *ChatRequest) GetMessage() string {
 	return ""
 }
 
+func (x *ChatRequest) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+// Attachment is an image or document sent along with a chat message.
+type Attachment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MediaType     string                 `protobuf:"bytes,1,opt,name=media_type,json=mediaType,proto3" json:"media_type,omitempty"` // MIME type, e.g. "image/png" or "application/pdf"
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"` // Optional file name for display
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_staff_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Attachment) GetMediaType() string {
+	if x != nil {
+		return x.MediaType
+	}
+	return ""
+}
+
+func (x *Attachment) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Attachment) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
 type ChatEvent struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Types that are valid to be assigned to Event:
@@ -92,6 +161,9 @@ type ChatEvent struct {
 	//	*ChatEvent_ToolResult
 	//	*ChatEvent_Complete
 	//	*ChatEvent_Error
+	//	*ChatEvent_ReasoningDelta
+	//	*ChatEvent_ToolInputDelta
+	//	*ChatEvent_Usage
 	Event         isChatEvent_Event `protobuf_oneof:"event"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -99,7 +171,7 @@ type ChatEvent struct {
 
 func (x *ChatEvent) Reset() {
 	*x = ChatEvent{}
-	mi := &file_staff_proto_msgTypes[1]
+	mi := &file_staff_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -111,7 +183,7 @@ func (x *ChatEvent) String() string {
 func (*ChatEvent) ProtoMessage() {}
 
 func (x *ChatEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[1]
+	mi := &file_staff_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -124,7 +196,7 @@ func (x *ChatEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChatEvent.ProtoReflect.Descriptor instead.
 func (*ChatEvent) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{1}
+	return file_staff_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *ChatEvent) GetEvent() isChatEvent_Event {
@@ -179,6 +251,33 @@ func (x *ChatEvent) GetError() *ChatError {
 	return nil
 }
 
+func (x *ChatEvent) GetReasoningDelta() *ReasoningDelta {
+	if x != nil {
+		if x, ok := x.Event.(*ChatEvent_ReasoningDelta); ok {
+			return x.ReasoningDelta
+		}
+	}
+	return nil
+}
+
+func (x *ChatEvent) GetToolInputDelta() *ToolInputDelta {
+	if x != nil {
+		if x, ok := x.Event.(*ChatEvent_ToolInputDelta); ok {
+			return x.ToolInputDelta
+		}
+	}
+	return nil
+}
+
+func (x *ChatEvent) GetUsage() *Usage {
+	if x != nil {
+		if x, ok := x.Event.(*ChatEvent_Usage); ok {
+			return x.Usage
+		}
+	}
+	return nil
+}
+
 type isChatEvent_Event interface {
 	isChatEvent_Event()
 }
@@ -203,6 +302,18 @@ type ChatEvent_Error struct {
 	Error *ChatError `protobuf:"bytes,5,opt,name=error,proto3,oneof"`
 }
 
+type ChatEvent_ReasoningDelta struct {
+	ReasoningDelta *ReasoningDelta `protobuf:"bytes,6,opt,name=reasoning_delta,json=reasoningDelta,proto3,oneof"`
+}
+
+type ChatEvent_ToolInputDelta struct {
+	ToolInputDelta *ToolInputDelta `protobuf:"bytes,7,opt,name=tool_input_delta,json=toolInputDelta,proto3,oneof"`
+}
+
+type ChatEvent_Usage struct {
+	Usage *Usage `protobuf:"bytes,8,opt,name=usage,proto3,oneof"`
+}
+
 func (*ChatEvent_TextDelta) isChatEvent_Event() {}
 
 func (*ChatEvent_ToolUse) isChatEvent_Event() {}
@@ -213,6 +324,12 @@ func (*ChatEvent_Complete) isChatEvent_Event() {}
 
 func (*ChatEvent_Error) isChatEvent_Event() {}
 
+func (*ChatEvent_ReasoningDelta) isChatEvent_Event() {}
+
+func (*ChatEvent_ToolInputDelta) isChatEvent_Event() {}
+
+func (*ChatEvent_Usage) isChatEvent_Event() {}
+
 type TextDelta struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
@@ -222,7 +339,7 @@ type TextDelta struct {
 
 func (x *TextDelta) Reset() {
 	*x = TextDelta{}
-	mi := &file_staff_proto_msgTypes[2]
+	mi := &file_staff_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -234,7 +351,7 @@ func (x *TextDelta) String() string {
 func (*TextDelta) ProtoMessage() {}
 
 func (x *TextDelta) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[2]
+	mi := &file_staff_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -247,7 +364,7 @@ func (x *TextDelta) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TextDelta.ProtoReflect.Descriptor instead.
 func (*TextDelta) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{2}
+	return file_staff_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *TextDelta) GetText() string {
@@ -257,6 +374,53 @@ func (x *TextDelta) GetText() string {
 	return ""
 }
 
+// ReasoningDelta carries the model's extended thinking, streamed separately from the answer.
+type ReasoningDelta struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReasoningDelta) Reset() {
+	*x = ReasoningDelta{}
+	mi := &file_staff_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReasoningDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReasoningDelta) ProtoMessage() {}
+
+func (x *ReasoningDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReasoningDelta.ProtoReflect.Descriptor instead.
+func (*ReasoningDelta) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReasoningDelta) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+// ToolUse is sent when the model starts a tool call. The input streams separately as
+// ToolInputDelta events, so input_json is usually empty.
 type ToolUse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ToolId        string                 `protobuf:"bytes,1,opt,name=tool_id,json=toolId,proto3" json:"tool_id,omitempty"`
@@ -268,7 +432,7 @@ type ToolUse struct {
 
 func (x *ToolUse) Reset() {
 	*x = ToolUse{}
-	mi := &file_staff_proto_msgTypes[3]
+	mi := &file_staff_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -280,7 +444,7 @@ func (x *ToolUse) String() string {
 func (*ToolUse) ProtoMessage() {}
 
 func (x *ToolUse) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[3]
+	mi := &file_staff_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -293,7 +457,7 @@ func (x *ToolUse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ToolUse.ProtoReflect.Descriptor instead.
 func (*ToolUse) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{3}
+	return file_staff_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ToolUse) GetToolId() string {
@@ -317,6 +481,59 @@ func (x *ToolUse) GetInputJson() string {
 	return ""
 }
 
+// ToolInputDelta carries a fragment of a tool call's JSON input.
+type ToolInputDelta struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ToolId        string                 `protobuf:"bytes,1,opt,name=tool_id,json=toolId,proto3" json:"tool_id,omitempty"`
+	PartialJson   string                 `protobuf:"bytes,2,opt,name=partial_json,json=partialJson,proto3" json:"partial_json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToolInputDelta) Reset() {
+	*x = ToolInputDelta{}
+	mi := &file_staff_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToolInputDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolInputDelta) ProtoMessage() {}
+
+func (x *ToolInputDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToolInputDelta.ProtoReflect.Descriptor instead.
+func (*ToolInputDelta) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ToolInputDelta) GetToolId() string {
+	if x != nil {
+		return x.ToolId
+	}
+	return ""
+}
+
+func (x *ToolInputDelta) GetPartialJson() string {
+	if x != nil {
+		return x.PartialJson
+	}
+	return ""
+}
+
 type ToolResult struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ToolId        string                 `protobuf:"bytes,1,opt,name=tool_id,json=toolId,proto3" json:"tool_id,omitempty"`
@@ -329,7 +546,7 @@ type ToolResult struct {
 
 func (x *ToolResult) Reset() {
 	*x = ToolResult{}
-	mi := &file_staff_proto_msgTypes[4]
+	mi := &file_staff_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -341,7 +558,7 @@ func (x *ToolResult) String() string {
 func (*ToolResult) ProtoMessage() {}
 
 func (x *ToolResult) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[4]
+	mi := &file_staff_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -354,7 +571,7 @@ func (x *ToolResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ToolResult.ProtoReflect.Descriptor instead.
 func (*ToolResult) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{4}
+	return file_staff_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ToolResult) GetToolId() string {
@@ -385,6 +602,91 @@ func (x *ToolResult) GetIsError() bool {
 	return false
 }
 
+// Usage reports tokens used by one LLM call. A turn with tool calls makes several.
+type Usage struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	Iteration                int32                  `protobuf:"varint,1,opt,name=iteration,proto3" json:"iteration,omitempty"`
+	InputTokens              int64                  `protobuf:"varint,2,opt,name=input_tokens,json=inputTokens,proto3" json:"input_tokens,omitempty"`
+	OutputTokens             int64                  `protobuf:"varint,3,opt,name=output_tokens,json=outputTokens,proto3" json:"output_tokens,omitempty"`
+	CacheCreationInputTokens int64                  `protobuf:"varint,4,opt,name=cache_creation_input_tokens,json=cacheCreationInputTokens,proto3" json:"cache_creation_input_tokens,omitempty"`
+	CacheReadInputTokens     int64                  `protobuf:"varint,5,opt,name=cache_read_input_tokens,json=cacheReadInputTokens,proto3" json:"cache_read_input_tokens,omitempty"`
+	StopReason               string                 `protobuf:"bytes,6,opt,name=stop_reason,json=stopReason,proto3" json:"stop_reason,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *Usage) Reset() {
+	*x = Usage{}
+	mi := &file_staff_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Usage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Usage) ProtoMessage() {}
+
+func (x *Usage) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Usage.ProtoReflect.Descriptor instead.
+func (*Usage) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Usage) GetIteration() int32 {
+	if x != nil {
+		return x.Iteration
+	}
+	return 0
+}
+
+func (x *Usage) GetInputTokens() int64 {
+	if x != nil {
+		return x.InputTokens
+	}
+	return 0
+}
+
+func (x *Usage) GetOutputTokens() int64 {
+	if x != nil {
+		return x.OutputTokens
+	}
+	return 0
+}
+
+func (x *Usage) GetCacheCreationInputTokens() int64 {
+	if x != nil {
+		return x.CacheCreationInputTokens
+	}
+	return 0
+}
+
+func (x *Usage) GetCacheReadInputTokens() int64 {
+	if x != nil {
+		return x.CacheReadInputTokens
+	}
+	return 0
+}
+
+func (x *Usage) GetStopReason() string {
+	if x != nil {
+		return x.StopReason
+	}
+	return ""
+}
+
 type ChatComplete struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	FullResponse  string                 `protobuf:"bytes,1,opt,name=full_response,json=fullResponse,proto3" json:"full_response,omitempty"`
@@ -395,7 +697,7 @@ type ChatComplete struct {
 
 func (x *ChatComplete) Reset() {
 	*x = ChatComplete{}
-	mi := &file_staff_proto_msgTypes[5]
+	mi := &file_staff_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -407,7 +709,7 @@ func (x *ChatComplete) String() string {
 func (*ChatComplete) ProtoMessage() {}
 
 func (x *ChatComplete) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[5]
+	mi := &file_staff_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -420,7 +722,7 @@ func (x *ChatComplete) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChatComplete.ProtoReflect.Descriptor instead.
 func (*ChatComplete) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{5}
+	return file_staff_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ChatComplete) GetFullResponse() string {
@@ -447,7 +749,7 @@ type ChatError struct {
 
 func (x *ChatError) Reset() {
 	*x = ChatError{}
-	mi := &file_staff_proto_msgTypes[6]
+	mi := &file_staff_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -459,7 +761,7 @@ func (x *ChatError) String() string {
 func (*ChatError) ProtoMessage() {}
 
 func (x *ChatError) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[6]
+	mi := &file_staff_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -472,7 +774,7 @@ func (x *ChatError) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChatError.ProtoReflect.Descriptor instead.
 func (*ChatError) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{6}
+	return file_staff_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ChatError) GetMessage() string {
@@ -498,7 +800,7 @@ type GetThreadRequest struct {
 
 func (x *GetThreadRequest) Reset() {
 	*x = GetThreadRequest{}
-	mi := &file_staff_proto_msgTypes[7]
+	mi := &file_staff_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -510,7 +812,7 @@ func (x *GetThreadRequest) String() string {
 func (*GetThreadRequest) ProtoMessage() {}
 
 func (x *GetThreadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[7]
+	mi := &file_staff_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -523,7 +825,7 @@ func (x *GetThreadRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetThreadRequest.ProtoReflect.Descriptor instead.
 func (*GetThreadRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{7}
+	return file_staff_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetThreadRequest) GetAgentId() string {
@@ -543,7 +845,7 @@ type GetThreadResponse struct {
 
 func (x *GetThreadResponse) Reset() {
 	*x = GetThreadResponse{}
-	mi := &file_staff_proto_msgTypes[8]
+	mi := &file_staff_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -555,7 +857,7 @@ func (x *GetThreadResponse) String() string {
 func (*GetThreadResponse) ProtoMessage() {}
 
 func (x *GetThreadResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[8]
+	mi := &file_staff_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -568,7 +870,7 @@ func (x *GetThreadResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetThreadResponse.ProtoReflect.Descriptor instead.
 func (*GetThreadResponse) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{8}
+	return file_staff_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetThreadResponse) GetThreadId() string {
@@ -596,7 +898,7 @@ type LoadHistoryRequest struct {
 
 func (x *LoadHistoryRequest) Reset() {
 	*x = LoadHistoryRequest{}
-	mi := &file_staff_proto_msgTypes[9]
+	mi := &file_staff_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -608,7 +910,7 @@ func (x *LoadHistoryRequest) String() string {
 func (*LoadHistoryRequest) ProtoMessage() {}
 
 func (x *LoadHistoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[9]
+	mi := &file_staff_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -621,7 +923,7 @@ func (x *LoadHistoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoadHistoryRequest.ProtoReflect.Descriptor instead.
 func (*LoadHistoryRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{9}
+	return file_staff_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *LoadHistoryRequest) GetAgentId() string {
@@ -654,7 +956,7 @@ type LoadHistoryResponse struct {
 
 func (x *LoadHistoryResponse) Reset() {
 	*x = LoadHistoryResponse{}
-	mi := &file_staff_proto_msgTypes[10]
+	mi := &file_staff_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -666,7 +968,7 @@ func (x *LoadHistoryResponse) String() string {
 func (*LoadHistoryResponse) ProtoMessage() {}
 
 func (x *LoadHistoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[10]
+	mi := &file_staff_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -679,7 +981,7 @@ func (x *LoadHistoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoadHistoryResponse.ProtoReflect.Descriptor instead.
 func (*LoadHistoryResponse) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{10}
+	return file_staff_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *LoadHistoryResponse) GetMessages() []*Message {
@@ -696,13 +998,14 @@ type Message struct {
 	ToolId        string                 `protobuf:"bytes,3,opt,name=tool_id,json=toolId,proto3" json:"tool_id,omitempty"`
 	ToolName      string                 `protobuf:"bytes,4,opt,name=tool_name,json=toolName,proto3" json:"tool_name,omitempty"`
 	Timestamp     int64                  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Reasoning     string                 `protobuf:"bytes,6,opt,name=reasoning,proto3" json:"reasoning,omitempty"` // Assistant reasoning, kept separate from content
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Message) Reset() {
 	*x = Message{}
-	mi := &file_staff_proto_msgTypes[11]
+	mi := &file_staff_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -714,7 +1017,7 @@ func (x *Message) String() string {
 func (*Message) ProtoMessage() {}
 
 func (x *Message) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[11]
+	mi := &file_staff_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -727,7 +1030,7 @@ func (x *Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Message.ProtoReflect.Descriptor instead.
 func (*Message) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{11}
+	return file_staff_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *Message) GetRole() string {
@@ -765,6 +1068,13 @@ func (x *Message) GetTimestamp() int64 {
 	return 0
 }
 
+func (x *Message) GetReasoning() string {
+	if x != nil {
+		return x.Reasoning
+	}
+	return ""
+}
+
 type ContextRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
@@ -775,7 +1085,7 @@ type ContextRequest struct {
 
 func (x *ContextRequest) Reset() {
 	*x = ContextRequest{}
-	mi := &file_staff_proto_msgTypes[12]
+	mi := &file_staff_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -787,7 +1097,7 @@ func (x *ContextRequest) String() string {
 func (*ContextRequest) ProtoMessage() {}
 
 func (x *ContextRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[12]
+	mi := &file_staff_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -800,7 +1110,7 @@ func (x *ContextRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ContextRequest.ProtoReflect.Descriptor instead.
 func (*ContextRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{12}
+	return file_staff_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *ContextRequest) GetAgentId() string {
@@ -827,7 +1137,7 @@ type ContextResponse struct {
 
 func (x *ContextResponse) Reset() {
 	*x = ContextResponse{}
-	mi := &file_staff_proto_msgTypes[13]
+	mi := &file_staff_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -839,7 +1149,7 @@ func (x *ContextResponse) String() string {
 func (*ContextResponse) ProtoMessage() {}
 
 func (x *ContextResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[13]
+	mi := &file_staff_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -852,7 +1162,7 @@ func (x *ContextResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ContextResponse.ProtoReflect.Descriptor instead.
 func (*ContextResponse) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{13}
+	return file_staff_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ContextResponse) GetSuccess() bool {
@@ -869,6 +1179,50 @@ func (x *ContextResponse) GetMessage() string {
 	return ""
 }
 
+type PinMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinMessageResponse) Reset() {
+	*x = PinMessageResponse{}
+	mi := &file_staff_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinMessageResponse) ProtoMessage() {}
+
+func (x *PinMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinMessageResponse.ProtoReflect.Descriptor instead.
+func (*PinMessageResponse) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PinMessageResponse) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
 type ListAgentsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -877,7 +1231,7 @@ type ListAgentsRequest struct {
 
 func (x *ListAgentsRequest) Reset() {
 	*x = ListAgentsRequest{}
-	mi := &file_staff_proto_msgTypes[14]
+	mi := &file_staff_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -889,7 +1243,7 @@ func (x *ListAgentsRequest) String() string {
 func (*ListAgentsRequest) ProtoMessage() {}
 
 func (x *ListAgentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[14]
+	mi := &file_staff_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -902,7 +1256,7 @@ func (x *ListAgentsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAgentsRequest.ProtoReflect.Descriptor instead.
 func (*ListAgentsRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{14}
+	return file_staff_proto_rawDescGZIP(), []int{19}
 }
 
 type ListAgentsResponse struct {
@@ -914,7 +1268,7 @@ type ListAgentsResponse struct {
 
 func (x *ListAgentsResponse) Reset() {
 	*x = ListAgentsResponse{}
-	mi := &file_staff_proto_msgTypes[15]
+	mi := &file_staff_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -926,7 +1280,7 @@ func (x *ListAgentsResponse) String() string {
 func (*ListAgentsResponse) ProtoMessage() {}
 
 func (x *ListAgentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[15]
+	mi := &file_staff_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -939,7 +1293,7 @@ func (x *ListAgentsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAgentsResponse.ProtoReflect.Descriptor instead.
 func (*ListAgentsResponse) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{15}
+	return file_staff_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *ListAgentsResponse) GetAgents() []*Agent {
@@ -966,7 +1320,7 @@ type Agent struct {
 
 func (x *Agent) Reset() {
 	*x = Agent{}
-	mi := &file_staff_proto_msgTypes[16]
+	mi := &file_staff_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -978,7 +1332,7 @@ func (x *Agent) String() string {
 func (*Agent) ProtoMessage() {}
 
 func (x *Agent) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[16]
+	mi := &file_staff_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -991,7 +1345,7 @@ func (x *Agent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Agent.ProtoReflect.Descriptor instead.
 func (*Agent) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{16}
+	return file_staff_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *Agent) GetId() string {
@@ -1066,7 +1420,7 @@ type GetAgentRequest struct {
 
 func (x *GetAgentRequest) Reset() {
 	*x = GetAgentRequest{}
-	mi := &file_staff_proto_msgTypes[17]
+	mi := &file_staff_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1078,7 +1432,7 @@ func (x *GetAgentRequest) String() string {
 func (*GetAgentRequest) ProtoMessage() {}
 
 func (x *GetAgentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[17]
+	mi := &file_staff_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1091,7 +1445,7 @@ func (x *GetAgentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAgentRequest.ProtoReflect.Descriptor instead.
 func (*GetAgentRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{17}
+	return file_staff_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *GetAgentRequest) GetAgentId() string {
@@ -1110,7 +1464,7 @@ type GetAgentStateRequest struct {
 
 func (x *GetAgentStateRequest) Reset() {
 	*x = GetAgentStateRequest{}
-	mi := &file_staff_proto_msgTypes[18]
+	mi := &file_staff_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1122,7 +1476,7 @@ func (x *GetAgentStateRequest) String() string {
 func (*GetAgentStateRequest) ProtoMessage() {}
 
 func (x *GetAgentStateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[18]
+	mi := &file_staff_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1135,7 +1489,7 @@ func (x *GetAgentStateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAgentStateRequest.ProtoReflect.Descriptor instead.
 func (*GetAgentStateRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{18}
+	return file_staff_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *GetAgentStateRequest) GetAgentId() string {
@@ -1157,7 +1511,7 @@ type AgentState struct {
 
 func (x *AgentState) Reset() {
 	*x = AgentState{}
-	mi := &file_staff_proto_msgTypes[19]
+	mi := &file_staff_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1169,7 +1523,7 @@ func (x *AgentState) String() string {
 func (*AgentState) ProtoMessage() {}
 
 func (x *AgentState) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[19]
+	mi := &file_staff_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1182,7 +1536,7 @@ func (x *AgentState) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AgentState.ProtoReflect.Descriptor instead.
 func (*AgentState) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{19}
+	return file_staff_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *AgentState) GetAgentId() string {
@@ -1222,7 +1576,7 @@ type GetAgentStatsRequest struct {
 
 func (x *GetAgentStatsRequest) Reset() {
 	*x = GetAgentStatsRequest{}
-	mi := &file_staff_proto_msgTypes[20]
+	mi := &file_staff_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1234,7 +1588,7 @@ func (x *GetAgentStatsRequest) String() string {
 func (*GetAgentStatsRequest) ProtoMessage() {}
 
 func (x *GetAgentStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[20]
+	mi := &file_staff_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1247,7 +1601,7 @@ func (x *GetAgentStatsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAgentStatsRequest.ProtoReflect.Descriptor instead.
 func (*GetAgentStatsRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{20}
+	return file_staff_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *GetAgentStatsRequest) GetAgentId() string {
@@ -1272,7 +1626,7 @@ type AgentStats struct {
 
 func (x *AgentStats) Reset() {
 	*x = AgentStats{}
-	mi := &file_staff_proto_msgTypes[21]
+	mi := &file_staff_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1284,7 +1638,7 @@ func (x *AgentStats) String() string {
 func (*AgentStats) ProtoMessage() {}
 
 func (x *AgentStats) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[21]
+	mi := &file_staff_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1297,7 +1651,7 @@ func (x *AgentStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AgentStats.ProtoReflect.Descriptor instead.
 func (*AgentStats) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{21}
+	return file_staff_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *AgentStats) GetAgentId() string {
@@ -1358,7 +1712,7 @@ type WatchStatesRequest struct {
 
 func (x *WatchStatesRequest) Reset() {
 	*x = WatchStatesRequest{}
-	mi := &file_staff_proto_msgTypes[22]
+	mi := &file_staff_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1370,7 +1724,7 @@ func (x *WatchStatesRequest) String() string {
 func (*WatchStatesRequest) ProtoMessage() {}
 
 func (x *WatchStatesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[22]
+	mi := &file_staff_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1383,7 +1737,7 @@ func (x *WatchStatesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WatchStatesRequest.ProtoReflect.Descriptor instead.
 func (*WatchStatesRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{22}
+	return file_staff_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *WatchStatesRequest) GetAgentIds() []string {
@@ -1402,7 +1756,7 @@ type ListInboxRequest struct {
 
 func (x *ListInboxRequest) Reset() {
 	*x = ListInboxRequest{}
-	mi := &file_staff_proto_msgTypes[23]
+	mi := &file_staff_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1414,7 +1768,7 @@ func (x *ListInboxRequest) String() string {
 func (*ListInboxRequest) ProtoMessage() {}
 
 func (x *ListInboxRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[23]
+	mi := &file_staff_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1427,7 +1781,7 @@ func (x *ListInboxRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListInboxRequest.ProtoReflect.Descriptor instead.
 func (*ListInboxRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{23}
+	return file_staff_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *ListInboxRequest) GetIncludeArchived() bool {
@@ -1446,7 +1800,7 @@ type ListInboxResponse struct {
 
 func (x *ListInboxResponse) Reset() {
 	*x = ListInboxResponse{}
-	mi := &file_staff_proto_msgTypes[24]
+	mi := &file_staff_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1458,7 +1812,7 @@ func (x *ListInboxResponse) String() string {
 func (*ListInboxResponse) ProtoMessage() {}
 
 func (x *ListInboxResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[24]
+	mi := &file_staff_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1471,7 +1825,7 @@ func (x *ListInboxResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListInboxResponse.ProtoReflect.Descriptor instead.
 func (*ListInboxResponse) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{24}
+	return file_staff_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *ListInboxResponse) GetItems() []*InboxItem {
@@ -1493,13 +1847,14 @@ type InboxItem struct {
 	ArchivedAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"`
 	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	PendingFact      string                 `protobuf:"bytes,11,opt,name=pending_fact,json=pendingFact,proto3" json:"pending_fact,omitempty"` // Extracted fact waiting for confirmation, if any
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
 
 func (x *InboxItem) Reset() {
 	*x = InboxItem{}
-	mi := &file_staff_proto_msgTypes[25]
+	mi := &file_staff_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1511,7 +1866,7 @@ func (x *InboxItem) String() string {
 func (*InboxItem) ProtoMessage() {}
 
 func (x *InboxItem) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[25]
+	mi := &file_staff_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1524,7 +1879,7 @@ func (x *InboxItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InboxItem.ProtoReflect.Descriptor instead.
 func (*InboxItem) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{25}
+	return file_staff_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *InboxItem) GetId() int64 {
@@ -1597,6 +1952,13 @@ func (x *InboxItem) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *InboxItem) GetPendingFact() string {
+	if x != nil {
+		return x.PendingFact
+	}
+	return ""
+}
+
 type ArchiveRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	InboxId       int64                  `protobuf:"varint,1,opt,name=inbox_id,json=inboxId,proto3" json:"inbox_id,omitempty"`
@@ -1606,7 +1968,7 @@ type ArchiveRequest struct {
 
 func (x *ArchiveRequest) Reset() {
 	*x = ArchiveRequest{}
-	mi := &file_staff_proto_msgTypes[26]
+	mi := &file_staff_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1618,7 +1980,7 @@ func (x *ArchiveRequest) String() string {
 func (*ArchiveRequest) ProtoMessage() {}
 
 func (x *ArchiveRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[26]
+	mi := &file_staff_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1631,7 +1993,7 @@ func (x *ArchiveRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ArchiveRequest.ProtoReflect.Descriptor instead.
 func (*ArchiveRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{26}
+	return file_staff_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *ArchiveRequest) GetInboxId() int64 {
@@ -1650,7 +2012,7 @@ type ArchiveResponse struct {
 
 func (x *ArchiveResponse) Reset() {
 	*x = ArchiveResponse{}
-	mi := &file_staff_proto_msgTypes[27]
+	mi := &file_staff_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1662,7 +2024,7 @@ func (x *ArchiveResponse) String() string {
 func (*ArchiveResponse) ProtoMessage() {}
 
 func (x *ArchiveResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[27]
+	mi := &file_staff_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1675,7 +2037,7 @@ func (x *ArchiveResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ArchiveResponse.ProtoReflect.Descriptor instead.
 func (*ArchiveResponse) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{27}
+	return file_staff_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *ArchiveResponse) GetSuccess() bool {
@@ -1693,7 +2055,7 @@ type WatchInboxRequest struct {
 
 func (x *WatchInboxRequest) Reset() {
 	*x = WatchInboxRequest{}
-	mi := &file_staff_proto_msgTypes[28]
+	mi := &file_staff_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1705,7 +2067,7 @@ func (x *WatchInboxRequest) String() string {
 func (*WatchInboxRequest) ProtoMessage() {}
 
 func (x *WatchInboxRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[28]
+	mi := &file_staff_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1718,23 +2080,120 @@ func (x *WatchInboxRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WatchInboxRequest.ProtoReflect.Descriptor instead.
 func (*WatchInboxRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{28}
+	return file_staff_proto_rawDescGZIP(), []int{33}
+}
+
+type ConfirmFactRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InboxId       int64                  `protobuf:"varint,1,opt,name=inbox_id,json=inboxId,proto3" json:"inbox_id,omitempty"`
+	Accept        bool                   `protobuf:"varint,2,opt,name=accept,proto3" json:"accept,omitempty"` // Store the fact; false declines it
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmFactRequest) Reset() {
+	*x = ConfirmFactRequest{}
+	mi := &file_staff_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmFactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmFactRequest) ProtoMessage() {}
+
+func (x *ConfirmFactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmFactRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmFactRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ConfirmFactRequest) GetInboxId() int64 {
+	if x != nil {
+		return x.InboxId
+	}
+	return 0
+}
+
+func (x *ConfirmFactRequest) GetAccept() bool {
+	if x != nil {
+		return x.Accept
+	}
+	return false
+}
+
+type ConfirmFactResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MemoryId      int64                  `protobuf:"varint,1,opt,name=memory_id,json=memoryId,proto3" json:"memory_id,omitempty"` // Stored memory, when accepted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmFactResponse) Reset() {
+	*x = ConfirmFactResponse{}
+	mi := &file_staff_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmFactResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmFactResponse) ProtoMessage() {}
+
+func (x *ConfirmFactResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmFactResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmFactResponse) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ConfirmFactResponse) GetMemoryId() int64 {
+	if x != nil {
+		return x.MemoryId
+	}
+	return 0
 }
 
 type SearchMemoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
-	Scope         string                 `protobuf:"bytes,2,opt,name=scope,proto3" json:"scope,omitempty"`                    // "agent" or "global"
+	Scope         string                 `protobuf:"bytes,2,opt,name=scope,proto3" json:"scope,omitempty"`                    // "agent", "group" or "global"; agent searches include the agent's groups
 	AgentId       string                 `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // Required if scope = "agent"
 	Types         []string               `protobuf:"bytes,4,rep,name=types,proto3" json:"types,omitempty"`                    // fact, episode, profile, doc_ref
 	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	Group         string                 `protobuf:"bytes,6,opt,name=group,proto3" json:"group,omitempty"` // Required if scope = "group"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SearchMemoryRequest) Reset() {
 	*x = SearchMemoryRequest{}
-	mi := &file_staff_proto_msgTypes[29]
+	mi := &file_staff_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1746,7 +2205,7 @@ func (x *SearchMemoryRequest) String() string {
 func (*SearchMemoryRequest) ProtoMessage() {}
 
 func (x *SearchMemoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[29]
+	mi := &file_staff_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1759,7 +2218,7 @@ func (x *SearchMemoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchMemoryRequest.ProtoReflect.Descriptor instead.
 func (*SearchMemoryRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{29}
+	return file_staff_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *SearchMemoryRequest) GetQuery() string {
@@ -1797,6 +2256,13 @@ func (x *SearchMemoryRequest) GetLimit() int32 {
 	return 0
 }
 
+func (x *SearchMemoryRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
 type SearchMemoryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Items         []*MemoryItem          `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
@@ -1806,7 +2272,7 @@ type SearchMemoryResponse struct {
 
 func (x *SearchMemoryResponse) Reset() {
 	*x = SearchMemoryResponse{}
-	mi := &file_staff_proto_msgTypes[30]
+	mi := &file_staff_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1818,7 +2284,7 @@ func (x *SearchMemoryResponse) String() string {
 func (*SearchMemoryResponse) ProtoMessage() {}
 
 func (x *SearchMemoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[30]
+	mi := &file_staff_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1831,7 +2297,7 @@ func (x *SearchMemoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchMemoryResponse.ProtoReflect.Descriptor instead.
 func (*SearchMemoryResponse) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{30}
+	return file_staff_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *SearchMemoryResponse) GetItems() []*MemoryItem {
@@ -1851,13 +2317,19 @@ type MemoryItem struct {
 	Importance    float64                `protobuf:"fixed64,6,opt,name=importance,proto3" json:"importance,omitempty"`
 	Metadata      *structpb.Struct       `protobuf:"bytes,7,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	MemoryType    string                 `protobuf:"bytes,9,opt,name=memory_type,json=memoryType,proto3" json:"memory_type,omitempty"` // Normalized type of personal memories (preference, habit, ...)
+	Tags          []string               `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Score         float64                `protobuf:"fixed64,12,opt,name=score,proto3" json:"score,omitempty"` // Relevance, set in search results
+	Match         *MemoryMatch           `protobuf:"bytes,13,opt,name=match,proto3" json:"match,omitempty"`   // Set in search results that matched the query text
+	Group         string                 `protobuf:"bytes,14,opt,name=group,proto3" json:"group,omitempty"`   // Sharing group of group-scoped memories
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *MemoryItem) Reset() {
 	*x = MemoryItem{}
-	mi := &file_staff_proto_msgTypes[31]
+	mi := &file_staff_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1869,7 +2341,7 @@ func (x *MemoryItem) String() string {
 func (*MemoryItem) ProtoMessage() {}
 
 func (x *MemoryItem) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[31]
+	mi := &file_staff_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1882,7 +2354,7 @@ func (x *MemoryItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MemoryItem.ProtoReflect.Descriptor instead.
 func (*MemoryItem) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{31}
+	return file_staff_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *MemoryItem) GetId() int64 {
@@ -1941,6 +2413,109 @@ func (x *MemoryItem) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *MemoryItem) GetMemoryType() string {
+	if x != nil {
+		return x.MemoryType
+	}
+	return ""
+}
+
+func (x *MemoryItem) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *MemoryItem) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *MemoryItem) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *MemoryItem) GetMatch() *MemoryMatch {
+	if x != nil {
+		return x.Match
+	}
+	return nil
+}
+
+func (x *MemoryItem) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+// Why a memory matched a full-text query. Matched terms are wrapped in "**".
+type MemoryMatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bm25          float64                `protobuf:"fixed64,1,opt,name=bm25,proto3" json:"bm25,omitempty"`         // Higher is better
+	Snippet       string                 `protobuf:"bytes,2,opt,name=snippet,proto3" json:"snippet,omitempty"`     // Fragment of the best matching field: content, raw content or tags
+	Highlight     string                 `protobuf:"bytes,3,opt,name=highlight,proto3" json:"highlight,omitempty"` // Full content
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemoryMatch) Reset() {
+	*x = MemoryMatch{}
+	mi := &file_staff_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemoryMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoryMatch) ProtoMessage() {}
+
+func (x *MemoryMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoryMatch.ProtoReflect.Descriptor instead.
+func (*MemoryMatch) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *MemoryMatch) GetBm25() float64 {
+	if x != nil {
+		return x.Bm25
+	}
+	return 0
+}
+
+func (x *MemoryMatch) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+func (x *MemoryMatch) GetHighlight() string {
+	if x != nil {
+		return x.Highlight
+	}
+	return ""
+}
+
 type StoreMemoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
@@ -1949,25 +2524,1560 @@ type StoreMemoryRequest struct {
 	Content       string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
 	Importance    float64                `protobuf:"fixed64,5,opt,name=importance,proto3" json:"importance,omitempty"`
 	Metadata      *structpb.Struct       `protobuf:"bytes,6,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Group         string                 `protobuf:"bytes,7,opt,name=group,proto3" json:"group,omitempty"` // Required if scope = "group"; agent_id must be a member
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *StoreMemoryRequest) Reset() {
 	*x = StoreMemoryRequest{}
-	mi := &file_staff_proto_msgTypes[32]
+	mi := &file_staff_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreMemoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreMemoryRequest) ProtoMessage() {}
+
+func (x *StoreMemoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreMemoryRequest.ProtoReflect.Descriptor instead.
+func (*StoreMemoryRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *StoreMemoryRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *StoreMemoryRequest) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *StoreMemoryRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *StoreMemoryRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *StoreMemoryRequest) GetImportance() float64 {
+	if x != nil {
+		return x.Importance
+	}
+	return 0
+}
+
+func (x *StoreMemoryRequest) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *StoreMemoryRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+type StoreMemoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreMemoryResponse) Reset() {
+	*x = StoreMemoryResponse{}
+	mi := &file_staff_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreMemoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreMemoryResponse) ProtoMessage() {}
+
+func (x *StoreMemoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreMemoryResponse.ProtoReflect.Descriptor instead.
+func (*StoreMemoryResponse) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *StoreMemoryResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetMemoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMemoryRequest) Reset() {
+	*x = GetMemoryRequest{}
+	mi := &file_staff_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMemoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMemoryRequest) ProtoMessage() {}
+
+func (x *GetMemoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMemoryRequest.ProtoReflect.Descriptor instead.
+func (*GetMemoryRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetMemoryRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Only fields that are set are changed.
+type UpdateMemoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Content       *string                `protobuf:"bytes,2,opt,name=content,proto3,oneof" json:"content,omitempty"`
+	Importance    *float64               `protobuf:"fixed64,3,opt,name=importance,proto3,oneof" json:"importance,omitempty"`
+	MemoryType    *string                `protobuf:"bytes,4,opt,name=memory_type,json=memoryType,proto3,oneof" json:"memory_type,omitempty"`
+	Tags          []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`                             // Replaces the tags when non-empty
+	ClearTags     bool                   `protobuf:"varint,6,opt,name=clear_tags,json=clearTags,proto3" json:"clear_tags,omitempty"` // Removes all tags
+	Metadata      *structpb.Struct       `protobuf:"bytes,7,opt,name=metadata,proto3" json:"metadata,omitempty"`                     // Merged into the existing metadata; null values remove keys
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMemoryRequest) Reset() {
+	*x = UpdateMemoryRequest{}
+	mi := &file_staff_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMemoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMemoryRequest) ProtoMessage() {}
+
+func (x *UpdateMemoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMemoryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMemoryRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *UpdateMemoryRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateMemoryRequest) GetContent() string {
+	if x != nil && x.Content != nil {
+		return *x.Content
+	}
+	return ""
+}
+
+func (x *UpdateMemoryRequest) GetImportance() float64 {
+	if x != nil && x.Importance != nil {
+		return *x.Importance
+	}
+	return 0
+}
+
+func (x *UpdateMemoryRequest) GetMemoryType() string {
+	if x != nil && x.MemoryType != nil {
+		return *x.MemoryType
+	}
+	return ""
+}
+
+func (x *UpdateMemoryRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpdateMemoryRequest) GetClearTags() bool {
+	if x != nil {
+		return x.ClearTags
+	}
+	return false
+}
+
+func (x *UpdateMemoryRequest) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type DeleteMemoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMemoryRequest) Reset() {
+	*x = DeleteMemoryRequest{}
+	mi := &file_staff_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMemoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMemoryRequest) ProtoMessage() {}
+
+func (x *DeleteMemoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMemoryRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMemoryRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *DeleteMemoryRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteMemoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMemoryResponse) Reset() {
+	*x = DeleteMemoryResponse{}
+	mi := &file_staff_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMemoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMemoryResponse) ProtoMessage() {}
+
+func (x *DeleteMemoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMemoryResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMemoryResponse) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *DeleteMemoryResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type DumpMemoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FilePath      string                 `protobuf:"bytes,1,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DumpMemoryRequest) Reset() {
+	*x = DumpMemoryRequest{}
+	mi := &file_staff_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DumpMemoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DumpMemoryRequest) ProtoMessage() {}
+
+func (x *DumpMemoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DumpMemoryRequest.ProtoReflect.Descriptor instead.
+func (*DumpMemoryRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *DumpMemoryRequest) GetFilePath() string {
+	if x != nil {
+		return x.FilePath
+	}
+	return ""
+}
+
+type DumpMemoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DumpMemoryResponse) Reset() {
+	*x = DumpMemoryResponse{}
+	mi := &file_staff_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DumpMemoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DumpMemoryResponse) ProtoMessage() {}
+
+func (x *DumpMemoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DumpMemoryResponse.ProtoReflect.Descriptor instead.
+func (*DumpMemoryResponse) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *DumpMemoryResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DumpMemoryResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ClearMemoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearMemoryRequest) Reset() {
+	*x = ClearMemoryRequest{}
+	mi := &file_staff_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearMemoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearMemoryRequest) ProtoMessage() {}
+
+func (x *ClearMemoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearMemoryRequest.ProtoReflect.Descriptor instead.
+func (*ClearMemoryRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{48}
+}
+
+type ClearMemoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	ItemsDeleted  int64                  `protobuf:"varint,2,opt,name=items_deleted,json=itemsDeleted,proto3" json:"items_deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearMemoryResponse) Reset() {
+	*x = ClearMemoryResponse{}
+	mi := &file_staff_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearMemoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearMemoryResponse) ProtoMessage() {}
+
+func (x *ClearMemoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearMemoryResponse.ProtoReflect.Descriptor instead.
+func (*ClearMemoryResponse) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ClearMemoryResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ClearMemoryResponse) GetItemsDeleted() int64 {
+	if x != nil {
+		return x.ItemsDeleted
+	}
+	return 0
+}
+
+type ReembedMemoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReembedMemoryRequest) Reset() {
+	*x = ReembedMemoryRequest{}
+	mi := &file_staff_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReembedMemoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReembedMemoryRequest) ProtoMessage() {}
+
+func (x *ReembedMemoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReembedMemoryRequest.ProtoReflect.Descriptor instead.
+func (*ReembedMemoryRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{50}
+}
+
+type GetReembedStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReembedStatusRequest) Reset() {
+	*x = GetReembedStatusRequest{}
+	mi := &file_staff_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReembedStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReembedStatusRequest) ProtoMessage() {}
+
+func (x *GetReembedStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReembedStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetReembedStatusRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{51}
+}
+
+type ReembedStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Running       bool                   `protobuf:"varint,1,opt,name=running,proto3" json:"running,omitempty"`
+	Started       bool                   `protobuf:"varint,2,opt,name=started,proto3" json:"started,omitempty"` // Set by Reembed when this request started a pass
+	Total         int64                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`     // Stale memories when the pass started
+	Done          int64                  `protobuf:"varint,4,opt,name=done,proto3" json:"done,omitempty"`
+	Embedder      string                 `protobuf:"bytes,5,opt,name=embedder,proto3" json:"embedder,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	Error         string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"` // Why the last pass stopped early, if it did
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReembedStatus) Reset() {
+	*x = ReembedStatus{}
+	mi := &file_staff_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReembedStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReembedStatus) ProtoMessage() {}
+
+func (x *ReembedStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReembedStatus.ProtoReflect.Descriptor instead.
+func (*ReembedStatus) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ReembedStatus) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *ReembedStatus) GetStarted() bool {
+	if x != nil {
+		return x.Started
+	}
+	return false
+}
+
+func (x *ReembedStatus) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ReembedStatus) GetDone() int64 {
+	if x != nil {
+		return x.Done
+	}
+	return 0
+}
+
+func (x *ReembedStatus) GetEmbedder() string {
+	if x != nil {
+		return x.Embedder
+	}
+	return ""
+}
+
+func (x *ReembedStatus) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *ReembedStatus) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+func (x *ReembedStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetMemoryProvenanceRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ContextMessages int32                  `protobuf:"varint,2,opt,name=context_messages,json=contextMessages,proto3" json:"context_messages,omitempty"` // Messages to include on either side of the source message; default 5
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetMemoryProvenanceRequest) Reset() {
+	*x = GetMemoryProvenanceRequest{}
+	mi := &file_staff_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMemoryProvenanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMemoryProvenanceRequest) ProtoMessage() {}
+
+func (x *GetMemoryProvenanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMemoryProvenanceRequest.ProtoReflect.Descriptor instead.
+func (*GetMemoryProvenanceRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetMemoryProvenanceRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetMemoryProvenanceRequest) GetContextMessages() int32 {
+	if x != nil {
+		return x.ContextMessages
+	}
+	return 0
+}
+
+// Where a memory came from. Fields are empty for memories stored without a known source.
+type MemoryProvenance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MemoryId      int64                  `protobuf:"varint,1,opt,name=memory_id,json=memoryId,proto3" json:"memory_id,omitempty"`
+	AgentId       string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	ThreadId      string                 `protobuf:"bytes,3,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"`
+	MessageId     int64                  `protobuf:"varint,4,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"` // Conversation message that led to the memory
+	ToolId        string                 `protobuf:"bytes,5,opt,name=tool_id,json=toolId,proto3" json:"tool_id,omitempty"`           // Tool call that stored it
+	Source        string                 `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`                         // Tool name, or "reflection"
+	Messages      []*SourceMessage       `protobuf:"bytes,7,rep,name=messages,proto3" json:"messages,omitempty"`                     // Conversation around message_id, oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemoryProvenance) Reset() {
+	*x = MemoryProvenance{}
+	mi := &file_staff_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemoryProvenance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoryProvenance) ProtoMessage() {}
+
+func (x *MemoryProvenance) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoryProvenance.ProtoReflect.Descriptor instead.
+func (*MemoryProvenance) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *MemoryProvenance) GetMemoryId() int64 {
+	if x != nil {
+		return x.MemoryId
+	}
+	return 0
+}
+
+func (x *MemoryProvenance) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *MemoryProvenance) GetThreadId() string {
+	if x != nil {
+		return x.ThreadId
+	}
+	return ""
+}
+
+func (x *MemoryProvenance) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *MemoryProvenance) GetToolId() string {
+	if x != nil {
+		return x.ToolId
+	}
+	return ""
+}
+
+func (x *MemoryProvenance) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *MemoryProvenance) GetMessages() []*SourceMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+type SourceMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	ToolName      string                 `protobuf:"bytes,4,opt,name=tool_name,json=toolName,proto3" json:"tool_name,omitempty"`
+	ToolId        string                 `protobuf:"bytes,5,opt,name=tool_id,json=toolId,proto3" json:"tool_id,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	IsSource      bool                   `protobuf:"varint,7,opt,name=is_source,json=isSource,proto3" json:"is_source,omitempty"` // The message the memory came from, or its tool call
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SourceMessage) Reset() {
+	*x = SourceMessage{}
+	mi := &file_staff_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SourceMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SourceMessage) ProtoMessage() {}
+
+func (x *SourceMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SourceMessage.ProtoReflect.Descriptor instead.
+func (*SourceMessage) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *SourceMessage) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SourceMessage) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *SourceMessage) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *SourceMessage) GetToolName() string {
+	if x != nil {
+		return x.ToolName
+	}
+	return ""
+}
+
+func (x *SourceMessage) GetToolId() string {
+	if x != nil {
+		return x.ToolId
+	}
+	return ""
+}
+
+func (x *SourceMessage) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *SourceMessage) GetIsSource() bool {
+	if x != nil {
+		return x.IsSource
+	}
+	return false
+}
+
+type GetProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProfileRequest) Reset() {
+	*x = GetProfileRequest{}
+	mi := &file_staff_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProfileRequest) ProtoMessage() {}
+
+func (x *GetProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProfileRequest.ProtoReflect.Descriptor instead.
+func (*GetProfileRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{56}
+}
+
+type UpdateProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProfileRequest) Reset() {
+	*x = UpdateProfileRequest{}
+	mi := &file_staff_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProfileRequest) ProtoMessage() {}
+
+func (x *UpdateProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProfileRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProfileRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *UpdateProfileRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+// The user profile: a markdown document of biographical details, preferences, goals and
+// projects. Its "## Notes" and any sections edited by hand are kept when the profile is
+// rebuilt.
+type UserProfile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"` // Memory item holding the profile
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	UpdatedBy     string                 `protobuf:"bytes,4,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"` // "synthesizer" or "user"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserProfile) Reset() {
+	*x = UserProfile{}
+	mi := &file_staff_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserProfile) ProtoMessage() {}
+
+func (x *UserProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserProfile.ProtoReflect.Descriptor instead.
+func (*UserProfile) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *UserProfile) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UserProfile) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *UserProfile) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *UserProfile) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+type GetEntityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`    // Used when id is not set; matched ignoring case
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`    // person, project, organization or place; narrows a lookup by name
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"` // Maximum number of memories to return (default 20)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEntityRequest) Reset() {
+	*x = GetEntityRequest{}
+	mi := &file_staff_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEntityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEntityRequest) ProtoMessage() {}
+
+func (x *GetEntityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEntityRequest.ProtoReflect.Descriptor instead.
+func (*GetEntityRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *GetEntityRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetEntityRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetEntityRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *GetEntityRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// A person, project, organization or place mentioned by memories, with what they say about it.
+type Entity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Memories      []*MemoryItem          `protobuf:"bytes,6,rep,name=memories,proto3" json:"memories,omitempty"` // Memories mentioning the entity, most recently updated first
+	Relations     []*EntityRelation      `protobuf:"bytes,7,rep,name=relations,proto3" json:"relations,omitempty"`
+	Related       []*RelatedEntity       `protobuf:"bytes,8,rep,name=related,proto3" json:"related,omitempty"` // Other entities in its relations and memories, most linked first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Entity) Reset() {
+	*x = Entity{}
+	mi := &file_staff_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Entity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Entity) ProtoMessage() {}
+
+func (x *Entity) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Entity.ProtoReflect.Descriptor instead.
+func (*Entity) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *Entity) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Entity) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Entity) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Entity) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Entity) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Entity) GetMemories() []*MemoryItem {
+	if x != nil {
+		return x.Memories
+	}
+	return nil
+}
+
+func (x *Entity) GetRelations() []*EntityRelation {
+	if x != nil {
+		return x.Relations
+	}
+	return nil
+}
+
+func (x *Entity) GetRelated() []*RelatedEntity {
+	if x != nil {
+		return x.Related
+	}
+	return nil
+}
+
+// A relation between two entities, stated by a memory.
+type EntityRelation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SubjectId     int64                  `protobuf:"varint,1,opt,name=subject_id,json=subjectId,proto3" json:"subject_id,omitempty"`
+	Subject       string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	Predicate     string                 `protobuf:"bytes,3,opt,name=predicate,proto3" json:"predicate,omitempty"` // e.g. works_on, manages, located_in
+	ObjectId      int64                  `protobuf:"varint,4,opt,name=object_id,json=objectId,proto3" json:"object_id,omitempty"`
+	Object        string                 `protobuf:"bytes,5,opt,name=object,proto3" json:"object,omitempty"`
+	MemoryId      int64                  `protobuf:"varint,6,opt,name=memory_id,json=memoryId,proto3" json:"memory_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntityRelation) Reset() {
+	*x = EntityRelation{}
+	mi := &file_staff_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntityRelation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityRelation) ProtoMessage() {}
+
+func (x *EntityRelation) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityRelation.ProtoReflect.Descriptor instead.
+func (*EntityRelation) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *EntityRelation) GetSubjectId() int64 {
+	if x != nil {
+		return x.SubjectId
+	}
+	return 0
+}
+
+func (x *EntityRelation) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *EntityRelation) GetPredicate() string {
+	if x != nil {
+		return x.Predicate
+	}
+	return ""
+}
+
+func (x *EntityRelation) GetObjectId() int64 {
+	if x != nil {
+		return x.ObjectId
+	}
+	return 0
+}
+
+func (x *EntityRelation) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *EntityRelation) GetMemoryId() int64 {
+	if x != nil {
+		return x.MemoryId
+	}
+	return 0
+}
+
+type RelatedEntity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Links         int32                  `protobuf:"varint,4,opt,name=links,proto3" json:"links,omitempty"` // Relations and shared memories connecting it
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelatedEntity) Reset() {
+	*x = RelatedEntity{}
+	mi := &file_staff_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelatedEntity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelatedEntity) ProtoMessage() {}
+
+func (x *RelatedEntity) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelatedEntity.ProtoReflect.Descriptor instead.
+func (*RelatedEntity) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *RelatedEntity) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RelatedEntity) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RelatedEntity) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *RelatedEntity) GetLinks() int32 {
+	if x != nil {
+		return x.Links
+	}
+	return 0
+}
+
+type ListArtifactsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // Optional: only artifacts visible to this agent
+	Query         string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`                    // Optional: text in the title or body
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListArtifactsRequest) Reset() {
+	*x = ListArtifactsRequest{}
+	mi := &file_staff_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListArtifactsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArtifactsRequest) ProtoMessage() {}
+
+func (x *ListArtifactsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArtifactsRequest.ProtoReflect.Descriptor instead.
+func (*ListArtifactsRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ListArtifactsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ListArtifactsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListArtifactsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListArtifactsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Artifacts     []*Artifact            `protobuf:"bytes,1,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListArtifactsResponse) Reset() {
+	*x = ListArtifactsResponse{}
+	mi := &file_staff_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListArtifactsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArtifactsResponse) ProtoMessage() {}
+
+func (x *ListArtifactsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArtifactsResponse.ProtoReflect.Descriptor instead.
+func (*ListArtifactsResponse) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ListArtifactsResponse) GetArtifacts() []*Artifact {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+type Artifact struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	AgentId       string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // Creator, or the author of a version in ListArtifactVersions; empty for the user
+	ThreadId      string                 `protobuf:"bytes,3,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"`
+	Scope         string                 `protobuf:"bytes,4,opt,name=scope,proto3" json:"scope,omitempty"` // "agent" or "global"
+	Title         string                 `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"`
+	Body          string                 `protobuf:"bytes,6,opt,name=body,proto3" json:"body,omitempty"`
+	Metadata      *structpb.Struct       `protobuf:"bytes,7,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Version       int32                  `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Artifact) Reset() {
+	*x = Artifact{}
+	mi := &file_staff_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StoreMemoryRequest) String() string {
+func (x *Artifact) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StoreMemoryRequest) ProtoMessage() {}
+func (*Artifact) ProtoMessage() {}
 
-func (x *StoreMemoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[32]
+func (x *Artifact) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1978,75 +4088,104 @@ func (x *StoreMemoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StoreMemoryRequest.ProtoReflect.Descriptor instead.
-func (*StoreMemoryRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{32}
+// Deprecated: Use Artifact.ProtoReflect.Descriptor instead.
+func (*Artifact) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{65}
 }
 
-func (x *StoreMemoryRequest) GetAgentId() string {
+func (x *Artifact) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Artifact) GetAgentId() string {
 	if x != nil {
 		return x.AgentId
 	}
 	return ""
 }
 
-func (x *StoreMemoryRequest) GetScope() string {
+func (x *Artifact) GetThreadId() string {
+	if x != nil {
+		return x.ThreadId
+	}
+	return ""
+}
+
+func (x *Artifact) GetScope() string {
 	if x != nil {
 		return x.Scope
 	}
 	return ""
 }
 
-func (x *StoreMemoryRequest) GetType() string {
+func (x *Artifact) GetTitle() string {
 	if x != nil {
-		return x.Type
+		return x.Title
 	}
 	return ""
 }
 
-func (x *StoreMemoryRequest) GetContent() string {
+func (x *Artifact) GetBody() string {
 	if x != nil {
-		return x.Content
+		return x.Body
 	}
 	return ""
 }
 
-func (x *StoreMemoryRequest) GetImportance() float64 {
+func (x *Artifact) GetMetadata() *structpb.Struct {
 	if x != nil {
-		return x.Importance
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Artifact) GetVersion() int32 {
+	if x != nil {
+		return x.Version
 	}
 	return 0
 }
 
-func (x *StoreMemoryRequest) GetMetadata() *structpb.Struct {
+func (x *Artifact) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Metadata
+		return x.CreatedAt
 	}
 	return nil
 }
 
-type StoreMemoryResponse struct {
+func (x *Artifact) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GetArtifactRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"` // Optional: earlier version to return (default: current)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StoreMemoryResponse) Reset() {
-	*x = StoreMemoryResponse{}
-	mi := &file_staff_proto_msgTypes[33]
+func (x *GetArtifactRequest) Reset() {
+	*x = GetArtifactRequest{}
+	mi := &file_staff_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StoreMemoryResponse) String() string {
+func (x *GetArtifactRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StoreMemoryResponse) ProtoMessage() {}
+func (*GetArtifactRequest) ProtoMessage() {}
 
-func (x *StoreMemoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[33]
+func (x *GetArtifactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2057,40 +4196,51 @@ func (x *StoreMemoryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StoreMemoryResponse.ProtoReflect.Descriptor instead.
-func (*StoreMemoryResponse) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use GetArtifactRequest.ProtoReflect.Descriptor instead.
+func (*GetArtifactRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *StoreMemoryResponse) GetId() int64 {
+func (x *GetArtifactRequest) GetId() int64 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-type DumpMemoryRequest struct {
+func (x *GetArtifactRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type CreateArtifactRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FilePath      string                 `protobuf:"bytes,1,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Body          string                 `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	Scope         string                 `protobuf:"bytes,3,opt,name=scope,proto3" json:"scope,omitempty"`                    // "global" (default) or "agent"
+	AgentId       string                 `protobuf:"bytes,4,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // Owner of agent-scoped artifacts
+	Metadata      *structpb.Struct       `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DumpMemoryRequest) Reset() {
-	*x = DumpMemoryRequest{}
-	mi := &file_staff_proto_msgTypes[34]
+func (x *CreateArtifactRequest) Reset() {
+	*x = CreateArtifactRequest{}
+	mi := &file_staff_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DumpMemoryRequest) String() string {
+func (x *CreateArtifactRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DumpMemoryRequest) ProtoMessage() {}
+func (*CreateArtifactRequest) ProtoMessage() {}
 
-func (x *DumpMemoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[34]
+func (x *CreateArtifactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2101,41 +4251,72 @@ func (x *DumpMemoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DumpMemoryRequest.ProtoReflect.Descriptor instead.
-func (*DumpMemoryRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{34}
+// Deprecated: Use CreateArtifactRequest.ProtoReflect.Descriptor instead.
+func (*CreateArtifactRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{67}
 }
 
-func (x *DumpMemoryRequest) GetFilePath() string {
+func (x *CreateArtifactRequest) GetTitle() string {
 	if x != nil {
-		return x.FilePath
+		return x.Title
 	}
 	return ""
 }
 
-type DumpMemoryResponse struct {
+func (x *CreateArtifactRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *CreateArtifactRequest) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *CreateArtifactRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *CreateArtifactRequest) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// Only fields that are set are changed.
+type UpdateArtifactRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Body          *string                `protobuf:"bytes,3,opt,name=body,proto3,oneof" json:"body,omitempty"`
+	Metadata      *structpb.Struct       `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"` // Merged into the existing metadata; null values remove keys
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DumpMemoryResponse) Reset() {
-	*x = DumpMemoryResponse{}
-	mi := &file_staff_proto_msgTypes[35]
+func (x *UpdateArtifactRequest) Reset() {
+	*x = UpdateArtifactRequest{}
+	mi := &file_staff_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DumpMemoryResponse) String() string {
+func (x *UpdateArtifactRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DumpMemoryResponse) ProtoMessage() {}
+func (*UpdateArtifactRequest) ProtoMessage() {}
 
-func (x *DumpMemoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[35]
+func (x *UpdateArtifactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2146,46 +4327,61 @@ func (x *DumpMemoryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DumpMemoryResponse.ProtoReflect.Descriptor instead.
-func (*DumpMemoryResponse) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{35}
+// Deprecated: Use UpdateArtifactRequest.ProtoReflect.Descriptor instead.
+func (*UpdateArtifactRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{68}
 }
 
-func (x *DumpMemoryResponse) GetSuccess() bool {
+func (x *UpdateArtifactRequest) GetId() int64 {
 	if x != nil {
-		return x.Success
+		return x.Id
 	}
-	return false
+	return 0
 }
 
-func (x *DumpMemoryResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
+func (x *UpdateArtifactRequest) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
 	}
 	return ""
 }
 
-type ClearMemoryRequest struct {
+func (x *UpdateArtifactRequest) GetBody() string {
+	if x != nil && x.Body != nil {
+		return *x.Body
+	}
+	return ""
+}
+
+func (x *UpdateArtifactRequest) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type ListArtifactVersionsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ClearMemoryRequest) Reset() {
-	*x = ClearMemoryRequest{}
-	mi := &file_staff_proto_msgTypes[36]
+func (x *ListArtifactVersionsRequest) Reset() {
+	*x = ListArtifactVersionsRequest{}
+	mi := &file_staff_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ClearMemoryRequest) String() string {
+func (x *ListArtifactVersionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClearMemoryRequest) ProtoMessage() {}
+func (*ListArtifactVersionsRequest) ProtoMessage() {}
 
-func (x *ClearMemoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[36]
+func (x *ListArtifactVersionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2196,34 +4392,40 @@ func (x *ClearMemoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClearMemoryRequest.ProtoReflect.Descriptor instead.
-func (*ClearMemoryRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{36}
+// Deprecated: Use ListArtifactVersionsRequest.ProtoReflect.Descriptor instead.
+func (*ListArtifactVersionsRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{69}
 }
 
-type ClearMemoryResponse struct {
+func (x *ListArtifactVersionsRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListArtifactVersionsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	ItemsDeleted  int64                  `protobuf:"varint,2,opt,name=items_deleted,json=itemsDeleted,proto3" json:"items_deleted,omitempty"`
+	Versions      []*Artifact            `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ClearMemoryResponse) Reset() {
-	*x = ClearMemoryResponse{}
-	mi := &file_staff_proto_msgTypes[37]
+func (x *ListArtifactVersionsResponse) Reset() {
+	*x = ListArtifactVersionsResponse{}
+	mi := &file_staff_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ClearMemoryResponse) String() string {
+func (x *ListArtifactVersionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClearMemoryResponse) ProtoMessage() {}
+func (*ListArtifactVersionsResponse) ProtoMessage() {}
 
-func (x *ClearMemoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[37]
+func (x *ListArtifactVersionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2234,23 +4436,16 @@ func (x *ClearMemoryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClearMemoryResponse.ProtoReflect.Descriptor instead.
-func (*ClearMemoryResponse) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{37}
-}
-
-func (x *ClearMemoryResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
+// Deprecated: Use ListArtifactVersionsResponse.ProtoReflect.Descriptor instead.
+func (*ListArtifactVersionsResponse) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{70}
 }
 
-func (x *ClearMemoryResponse) GetItemsDeleted() int64 {
+func (x *ListArtifactVersionsResponse) GetVersions() []*Artifact {
 	if x != nil {
-		return x.ItemsDeleted
+		return x.Versions
 	}
-	return 0
+	return nil
 }
 
 type GetInfoRequest struct {
@@ -2261,7 +4456,7 @@ type GetInfoRequest struct {
 
 func (x *GetInfoRequest) Reset() {
 	*x = GetInfoRequest{}
-	mi := &file_staff_proto_msgTypes[38]
+	mi := &file_staff_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2273,7 +4468,7 @@ func (x *GetInfoRequest) String() string {
 func (*GetInfoRequest) ProtoMessage() {}
 
 func (x *GetInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[38]
+	mi := &file_staff_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2286,7 +4481,7 @@ func (x *GetInfoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetInfoRequest.ProtoReflect.Descriptor instead.
 func (*GetInfoRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{38}
+	return file_staff_proto_rawDescGZIP(), []int{71}
 }
 
 type SystemInfo struct {
@@ -2303,7 +4498,7 @@ type SystemInfo struct {
 
 func (x *SystemInfo) Reset() {
 	*x = SystemInfo{}
-	mi := &file_staff_proto_msgTypes[39]
+	mi := &file_staff_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2315,7 +4510,7 @@ func (x *SystemInfo) String() string {
 func (*SystemInfo) ProtoMessage() {}
 
 func (x *SystemInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[39]
+	mi := &file_staff_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2328,7 +4523,7 @@ func (x *SystemInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SystemInfo.ProtoReflect.Descriptor instead.
 func (*SystemInfo) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{39}
+	return file_staff_proto_rawDescGZIP(), []int{72}
 }
 
 func (x *SystemInfo) GetVersion() string {
@@ -2382,7 +4577,7 @@ type ListToolsRequest struct {
 
 func (x *ListToolsRequest) Reset() {
 	*x = ListToolsRequest{}
-	mi := &file_staff_proto_msgTypes[40]
+	mi := &file_staff_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2394,7 +4589,7 @@ func (x *ListToolsRequest) String() string {
 func (*ListToolsRequest) ProtoMessage() {}
 
 func (x *ListToolsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[40]
+	mi := &file_staff_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2407,7 +4602,7 @@ func (x *ListToolsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListToolsRequest.ProtoReflect.Descriptor instead.
 func (*ListToolsRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{40}
+	return file_staff_proto_rawDescGZIP(), []int{73}
 }
 
 func (x *ListToolsRequest) GetAgentId() string {
@@ -2426,7 +4621,7 @@ type ListToolsResponse struct {
 
 func (x *ListToolsResponse) Reset() {
 	*x = ListToolsResponse{}
-	mi := &file_staff_proto_msgTypes[41]
+	mi := &file_staff_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2438,7 +4633,7 @@ func (x *ListToolsResponse) String() string {
 func (*ListToolsResponse) ProtoMessage() {}
 
 func (x *ListToolsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[41]
+	mi := &file_staff_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2451,7 +4646,7 @@ func (x *ListToolsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListToolsResponse.ProtoReflect.Descriptor instead.
 func (*ListToolsResponse) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{41}
+	return file_staff_proto_rawDescGZIP(), []int{74}
 }
 
 func (x *ListToolsResponse) GetTools() []*ToolInfo {
@@ -2472,7 +4667,7 @@ type ToolInfo struct {
 
 func (x *ToolInfo) Reset() {
 	*x = ToolInfo{}
-	mi := &file_staff_proto_msgTypes[42]
+	mi := &file_staff_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2484,7 +4679,7 @@ func (x *ToolInfo) String() string {
 func (*ToolInfo) ProtoMessage() {}
 
 func (x *ToolInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[42]
+	mi := &file_staff_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2497,7 +4692,7 @@ func (x *ToolInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ToolInfo.ProtoReflect.Descriptor instead.
 func (*ToolInfo) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{42}
+	return file_staff_proto_rawDescGZIP(), []int{75}
 }
 
 func (x *ToolInfo) GetName() string {
@@ -2529,7 +4724,7 @@ type ListMCPServersRequest struct {
 
 func (x *ListMCPServersRequest) Reset() {
 	*x = ListMCPServersRequest{}
-	mi := &file_staff_proto_msgTypes[43]
+	mi := &file_staff_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2541,7 +4736,7 @@ func (x *ListMCPServersRequest) String() string {
 func (*ListMCPServersRequest) ProtoMessage() {}
 
 func (x *ListMCPServersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[43]
+	mi := &file_staff_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2554,7 +4749,7 @@ func (x *ListMCPServersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListMCPServersRequest.ProtoReflect.Descriptor instead.
 func (*ListMCPServersRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{43}
+	return file_staff_proto_rawDescGZIP(), []int{76}
 }
 
 type ListMCPServersResponse struct {
@@ -2566,7 +4761,7 @@ type ListMCPServersResponse struct {
 
 func (x *ListMCPServersResponse) Reset() {
 	*x = ListMCPServersResponse{}
-	mi := &file_staff_proto_msgTypes[44]
+	mi := &file_staff_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2578,7 +4773,7 @@ func (x *ListMCPServersResponse) String() string {
 func (*ListMCPServersResponse) ProtoMessage() {}
 
 func (x *ListMCPServersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[44]
+	mi := &file_staff_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2591,7 +4786,7 @@ func (x *ListMCPServersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListMCPServersResponse.ProtoReflect.Descriptor instead.
 func (*ListMCPServersResponse) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{44}
+	return file_staff_proto_rawDescGZIP(), []int{77}
 }
 
 func (x *ListMCPServersResponse) GetServers() []*MCPServerInfo {
@@ -2614,7 +4809,7 @@ type MCPServerInfo struct {
 
 func (x *MCPServerInfo) Reset() {
 	*x = MCPServerInfo{}
-	mi := &file_staff_proto_msgTypes[45]
+	mi := &file_staff_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2626,7 +4821,7 @@ func (x *MCPServerInfo) String() string {
 func (*MCPServerInfo) ProtoMessage() {}
 
 func (x *MCPServerInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[45]
+	mi := &file_staff_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2639,7 +4834,7 @@ func (x *MCPServerInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MCPServerInfo.ProtoReflect.Descriptor instead.
 func (*MCPServerInfo) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{45}
+	return file_staff_proto_rawDescGZIP(), []int{78}
 }
 
 func (x *MCPServerInfo) GetName() string {
@@ -2686,7 +4881,7 @@ type DumpToolSchemasRequest struct {
 
 func (x *DumpToolSchemasRequest) Reset() {
 	*x = DumpToolSchemasRequest{}
-	mi := &file_staff_proto_msgTypes[46]
+	mi := &file_staff_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2698,7 +4893,7 @@ func (x *DumpToolSchemasRequest) String() string {
 func (*DumpToolSchemasRequest) ProtoMessage() {}
 
 func (x *DumpToolSchemasRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[46]
+	mi := &file_staff_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2711,7 +4906,7 @@ func (x *DumpToolSchemasRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DumpToolSchemasRequest.ProtoReflect.Descriptor instead.
 func (*DumpToolSchemasRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{46}
+	return file_staff_proto_rawDescGZIP(), []int{79}
 }
 
 func (x *DumpToolSchemasRequest) GetFilePath() string {
@@ -2731,7 +4926,7 @@ type DumpToolSchemasResponse struct {
 
 func (x *DumpToolSchemasResponse) Reset() {
 	*x = DumpToolSchemasResponse{}
-	mi := &file_staff_proto_msgTypes[47]
+	mi := &file_staff_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2743,7 +4938,7 @@ func (x *DumpToolSchemasResponse) String() string {
 func (*DumpToolSchemasResponse) ProtoMessage() {}
 
 func (x *DumpToolSchemasResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[47]
+	mi := &file_staff_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2756,7 +4951,7 @@ func (x *DumpToolSchemasResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DumpToolSchemasResponse.ProtoReflect.Descriptor instead.
 func (*DumpToolSchemasResponse) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{47}
+	return file_staff_proto_rawDescGZIP(), []int{80}
 }
 
 func (x *DumpToolSchemasResponse) GetSuccess() bool {
@@ -2782,7 +4977,7 @@ type DumpConversationsRequest struct {
 
 func (x *DumpConversationsRequest) Reset() {
 	*x = DumpConversationsRequest{}
-	mi := &file_staff_proto_msgTypes[48]
+	mi := &file_staff_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2794,7 +4989,7 @@ func (x *DumpConversationsRequest) String() string {
 func (*DumpConversationsRequest) ProtoMessage() {}
 
 func (x *DumpConversationsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[48]
+	mi := &file_staff_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2807,7 +5002,7 @@ func (x *DumpConversationsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DumpConversationsRequest.ProtoReflect.Descriptor instead.
 func (*DumpConversationsRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{48}
+	return file_staff_proto_rawDescGZIP(), []int{81}
 }
 
 func (x *DumpConversationsRequest) GetOutputDir() string {
@@ -2827,7 +5022,7 @@ type DumpConversationsResponse struct {
 
 func (x *DumpConversationsResponse) Reset() {
 	*x = DumpConversationsResponse{}
-	mi := &file_staff_proto_msgTypes[49]
+	mi := &file_staff_proto_msgTypes[82]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2839,7 +5034,7 @@ func (x *DumpConversationsResponse) String() string {
 func (*DumpConversationsResponse) ProtoMessage() {}
 
 func (x *DumpConversationsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[49]
+	mi := &file_staff_proto_msgTypes[82]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2852,7 +5047,7 @@ func (x *DumpConversationsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DumpConversationsResponse.ProtoReflect.Descriptor instead.
 func (*DumpConversationsResponse) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{49}
+	return file_staff_proto_rawDescGZIP(), []int{82}
 }
 
 func (x *DumpConversationsResponse) GetSuccess() bool {
@@ -2877,7 +5072,7 @@ type ClearConversationsRequest struct {
 
 func (x *ClearConversationsRequest) Reset() {
 	*x = ClearConversationsRequest{}
-	mi := &file_staff_proto_msgTypes[50]
+	mi := &file_staff_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2889,7 +5084,7 @@ func (x *ClearConversationsRequest) String() string {
 func (*ClearConversationsRequest) ProtoMessage() {}
 
 func (x *ClearConversationsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[50]
+	mi := &file_staff_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2902,7 +5097,7 @@ func (x *ClearConversationsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClearConversationsRequest.ProtoReflect.Descriptor instead.
 func (*ClearConversationsRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{50}
+	return file_staff_proto_rawDescGZIP(), []int{83}
 }
 
 type ClearConversationsResponse struct {
@@ -2914,7 +5109,7 @@ type ClearConversationsResponse struct {
 
 func (x *ClearConversationsResponse) Reset() {
 	*x = ClearConversationsResponse{}
-	mi := &file_staff_proto_msgTypes[51]
+	mi := &file_staff_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2926,7 +5121,7 @@ func (x *ClearConversationsResponse) String() string {
 func (*ClearConversationsResponse) ProtoMessage() {}
 
 func (x *ClearConversationsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[51]
+	mi := &file_staff_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2939,7 +5134,7 @@ func (x *ClearConversationsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClearConversationsResponse.ProtoReflect.Descriptor instead.
 func (*ClearConversationsResponse) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{51}
+	return file_staff_proto_rawDescGZIP(), []int{84}
 }
 
 func (x *ClearConversationsResponse) GetSuccess() bool {
@@ -2957,7 +5152,7 @@ type ResetStatsRequest struct {
 
 func (x *ResetStatsRequest) Reset() {
 	*x = ResetStatsRequest{}
-	mi := &file_staff_proto_msgTypes[52]
+	mi := &file_staff_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2969,7 +5164,7 @@ func (x *ResetStatsRequest) String() string {
 func (*ResetStatsRequest) ProtoMessage() {}
 
 func (x *ResetStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[52]
+	mi := &file_staff_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2982,7 +5177,7 @@ func (x *ResetStatsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResetStatsRequest.ProtoReflect.Descriptor instead.
 func (*ResetStatsRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{52}
+	return file_staff_proto_rawDescGZIP(), []int{85}
 }
 
 type ResetStatsResponse struct {
@@ -2994,7 +5189,7 @@ type ResetStatsResponse struct {
 
 func (x *ResetStatsResponse) Reset() {
 	*x = ResetStatsResponse{}
-	mi := &file_staff_proto_msgTypes[53]
+	mi := &file_staff_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3006,7 +5201,7 @@ func (x *ResetStatsResponse) String() string {
 func (*ResetStatsResponse) ProtoMessage() {}
 
 func (x *ResetStatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[53]
+	mi := &file_staff_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3019,7 +5214,7 @@ func (x *ResetStatsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResetStatsResponse.ProtoReflect.Descriptor instead.
 func (*ResetStatsResponse) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{53}
+	return file_staff_proto_rawDescGZIP(), []int{86}
 }
 
 func (x *ResetStatsResponse) GetSuccess() bool {
@@ -3038,7 +5233,7 @@ type DumpInboxRequest struct {
 
 func (x *DumpInboxRequest) Reset() {
 	*x = DumpInboxRequest{}
-	mi := &file_staff_proto_msgTypes[54]
+	mi := &file_staff_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3050,7 +5245,7 @@ func (x *DumpInboxRequest) String() string {
 func (*DumpInboxRequest) ProtoMessage() {}
 
 func (x *DumpInboxRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[54]
+	mi := &file_staff_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3063,7 +5258,7 @@ func (x *DumpInboxRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DumpInboxRequest.ProtoReflect.Descriptor instead.
 func (*DumpInboxRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{54}
+	return file_staff_proto_rawDescGZIP(), []int{87}
 }
 
 func (x *DumpInboxRequest) GetFilePath() string {
@@ -3083,7 +5278,7 @@ type DumpInboxResponse struct {
 
 func (x *DumpInboxResponse) Reset() {
 	*x = DumpInboxResponse{}
-	mi := &file_staff_proto_msgTypes[55]
+	mi := &file_staff_proto_msgTypes[88]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3095,7 +5290,7 @@ func (x *DumpInboxResponse) String() string {
 func (*DumpInboxResponse) ProtoMessage() {}
 
 func (x *DumpInboxResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[55]
+	mi := &file_staff_proto_msgTypes[88]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3108,7 +5303,7 @@ func (x *DumpInboxResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DumpInboxResponse.ProtoReflect.Descriptor instead.
 func (*DumpInboxResponse) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{55}
+	return file_staff_proto_rawDescGZIP(), []int{88}
 }
 
 func (x *DumpInboxResponse) GetSuccess() bool {
@@ -3133,7 +5328,7 @@ type ClearInboxRequest struct {
 
 func (x *ClearInboxRequest) Reset() {
 	*x = ClearInboxRequest{}
-	mi := &file_staff_proto_msgTypes[56]
+	mi := &file_staff_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3145,7 +5340,7 @@ func (x *ClearInboxRequest) String() string {
 func (*ClearInboxRequest) ProtoMessage() {}
 
 func (x *ClearInboxRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[56]
+	mi := &file_staff_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3158,7 +5353,7 @@ func (x *ClearInboxRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClearInboxRequest.ProtoReflect.Descriptor instead.
 func (*ClearInboxRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{56}
+	return file_staff_proto_rawDescGZIP(), []int{89}
 }
 
 type ClearInboxResponse struct {
@@ -3170,7 +5365,7 @@ type ClearInboxResponse struct {
 
 func (x *ClearInboxResponse) Reset() {
 	*x = ClearInboxResponse{}
-	mi := &file_staff_proto_msgTypes[57]
+	mi := &file_staff_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3182,7 +5377,7 @@ func (x *ClearInboxResponse) String() string {
 func (*ClearInboxResponse) ProtoMessage() {}
 
 func (x *ClearInboxResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[57]
+	mi := &file_staff_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3195,7 +5390,7 @@ func (x *ClearInboxResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClearInboxResponse.ProtoReflect.Descriptor instead.
 func (*ClearInboxResponse) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{57}
+	return file_staff_proto_rawDescGZIP(), []int{90}
 }
 
 func (x *ClearInboxResponse) GetSuccess() bool {
@@ -3209,11 +5404,18 @@ var File_staff_proto protoreflect.FileDescriptor
 
 const file_staff_proto_rawDesc = "" +
 	"\n" +
-	"\vstaff.proto\x12\bstaff.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/protobuf/struct.proto\"_\n" +
+	"\vstaff.proto\x12\bstaff.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/protobuf/struct.proto\"\x97\x01\n" +
 	"\vChatRequest\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1b\n" +
 	"\tthread_id\x18\x02 \x01(\tR\bthreadId\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"\x96\x02\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x126\n" +
+	"\vattachments\x18\x04 \x03(\v2\x14.staff.v1.AttachmentR\vattachments\"S\n" +
+	"\n" +
+	"Attachment\x12\x1d\n" +
+	"\n" +
+	"media_type\x18\x01 \x01(\tR\tmediaType\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"\xca\x03\n" +
 	"\tChatEvent\x124\n" +
 	"\n" +
 	"text_delta\x18\x01 \x01(\v2\x13.staff.v1.TextDeltaH\x00R\ttextDelta\x12.\n" +
@@ -3221,21 +5423,37 @@ const file_staff_proto_rawDesc = "" +
 	"\vtool_result\x18\x03 \x01(\v2\x14.staff.v1.ToolResultH\x00R\n" +
 	"toolResult\x124\n" +
 	"\bcomplete\x18\x04 \x01(\v2\x16.staff.v1.ChatCompleteH\x00R\bcomplete\x12+\n" +
-	"\x05error\x18\x05 \x01(\v2\x13.staff.v1.ChatErrorH\x00R\x05errorB\a\n" +
+	"\x05error\x18\x05 \x01(\v2\x13.staff.v1.ChatErrorH\x00R\x05error\x12C\n" +
+	"\x0freasoning_delta\x18\x06 \x01(\v2\x18.staff.v1.ReasoningDeltaH\x00R\x0ereasoningDelta\x12D\n" +
+	"\x10tool_input_delta\x18\a \x01(\v2\x18.staff.v1.ToolInputDeltaH\x00R\x0etoolInputDelta\x12'\n" +
+	"\x05usage\x18\b \x01(\v2\x0f.staff.v1.UsageH\x00R\x05usageB\a\n" +
 	"\x05event\"\x1f\n" +
 	"\tTextDelta\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\"$\n" +
+	"\x0eReasoningDelta\x12\x12\n" +
 	"\x04text\x18\x01 \x01(\tR\x04text\"^\n" +
 	"\aToolUse\x12\x17\n" +
 	"\atool_id\x18\x01 \x01(\tR\x06toolId\x12\x1b\n" +
 	"\ttool_name\x18\x02 \x01(\tR\btoolName\x12\x1d\n" +
 	"\n" +
-	"input_json\x18\x03 \x01(\tR\tinputJson\"u\n" +
+	"input_json\x18\x03 \x01(\tR\tinputJson\"L\n" +
+	"\x0eToolInputDelta\x12\x17\n" +
+	"\atool_id\x18\x01 \x01(\tR\x06toolId\x12!\n" +
+	"\fpartial_json\x18\x02 \x01(\tR\vpartialJson\"u\n" +
 	"\n" +
 	"ToolResult\x12\x17\n" +
 	"\atool_id\x18\x01 \x01(\tR\x06toolId\x12\x1b\n" +
 	"\ttool_name\x18\x02 \x01(\tR\btoolName\x12\x16\n" +
 	"\x06result\x18\x03 \x01(\tR\x06result\x12\x19\n" +
-	"\bis_error\x18\x04 \x01(\bR\aisError\"T\n" +
+	"\bis_error\x18\x04 \x01(\bR\aisError\"\x84\x02\n" +
+	"\x05Usage\x12\x1c\n" +
+	"\titeration\x18\x01 \x01(\x05R\titeration\x12!\n" +
+	"\finput_tokens\x18\x02 \x01(\x03R\vinputTokens\x12#\n" +
+	"\routput_tokens\x18\x03 \x01(\x03R\foutputTokens\x12=\n" +
+	"\x1bcache_creation_input_tokens\x18\x04 \x01(\x03R\x18cacheCreationInputTokens\x125\n" +
+	"\x17cache_read_input_tokens\x18\x05 \x01(\x03R\x14cacheReadInputTokens\x12\x1f\n" +
+	"\vstop_reason\x18\x06 \x01(\tR\n" +
+	"stopReason\"T\n" +
 	"\fChatComplete\x12#\n" +
 	"\rfull_response\x18\x01 \x01(\tR\ffullResponse\x12\x1f\n" +
 	"\vstop_reason\x18\x02 \x01(\tR\n" +
@@ -3254,19 +5472,22 @@ const file_staff_proto_rawDesc = "" +
 	"\vinclude_all\x18\x03 \x01(\bR\n" +
 	"includeAll\"D\n" +
 	"\x13LoadHistoryResponse\x12-\n" +
-	"\bmessages\x18\x01 \x03(\v2\x11.staff.v1.MessageR\bmessages\"\x8b\x01\n" +
+	"\bmessages\x18\x01 \x03(\v2\x11.staff.v1.MessageR\bmessages\"\xa9\x01\n" +
 	"\aMessage\x12\x12\n" +
 	"\x04role\x18\x01 \x01(\tR\x04role\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\tR\acontent\x12\x17\n" +
 	"\atool_id\x18\x03 \x01(\tR\x06toolId\x12\x1b\n" +
 	"\ttool_name\x18\x04 \x01(\tR\btoolName\x12\x1c\n" +
-	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\"H\n" +
+	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\x12\x1c\n" +
+	"\treasoning\x18\x06 \x01(\tR\treasoning\"H\n" +
 	"\x0eContextRequest\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1b\n" +
 	"\tthread_id\x18\x02 \x01(\tR\bthreadId\"E\n" +
 	"\x0fContextResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\x13\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\".\n" +
+	"\x12PinMessageResponse\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\"\x13\n" +
 	"\x11ListAgentsRequest\"=\n" +
 	"\x12ListAgentsResponse\x12'\n" +
 	"\x06agents\x18\x01 \x03(\v2\x0f.staff.v1.AgentR\x06agents\"\xef\x01\n" +
@@ -3308,7 +5529,7 @@ const file_staff_proto_rawDesc = "" +
 	"\x10ListInboxRequest\x12)\n" +
 	"\x10include_archived\x18\x01 \x01(\bR\x0fincludeArchived\">\n" +
 	"\x11ListInboxResponse\x12)\n" +
-	"\x05items\x18\x01 \x03(\v2\x13.staff.v1.InboxItemR\x05items\"\xa6\x03\n" +
+	"\x05items\x18\x01 \x03(\v2\x13.staff.v1.InboxItemR\x05items\"\xc9\x03\n" +
 	"\tInboxItem\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
 	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12\x1b\n" +
@@ -3324,20 +5545,27 @@ const file_staff_proto_rawDesc = "" +
 	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"updated_at\x18\n" +
-	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"+\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12!\n" +
+	"\fpending_fact\x18\v \x01(\tR\vpendingFact\"+\n" +
 	"\x0eArchiveRequest\x12\x19\n" +
 	"\binbox_id\x18\x01 \x01(\x03R\ainboxId\"+\n" +
 	"\x0fArchiveResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x13\n" +
-	"\x11WatchInboxRequest\"\x88\x01\n" +
+	"\x11WatchInboxRequest\"G\n" +
+	"\x12ConfirmFactRequest\x12\x19\n" +
+	"\binbox_id\x18\x01 \x01(\x03R\ainboxId\x12\x16\n" +
+	"\x06accept\x18\x02 \x01(\bR\x06accept\"2\n" +
+	"\x13ConfirmFactResponse\x12\x1b\n" +
+	"\tmemory_id\x18\x01 \x01(\x03R\bmemoryId\"\x9e\x01\n" +
 	"\x13SearchMemoryRequest\x12\x14\n" +
 	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
 	"\x05scope\x18\x02 \x01(\tR\x05scope\x12\x19\n" +
 	"\bagent_id\x18\x03 \x01(\tR\aagentId\x12\x14\n" +
 	"\x05types\x18\x04 \x03(\tR\x05types\x12\x14\n" +
-	"\x05limit\x18\x05 \x01(\x05R\x05limit\"B\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x14\n" +
+	"\x05group\x18\x06 \x01(\tR\x05group\"B\n" +
 	"\x14SearchMemoryResponse\x12*\n" +
-	"\x05items\x18\x01 \x03(\v2\x14.staff.v1.MemoryItemR\x05items\"\x8b\x02\n" +
+	"\x05items\x18\x01 \x03(\v2\x14.staff.v1.MemoryItemR\x05items\"\xd4\x03\n" +
 	"\n" +
 	"MemoryItem\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
@@ -3350,7 +5578,20 @@ const file_staff_proto_rawDesc = "" +
 	"importance\x123\n" +
 	"\bmetadata\x18\a \x01(\v2\x17.google.protobuf.StructR\bmetadata\x129\n" +
 	"\n" +
-	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xc8\x01\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1f\n" +
+	"\vmemory_type\x18\t \x01(\tR\n" +
+	"memoryType\x12\x12\n" +
+	"\x04tags\x18\n" +
+	" \x03(\tR\x04tags\x129\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x14\n" +
+	"\x05score\x18\f \x01(\x01R\x05score\x12+\n" +
+	"\x05match\x18\r \x01(\v2\x15.staff.v1.MemoryMatchR\x05match\x12\x14\n" +
+	"\x05group\x18\x0e \x01(\tR\x05group\"Y\n" +
+	"\vMemoryMatch\x12\x12\n" +
+	"\x04bm25\x18\x01 \x01(\x01R\x04bm25\x12\x18\n" +
+	"\asnippet\x18\x02 \x01(\tR\asnippet\x12\x1c\n" +
+	"\thighlight\x18\x03 \x01(\tR\thighlight\"\xde\x01\n" +
 	"\x12StoreMemoryRequest\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x14\n" +
 	"\x05scope\x18\x02 \x01(\tR\x05scope\x12\x12\n" +
@@ -3359,9 +5600,32 @@ const file_staff_proto_rawDesc = "" +
 	"\n" +
 	"importance\x18\x05 \x01(\x01R\n" +
 	"importance\x123\n" +
-	"\bmetadata\x18\x06 \x01(\v2\x17.google.protobuf.StructR\bmetadata\"%\n" +
+	"\bmetadata\x18\x06 \x01(\v2\x17.google.protobuf.StructR\bmetadata\x12\x14\n" +
+	"\x05group\x18\a \x01(\tR\x05group\"%\n" +
 	"\x13StoreMemoryResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\"\n" +
+	"\x10GetMemoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\xa2\x02\n" +
+	"\x13UpdateMemoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\acontent\x18\x02 \x01(\tH\x00R\acontent\x88\x01\x01\x12#\n" +
+	"\n" +
+	"importance\x18\x03 \x01(\x01H\x01R\n" +
+	"importance\x88\x01\x01\x12$\n" +
+	"\vmemory_type\x18\x04 \x01(\tH\x02R\n" +
+	"memoryType\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x1d\n" +
+	"\n" +
+	"clear_tags\x18\x06 \x01(\bR\tclearTags\x123\n" +
+	"\bmetadata\x18\a \x01(\v2\x17.google.protobuf.StructR\bmetadataB\n" +
+	"\n" +
+	"\b_contentB\r\n" +
+	"\v_importanceB\x0e\n" +
+	"\f_memory_type\"%\n" +
+	"\x13DeleteMemoryRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\"0\n" +
+	"\x14DeleteMemoryResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"0\n" +
 	"\x11DumpMemoryRequest\x12\x1b\n" +
 	"\tfile_path\x18\x01 \x01(\tR\bfilePath\"H\n" +
 	"\x12DumpMemoryResponse\x12\x18\n" +
@@ -3370,7 +5634,119 @@ const file_staff_proto_rawDesc = "" +
 	"\x12ClearMemoryRequest\"T\n" +
 	"\x13ClearMemoryResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
-	"\ritems_deleted\x18\x02 \x01(\x03R\fitemsDeleted\"\x10\n" +
+	"\ritems_deleted\x18\x02 \x01(\x03R\fitemsDeleted\"\x16\n" +
+	"\x14ReembedMemoryRequest\"\x19\n" +
+	"\x17GetReembedStatusRequest\"\x97\x02\n" +
+	"\rReembedStatus\x12\x18\n" +
+	"\arunning\x18\x01 \x01(\bR\arunning\x12\x18\n" +
+	"\astarted\x18\x02 \x01(\bR\astarted\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04done\x18\x04 \x01(\x03R\x04done\x12\x1a\n" +
+	"\bembedder\x18\x05 \x01(\tR\bembedder\x129\n" +
+	"\n" +
+	"started_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
+	"\vfinished_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\"W\n" +
+	"\x1aGetMemoryProvenanceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12)\n" +
+	"\x10context_messages\x18\x02 \x01(\x05R\x0fcontextMessages\"\xec\x01\n" +
+	"\x10MemoryProvenance\x12\x1b\n" +
+	"\tmemory_id\x18\x01 \x01(\x03R\bmemoryId\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12\x1b\n" +
+	"\tthread_id\x18\x03 \x01(\tR\bthreadId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x04 \x01(\x03R\tmessageId\x12\x17\n" +
+	"\atool_id\x18\x05 \x01(\tR\x06toolId\x12\x16\n" +
+	"\x06source\x18\x06 \x01(\tR\x06source\x123\n" +
+	"\bmessages\x18\a \x03(\v2\x17.staff.v1.SourceMessageR\bmessages\"\xda\x01\n" +
+	"\rSourceMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12\x1b\n" +
+	"\ttool_name\x18\x04 \x01(\tR\btoolName\x12\x17\n" +
+	"\atool_id\x18\x05 \x01(\tR\x06toolId\x128\n" +
+	"\ttimestamp\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x1b\n" +
+	"\tis_source\x18\a \x01(\bR\bisSource\"\x13\n" +
+	"\x11GetProfileRequest\"0\n" +
+	"\x14UpdateProfileRequest\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\"\x91\x01\n" +
+	"\vUserProfile\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x04 \x01(\tR\tupdatedBy\"`\n" +
+	"\x10GetEntityRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\xd3\x02\n" +
+	"\x06Entity\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x120\n" +
+	"\bmemories\x18\x06 \x03(\v2\x14.staff.v1.MemoryItemR\bmemories\x126\n" +
+	"\trelations\x18\a \x03(\v2\x18.staff.v1.EntityRelationR\trelations\x121\n" +
+	"\arelated\x18\b \x03(\v2\x17.staff.v1.RelatedEntityR\arelated\"\xb9\x01\n" +
+	"\x0eEntityRelation\x12\x1d\n" +
+	"\n" +
+	"subject_id\x18\x01 \x01(\x03R\tsubjectId\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\x12\x1c\n" +
+	"\tpredicate\x18\x03 \x01(\tR\tpredicate\x12\x1b\n" +
+	"\tobject_id\x18\x04 \x01(\x03R\bobjectId\x12\x16\n" +
+	"\x06object\x18\x05 \x01(\tR\x06object\x12\x1b\n" +
+	"\tmemory_id\x18\x06 \x01(\x03R\bmemoryId\"]\n" +
+	"\rRelatedEntity\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x14\n" +
+	"\x05links\x18\x04 \x01(\x05R\x05links\"]\n" +
+	"\x14ListArtifactsRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"I\n" +
+	"\x15ListArtifactsResponse\x120\n" +
+	"\tartifacts\x18\x01 \x03(\v2\x12.staff.v1.ArtifactR\tartifacts\"\xd7\x02\n" +
+	"\bArtifact\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12\x1b\n" +
+	"\tthread_id\x18\x03 \x01(\tR\bthreadId\x12\x14\n" +
+	"\x05scope\x18\x04 \x01(\tR\x05scope\x12\x14\n" +
+	"\x05title\x18\x05 \x01(\tR\x05title\x12\x12\n" +
+	"\x04body\x18\x06 \x01(\tR\x04body\x123\n" +
+	"\bmetadata\x18\a \x01(\v2\x17.google.protobuf.StructR\bmetadata\x12\x18\n" +
+	"\aversion\x18\b \x01(\x05R\aversion\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\">\n" +
+	"\x12GetArtifactRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"\xa7\x01\n" +
+	"\x15CreateArtifactRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x12\n" +
+	"\x04body\x18\x02 \x01(\tR\x04body\x12\x14\n" +
+	"\x05scope\x18\x03 \x01(\tR\x05scope\x12\x19\n" +
+	"\bagent_id\x18\x04 \x01(\tR\aagentId\x123\n" +
+	"\bmetadata\x18\x05 \x01(\v2\x17.google.protobuf.StructR\bmetadata\"\xa3\x01\n" +
+	"\x15UpdateArtifactRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12\x17\n" +
+	"\x04body\x18\x03 \x01(\tH\x01R\x04body\x88\x01\x01\x123\n" +
+	"\bmetadata\x18\x04 \x01(\v2\x17.google.protobuf.StructR\bmetadataB\b\n" +
+	"\x06_titleB\a\n" +
+	"\x05_body\"-\n" +
+	"\x1bListArtifactVersionsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"N\n" +
+	"\x1cListArtifactVersionsResponse\x12.\n" +
+	"\bversions\x18\x01 \x03(\v2\x12.staff.v1.ArtifactR\bversions\"\x10\n" +
 	"\x0eGetInfoRequest\"\xec\x01\n" +
 	"\n" +
 	"SystemInfo\x12\x18\n" +
@@ -3424,29 +5800,47 @@ const file_staff_proto_rawDesc = "" +
 	"\amessage\x18\x02 \x01(\tR\amessage\"\x13\n" +
 	"\x11ClearInboxRequest\".\n" +
 	"\x12ClearInboxResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\xea\x02\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess2\xb8\x03\n" +
 	"\vChatService\x124\n" +
 	"\x04Chat\x12\x15.staff.v1.ChatRequest\x1a\x13.staff.v1.ChatEvent0\x01\x12L\n" +
 	"\x11GetOrCreateThread\x12\x1a.staff.v1.GetThreadRequest\x1a\x1b.staff.v1.GetThreadResponse\x12J\n" +
 	"\vLoadHistory\x12\x1c.staff.v1.LoadHistoryRequest\x1a\x1d.staff.v1.LoadHistoryResponse\x12C\n" +
 	"\fResetContext\x12\x18.staff.v1.ContextRequest\x1a\x19.staff.v1.ContextResponse\x12F\n" +
-	"\x0fCompressContext\x12\x18.staff.v1.ContextRequest\x1a\x19.staff.v1.ContextResponse2\xe2\x02\n" +
+	"\x0fCompressContext\x12\x18.staff.v1.ContextRequest\x1a\x19.staff.v1.ContextResponse\x12L\n" +
+	"\x12PinLastUserMessage\x12\x18.staff.v1.ContextRequest\x1a\x1c.staff.v1.PinMessageResponse2\xe2\x02\n" +
 	"\fAgentService\x12G\n" +
 	"\n" +
 	"ListAgents\x12\x1b.staff.v1.ListAgentsRequest\x1a\x1c.staff.v1.ListAgentsResponse\x126\n" +
 	"\bGetAgent\x12\x19.staff.v1.GetAgentRequest\x1a\x0f.staff.v1.Agent\x12E\n" +
 	"\rGetAgentState\x12\x1e.staff.v1.GetAgentStateRequest\x1a\x14.staff.v1.AgentState\x12E\n" +
 	"\rGetAgentStats\x12\x1e.staff.v1.GetAgentStatsRequest\x1a\x14.staff.v1.AgentStats\x12C\n" +
-	"\vWatchStates\x12\x1c.staff.v1.WatchStatesRequest\x1a\x14.staff.v1.AgentState0\x012\xd1\x01\n" +
+	"\vWatchStates\x12\x1c.staff.v1.WatchStatesRequest\x1a\x14.staff.v1.AgentState0\x012\x9d\x02\n" +
 	"\fInboxService\x12D\n" +
 	"\tListItems\x12\x1a.staff.v1.ListInboxRequest\x1a\x1b.staff.v1.ListInboxResponse\x12>\n" +
 	"\aArchive\x12\x18.staff.v1.ArchiveRequest\x1a\x19.staff.v1.ArchiveResponse\x12;\n" +
-	"\x05Watch\x12\x1b.staff.v1.WatchInboxRequest\x1a\x13.staff.v1.InboxItem0\x012\xa7\x02\n" +
+	"\x05Watch\x12\x1b.staff.v1.WatchInboxRequest\x1a\x13.staff.v1.InboxItem0\x01\x12J\n" +
+	"\vConfirmFact\x12\x1c.staff.v1.ConfirmFactRequest\x1a\x1d.staff.v1.ConfirmFactResponse2\x94\a\n" +
 	"\rMemoryService\x12G\n" +
 	"\x06Search\x12\x1d.staff.v1.SearchMemoryRequest\x1a\x1e.staff.v1.SearchMemoryResponse\x12D\n" +
-	"\x05Store\x12\x1c.staff.v1.StoreMemoryRequest\x1a\x1d.staff.v1.StoreMemoryResponse\x12A\n" +
+	"\x05Store\x12\x1c.staff.v1.StoreMemoryRequest\x1a\x1d.staff.v1.StoreMemoryResponse\x127\n" +
+	"\x03Get\x12\x1a.staff.v1.GetMemoryRequest\x1a\x14.staff.v1.MemoryItem\x12=\n" +
+	"\x06Update\x12\x1d.staff.v1.UpdateMemoryRequest\x1a\x14.staff.v1.MemoryItem\x12G\n" +
+	"\x06Delete\x12\x1d.staff.v1.DeleteMemoryRequest\x1a\x1e.staff.v1.DeleteMemoryResponse\x12A\n" +
 	"\x04Dump\x12\x1b.staff.v1.DumpMemoryRequest\x1a\x1c.staff.v1.DumpMemoryResponse\x12D\n" +
-	"\x05Clear\x12\x1c.staff.v1.ClearMemoryRequest\x1a\x1d.staff.v1.ClearMemoryResponse2\xd4\x05\n" +
+	"\x05Clear\x12\x1c.staff.v1.ClearMemoryRequest\x1a\x1d.staff.v1.ClearMemoryResponse\x12B\n" +
+	"\aReembed\x12\x1e.staff.v1.ReembedMemoryRequest\x1a\x17.staff.v1.ReembedStatus\x12N\n" +
+	"\x10GetReembedStatus\x12!.staff.v1.GetReembedStatusRequest\x1a\x17.staff.v1.ReembedStatus\x12Q\n" +
+	"\rGetProvenance\x12$.staff.v1.GetMemoryProvenanceRequest\x1a\x1a.staff.v1.MemoryProvenance\x12@\n" +
+	"\n" +
+	"GetProfile\x12\x1b.staff.v1.GetProfileRequest\x1a\x15.staff.v1.UserProfile\x12F\n" +
+	"\rUpdateProfile\x12\x1e.staff.v1.UpdateProfileRequest\x1a\x15.staff.v1.UserProfile\x129\n" +
+	"\tGetEntity\x12\x1a.staff.v1.GetEntityRequest\x1a\x10.staff.v1.Entity2\x99\x03\n" +
+	"\x0fArtifactService\x12P\n" +
+	"\rListArtifacts\x12\x1e.staff.v1.ListArtifactsRequest\x1a\x1f.staff.v1.ListArtifactsResponse\x12?\n" +
+	"\vGetArtifact\x12\x1c.staff.v1.GetArtifactRequest\x1a\x12.staff.v1.Artifact\x12E\n" +
+	"\x0eCreateArtifact\x12\x1f.staff.v1.CreateArtifactRequest\x1a\x12.staff.v1.Artifact\x12E\n" +
+	"\x0eUpdateArtifact\x12\x1f.staff.v1.UpdateArtifactRequest\x1a\x12.staff.v1.Artifact\x12e\n" +
+	"\x14ListArtifactVersions\x12%.staff.v1.ListArtifactVersionsRequest\x1a&.staff.v1.ListArtifactVersionsResponse2\xd4\x05\n" +
 	"\rSystemService\x129\n" +
 	"\aGetInfo\x12\x18.staff.v1.GetInfoRequest\x1a\x14.staff.v1.SystemInfo\x12D\n" +
 	"\tListTools\x12\x1a.staff.v1.ListToolsRequest\x1a\x1b.staff.v1.ListToolsResponse\x12S\n" +
@@ -3472,150 +5866,239 @@ func file_staff_proto_rawDescGZIP() []byte {
 	return file_staff_proto_rawDescData
 }
 
-var file_staff_proto_msgTypes = make([]protoimpl.MessageInfo, 58)
+var file_staff_proto_msgTypes = make([]protoimpl.MessageInfo, 91)
 var file_staff_proto_goTypes = []any{
-	(*ChatRequest)(nil),                // 0: staff.v1.ChatRequest
-	(*ChatEvent)(nil),                  // 1: staff.v1.ChatEvent
-	(*TextDelta)(nil),                  // 2: staff.v1.TextDelta
-	(*ToolUse)(nil),                    // 3: staff.v1.ToolUse
-	(*ToolResult)(nil),                 // 4: staff.v1.ToolResult
-	(*ChatComplete)(nil),               // 5: staff.v1.ChatComplete
-	(*ChatError)(nil),                  // 6: staff.v1.ChatError
-	(*GetThreadRequest)(nil),           // 7: staff.v1.GetThreadRequest
-	(*GetThreadResponse)(nil),          // 8: staff.v1.GetThreadResponse
-	(*LoadHistoryRequest)(nil),         // 9: staff.v1.LoadHistoryRequest
-	(*LoadHistoryResponse)(nil),        // 10: staff.v1.LoadHistoryResponse
-	(*Message)(nil),                    // 11: staff.v1.Message
-	(*ContextRequest)(nil),             // 12: staff.v1.ContextRequest
-	(*ContextResponse)(nil),            // 13: staff.v1.ContextResponse
-	(*ListAgentsRequest)(nil),          // 14: staff.v1.ListAgentsRequest
-	(*ListAgentsResponse)(nil),         // 15: staff.v1.ListAgentsResponse
-	(*Agent)(nil),                      // 16: staff.v1.Agent
-	(*GetAgentRequest)(nil),            // 17: staff.v1.GetAgentRequest
-	(*GetAgentStateRequest)(nil),       // 18: staff.v1.GetAgentStateRequest
-	(*AgentState)(nil),                 // 19: staff.v1.AgentState
-	(*GetAgentStatsRequest)(nil),       // 20: staff.v1.GetAgentStatsRequest
-	(*AgentStats)(nil),                 // 21: staff.v1.AgentStats
-	(*WatchStatesRequest)(nil),         // 22: staff.v1.WatchStatesRequest
-	(*ListInboxRequest)(nil),           // 23: staff.v1.ListInboxRequest
-	(*ListInboxResponse)(nil),          // 24: staff.v1.ListInboxResponse
-	(*InboxItem)(nil),                  // 25: staff.v1.InboxItem
-	(*ArchiveRequest)(nil),             // 26: staff.v1.ArchiveRequest
-	(*ArchiveResponse)(nil),            // 27: staff.v1.ArchiveResponse
-	(*WatchInboxRequest)(nil),          // 28: staff.v1.WatchInboxRequest
-	(*SearchMemoryRequest)(nil),        // 29: staff.v1.SearchMemoryRequest
-	(*SearchMemoryResponse)(nil),       // 30: staff.v1.SearchMemoryResponse
-	(*MemoryItem)(nil),                 // 31: staff.v1.MemoryItem
-	(*StoreMemoryRequest)(nil),         // 32: staff.v1.StoreMemoryRequest
-	(*StoreMemoryResponse)(nil),        // 33: staff.v1.StoreMemoryResponse
-	(*DumpMemoryRequest)(nil),          // 34: staff.v1.DumpMemoryRequest
-	(*DumpMemoryResponse)(nil),         // 35: staff.v1.DumpMemoryResponse
-	(*ClearMemoryRequest)(nil),         // 36: staff.v1.ClearMemoryRequest
-	(*ClearMemoryResponse)(nil),        // 37: staff.v1.ClearMemoryResponse
-	(*GetInfoRequest)(nil),             // 38: staff.v1.GetInfoRequest
-	(*SystemInfo)(nil),                 // 39: staff.v1.SystemInfo
-	(*ListToolsRequest)(nil),           // 40: staff.v1.ListToolsRequest
-	(*ListToolsResponse)(nil),          // 41: staff.v1.ListToolsResponse
-	(*ToolInfo)(nil),                   // 42: staff.v1.ToolInfo
-	(*ListMCPServersRequest)(nil),      // 43: staff.v1.ListMCPServersRequest
-	(*ListMCPServersResponse)(nil),     // 44: staff.v1.ListMCPServersResponse
-	(*MCPServerInfo)(nil),              // 45: staff.v1.MCPServerInfo
-	(*DumpToolSchemasRequest)(nil),     // 46: staff.v1.DumpToolSchemasRequest
-	(*DumpToolSchemasResponse)(nil),    // 47: staff.v1.DumpToolSchemasResponse
-	(*DumpConversationsRequest)(nil),   // 48: staff.v1.DumpConversationsRequest
-	(*DumpConversationsResponse)(nil),  // 49: staff.v1.DumpConversationsResponse
-	(*ClearConversationsRequest)(nil),  // 50: staff.v1.ClearConversationsRequest
-	(*ClearConversationsResponse)(nil), // 51: staff.v1.ClearConversationsResponse
-	(*ResetStatsRequest)(nil),          // 52: staff.v1.ResetStatsRequest
-	(*ResetStatsResponse)(nil),         // 53: staff.v1.ResetStatsResponse
-	(*DumpInboxRequest)(nil),           // 54: staff.v1.DumpInboxRequest
-	(*DumpInboxResponse)(nil),          // 55: staff.v1.DumpInboxResponse
-	(*ClearInboxRequest)(nil),          // 56: staff.v1.ClearInboxRequest
-	(*ClearInboxResponse)(nil),         // 57: staff.v1.ClearInboxResponse
-	(*timestamppb.Timestamp)(nil),      // 58: google.protobuf.Timestamp
-	(*structpb.Struct)(nil),            // 59: google.protobuf.Struct
+	(*ChatRequest)(nil),                  // 0: staff.v1.ChatRequest
+	(*Attachment)(nil),                   // 1: staff.v1.Attachment
+	(*ChatEvent)(nil),                    // 2: staff.v1.ChatEvent
+	(*TextDelta)(nil),                    // 3: staff.v1.TextDelta
+	(*ReasoningDelta)(nil),               // 4: staff.v1.ReasoningDelta
+	(*ToolUse)(nil),                      // 5: staff.v1.ToolUse
+	(*ToolInputDelta)(nil),               // 6: staff.v1.ToolInputDelta
+	(*ToolResult)(nil),                   // 7: staff.v1.ToolResult
+	(*Usage)(nil),                        // 8: staff.v1.Usage
+	(*ChatComplete)(nil),                 // 9: staff.v1.ChatComplete
+	(*ChatError)(nil),                    // 10: staff.v1.ChatError
+	(*GetThreadRequest)(nil),             // 11: staff.v1.GetThreadRequest
+	(*GetThreadResponse)(nil),            // 12: staff.v1.GetThreadResponse
+	(*LoadHistoryRequest)(nil),           // 13: staff.v1.LoadHistoryRequest
+	(*LoadHistoryResponse)(nil),          // 14: staff.v1.LoadHistoryResponse
+	(*Message)(nil),                      // 15: staff.v1.Message
+	(*ContextRequest)(nil),               // 16: staff.v1.ContextRequest
+	(*ContextResponse)(nil),              // 17: staff.v1.ContextResponse
+	(*PinMessageResponse)(nil),           // 18: staff.v1.PinMessageResponse
+	(*ListAgentsRequest)(nil),            // 19: staff.v1.ListAgentsRequest
+	(*ListAgentsResponse)(nil),           // 20: staff.v1.ListAgentsResponse
+	(*Agent)(nil),                        // 21: staff.v1.Agent
+	(*GetAgentRequest)(nil),              // 22: staff.v1.GetAgentRequest
+	(*GetAgentStateRequest)(nil),         // 23: staff.v1.GetAgentStateRequest
+	(*AgentState)(nil),                   // 24: staff.v1.AgentState
+	(*GetAgentStatsRequest)(nil),         // 25: staff.v1.GetAgentStatsRequest
+	(*AgentStats)(nil),                   // 26: staff.v1.AgentStats
+	(*WatchStatesRequest)(nil),           // 27: staff.v1.WatchStatesRequest
+	(*ListInboxRequest)(nil),             // 28: staff.v1.ListInboxRequest
+	(*ListInboxResponse)(nil),            // 29: staff.v1.ListInboxResponse
+	(*InboxItem)(nil),                    // 30: staff.v1.InboxItem
+	(*ArchiveRequest)(nil),               // 31: staff.v1.ArchiveRequest
+	(*ArchiveResponse)(nil),              // 32: staff.v1.ArchiveResponse
+	(*WatchInboxRequest)(nil),            // 33: staff.v1.WatchInboxRequest
+	(*ConfirmFactRequest)(nil),           // 34: staff.v1.ConfirmFactRequest
+	(*ConfirmFactResponse)(nil),          // 35: staff.v1.ConfirmFactResponse
+	(*SearchMemoryRequest)(nil),          // 36: staff.v1.SearchMemoryRequest
+	(*SearchMemoryResponse)(nil),         // 37: staff.v1.SearchMemoryResponse
+	(*MemoryItem)(nil),                   // 38: staff.v1.MemoryItem
+	(*MemoryMatch)(nil),                  // 39: staff.v1.MemoryMatch
+	(*StoreMemoryRequest)(nil),           // 40: staff.v1.StoreMemoryRequest
+	(*StoreMemoryResponse)(nil),          // 41: staff.v1.StoreMemoryResponse
+	(*GetMemoryRequest)(nil),             // 42: staff.v1.GetMemoryRequest
+	(*UpdateMemoryRequest)(nil),          // 43: staff.v1.UpdateMemoryRequest
+	(*DeleteMemoryRequest)(nil),          // 44: staff.v1.DeleteMemoryRequest
+	(*DeleteMemoryResponse)(nil),         // 45: staff.v1.DeleteMemoryResponse
+	(*DumpMemoryRequest)(nil),            // 46: staff.v1.DumpMemoryRequest
+	(*DumpMemoryResponse)(nil),           // 47: staff.v1.DumpMemoryResponse
+	(*ClearMemoryRequest)(nil),           // 48: staff.v1.ClearMemoryRequest
+	(*ClearMemoryResponse)(nil),          // 49: staff.v1.ClearMemoryResponse
+	(*ReembedMemoryRequest)(nil),         // 50: staff.v1.ReembedMemoryRequest
+	(*GetReembedStatusRequest)(nil),      // 51: staff.v1.GetReembedStatusRequest
+	(*ReembedStatus)(nil),                // 52: staff.v1.ReembedStatus
+	(*GetMemoryProvenanceRequest)(nil),   // 53: staff.v1.GetMemoryProvenanceRequest
+	(*MemoryProvenance)(nil),             // 54: staff.v1.MemoryProvenance
+	(*SourceMessage)(nil),                // 55: staff.v1.SourceMessage
+	(*GetProfileRequest)(nil),            // 56: staff.v1.GetProfileRequest
+	(*UpdateProfileRequest)(nil),         // 57: staff.v1.UpdateProfileRequest
+	(*UserProfile)(nil),                  // 58: staff.v1.UserProfile
+	(*GetEntityRequest)(nil),             // 59: staff.v1.GetEntityRequest
+	(*Entity)(nil),                       // 60: staff.v1.Entity
+	(*EntityRelation)(nil),               // 61: staff.v1.EntityRelation
+	(*RelatedEntity)(nil),                // 62: staff.v1.RelatedEntity
+	(*ListArtifactsRequest)(nil),         // 63: staff.v1.ListArtifactsRequest
+	(*ListArtifactsResponse)(nil),        // 64: staff.v1.ListArtifactsResponse
+	(*Artifact)(nil),                     // 65: staff.v1.Artifact
+	(*GetArtifactRequest)(nil),           // 66: staff.v1.GetArtifactRequest
+	(*CreateArtifactRequest)(nil),        // 67: staff.v1.CreateArtifactRequest
+	(*UpdateArtifactRequest)(nil),        // 68: staff.v1.UpdateArtifactRequest
+	(*ListArtifactVersionsRequest)(nil),  // 69: staff.v1.ListArtifactVersionsRequest
+	(*ListArtifactVersionsResponse)(nil), // 70: staff.v1.ListArtifactVersionsResponse
+	(*GetInfoRequest)(nil),               // 71: staff.v1.GetInfoRequest
+	(*SystemInfo)(nil),                   // 72: staff.v1.SystemInfo
+	(*ListToolsRequest)(nil),             // 73: staff.v1.ListToolsRequest
+	(*ListToolsResponse)(nil),            // 74: staff.v1.ListToolsResponse
+	(*ToolInfo)(nil),                     // 75: staff.v1.ToolInfo
+	(*ListMCPServersRequest)(nil),        // 76: staff.v1.ListMCPServersRequest
+	(*ListMCPServersResponse)(nil),       // 77: staff.v1.ListMCPServersResponse
+	(*MCPServerInfo)(nil),                // 78: staff.v1.MCPServerInfo
+	(*DumpToolSchemasRequest)(nil),       // 79: staff.v1.DumpToolSchemasRequest
+	(*DumpToolSchemasResponse)(nil),      // 80: staff.v1.DumpToolSchemasResponse
+	(*DumpConversationsRequest)(nil),     // 81: staff.v1.DumpConversationsRequest
+	(*DumpConversationsResponse)(nil),    // 82: staff.v1.DumpConversationsResponse
+	(*ClearConversationsRequest)(nil),    // 83: staff.v1.ClearConversationsRequest
+	(*ClearConversationsResponse)(nil),   // 84: staff.v1.ClearConversationsResponse
+	(*ResetStatsRequest)(nil),            // 85: staff.v1.ResetStatsRequest
+	(*ResetStatsResponse)(nil),           // 86: staff.v1.ResetStatsResponse
+	(*DumpInboxRequest)(nil),             // 87: staff.v1.DumpInboxRequest
+	(*DumpInboxResponse)(nil),            // 88: staff.v1.DumpInboxResponse
+	(*ClearInboxRequest)(nil),            // 89: staff.v1.ClearInboxRequest
+	(*ClearInboxResponse)(nil),           // 90: staff.v1.ClearInboxResponse
+	(*timestamppb.Timestamp)(nil),        // 91: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),              // 92: google.protobuf.Struct
 }
 var file_staff_proto_depIdxs = []int32{
-	2,  // 0: staff.v1.ChatEvent.text_delta:type_name -> staff.v1.TextDelta
-	3,  // 1: staff.v1.ChatEvent.tool_use:type_name -> staff.v1.ToolUse
-	4,  // 2: staff.v1.ChatEvent.tool_result:type_name -> staff.v1.ToolResult
-	5,  // 3: staff.v1.ChatEvent.complete:type_name -> staff.v1.ChatComplete
-	6,  // 4: staff.v1.ChatEvent.error:type_name -> staff.v1.ChatError
-	11, // 5: staff.v1.LoadHistoryResponse.messages:type_name -> staff.v1.Message
-	16, // 6: staff.v1.ListAgentsResponse.agents:type_name -> staff.v1.Agent
-	58, // 7: staff.v1.AgentState.next_wake:type_name -> google.protobuf.Timestamp
-	58, // 8: staff.v1.AgentState.updated_at:type_name -> google.protobuf.Timestamp
-	58, // 9: staff.v1.AgentStats.last_execution:type_name -> google.protobuf.Timestamp
-	58, // 10: staff.v1.AgentStats.last_failure:type_name -> google.protobuf.Timestamp
-	25, // 11: staff.v1.ListInboxResponse.items:type_name -> staff.v1.InboxItem
-	58, // 12: staff.v1.InboxItem.response_at:type_name -> google.protobuf.Timestamp
-	58, // 13: staff.v1.InboxItem.archived_at:type_name -> google.protobuf.Timestamp
-	58, // 14: staff.v1.InboxItem.created_at:type_name -> google.protobuf.Timestamp
-	58, // 15: staff.v1.InboxItem.updated_at:type_name -> google.protobuf.Timestamp
-	31, // 16: staff.v1.SearchMemoryResponse.items:type_name -> staff.v1.MemoryItem
-	59, // 17: staff.v1.MemoryItem.metadata:type_name -> google.protobuf.Struct
-	58, // 18: staff.v1.MemoryItem.created_at:type_name -> google.protobuf.Timestamp
-	59, // 19: staff.v1.StoreMemoryRequest.metadata:type_name -> google.protobuf.Struct
-	58, // 20: staff.v1.SystemInfo.started_at:type_name -> google.protobuf.Timestamp
-	42, // 21: staff.v1.ListToolsResponse.tools:type_name -> staff.v1.ToolInfo
-	45, // 22: staff.v1.ListMCPServersResponse.servers:type_name -> staff.v1.MCPServerInfo
-	0,  // 23: staff.v1.ChatService.Chat:input_type -> staff.v1.ChatRequest
-	7,  // 24: staff.v1.ChatService.GetOrCreateThread:input_type -> staff.v1.GetThreadRequest
-	9,  // 25: staff.v1.ChatService.LoadHistory:input_type -> staff.v1.LoadHistoryRequest
-	12, // 26: staff.v1.ChatService.ResetContext:input_type -> staff.v1.ContextRequest
-	12, // 27: staff.v1.ChatService.CompressContext:input_type -> staff.v1.ContextRequest
-	14, // 28: staff.v1.AgentService.ListAgents:input_type -> staff.v1.ListAgentsRequest
-	17, // 29: staff.v1.AgentService.GetAgent:input_type -> staff.v1.GetAgentRequest
-	18, // 30: staff.v1.AgentService.GetAgentState:input_type -> staff.v1.GetAgentStateRequest
-	20, // 31: staff.v1.AgentService.GetAgentStats:input_type -> staff.v1.GetAgentStatsRequest
-	22, // 32: staff.v1.AgentService.WatchStates:input_type -> staff.v1.WatchStatesRequest
-	23, // 33: staff.v1.InboxService.ListItems:input_type -> staff.v1.ListInboxRequest
-	26, // 34: staff.v1.InboxService.Archive:input_type -> staff.v1.ArchiveRequest
-	28, // 35: staff.v1.InboxService.Watch:input_type -> staff.v1.WatchInboxRequest
-	29, // 36: staff.v1.MemoryService.Search:input_type -> staff.v1.SearchMemoryRequest
-	32, // 37: staff.v1.MemoryService.Store:input_type -> staff.v1.StoreMemoryRequest
-	34, // 38: staff.v1.MemoryService.Dump:input_type -> staff.v1.DumpMemoryRequest
-	36, // 39: staff.v1.MemoryService.Clear:input_type -> staff.v1.ClearMemoryRequest
-	38, // 40: staff.v1.SystemService.GetInfo:input_type -> staff.v1.GetInfoRequest
-	40, // 41: staff.v1.SystemService.ListTools:input_type -> staff.v1.ListToolsRequest
-	43, // 42: staff.v1.SystemService.ListMCPServers:input_type -> staff.v1.ListMCPServersRequest
-	46, // 43: staff.v1.SystemService.DumpToolSchemas:input_type -> staff.v1.DumpToolSchemasRequest
-	48, // 44: staff.v1.SystemService.DumpConversations:input_type -> staff.v1.DumpConversationsRequest
-	50, // 45: staff.v1.SystemService.ClearConversations:input_type -> staff.v1.ClearConversationsRequest
-	52, // 46: staff.v1.SystemService.ResetStats:input_type -> staff.v1.ResetStatsRequest
-	54, // 47: staff.v1.SystemService.DumpInbox:input_type -> staff.v1.DumpInboxRequest
-	56, // 48: staff.v1.SystemService.ClearInbox:input_type -> staff.v1.ClearInboxRequest
-	1,  // 49: staff.v1.ChatService.Chat:output_type -> staff.v1.ChatEvent
-	8,  // 50: staff.v1.ChatService.GetOrCreateThread:output_type -> staff.v1.GetThreadResponse
-	10, // 51: staff.v1.ChatService.LoadHistory:output_type -> staff.v1.LoadHistoryResponse
-	13, // 52: staff.v1.ChatService.ResetContext:output_type -> staff.v1.ContextResponse
-	13, // 53: staff.v1.ChatService.CompressContext:output_type -> staff.v1.ContextResponse
-	15, // 54: staff.v1.AgentService.ListAgents:output_type -> staff.v1.ListAgentsResponse
-	16, // 55: staff.v1.AgentService.GetAgent:output_type -> staff.v1.Agent
-	19, // 56: staff.v1.AgentService.GetAgentState:output_type -> staff.v1.AgentState
-	21, // 57: staff.v1.AgentService.GetAgentStats:output_type -> staff.v1.AgentStats
-	19, // 58: staff.v1.AgentService.WatchStates:output_type -> staff.v1.AgentState
-	24, // 59: staff.v1.InboxService.ListItems:output_type -> staff.v1.ListInboxResponse
-	27, // 60: staff.v1.InboxService.Archive:output_type -> staff.v1.ArchiveResponse
-	25, // 61: staff.v1.InboxService.Watch:output_type -> staff.v1.InboxItem
-	30, // 62: staff.v1.MemoryService.Search:output_type -> staff.v1.SearchMemoryResponse
-	33, // 63: staff.v1.MemoryService.Store:output_type -> staff.v1.StoreMemoryResponse
-	35, // 64: staff.v1.MemoryService.Dump:output_type -> staff.v1.DumpMemoryResponse
-	37, // 65: staff.v1.MemoryService.Clear:output_type -> staff.v1.ClearMemoryResponse
-	39, // 66: staff.v1.SystemService.GetInfo:output_type -> staff.v1.SystemInfo
-	41, // 67: staff.v1.SystemService.ListTools:output_type -> staff.v1.ListToolsResponse
-	44, // 68: staff.v1.SystemService.ListMCPServers:output_type -> staff.v1.ListMCPServersResponse
-	47, // 69: staff.v1.SystemService.DumpToolSchemas:output_type -> staff.v1.DumpToolSchemasResponse
-	49, // 70: staff.v1.SystemService.DumpConversations:output_type -> staff.v1.DumpConversationsResponse
-	51, // 71: staff.v1.SystemService.ClearConversations:output_type -> staff.v1.ClearConversationsResponse
-	53, // 72: staff.v1.SystemService.ResetStats:output_type -> staff.v1.ResetStatsResponse
-	55, // 73: staff.v1.SystemService.DumpInbox:output_type -> staff.v1.DumpInboxResponse
-	57, // 74: staff.v1.SystemService.ClearInbox:output_type -> staff.v1.ClearInboxResponse
-	49, // [49:75] is the sub-list for method output_type
-	23, // [23:49] is the sub-list for method input_type
-	23, // [23:23] is the sub-list for extension type_name
-	23, // [23:23] is the sub-list for extension extendee
-	0,  // [0:23] is the sub-list for field type_name
+	1,  // 0: staff.v1.ChatRequest.attachments:type_name -> staff.v1.Attachment
+	3,  // 1: staff.v1.ChatEvent.text_delta:type_name -> staff.v1.TextDelta
+	5,  // 2: staff.v1.ChatEvent.tool_use:type_name -> staff.v1.ToolUse
+	7,  // 3: staff.v1.ChatEvent.tool_result:type_name -> staff.v1.ToolResult
+	9,  // 4: staff.v1.ChatEvent.complete:type_name -> staff.v1.ChatComplete
+	10, // 5: staff.v1.ChatEvent.error:type_name -> staff.v1.ChatError
+	4,  // 6: staff.v1.ChatEvent.reasoning_delta:type_name -> staff.v1.ReasoningDelta
+	6,  // 7: staff.v1.ChatEvent.tool_input_delta:type_name -> staff.v1.ToolInputDelta
+	8,  // 8: staff.v1.ChatEvent.usage:type_name -> staff.v1.Usage
+	15, // 9: staff.v1.LoadHistoryResponse.messages:type_name -> staff.v1.Message
+	21, // 10: staff.v1.ListAgentsResponse.agents:type_name -> staff.v1.Agent
+	91, // 11: staff.v1.AgentState.next_wake:type_name -> google.protobuf.Timestamp
+	91, // 12: staff.v1.AgentState.updated_at:type_name -> google.protobuf.Timestamp
+	91, // 13: staff.v1.AgentStats.last_execution:type_name -> google.protobuf.Timestamp
+	91, // 14: staff.v1.AgentStats.last_failure:type_name -> google.protobuf.Timestamp
+	30, // 15: staff.v1.ListInboxResponse.items:type_name -> staff.v1.InboxItem
+	91, // 16: staff.v1.InboxItem.response_at:type_name -> google.protobuf.Timestamp
+	91, // 17: staff.v1.InboxItem.archived_at:type_name -> google.protobuf.Timestamp
+	91, // 18: staff.v1.InboxItem.created_at:type_name -> google.protobuf.Timestamp
+	91, // 19: staff.v1.InboxItem.updated_at:type_name -> google.protobuf.Timestamp
+	38, // 20: staff.v1.SearchMemoryResponse.items:type_name -> staff.v1.MemoryItem
+	92, // 21: staff.v1.MemoryItem.metadata:type_name -> google.protobuf.Struct
+	91, // 22: staff.v1.MemoryItem.created_at:type_name -> google.protobuf.Timestamp
+	91, // 23: staff.v1.MemoryItem.updated_at:type_name -> google.protobuf.Timestamp
+	39, // 24: staff.v1.MemoryItem.match:type_name -> staff.v1.MemoryMatch
+	92, // 25: staff.v1.StoreMemoryRequest.metadata:type_name -> google.protobuf.Struct
+	92, // 26: staff.v1.UpdateMemoryRequest.metadata:type_name -> google.protobuf.Struct
+	91, // 27: staff.v1.ReembedStatus.started_at:type_name -> google.protobuf.Timestamp
+	91, // 28: staff.v1.ReembedStatus.finished_at:type_name -> google.protobuf.Timestamp
+	55, // 29: staff.v1.MemoryProvenance.messages:type_name -> staff.v1.SourceMessage
+	91, // 30: staff.v1.SourceMessage.timestamp:type_name -> google.protobuf.Timestamp
+	91, // 31: staff.v1.UserProfile.updated_at:type_name -> google.protobuf.Timestamp
+	91, // 32: staff.v1.Entity.created_at:type_name -> google.protobuf.Timestamp
+	91, // 33: staff.v1.Entity.updated_at:type_name -> google.protobuf.Timestamp
+	38, // 34: staff.v1.Entity.memories:type_name -> staff.v1.MemoryItem
+	61, // 35: staff.v1.Entity.relations:type_name -> staff.v1.EntityRelation
+	62, // 36: staff.v1.Entity.related:type_name -> staff.v1.RelatedEntity
+	65, // 37: staff.v1.ListArtifactsResponse.artifacts:type_name -> staff.v1.Artifact
+	92, // 38: staff.v1.Artifact.metadata:type_name -> google.protobuf.Struct
+	91, // 39: staff.v1.Artifact.created_at:type_name -> google.protobuf.Timestamp
+	91, // 40: staff.v1.Artifact.updated_at:type_name -> google.protobuf.Timestamp
+	92, // 41: staff.v1.CreateArtifactRequest.metadata:type_name -> google.protobuf.Struct
+	92, // 42: staff.v1.UpdateArtifactRequest.metadata:type_name -> google.protobuf.Struct
+	65, // 43: staff.v1.ListArtifactVersionsResponse.versions:type_name -> staff.v1.Artifact
+	91, // 44: staff.v1.SystemInfo.started_at:type_name -> google.protobuf.Timestamp
+	75, // 45: staff.v1.ListToolsResponse.tools:type_name -> staff.v1.ToolInfo
+	78, // 46: staff.v1.ListMCPServersResponse.servers:type_name -> staff.v1.MCPServerInfo
+	0,  // 47: staff.v1.ChatService.Chat:input_type -> staff.v1.ChatRequest
+	11, // 48: staff.v1.ChatService.GetOrCreateThread:input_type -> staff.v1.GetThreadRequest
+	13, // 49: staff.v1.ChatService.LoadHistory:input_type -> staff.v1.LoadHistoryRequest
+	16, // 50: staff.v1.ChatService.ResetContext:input_type -> staff.v1.ContextRequest
+	16, // 51: staff.v1.ChatService.CompressContext:input_type -> staff.v1.ContextRequest
+	16, // 52: staff.v1.ChatService.PinLastUserMessage:input_type -> staff.v1.ContextRequest
+	19, // 53: staff.v1.AgentService.ListAgents:input_type -> staff.v1.ListAgentsRequest
+	22, // 54: staff.v1.AgentService.GetAgent:input_type -> staff.v1.GetAgentRequest
+	23, // 55: staff.v1.AgentService.GetAgentState:input_type -> staff.v1.GetAgentStateRequest
+	25, // 56: staff.v1.AgentService.GetAgentStats:input_type -> staff.v1.GetAgentStatsRequest
+	27, // 57: staff.v1.AgentService.WatchStates:input_type -> staff.v1.WatchStatesRequest
+	28, // 58: staff.v1.InboxService.ListItems:input_type -> staff.v1.ListInboxRequest
+	31, // 59: staff.v1.InboxService.Archive:input_type -> staff.v1.ArchiveRequest
+	33, // 60: staff.v1.InboxService.Watch:input_type -> staff.v1.WatchInboxRequest
+	34, // 61: staff.v1.InboxService.ConfirmFact:input_type -> staff.v1.ConfirmFactRequest
+	36, // 62: staff.v1.MemoryService.Search:input_type -> staff.v1.SearchMemoryRequest
+	40, // 63: staff.v1.MemoryService.Store:input_type -> staff.v1.StoreMemoryRequest
+	42, // 64: staff.v1.MemoryService.Get:input_type -> staff.v1.GetMemoryRequest
+	43, // 65: staff.v1.MemoryService.Update:input_type -> staff.v1.UpdateMemoryRequest
+	44, // 66: staff.v1.MemoryService.Delete:input_type -> staff.v1.DeleteMemoryRequest
+	46, // 67: staff.v1.MemoryService.Dump:input_type -> staff.v1.DumpMemoryRequest
+	48, // 68: staff.v1.MemoryService.Clear:input_type -> staff.v1.ClearMemoryRequest
+	50, // 69: staff.v1.MemoryService.Reembed:input_type -> staff.v1.ReembedMemoryRequest
+	51, // 70: staff.v1.MemoryService.GetReembedStatus:input_type -> staff.v1.GetReembedStatusRequest
+	53, // 71: staff.v1.MemoryService.GetProvenance:input_type -> staff.v1.GetMemoryProvenanceRequest
+	56, // 72: staff.v1.MemoryService.GetProfile:input_type -> staff.v1.GetProfileRequest
+	57, // 73: staff.v1.MemoryService.UpdateProfile:input_type -> staff.v1.UpdateProfileRequest
+	59, // 74: staff.v1.MemoryService.GetEntity:input_type -> staff.v1.GetEntityRequest
+	63, // 75: staff.v1.ArtifactService.ListArtifacts:input_type -> staff.v1.ListArtifactsRequest
+	66, // 76: staff.v1.ArtifactService.GetArtifact:input_type -> staff.v1.GetArtifactRequest
+	67, // 77: staff.v1.ArtifactService.CreateArtifact:input_type -> staff.v1.CreateArtifactRequest
+	68, // 78: staff.v1.ArtifactService.UpdateArtifact:input_type -> staff.v1.UpdateArtifactRequest
+	69, // 79: staff.v1.ArtifactService.ListArtifactVersions:input_type -> staff.v1.ListArtifactVersionsRequest
+	71, // 80: staff.v1.SystemService.GetInfo:input_type -> staff.v1.GetInfoRequest
+	73, // 81: staff.v1.SystemService.ListTools:input_type -> staff.v1.ListToolsRequest
+	76, // 82: staff.v1.SystemService.ListMCPServers:input_type -> staff.v1.ListMCPServersRequest
+	79, // 83: staff.v1.SystemService.DumpToolSchemas:input_type -> staff.v1.DumpToolSchemasRequest
+	81, // 84: staff.v1.SystemService.DumpConversations:input_type -> staff.v1.DumpConversationsRequest
+	83, // 85: staff.v1.SystemService.ClearConversations:input_type -> staff.v1.ClearConversationsRequest
+	85, // 86: staff.v1.SystemService.ResetStats:input_type -> staff.v1.ResetStatsRequest
+	87, // 87: staff.v1.SystemService.DumpInbox:input_type -> staff.v1.DumpInboxRequest
+	89, // 88: staff.v1.SystemService.ClearInbox:input_type -> staff.v1.ClearInboxRequest
+	2,  // 89: staff.v1.ChatService.Chat:output_type -> staff.v1.ChatEvent
+	12, // 90: staff.v1.ChatService.GetOrCreateThread:output_type -> staff.v1.GetThreadResponse
+	14, // 91: staff.v1.ChatService.LoadHistory:output_type -> staff.v1.LoadHistoryResponse
+	17, // 92: staff.v1.ChatService.ResetContext:output_type -> staff.v1.ContextResponse
+	17, // 93: staff.v1.ChatService.CompressContext:output_type -> staff.v1.ContextResponse
+	18, // 94: staff.v1.ChatService.PinLastUserMessage:output_type -> staff.v1.PinMessageResponse
+	20, // 95: staff.v1.AgentService.ListAgents:output_type -> staff.v1.ListAgentsResponse
+	21, // 96: staff.v1.AgentService.GetAgent:output_type -> staff.v1.Agent
+	24, // 97: staff.v1.AgentService.GetAgentState:output_type -> staff.v1.AgentState
+	26, // 98: staff.v1.AgentService.GetAgentStats:output_type -> staff.v1.AgentStats
+	24, // 99: staff.v1.AgentService.WatchStates:output_type -> staff.v1.AgentState
+	29, // 100: staff.v1.InboxService.ListItems:output_type -> staff.v1.ListInboxResponse
+	32, // 101: staff.v1.InboxService.Archive:output_type -> staff.v1.ArchiveResponse
+	30, // 102: staff.v1.InboxService.Watch:output_type -> staff.v1.InboxItem
+	35, // 103: staff.v1.InboxService.ConfirmFact:output_type -> staff.v1.ConfirmFactResponse
+	37, // 104: staff.v1.MemoryService.Search:output_type -> staff.v1.SearchMemoryResponse
+	41, // 105: staff.v1.MemoryService.Store:output_type -> staff.v1.StoreMemoryResponse
+	38, // 106: staff.v1.MemoryService.Get:output_type -> staff.v1.MemoryItem
+	38, // 107: staff.v1.MemoryService.Update:output_type -> staff.v1.MemoryItem
+	45, // 108: staff.v1.MemoryService.Delete:output_type -> staff.v1.DeleteMemoryResponse
+	47, // 109: staff.v1.MemoryService.Dump:output_type -> staff.v1.DumpMemoryResponse
+	49, // 110: staff.v1.MemoryService.Clear:output_type -> staff.v1.ClearMemoryResponse
+	52, // 111: staff.v1.MemoryService.Reembed:output_type -> staff.v1.ReembedStatus
+	52, // 112: staff.v1.MemoryService.GetReembedStatus:output_type -> staff.v1.ReembedStatus
+	54, // 113: staff.v1.MemoryService.GetProvenance:output_type -> staff.v1.MemoryProvenance
+	58, // 114: staff.v1.MemoryService.GetProfile:output_type -> staff.v1.UserProfile
+	58, // 115: staff.v1.MemoryService.UpdateProfile:output_type -> staff.v1.UserProfile
+	60, // 116: staff.v1.MemoryService.GetEntity:output_type -> staff.v1.Entity
+	64, // 117: staff.v1.ArtifactService.ListArtifacts:output_type -> staff.v1.ListArtifactsResponse
+	65, // 118: staff.v1.ArtifactService.GetArtifact:output_type -> staff.v1.Artifact
+	65, // 119: staff.v1.ArtifactService.CreateArtifact:output_type -> staff.v1.Artifact
+	65, // 120: staff.v1.ArtifactService.UpdateArtifact:output_type -> staff.v1.Artifact
+	70, // 121: staff.v1.ArtifactService.ListArtifactVersions:output_type -> staff.v1.ListArtifactVersionsResponse
+	72, // 122: staff.v1.SystemService.GetInfo:output_type -> staff.v1.SystemInfo
+	74, // 123: staff.v1.SystemService.ListTools:output_type -> staff.v1.ListToolsResponse
+	77, // 124: staff.v1.SystemService.ListMCPServers:output_type -> staff.v1.ListMCPServersResponse
+	80, // 125: staff.v1.SystemService.DumpToolSchemas:output_type -> staff.v1.DumpToolSchemasResponse
+	82, // 126: staff.v1.SystemService.DumpConversations:output_type -> staff.v1.DumpConversationsResponse
+	84, // 127: staff.v1.SystemService.ClearConversations:output_type -> staff.v1.ClearConversationsResponse
+	86, // 128: staff.v1.SystemService.ResetStats:output_type -> staff.v1.ResetStatsResponse
+	88, // 129: staff.v1.SystemService.DumpInbox:output_type -> staff.v1.DumpInboxResponse
+	90, // 130: staff.v1.SystemService.ClearInbox:output_type -> staff.v1.ClearInboxResponse
+	89, // [89:131] is the sub-list for method output_type
+	47, // [47:89] is the sub-list for method input_type
+	47, // [47:47] is the sub-list for extension type_name
+	47, // [47:47] is the sub-list for extension extendee
+	0,  // [0:47] is the sub-list for field type_name
 }
 
 func init() { file_staff_proto_init() }
@@ -3623,22 +6106,27 @@ func file_staff_proto_init() {
 	if File_staff_proto != nil {
 		return
 	}
-	file_staff_proto_msgTypes[1].OneofWrappers = []any{
+	file_staff_proto_msgTypes[2].OneofWrappers = []any{
 		(*ChatEvent_TextDelta)(nil),
 		(*ChatEvent_ToolUse)(nil),
 		(*ChatEvent_ToolResult)(nil),
 		(*ChatEvent_Complete)(nil),
 		(*ChatEvent_Error)(nil),
+		(*ChatEvent_ReasoningDelta)(nil),
+		(*ChatEvent_ToolInputDelta)(nil),
+		(*ChatEvent_Usage)(nil),
 	}
+	file_staff_proto_msgTypes[43].OneofWrappers = []any{}
+	file_staff_proto_msgTypes[68].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_staff_proto_rawDesc), len(file_staff_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   58,
+			NumMessages:   91,
 			NumExtensions: 0,
-			NumServices:   5,
+			NumServices:   6,
 		},
 		GoTypes:           file_staff_proto_goTypes,
 		DependencyIndexes: file_staff_proto_depIdxs,
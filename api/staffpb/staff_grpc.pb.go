@@ -19,11 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ChatService_Chat_FullMethodName              = "/staff.v1.ChatService/Chat"
-	ChatService_GetOrCreateThread_FullMethodName = "/staff.v1.ChatService/GetOrCreateThread"
-	ChatService_LoadHistory_FullMethodName       = "/staff.v1.ChatService/LoadHistory"
-	ChatService_ResetContext_FullMethodName      = "/staff.v1.ChatService/ResetContext"
-	ChatService_CompressContext_FullMethodName   = "/staff.v1.ChatService/CompressContext"
+	ChatService_Chat_FullMethodName               = "/staff.v1.ChatService/Chat"
+	ChatService_GetOrCreateThread_FullMethodName  = "/staff.v1.ChatService/GetOrCreateThread"
+	ChatService_LoadHistory_FullMethodName        = "/staff.v1.ChatService/LoadHistory"
+	ChatService_ResetContext_FullMethodName       = "/staff.v1.ChatService/ResetContext"
+	ChatService_CompressContext_FullMethodName    = "/staff.v1.ChatService/CompressContext"
+	ChatService_PinLastUserMessage_FullMethodName = "/staff.v1.ChatService/PinLastUserMessage"
 )
 
 // ChatServiceClient is the client API for ChatService service.
@@ -40,6 +41,8 @@ type ChatServiceClient interface {
 	ResetContext(ctx context.Context, in *ContextRequest, opts ...grpc.CallOption) (*ContextResponse, error)
 	// Compress conversation context (summarizes and resets)
 	CompressContext(ctx context.Context, in *ContextRequest, opts ...grpc.CallOption) (*ContextResponse, error)
+	// Pin the most recent user message so it survives context compression
+	PinLastUserMessage(ctx context.Context, in *ContextRequest, opts ...grpc.CallOption) (*PinMessageResponse, error)
 }
 
 type chatServiceClient struct {
@@ -109,6 +112,16 @@ func (c *chatServiceClient) CompressContext(ctx context.Context, in *ContextRequ
 	return out, nil
 }
 
+func (c *chatServiceClient) PinLastUserMessage(ctx context.Context, in *ContextRequest, opts ...grpc.CallOption) (*PinMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PinMessageResponse)
+	err := c.cc.Invoke(ctx, ChatService_PinLastUserMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ChatServiceServer is the server API for ChatService service.
 // All implementations must embed UnimplementedChatServiceServer
 // for forward compatibility.
@@ -123,6 +136,8 @@ type ChatServiceServer interface {
 	ResetContext(context.Context, *ContextRequest) (*ContextResponse, error)
 	// Compress conversation context (summarizes and resets)
 	CompressContext(context.Context, *ContextRequest) (*ContextResponse, error)
+	// Pin the most recent user message so it survives context compression
+	PinLastUserMessage(context.Context, *ContextRequest) (*PinMessageResponse, error)
 	mustEmbedUnimplementedChatServiceServer()
 }
 
@@ -148,6 +163,9 @@ func (UnimplementedChatServiceServer) ResetContext(context.Context, *ContextRequ
 func (UnimplementedChatServiceServer) CompressContext(context.Context, *ContextRequest) (*ContextResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CompressContext not implemented")
 }
+func (UnimplementedChatServiceServer) PinLastUserMessage(context.Context, *ContextRequest) (*PinMessageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PinLastUserMessage not implemented")
+}
 func (UnimplementedChatServiceServer) mustEmbedUnimplementedChatServiceServer() {}
 func (UnimplementedChatServiceServer) testEmbeddedByValue()                     {}
 
@@ -252,6 +270,24 @@ func _ChatService_CompressContext_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ChatService_PinLastUserMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContextRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).PinLastUserMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_PinLastUserMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).PinLastUserMessage(ctx, req.(*ContextRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ChatService_ServiceDesc is the grpc.ServiceDesc for ChatService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -275,6 +311,10 @@ var ChatService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CompressContext",
 			Handler:    _ChatService_CompressContext_Handler,
 		},
+		{
+			MethodName: "PinLastUserMessage",
+			Handler:    _ChatService_PinLastUserMessage_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -555,9 +595,10 @@ var AgentService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	InboxService_ListItems_FullMethodName = "/staff.v1.InboxService/ListItems"
-	InboxService_Archive_FullMethodName   = "/staff.v1.InboxService/Archive"
-	InboxService_Watch_FullMethodName     = "/staff.v1.InboxService/Watch"
+	InboxService_ListItems_FullMethodName   = "/staff.v1.InboxService/ListItems"
+	InboxService_Archive_FullMethodName     = "/staff.v1.InboxService/Archive"
+	InboxService_Watch_FullMethodName       = "/staff.v1.InboxService/Watch"
+	InboxService_ConfirmFact_FullMethodName = "/staff.v1.InboxService/ConfirmFact"
 )
 
 // InboxServiceClient is the client API for InboxService service.
@@ -570,6 +611,8 @@ type InboxServiceClient interface {
 	Archive(ctx context.Context, in *ArchiveRequest, opts ...grpc.CallOption) (*ArchiveResponse, error)
 	// Stream new inbox items as they arrive
 	Watch(ctx context.Context, in *WatchInboxRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[InboxItem], error)
+	// Confirm or decline the fact an inbox item asks about, then archive the item
+	ConfirmFact(ctx context.Context, in *ConfirmFactRequest, opts ...grpc.CallOption) (*ConfirmFactResponse, error)
 }
 
 type inboxServiceClient struct {
@@ -619,6 +662,16 @@ func (c *inboxServiceClient) Watch(ctx context.Context, in *WatchInboxRequest, o
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type InboxService_WatchClient = grpc.ServerStreamingClient[InboxItem]
 
+func (c *inboxServiceClient) ConfirmFact(ctx context.Context, in *ConfirmFactRequest, opts ...grpc.CallOption) (*ConfirmFactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmFactResponse)
+	err := c.cc.Invoke(ctx, InboxService_ConfirmFact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InboxServiceServer is the server API for InboxService service.
 // All implementations must embed UnimplementedInboxServiceServer
 // for forward compatibility.
@@ -629,6 +682,8 @@ type InboxServiceServer interface {
 	Archive(context.Context, *ArchiveRequest) (*ArchiveResponse, error)
 	// Stream new inbox items as they arrive
 	Watch(*WatchInboxRequest, grpc.ServerStreamingServer[InboxItem]) error
+	// Confirm or decline the fact an inbox item asks about, then archive the item
+	ConfirmFact(context.Context, *ConfirmFactRequest) (*ConfirmFactResponse, error)
 	mustEmbedUnimplementedInboxServiceServer()
 }
 
@@ -648,6 +703,9 @@ func (UnimplementedInboxServiceServer) Archive(context.Context, *ArchiveRequest)
 func (UnimplementedInboxServiceServer) Watch(*WatchInboxRequest, grpc.ServerStreamingServer[InboxItem]) error {
 	return status.Error(codes.Unimplemented, "method Watch not implemented")
 }
+func (UnimplementedInboxServiceServer) ConfirmFact(context.Context, *ConfirmFactRequest) (*ConfirmFactResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ConfirmFact not implemented")
+}
 func (UnimplementedInboxServiceServer) mustEmbedUnimplementedInboxServiceServer() {}
 func (UnimplementedInboxServiceServer) testEmbeddedByValue()                      {}
 
@@ -716,6 +774,24 @@ func _InboxService_Watch_Handler(srv interface{}, stream grpc.ServerStream) erro
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type InboxService_WatchServer = grpc.ServerStreamingServer[InboxItem]
 
+func _InboxService_ConfirmFact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmFactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InboxServiceServer).ConfirmFact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InboxService_ConfirmFact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InboxServiceServer).ConfirmFact(ctx, req.(*ConfirmFactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // InboxService_ServiceDesc is the grpc.ServiceDesc for InboxService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -731,6 +807,10 @@ var InboxService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Archive",
 			Handler:    _InboxService_Archive_Handler,
 		},
+		{
+			MethodName: "ConfirmFact",
+			Handler:    _InboxService_ConfirmFact_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -743,10 +823,19 @@ var InboxService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	MemoryService_Search_FullMethodName = "/staff.v1.MemoryService/Search"
-	MemoryService_Store_FullMethodName  = "/staff.v1.MemoryService/Store"
-	MemoryService_Dump_FullMethodName   = "/staff.v1.MemoryService/Dump"
-	MemoryService_Clear_FullMethodName  = "/staff.v1.MemoryService/Clear"
+	MemoryService_Search_FullMethodName           = "/staff.v1.MemoryService/Search"
+	MemoryService_Store_FullMethodName            = "/staff.v1.MemoryService/Store"
+	MemoryService_Get_FullMethodName              = "/staff.v1.MemoryService/Get"
+	MemoryService_Update_FullMethodName           = "/staff.v1.MemoryService/Update"
+	MemoryService_Delete_FullMethodName           = "/staff.v1.MemoryService/Delete"
+	MemoryService_Dump_FullMethodName             = "/staff.v1.MemoryService/Dump"
+	MemoryService_Clear_FullMethodName            = "/staff.v1.MemoryService/Clear"
+	MemoryService_Reembed_FullMethodName          = "/staff.v1.MemoryService/Reembed"
+	MemoryService_GetReembedStatus_FullMethodName = "/staff.v1.MemoryService/GetReembedStatus"
+	MemoryService_GetProvenance_FullMethodName    = "/staff.v1.MemoryService/GetProvenance"
+	MemoryService_GetProfile_FullMethodName       = "/staff.v1.MemoryService/GetProfile"
+	MemoryService_UpdateProfile_FullMethodName    = "/staff.v1.MemoryService/UpdateProfile"
+	MemoryService_GetEntity_FullMethodName        = "/staff.v1.MemoryService/GetEntity"
 )
 
 // MemoryServiceClient is the client API for MemoryService service.
@@ -757,10 +846,28 @@ type MemoryServiceClient interface {
 	Search(ctx context.Context, in *SearchMemoryRequest, opts ...grpc.CallOption) (*SearchMemoryResponse, error)
 	// Store a memory item
 	Store(ctx context.Context, in *StoreMemoryRequest, opts ...grpc.CallOption) (*StoreMemoryResponse, error)
+	// Get a memory item by ID
+	Get(ctx context.Context, in *GetMemoryRequest, opts ...grpc.CallOption) (*MemoryItem, error)
+	// Update a memory item, keeping its previous content in the item's history
+	Update(ctx context.Context, in *UpdateMemoryRequest, opts ...grpc.CallOption) (*MemoryItem, error)
+	// Delete a memory item
+	Delete(ctx context.Context, in *DeleteMemoryRequest, opts ...grpc.CallOption) (*DeleteMemoryResponse, error)
 	// Dump all memory to file (admin operation)
 	Dump(ctx context.Context, in *DumpMemoryRequest, opts ...grpc.CallOption) (*DumpMemoryResponse, error)
 	// Clear all memory (admin operation)
 	Clear(ctx context.Context, in *ClearMemoryRequest, opts ...grpc.CallOption) (*ClearMemoryResponse, error)
+	// Start re-embedding memories whose vectors came from a different embedder
+	Reembed(ctx context.Context, in *ReembedMemoryRequest, opts ...grpc.CallOption) (*ReembedStatus, error)
+	// Get the progress of the current or last re-embedding pass
+	GetReembedStatus(ctx context.Context, in *GetReembedStatusRequest, opts ...grpc.CallOption) (*ReembedStatus, error)
+	// Get where a memory came from, with the conversation around its source message
+	GetProvenance(ctx context.Context, in *GetMemoryProvenanceRequest, opts ...grpc.CallOption) (*MemoryProvenance, error)
+	// Get the user profile built from personal memories
+	GetProfile(ctx context.Context, in *GetProfileRequest, opts ...grpc.CallOption) (*UserProfile, error)
+	// Replace the user profile, keeping the previous content in its history
+	UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*UserProfile, error)
+	// Get a person, project, organization or place with the memories that mention it
+	GetEntity(ctx context.Context, in *GetEntityRequest, opts ...grpc.CallOption) (*Entity, error)
 }
 
 type memoryServiceClient struct {
@@ -791,6 +898,36 @@ func (c *memoryServiceClient) Store(ctx context.Context, in *StoreMemoryRequest,
 	return out, nil
 }
 
+func (c *memoryServiceClient) Get(ctx context.Context, in *GetMemoryRequest, opts ...grpc.CallOption) (*MemoryItem, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MemoryItem)
+	err := c.cc.Invoke(ctx, MemoryService_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoryServiceClient) Update(ctx context.Context, in *UpdateMemoryRequest, opts ...grpc.CallOption) (*MemoryItem, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MemoryItem)
+	err := c.cc.Invoke(ctx, MemoryService_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoryServiceClient) Delete(ctx context.Context, in *DeleteMemoryRequest, opts ...grpc.CallOption) (*DeleteMemoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteMemoryResponse)
+	err := c.cc.Invoke(ctx, MemoryService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *memoryServiceClient) Dump(ctx context.Context, in *DumpMemoryRequest, opts ...grpc.CallOption) (*DumpMemoryResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DumpMemoryResponse)
@@ -811,6 +948,66 @@ func (c *memoryServiceClient) Clear(ctx context.Context, in *ClearMemoryRequest,
 	return out, nil
 }
 
+func (c *memoryServiceClient) Reembed(ctx context.Context, in *ReembedMemoryRequest, opts ...grpc.CallOption) (*ReembedStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReembedStatus)
+	err := c.cc.Invoke(ctx, MemoryService_Reembed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoryServiceClient) GetReembedStatus(ctx context.Context, in *GetReembedStatusRequest, opts ...grpc.CallOption) (*ReembedStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReembedStatus)
+	err := c.cc.Invoke(ctx, MemoryService_GetReembedStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoryServiceClient) GetProvenance(ctx context.Context, in *GetMemoryProvenanceRequest, opts ...grpc.CallOption) (*MemoryProvenance, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MemoryProvenance)
+	err := c.cc.Invoke(ctx, MemoryService_GetProvenance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoryServiceClient) GetProfile(ctx context.Context, in *GetProfileRequest, opts ...grpc.CallOption) (*UserProfile, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserProfile)
+	err := c.cc.Invoke(ctx, MemoryService_GetProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoryServiceClient) UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*UserProfile, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserProfile)
+	err := c.cc.Invoke(ctx, MemoryService_UpdateProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoryServiceClient) GetEntity(ctx context.Context, in *GetEntityRequest, opts ...grpc.CallOption) (*Entity, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Entity)
+	err := c.cc.Invoke(ctx, MemoryService_GetEntity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MemoryServiceServer is the server API for MemoryService service.
 // All implementations must embed UnimplementedMemoryServiceServer
 // for forward compatibility.
@@ -819,10 +1016,28 @@ type MemoryServiceServer interface {
 	Search(context.Context, *SearchMemoryRequest) (*SearchMemoryResponse, error)
 	// Store a memory item
 	Store(context.Context, *StoreMemoryRequest) (*StoreMemoryResponse, error)
+	// Get a memory item by ID
+	Get(context.Context, *GetMemoryRequest) (*MemoryItem, error)
+	// Update a memory item, keeping its previous content in the item's history
+	Update(context.Context, *UpdateMemoryRequest) (*MemoryItem, error)
+	// Delete a memory item
+	Delete(context.Context, *DeleteMemoryRequest) (*DeleteMemoryResponse, error)
 	// Dump all memory to file (admin operation)
 	Dump(context.Context, *DumpMemoryRequest) (*DumpMemoryResponse, error)
 	// Clear all memory (admin operation)
 	Clear(context.Context, *ClearMemoryRequest) (*ClearMemoryResponse, error)
+	// Start re-embedding memories whose vectors came from a different embedder
+	Reembed(context.Context, *ReembedMemoryRequest) (*ReembedStatus, error)
+	// Get the progress of the current or last re-embedding pass
+	GetReembedStatus(context.Context, *GetReembedStatusRequest) (*ReembedStatus, error)
+	// Get where a memory came from, with the conversation around its source message
+	GetProvenance(context.Context, *GetMemoryProvenanceRequest) (*MemoryProvenance, error)
+	// Get the user profile built from personal memories
+	GetProfile(context.Context, *GetProfileRequest) (*UserProfile, error)
+	// Replace the user profile, keeping the previous content in its history
+	UpdateProfile(context.Context, *UpdateProfileRequest) (*UserProfile, error)
+	// Get a person, project, organization or place with the memories that mention it
+	GetEntity(context.Context, *GetEntityRequest) (*Entity, error)
 	mustEmbedUnimplementedMemoryServiceServer()
 }
 
@@ -839,12 +1054,39 @@ func (UnimplementedMemoryServiceServer) Search(context.Context, *SearchMemoryReq
 func (UnimplementedMemoryServiceServer) Store(context.Context, *StoreMemoryRequest) (*StoreMemoryResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Store not implemented")
 }
+func (UnimplementedMemoryServiceServer) Get(context.Context, *GetMemoryRequest) (*MemoryItem, error) {
+	return nil, status.Error(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedMemoryServiceServer) Update(context.Context, *UpdateMemoryRequest) (*MemoryItem, error) {
+	return nil, status.Error(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedMemoryServiceServer) Delete(context.Context, *DeleteMemoryRequest) (*DeleteMemoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Delete not implemented")
+}
 func (UnimplementedMemoryServiceServer) Dump(context.Context, *DumpMemoryRequest) (*DumpMemoryResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Dump not implemented")
 }
 func (UnimplementedMemoryServiceServer) Clear(context.Context, *ClearMemoryRequest) (*ClearMemoryResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Clear not implemented")
 }
+func (UnimplementedMemoryServiceServer) Reembed(context.Context, *ReembedMemoryRequest) (*ReembedStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method Reembed not implemented")
+}
+func (UnimplementedMemoryServiceServer) GetReembedStatus(context.Context, *GetReembedStatusRequest) (*ReembedStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetReembedStatus not implemented")
+}
+func (UnimplementedMemoryServiceServer) GetProvenance(context.Context, *GetMemoryProvenanceRequest) (*MemoryProvenance, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetProvenance not implemented")
+}
+func (UnimplementedMemoryServiceServer) GetProfile(context.Context, *GetProfileRequest) (*UserProfile, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetProfile not implemented")
+}
+func (UnimplementedMemoryServiceServer) UpdateProfile(context.Context, *UpdateProfileRequest) (*UserProfile, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateProfile not implemented")
+}
+func (UnimplementedMemoryServiceServer) GetEntity(context.Context, *GetEntityRequest) (*Entity, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetEntity not implemented")
+}
 func (UnimplementedMemoryServiceServer) mustEmbedUnimplementedMemoryServiceServer() {}
 func (UnimplementedMemoryServiceServer) testEmbeddedByValue()                       {}
 
@@ -902,6 +1144,60 @@ func _MemoryService_Store_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MemoryService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMemoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoryServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoryService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoryServiceServer).Get(ctx, req.(*GetMemoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoryService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMemoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoryServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoryService_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoryServiceServer).Update(ctx, req.(*UpdateMemoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoryService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMemoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoryServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoryService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoryServiceServer).Delete(ctx, req.(*DeleteMemoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MemoryService_Dump_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DumpMemoryRequest)
 	if err := dec(in); err != nil {
@@ -938,6 +1234,114 @@ func _MemoryService_Clear_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MemoryService_Reembed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReembedMemoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoryServiceServer).Reembed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoryService_Reembed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoryServiceServer).Reembed(ctx, req.(*ReembedMemoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoryService_GetReembedStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReembedStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoryServiceServer).GetReembedStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoryService_GetReembedStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoryServiceServer).GetReembedStatus(ctx, req.(*GetReembedStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoryService_GetProvenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMemoryProvenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoryServiceServer).GetProvenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoryService_GetProvenance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoryServiceServer).GetProvenance(ctx, req.(*GetMemoryProvenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoryService_GetProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoryServiceServer).GetProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoryService_GetProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoryServiceServer).GetProfile(ctx, req.(*GetProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoryService_UpdateProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoryServiceServer).UpdateProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoryService_UpdateProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoryServiceServer).UpdateProfile(ctx, req.(*UpdateProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoryService_GetEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoryServiceServer).GetEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoryService_GetEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoryServiceServer).GetEntity(ctx, req.(*GetEntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MemoryService_ServiceDesc is the grpc.ServiceDesc for MemoryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -953,6 +1357,18 @@ var MemoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Store",
 			Handler:    _MemoryService_Store_Handler,
 		},
+		{
+			MethodName: "Get",
+			Handler:    _MemoryService_Get_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _MemoryService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _MemoryService_Delete_Handler,
+		},
 		{
 			MethodName: "Dump",
 			Handler:    _MemoryService_Dump_Handler,
@@ -961,6 +1377,294 @@ var MemoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Clear",
 			Handler:    _MemoryService_Clear_Handler,
 		},
+		{
+			MethodName: "Reembed",
+			Handler:    _MemoryService_Reembed_Handler,
+		},
+		{
+			MethodName: "GetReembedStatus",
+			Handler:    _MemoryService_GetReembedStatus_Handler,
+		},
+		{
+			MethodName: "GetProvenance",
+			Handler:    _MemoryService_GetProvenance_Handler,
+		},
+		{
+			MethodName: "GetProfile",
+			Handler:    _MemoryService_GetProfile_Handler,
+		},
+		{
+			MethodName: "UpdateProfile",
+			Handler:    _MemoryService_UpdateProfile_Handler,
+		},
+		{
+			MethodName: "GetEntity",
+			Handler:    _MemoryService_GetEntity_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "staff.proto",
+}
+
+const (
+	ArtifactService_ListArtifacts_FullMethodName        = "/staff.v1.ArtifactService/ListArtifacts"
+	ArtifactService_GetArtifact_FullMethodName          = "/staff.v1.ArtifactService/GetArtifact"
+	ArtifactService_CreateArtifact_FullMethodName       = "/staff.v1.ArtifactService/CreateArtifact"
+	ArtifactService_UpdateArtifact_FullMethodName       = "/staff.v1.ArtifactService/UpdateArtifact"
+	ArtifactService_ListArtifactVersions_FullMethodName = "/staff.v1.ArtifactService/ListArtifactVersions"
+)
+
+// ArtifactServiceClient is the client API for ArtifactService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ArtifactServiceClient interface {
+	// List artifacts, most recently updated first
+	ListArtifacts(ctx context.Context, in *ListArtifactsRequest, opts ...grpc.CallOption) (*ListArtifactsResponse, error)
+	// Get an artifact, or one of its earlier versions
+	GetArtifact(ctx context.Context, in *GetArtifactRequest, opts ...grpc.CallOption) (*Artifact, error)
+	// Create an artifact
+	CreateArtifact(ctx context.Context, in *CreateArtifactRequest, opts ...grpc.CallOption) (*Artifact, error)
+	// Update an artifact, creating a new version
+	UpdateArtifact(ctx context.Context, in *UpdateArtifactRequest, opts ...grpc.CallOption) (*Artifact, error)
+	// List every version of an artifact, oldest first
+	ListArtifactVersions(ctx context.Context, in *ListArtifactVersionsRequest, opts ...grpc.CallOption) (*ListArtifactVersionsResponse, error)
+}
+
+type artifactServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewArtifactServiceClient(cc grpc.ClientConnInterface) ArtifactServiceClient {
+	return &artifactServiceClient{cc}
+}
+
+func (c *artifactServiceClient) ListArtifacts(ctx context.Context, in *ListArtifactsRequest, opts ...grpc.CallOption) (*ListArtifactsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListArtifactsResponse)
+	err := c.cc.Invoke(ctx, ArtifactService_ListArtifacts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artifactServiceClient) GetArtifact(ctx context.Context, in *GetArtifactRequest, opts ...grpc.CallOption) (*Artifact, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Artifact)
+	err := c.cc.Invoke(ctx, ArtifactService_GetArtifact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artifactServiceClient) CreateArtifact(ctx context.Context, in *CreateArtifactRequest, opts ...grpc.CallOption) (*Artifact, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Artifact)
+	err := c.cc.Invoke(ctx, ArtifactService_CreateArtifact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artifactServiceClient) UpdateArtifact(ctx context.Context, in *UpdateArtifactRequest, opts ...grpc.CallOption) (*Artifact, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Artifact)
+	err := c.cc.Invoke(ctx, ArtifactService_UpdateArtifact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artifactServiceClient) ListArtifactVersions(ctx context.Context, in *ListArtifactVersionsRequest, opts ...grpc.CallOption) (*ListArtifactVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListArtifactVersionsResponse)
+	err := c.cc.Invoke(ctx, ArtifactService_ListArtifactVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ArtifactServiceServer is the server API for ArtifactService service.
+// All implementations must embed UnimplementedArtifactServiceServer
+// for forward compatibility.
+type ArtifactServiceServer interface {
+	// List artifacts, most recently updated first
+	ListArtifacts(context.Context, *ListArtifactsRequest) (*ListArtifactsResponse, error)
+	// Get an artifact, or one of its earlier versions
+	GetArtifact(context.Context, *GetArtifactRequest) (*Artifact, error)
+	// Create an artifact
+	CreateArtifact(context.Context, *CreateArtifactRequest) (*Artifact, error)
+	// Update an artifact, creating a new version
+	UpdateArtifact(context.Context, *UpdateArtifactRequest) (*Artifact, error)
+	// List every version of an artifact, oldest first
+	ListArtifactVersions(context.Context, *ListArtifactVersionsRequest) (*ListArtifactVersionsResponse, error)
+	mustEmbedUnimplementedArtifactServiceServer()
+}
+
+// UnimplementedArtifactServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedArtifactServiceServer struct{}
+
+func (UnimplementedArtifactServiceServer) ListArtifacts(context.Context, *ListArtifactsRequest) (*ListArtifactsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListArtifacts not implemented")
+}
+func (UnimplementedArtifactServiceServer) GetArtifact(context.Context, *GetArtifactRequest) (*Artifact, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetArtifact not implemented")
+}
+func (UnimplementedArtifactServiceServer) CreateArtifact(context.Context, *CreateArtifactRequest) (*Artifact, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateArtifact not implemented")
+}
+func (UnimplementedArtifactServiceServer) UpdateArtifact(context.Context, *UpdateArtifactRequest) (*Artifact, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateArtifact not implemented")
+}
+func (UnimplementedArtifactServiceServer) ListArtifactVersions(context.Context, *ListArtifactVersionsRequest) (*ListArtifactVersionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListArtifactVersions not implemented")
+}
+func (UnimplementedArtifactServiceServer) mustEmbedUnimplementedArtifactServiceServer() {}
+func (UnimplementedArtifactServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeArtifactServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ArtifactServiceServer will
+// result in compilation errors.
+type UnsafeArtifactServiceServer interface {
+	mustEmbedUnimplementedArtifactServiceServer()
+}
+
+func RegisterArtifactServiceServer(s grpc.ServiceRegistrar, srv ArtifactServiceServer) {
+	// If the following call panics, it indicates UnimplementedArtifactServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ArtifactService_ServiceDesc, srv)
+}
+
+func _ArtifactService_ListArtifacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListArtifactsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArtifactServiceServer).ListArtifacts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArtifactService_ListArtifacts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtifactServiceServer).ListArtifacts(ctx, req.(*ListArtifactsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArtifactService_GetArtifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetArtifactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArtifactServiceServer).GetArtifact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArtifactService_GetArtifact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtifactServiceServer).GetArtifact(ctx, req.(*GetArtifactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArtifactService_CreateArtifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateArtifactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArtifactServiceServer).CreateArtifact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArtifactService_CreateArtifact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtifactServiceServer).CreateArtifact(ctx, req.(*CreateArtifactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArtifactService_UpdateArtifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateArtifactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArtifactServiceServer).UpdateArtifact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArtifactService_UpdateArtifact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtifactServiceServer).UpdateArtifact(ctx, req.(*UpdateArtifactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArtifactService_ListArtifactVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListArtifactVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArtifactServiceServer).ListArtifactVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArtifactService_ListArtifactVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtifactServiceServer).ListArtifactVersions(ctx, req.(*ListArtifactVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ArtifactService_ServiceDesc is the grpc.ServiceDesc for ArtifactService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ArtifactService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "staff.v1.ArtifactService",
+	HandlerType: (*ArtifactServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListArtifacts",
+			Handler:    _ArtifactService_ListArtifacts_Handler,
+		},
+		{
+			MethodName: "GetArtifact",
+			Handler:    _ArtifactService_GetArtifact_Handler,
+		},
+		{
+			MethodName: "CreateArtifact",
+			Handler:    _ArtifactService_CreateArtifact_Handler,
+		},
+		{
+			MethodName: "UpdateArtifact",
+			Handler:    _ArtifactService_UpdateArtifact_Handler,
+		},
+		{
+			MethodName: "ListArtifactVersions",
+			Handler:    _ArtifactService_ListArtifactVersions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "staff.proto",
@@ -7,15 +7,18 @@ import (
 	"io"
 	"time"
 
+	"github.com/aschepis/backscratcher/staff/agent"
 	"github.com/aschepis/backscratcher/staff/api/staffpb"
 	"github.com/aschepis/backscratcher/staff/llm"
 	"github.com/aschepis/backscratcher/staff/ui"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const roleSystem = "system"
 
 // ServiceAdapter implements ui.ChatService by calling the gRPC daemon.
-// It adapts all 5 gRPC services (Chat, Agent, Inbox, Memory, System) to the UI interface.
+// It adapts all 6 gRPC services (Chat, Agent, Inbox, Memory, Artifact, System) to the UI interface.
 type ServiceAdapter struct {
 	client      *Client
 	chatTimeout time.Duration
@@ -55,11 +58,30 @@ func (a *ServiceAdapter) SendMessageStream(ctx context.Context, agentID, threadI
 		Message:  message,
 	}
 
+	// Forward any attachments queued on the context; file references are read here
+	// because the daemon may not share the client's filesystem.
+	if attachments, ok := agent.GetAttachments(ctx); ok {
+		for i := range attachments {
+			data, err := attachments[i].Bytes()
+			if err != nil {
+				return "", fmt.Errorf("failed to read attachment %s: %w", attachments[i].DisplayName(), err)
+			}
+			req.Attachments = append(req.Attachments, &staffpb.Attachment{
+				MediaType: attachments[i].MediaType,
+				Data:      data,
+				Name:      attachments[i].DisplayName(),
+			})
+		}
+	}
+
 	stream, err := a.client.Chat.Chat(ctx, req)
 	if err != nil {
 		return "", fmt.Errorf("failed to start chat stream: %w", err)
 	}
 
+	reasoningCallback, _ := agent.GetReasoningCallback(ctx)
+	turnEventCallback, _ := agent.GetTurnEventCallback(ctx)
+
 	var fullResponse string
 	for {
 		event, err := stream.Recv()
@@ -79,6 +101,14 @@ func (a *ServiceAdapter) SendMessageStream(ctx context.Context, agentID, threadI
 				}
 				fullResponse += text
 			}
+		case *staffpb.ChatEvent_ReasoningDelta:
+			if e.ReasoningDelta != nil && reasoningCallback != nil {
+				reasoningCallback(e.ReasoningDelta.Text)
+			}
+		case *staffpb.ChatEvent_ToolUse, *staffpb.ChatEvent_ToolInputDelta, *staffpb.ChatEvent_ToolResult, *staffpb.ChatEvent_Usage:
+			if turnEventCallback != nil {
+				turnEventCallback(fromProtoTurnEvent(event))
+			}
 		case *staffpb.ChatEvent_Complete:
 			if e.Complete != nil {
 				fullResponse = e.Complete.FullResponse
@@ -93,6 +123,46 @@ func (a *ServiceAdapter) SendMessageStream(ctx context.Context, agentID, threadI
 	return fullResponse, nil
 }
 
+// fromProtoTurnEvent converts a tool or usage chat event to a turn event.
+func fromProtoTurnEvent(event *staffpb.ChatEvent) agent.TurnEvent {
+	switch e := event.Event.(type) {
+	case *staffpb.ChatEvent_ToolUse:
+		return agent.TurnEvent{
+			Type:      agent.TurnEventToolUse,
+			ToolID:    e.ToolUse.GetToolId(),
+			ToolName:  e.ToolUse.GetToolName(),
+			ToolInput: e.ToolUse.GetInputJson(),
+		}
+	case *staffpb.ChatEvent_ToolInputDelta:
+		return agent.TurnEvent{
+			Type:      agent.TurnEventToolInput,
+			ToolID:    e.ToolInputDelta.GetToolId(),
+			ToolInput: e.ToolInputDelta.GetPartialJson(),
+		}
+	case *staffpb.ChatEvent_ToolResult:
+		return agent.TurnEvent{
+			Type:     agent.TurnEventToolResult,
+			ToolID:   e.ToolResult.GetToolId(),
+			ToolName: e.ToolResult.GetToolName(),
+			Result:   e.ToolResult.GetResult(),
+			IsError:  e.ToolResult.GetIsError(),
+		}
+	default:
+		usage := event.GetUsage()
+		return agent.TurnEvent{
+			Type:      agent.TurnEventUsage,
+			Iteration: int(usage.GetIteration()),
+			Usage: &llm.Usage{
+				InputTokens:              usage.GetInputTokens(),
+				OutputTokens:             usage.GetOutputTokens(),
+				CacheCreationInputTokens: usage.GetCacheCreationInputTokens(),
+				CacheReadInputTokens:     usage.GetCacheReadInputTokens(),
+			},
+			StopReason: usage.GetStopReason(),
+		}
+	}
+}
+
 // GetChatTimeout returns the timeout duration for chat operations.
 func (a *ServiceAdapter) GetChatTimeout() time.Duration {
 	return a.chatTimeout
@@ -139,6 +209,7 @@ func (a *ServiceAdapter) ListInboxItems(ctx context.Context, includeArchived boo
 			Message:          item.Message,
 			RequiresResponse: item.RequiresResponse,
 			Response:         item.Response,
+			PendingFact:      item.PendingFact,
 		}
 
 		if item.ResponseAt != nil {
@@ -169,6 +240,15 @@ func (a *ServiceAdapter) ArchiveInboxItem(ctx context.Context, inboxID int64) er
 	return err
 }
 
+// ConfirmInboxFact stores or declines the fact an inbox item asks about.
+func (a *ServiceAdapter) ConfirmInboxFact(ctx context.Context, inboxID int64, accept bool) error {
+	_, err := a.client.Inbox.ConfirmFact(ctx, &staffpb.ConfirmFactRequest{
+		InboxId: inboxID,
+		Accept:  accept,
+	})
+	return err
+}
+
 // GetOrCreateThreadID gets an existing thread ID for an agent, or creates a new one.
 func (a *ServiceAdapter) GetOrCreateThreadID(ctx context.Context, agentID string) (string, error) {
 	resp, err := a.client.Chat.GetOrCreateThread(ctx, &staffpb.GetThreadRequest{
@@ -253,6 +333,18 @@ func (a *ServiceAdapter) CompressContext(ctx context.Context, agentID, threadID
 	return err
 }
 
+// PinLastUserMessage pins the most recent user message so it is kept verbatim when the context is compressed.
+func (a *ServiceAdapter) PinLastUserMessage(ctx context.Context, agentID, threadID string) (string, error) {
+	resp, err := a.client.Chat.PinLastUserMessage(ctx, &staffpb.ContextRequest{
+		AgentId:  agentID,
+		ThreadId: threadID,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.Content, nil
+}
+
 // LoadSystemMessages loads system messages (context breaks) for a given agent and thread ID.
 func (a *ServiceAdapter) LoadSystemMessages(ctx context.Context, agentID, threadID string) ([]map[string]interface{}, error) {
 	// Load all messages and filter for system messages
@@ -491,5 +583,171 @@ func convertProtoToMessage(msg *staffpb.Message) llm.Message {
 		}
 	}
 
+	// Reasoning precedes the answer it led to
+	if msg.Reasoning != "" {
+		llmMsg.Content = append([]llm.ContentBlock{
+			llm.NewReasoningContentBlock(llm.ReasoningBlock{Text: msg.Reasoning}),
+		}, llmMsg.Content...)
+	}
+
 	return llmMsg
 }
+
+// ListArtifacts returns artifacts whose title or body contains query.
+func (a *ServiceAdapter) ListArtifacts(ctx context.Context, query string) ([]*ui.Artifact, error) {
+	resp, err := a.client.Artifact.ListArtifacts(ctx, &staffpb.ListArtifactsRequest{
+		Query: query,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list artifacts: %w", err)
+	}
+	artifacts := make([]*ui.Artifact, 0, len(resp.Artifacts))
+	for _, artifact := range resp.Artifacts {
+		artifacts = append(artifacts, convertProtoToArtifact(artifact))
+	}
+	return artifacts, nil
+}
+
+// GetArtifact returns an artifact, or one of its earlier versions.
+func (a *ServiceAdapter) GetArtifact(ctx context.Context, id int64, version int) (*ui.Artifact, error) {
+	resp, err := a.client.Artifact.GetArtifact(ctx, &staffpb.GetArtifactRequest{
+		Id:      id,
+		Version: int32(version), //nolint:gosec // Version counts stay small
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artifact: %w", err)
+	}
+	return convertProtoToArtifact(resp), nil
+}
+
+// ListArtifactVersions returns every version of an artifact, oldest first.
+func (a *ServiceAdapter) ListArtifactVersions(ctx context.Context, id int64) ([]*ui.Artifact, error) {
+	resp, err := a.client.Artifact.ListArtifactVersions(ctx, &staffpb.ListArtifactVersionsRequest{
+		Id: id,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list artifact versions: %w", err)
+	}
+	versions := make([]*ui.Artifact, 0, len(resp.Versions))
+	for _, v := range resp.Versions {
+		versions = append(versions, convertProtoToArtifact(v))
+	}
+	return versions, nil
+}
+
+// SearchMemory returns the memories of every agent that match query, best first.
+func (a *ServiceAdapter) SearchMemory(ctx context.Context, query string) ([]*ui.MemoryResult, error) {
+	resp, err := a.client.Memory.Search(ctx, &staffpb.SearchMemoryRequest{
+		Query: query,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search memory: %w", err)
+	}
+	results := make([]*ui.MemoryResult, 0, len(resp.Items))
+	for _, item := range resp.Items {
+		result := &ui.MemoryResult{
+			ID:      item.Id,
+			AgentID: item.AgentId,
+			Group:   item.Group,
+			Scope:   item.Scope,
+			Type:    item.Type,
+			Content: item.Content,
+		}
+		if item.Match != nil {
+			result.Snippet = item.Match.Snippet
+		}
+		if item.CreatedAt != nil {
+			result.CreatedAt = item.CreatedAt.AsTime()
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// GetMemoryProvenance returns where a memory came from, with the conversation around its source message.
+func (a *ServiceAdapter) GetMemoryProvenance(ctx context.Context, id int64) (*ui.MemoryProvenance, error) {
+	resp, err := a.client.Memory.GetProvenance(ctx, &staffpb.GetMemoryProvenanceRequest{
+		Id: id,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get memory provenance: %w", err)
+	}
+	provenance := &ui.MemoryProvenance{
+		MemoryID:  resp.MemoryId,
+		AgentID:   resp.AgentId,
+		ThreadID:  resp.ThreadId,
+		MessageID: resp.MessageId,
+		ToolID:    resp.ToolId,
+		Source:    resp.Source,
+	}
+	for _, m := range resp.Messages {
+		msg := ui.SourceMessage{
+			ID:       m.Id,
+			Role:     m.Role,
+			Content:  m.Content,
+			ToolName: m.ToolName,
+			IsSource: m.IsSource,
+		}
+		if m.Timestamp != nil {
+			msg.Timestamp = m.Timestamp.AsTime()
+		}
+		provenance.Messages = append(provenance.Messages, msg)
+	}
+	return provenance, nil
+}
+
+// GetUserProfile returns the user profile, or nil if none has been built yet.
+func (a *ServiceAdapter) GetUserProfile(ctx context.Context) (*ui.UserProfile, error) {
+	resp, err := a.client.Memory.GetProfile(ctx, &staffpb.GetProfileRequest{})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user profile: %w", err)
+	}
+	return convertProtoToUserProfile(resp), nil
+}
+
+// UpdateUserProfile replaces the user profile.
+func (a *ServiceAdapter) UpdateUserProfile(ctx context.Context, content string) (*ui.UserProfile, error) {
+	resp, err := a.client.Memory.UpdateProfile(ctx, &staffpb.UpdateProfileRequest{
+		Content: content,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user profile: %w", err)
+	}
+	return convertProtoToUserProfile(resp), nil
+}
+
+// convertProtoToUserProfile converts a protobuf UserProfile to a ui.UserProfile.
+func convertProtoToUserProfile(pb *staffpb.UserProfile) *ui.UserProfile {
+	profile := &ui.UserProfile{
+		ID:        pb.Id,
+		Content:   pb.Content,
+		UpdatedBy: pb.UpdatedBy,
+	}
+	if pb.UpdatedAt != nil {
+		profile.UpdatedAt = pb.UpdatedAt.AsTime()
+	}
+	return profile
+}
+
+// convertProtoToArtifact converts a protobuf Artifact to a ui.Artifact.
+func convertProtoToArtifact(pb *staffpb.Artifact) *ui.Artifact {
+	artifact := &ui.Artifact{
+		ID:       pb.Id,
+		AgentID:  pb.AgentId,
+		ThreadID: pb.ThreadId,
+		Scope:    pb.Scope,
+		Title:    pb.Title,
+		Body:     pb.Body,
+		Version:  int(pb.Version),
+	}
+	if pb.CreatedAt != nil {
+		artifact.CreatedAt = pb.CreatedAt.AsTime()
+	}
+	if pb.UpdatedAt != nil {
+		artifact.UpdatedAt = pb.UpdatedAt.AsTime()
+	}
+	return artifact
+}
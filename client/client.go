@@ -20,11 +20,12 @@ type Client struct {
 	conn *grpc.ClientConn
 
 	// Service clients
-	Chat   staffpb.ChatServiceClient
-	Agent  staffpb.AgentServiceClient
-	Inbox  staffpb.InboxServiceClient
-	Memory staffpb.MemoryServiceClient
-	System staffpb.SystemServiceClient
+	Chat     staffpb.ChatServiceClient
+	Agent    staffpb.AgentServiceClient
+	Inbox    staffpb.InboxServiceClient
+	Memory   staffpb.MemoryServiceClient
+	Artifact staffpb.ArtifactServiceClient
+	System   staffpb.SystemServiceClient
 }
 
 // Connect connects to the staffd daemon.
@@ -60,12 +61,13 @@ func Connect(address string) (*Client, error) {
 
 	// Create service clients
 	client := &Client{
-		conn:   conn,
-		Chat:   staffpb.NewChatServiceClient(conn),
-		Agent:  staffpb.NewAgentServiceClient(conn),
-		Inbox:  staffpb.NewInboxServiceClient(conn),
-		Memory: staffpb.NewMemoryServiceClient(conn),
-		System: staffpb.NewSystemServiceClient(conn),
+		conn:     conn,
+		Chat:     staffpb.NewChatServiceClient(conn),
+		Agent:    staffpb.NewAgentServiceClient(conn),
+		Inbox:    staffpb.NewInboxServiceClient(conn),
+		Memory:   staffpb.NewMemoryServiceClient(conn),
+		Artifact: staffpb.NewArtifactServiceClient(conn),
+		System:   staffpb.NewSystemServiceClient(conn),
 	}
 
 	return client, nil
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -15,11 +16,12 @@ import (
 	"github.com/aschepis/backscratcher/staff/agent"
 	"github.com/aschepis/backscratcher/staff/config"
 	"github.com/aschepis/backscratcher/staff/conversations"
+	"github.com/aschepis/backscratcher/staff/ingest"
 	"github.com/aschepis/backscratcher/staff/llm"
+	"github.com/aschepis/backscratcher/staff/llm/cassette"
 	stafflogger "github.com/aschepis/backscratcher/staff/logger"
 	"github.com/aschepis/backscratcher/staff/mcp"
 	"github.com/aschepis/backscratcher/staff/memory"
-	"github.com/aschepis/backscratcher/staff/memory/ollama"
 	"github.com/aschepis/backscratcher/staff/migrations"
 	"github.com/aschepis/backscratcher/staff/runtime"
 	"github.com/aschepis/backscratcher/staff/server"
@@ -66,11 +68,12 @@ func main() {
 func run() error {
 	// Parse command-line flags
 	var (
-		socketPath = flag.String("socket", defaultSocketPath, "Unix socket path for gRPC server")
-		tcpAddress = flag.String("tcp", "", "TCP address to listen on (e.g., localhost:50051). If set, disables Unix socket")
-		logFile    = flag.String("logfile", "", "Path to log file. If not set, logs to stdout/stderr")
-		pretty     = flag.Bool("pretty", false, "Use pretty console output (only valid when logfile is not set)")
-		dbPath     = flag.String("db", "staff_memory.db", "Path to SQLite database file")
+		socketPath   = flag.String("socket", defaultSocketPath, "Unix socket path for gRPC server")
+		tcpAddress   = flag.String("tcp", "", "TCP address to listen on (e.g., localhost:50051). If set, disables Unix socket")
+		logFile      = flag.String("logfile", "", "Path to log file. If not set, logs to stdout/stderr")
+		pretty       = flag.Bool("pretty", false, "Use pretty console output (only valid when logfile is not set)")
+		dbPath       = flag.String("db", "staff_memory.db", "Path to SQLite database file")
+		rebuildIndex = flag.Bool("rebuild-index", false, "Rebuild the memory vector index from the database and exit")
 	)
 	flag.Parse()
 
@@ -107,9 +110,13 @@ func run() error {
 		appConfig.Server.TCP = *tcpAddress
 	}
 
+	// Replaying cassettes never contacts a provider, so it runs without credentials
+	cassetteDir, cassetteMode := config.LoadLLMCassetteConfig(appConfig)
+	replay := cassetteDir != "" && cassette.Mode(cassetteMode) == cassette.ModeReplay
+
 	// Get Anthropic API key from config file
 	anthropicAPIKey := appConfig.Anthropic.APIKey
-	if anthropicAPIKey == "" {
+	if anthropicAPIKey == "" && !replay {
 		return fmt.Errorf("missing anthropic.api_key in config file")
 	}
 
@@ -129,18 +136,68 @@ func run() error {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
-	embedder, err := ollama.NewEmbedder(ollama.ModelMXBAI)
+	embedder, err := config.NewEmbedder(appConfig)
 	if err != nil {
-		return fmt.Errorf("failed to create ollama embedder: %w", err)
+		return fmt.Errorf("failed to create embedder: %w", err)
 	}
+	logger.Info().Str("embedder", embedder.Name()).Msg("Memory embedder configured")
 
 	memoryStore, err := memory.NewStore(db, embedder, logger)
 	if err != nil {
 		return fmt.Errorf("failed to create memory store: %w", err)
 	}
 
+	// The vector index lives next to the database; search falls back to scanning
+	// recent memories if it can't be opened
+	indexPath := *dbPath + ".vectors"
+	if *rebuildIndex {
+		n, err := memoryStore.RebuildVectorIndex(context.Background(), indexPath)
+		if err != nil {
+			return fmt.Errorf("failed to rebuild vector index: %w", err)
+		}
+		fmt.Printf("Rebuilt vector index %s with %d memories\n", indexPath, n)
+		return nil
+	}
+	if err := memoryStore.OpenVectorIndex(context.Background(), indexPath); err != nil {
+		logger.Warn().Err(err).Str("path", indexPath).Msg("Vector index unavailable, using linear vector search")
+	}
+	defer func() {
+		if err := memoryStore.CloseVectorIndex(); err != nil {
+			logger.Warn().Err(err).Msg("Failed to save vector index")
+		}
+	}()
+
+	dedupConfig, consolidateInterval, err := config.LoadMemoryConfig(appConfig)
+	if err != nil {
+		return err
+	}
+	memoryStore.SetDedupConfig(dedupConfig)
+	retentionConfig, retentionInterval, err := config.LoadRetentionConfig(appConfig)
+	if err != nil {
+		return err
+	}
+	memoryStore.SetRetentionConfig(retentionConfig)
+
+	// `staffd memory export|import` runs against the store and exits
+	if flag.NArg() > 0 {
+		if flag.Arg(0) != "memory" {
+			return fmt.Errorf("unknown command %q", flag.Arg(0))
+		}
+		return runMemoryCommand(context.Background(), memoryStore, flag.Args()[1:])
+	}
+
+	memoryGroups, err := config.LoadMemoryGroups(appConfig)
+	if err != nil {
+		return err
+	}
+	timezone, err := config.LoadTimezone(appConfig)
+	if err != nil {
+		return err
+	}
 	memoryRouter := memory.NewMemoryRouter(memoryStore, memory.Config{
 		Summarizer: memory.NewAnthropicSummarizer("claude-3.5-haiku-latest", anthropicAPIKey, 256, logger),
+		Groups:     memoryGroups,
+		Location:   timezone,
 	}, logger)
 
 	// Create conversations store for message persistence
@@ -183,6 +240,50 @@ func run() error {
 	if messageSummarizer != nil {
 		crewOpts = append(crewOpts, agent.WithMessageSummarizer(messageSummarizer))
 	}
+	if cassetteDir != "" {
+		openaiAPIKey, _, _, _ := config.LoadOpenAIConfig(appConfig)
+		geminiAPIKey, _, _ := config.LoadGeminiConfig(appConfig)
+		cassetteTransport, err := cassette.New(cassette.Config{
+			Dir:     cassetteDir,
+			Mode:    cassette.Mode(cassetteMode),
+			Secrets: []string{anthropicAPIKey, openaiAPIKey, geminiAPIKey},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create LLM cassette: %w", err)
+		}
+		logger.Info().Str("dir", cassetteDir).Str("mode", cassetteMode).Msg("LLM cassette enabled")
+		crewOpts = append(crewOpts, agent.WithLLMTransport(cassetteTransport))
+	}
+	extractionEnabled, extractionModel, extractionMinConfidence, err := config.LoadExtractionConfig(appConfig)
+	if err != nil {
+		return err
+	}
+	var extractionJob *runtime.FactExtractionJob
+	if extractionEnabled {
+		extractionJob, err = runtime.NewFactExtractionJob(
+			memoryStore,
+			memory.NewAnthropicFactExtractor(extractionModel, anthropicAPIKey, 512, logger),
+			memory.NewNormalizer("claude-3.5-haiku-latest", anthropicAPIKey, 512, logger),
+			db,
+			extractionMinConfidence,
+			logger,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create fact extraction job: %w", err)
+		}
+		logger.Info().Str("model", extractionModel).Msg("Fact extraction is enabled")
+		crewOpts = append(crewOpts, agent.WithTurnHook(extractionJob.Hook))
+	}
+	crewOpts = append(crewOpts, agent.WithProfileProvider(func(ctx context.Context) (string, error) {
+		profile, err := memoryStore.Profile(ctx)
+		if errors.Is(err, memory.ErrNoProfile) {
+			return "", nil
+		}
+		if err != nil {
+			return "", err
+		}
+		return profile.Content, nil
+	}))
 	crew := agent.NewCrew(logger, anthropicAPIKey, db, crewOpts...)
 
 	// Get workspace path (default to current directory)
@@ -247,6 +348,12 @@ func run() error {
 	providerConfig.OpenAIBaseURL = openaiBaseURL
 	providerConfig.OpenAIModel = openaiModel
 	providerConfig.OpenAIOrg = openaiOrg
+	geminiAPIKey, geminiBaseURL, geminiModel := config.LoadGeminiConfig(appConfig)
+	providerConfig.GeminiAPIKey = geminiAPIKey
+	providerConfig.GeminiBaseURL = geminiBaseURL
+	providerConfig.GeminiModel = geminiModel
+	providerConfig.MockScriptPath = config.LoadMockConfig(appConfig)
+	providerConfig.Replay = replay
 
 	registry := llm.NewProviderRegistry(&providerConfig, enabledProviders)
 	if err := crew.InitializeAgents(registry); err != nil {
@@ -268,6 +375,66 @@ func run() error {
 	go scheduler.Start(schedulerCtx)
 	logger.Info().Msg("Background scheduler started")
 
+	if consolidateInterval > 0 {
+		go memoryStore.RunConsolidation(schedulerCtx, consolidateInterval)
+		logger.Info().Dur("interval", consolidateInterval).Msg("Memory consolidation started")
+	}
+	if retentionInterval > 0 {
+		retentionJob, err := runtime.NewRetentionJob(memoryStore, retentionInterval, logger)
+		if err != nil {
+			return fmt.Errorf("failed to create memory retention job: %w", err)
+		}
+		go retentionJob.Start(schedulerCtx)
+	}
+
+	if extractionJob != nil {
+		go extractionJob.Start(schedulerCtx)
+	}
+	profileInterval, err := config.LoadProfileInterval(appConfig)
+	if err != nil {
+		return err
+	}
+	if profileInterval > 0 {
+		profileJob, err := runtime.NewProfileJob(memoryStore, profileInterval, logger)
+		if err != nil {
+			return fmt.Errorf("failed to create user profile job: %w", err)
+		}
+		go profileJob.Start(schedulerCtx)
+	}
+	reflectionPolicies, err := config.LoadReflectionConfig(appConfig)
+	if err != nil {
+		return err
+	}
+	if len(reflectionPolicies) > 0 {
+		reflectionScheduler, err := runtime.NewReflectionScheduler(memoryRouter, reflectionPolicies, logger)
+		if err != nil {
+			return fmt.Errorf("failed to create memory reflection scheduler: %w", err)
+		}
+		go reflectionScheduler.Start(schedulerCtx)
+	}
+	docsConfig, docsInterval, err := config.LoadDocsConfig(appConfig)
+	if err != nil {
+		return err
+	}
+	if docsConfig != nil {
+		ingester, err := ingest.NewIngester(memoryStore, *docsConfig, logger)
+		if err != nil {
+			return fmt.Errorf("failed to create document ingester: %w", err)
+		}
+		docIngestJob, err := runtime.NewDocIngestJob(ingester, docsInterval, logger)
+		if err != nil {
+			return fmt.Errorf("failed to create document ingestion job: %w", err)
+		}
+		go docIngestJob.Start(schedulerCtx)
+	}
+
+	// Bring memories stored under a previous embedder up to date with the current one
+	reembedJob, err := runtime.NewReembedJob(memoryStore, appConfig.Memory.ReembedBatchSize, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create memory re-embedding job: %w", err)
+	}
+	go reembedJob.Start(schedulerCtx)
+
 	// ---------------------------
 	// 7. Create and Start gRPC Server
 	// ---------------------------
@@ -290,6 +457,7 @@ func run() error {
 	srv := server.New(server.Config{
 		SocketPath: listenPath,
 		Logger:     logger,
+		ReembedJob: reembedJob,
 	}, crew, db, memoryRouter, memoryStore, chatService)
 
 	// Setup signal handling for graceful shutdown
@@ -364,6 +532,8 @@ func registerToolSchemas(logger zerolog.Logger, crew *agent.Crew) {
 // registerToolHandlers registers all tool handlers with the ToolRegistry.
 func registerToolHandlers(crew *agent.Crew, memoryRouter *memory.MemoryRouter, workspacePath string, db *sql.DB, stateManager *agent.StateManager, apiKey string) {
 	crew.ToolRegistry.RegisterMemoryTools(memoryRouter, apiKey)
+	crew.ToolRegistry.RegisterArtifactTools(memoryRouter)
+	crew.ToolRegistry.RegisterDocsTools(memoryRouter)
 	crew.ToolRegistry.RegisterFilesystemTools(workspacePath)
 	crew.ToolRegistry.RegisterSystemTools(workspacePath)
 	crew.ToolRegistry.RegisterNotificationTools(db, func(agentID string, state string) error {
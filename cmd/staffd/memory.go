@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/aschepis/backscratcher/staff/memory"
+)
+
+// agentMapFlag collects repeated -map old=new flags.
+type agentMapFlag map[string]string
+
+func (m agentMapFlag) String() string {
+	pairs := make([]string, 0, len(m))
+	for from, to := range m {
+		pairs = append(pairs, from+"="+to)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (m agentMapFlag) Set(value string) error {
+	from, to, ok := strings.Cut(value, "=")
+	if !ok || from == "" || to == "" {
+		return fmt.Errorf("expected old=new, got %q", value)
+	}
+	m[from] = to
+	return nil
+}
+
+// runMemoryCommand runs `staffd memory export|import` against an opened store.
+func runMemoryCommand(ctx context.Context, store *memory.Store, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: staffd memory export|import [flags]")
+	}
+
+	switch args[0] {
+	case "export":
+		fs := flag.NewFlagSet("memory export", flag.ContinueOnError)
+		output := fs.String("o", "", "File to write the export to (default: stdout)")
+		embeddings := fs.Bool("embeddings", false, "Include embedding vectors")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		var (
+			w    io.Writer = os.Stdout
+			file *os.File
+		)
+		if *output != "" {
+			var err error
+			if file, err = os.Create(*output); err != nil {
+				return fmt.Errorf("create export file: %w", err)
+			}
+			defer file.Close() //nolint:errcheck // Closed explicitly below on success
+			w = file
+		}
+		result, err := store.ExportMemory(ctx, w, memory.ExportOptions{IncludeEmbeddings: *embeddings})
+		if err != nil {
+			return fmt.Errorf("export memory: %w", err)
+		}
+		if file != nil {
+			if err := file.Close(); err != nil {
+				return fmt.Errorf("close export file: %w", err)
+			}
+		}
+		fmt.Fprintf(os.Stderr, "Exported %d memories and %d artifacts\n", result.Memories, result.Artifacts)
+		return nil
+
+	case "import":
+		fs := flag.NewFlagSet("memory import", flag.ContinueOnError)
+		strategy := fs.String("strategy", string(memory.ImportMerge), "merge: add alongside existing memories; overwrite: replace the memories of imported agents")
+		agentMap := agentMapFlag{}
+		fs.Var(agentMap, "map", "Rename an agent ID from the export, as old=new (repeatable)")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if fs.NArg() != 1 {
+			return fmt.Errorf("usage: staffd memory import [-strategy merge|overwrite] [-map old=new]... FILE")
+		}
+
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			return fmt.Errorf("open export file: %w", err)
+		}
+		defer f.Close() //nolint:errcheck // Read-only file
+
+		result, err := store.ImportMemory(ctx, f, memory.ImportOptions{
+			Strategy: memory.ImportStrategy(*strategy),
+			AgentMap: agentMap,
+		})
+		if err != nil {
+			return fmt.Errorf("import memory: %w", err)
+		}
+		fmt.Printf("Imported %d memories and %d artifacts (%d duplicates skipped, %d re-embedded, %d removed)\n",
+			result.Memories, result.Artifacts, result.Skipped, result.Reembedded, result.Removed)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown memory command %q (expected export or import)", args[0])
+	}
+}
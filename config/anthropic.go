@@ -18,5 +18,5 @@ func LoadAnthropicConfig(cfg *ServerConfig) (apiKey string) {
 // NewAnthropicClient creates a new Anthropic LLM client from the configuration.
 func NewAnthropicClient(cfg *ServerConfig, logger zerolog.Logger) (*llmanthropic.AnthropicClient, error) {
 	apiKey := LoadAnthropicConfig(cfg)
-	return llmanthropic.NewAnthropicClient(apiKey, logger)
+	return llmanthropic.NewAnthropicClient(apiKey, nil, logger)
 }
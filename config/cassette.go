@@ -0,0 +1,28 @@
+package config
+
+import (
+	"os"
+)
+
+// LoadLLMCassetteConfig loads the LLM cassette configuration from server config.
+// It returns the directory holding cassette files and the mode ("record" or "replay").
+// An empty directory means LLM traffic is neither recorded nor replayed.
+func LoadLLMCassetteConfig(cfg *ServerConfig) (dir, mode string) {
+	if cfg != nil {
+		dir = cfg.LLMCassette.Dir
+		mode = cfg.LLMCassette.Mode
+	}
+
+	// Apply environment variable overrides
+	if envDir := os.Getenv("STAFF_LLM_CASSETTE_DIR"); envDir != "" {
+		dir = envDir
+	}
+	if envMode := os.Getenv("STAFF_LLM_CASSETTE_MODE"); envMode != "" {
+		mode = envMode
+	}
+	if mode == "" {
+		mode = "replay"
+	}
+
+	return expandPath(dir), mode
+}
@@ -53,14 +53,107 @@ type OpenAIConfig struct {
 	Organization string `yaml:"organization,omitempty"` // Organization ID
 }
 
+// GeminiConfig represents configuration for Google Gemini LLM provider.
+type GeminiConfig struct {
+	APIKey  string `yaml:"api_key,omitempty"`  // Gemini API key
+	BaseURL string `yaml:"base_url,omitempty"` // Custom base URL (default: official API)
+	Model   string `yaml:"model,omitempty"`    // Default model name
+}
+
+// MockConfig represents configuration for the scripted mock LLM provider.
+type MockConfig struct {
+	Script string `yaml:"script,omitempty"` // Path to the YAML script of canned turns
+}
+
+// EmbeddingsConfig selects the embedding provider used for semantic memory search.
+type EmbeddingsConfig struct {
+	Provider   string `yaml:"provider,omitempty"`   // "ollama" (default), "openai", or "ngram" (offline)
+	Model      string `yaml:"model,omitempty"`      // Embedding model (provider default if omitted)
+	BaseURL    string `yaml:"base_url,omitempty"`   // OpenAI-compatible endpoint (default: openai.base_url)
+	APIKey     string `yaml:"api_key,omitempty"`    // API key for openai (default: openai.api_key)
+	Dimensions int    `yaml:"dimensions,omitempty"` // Vector size for ngram, or shortened openai vectors
+}
+
+// MemoryConfig controls how stored memories are maintained.
+type MemoryConfig struct {
+	DisableDedup        bool    `yaml:"disable_dedup,omitempty"`        // Store every memory as a new item
+	DuplicateThreshold  float64 `yaml:"duplicate_threshold,omitempty"`  // Similarity at which memories are merged (default: 0.95)
+	SupersedeThreshold  float64 `yaml:"supersede_threshold,omitempty"`  // Similarity at which a memory replaces an older one (default: 0.85)
+	ConsolidateInterval string  `yaml:"consolidate_interval,omitempty"` // How often stored memories are deduplicated, e.g. "6h" ("0" disables)
+
+	// Retention policies keyed by memory type (fact, episode, ...) or personal memory type (preference, habit, ...)
+	Retention         map[string]RetentionPolicyConfig `yaml:"retention,omitempty"`
+	RetentionInterval string                           `yaml:"retention_interval,omitempty"` // How often expiry and decay run (default: 1h, "0" disables)
+
+	ReembedBatchSize int `yaml:"reembed_batch_size,omitempty"` // Memories per embedding request when re-embedding (default: 32)
+
+	Extraction ExtractionConfig `yaml:"extraction,omitempty"` // Background fact extraction after chat turns
+
+	Reflection ReflectionConfig `yaml:"reflection,omitempty"` // Default reflection cadence for all agents
+
+	ProfileInterval string `yaml:"profile_interval,omitempty"` // How often the user profile is rebuilt from personal memories (default: 1h, "0" disables)
+
+	// Sharing groups keyed by name, each listing the agents whose group memories it shares
+	Groups map[string][]string `yaml:"groups,omitempty"`
+
+	Docs DocsConfig `yaml:"docs,omitempty"` // Workspace documents indexed for docs_search
+
+	Timezone string `yaml:"timezone,omitempty"` // IANA timezone for relative times in searches, e.g. "Europe/Berlin" (default: system local)
+}
+
+// DocsConfig selects the directories of documents ingested as doc_ref memories.
+type DocsConfig struct {
+	Dirs         []string `yaml:"dirs,omitempty"`          // Directories of Markdown, text, HTML and PDF files
+	Interval     string   `yaml:"interval,omitempty"`      // How often the directories are rescanned (default: 5m)
+	ChunkSize    int      `yaml:"chunk_size,omitempty"`    // Bytes of text per chunk (default: 1000)
+	ChunkOverlap int      `yaml:"chunk_overlap,omitempty"` // Bytes shared by neighbouring chunks (default: 200)
+}
+
+// ReflectionConfig controls how often an agent's episodes are summarized into durable facts.
+type ReflectionConfig struct {
+	Interval string `yaml:"interval,omitempty"` // How often to reflect, e.g. "24h" (empty or "0" disables)
+	Lookback string `yaml:"lookback,omitempty"` // Only summarize episodes this recent (default: 168h)
+	Scope    string `yaml:"scope,omitempty"`    // Store results as "agent" or "global" facts (default: global)
+}
+
+// ExtractionConfig controls automatic extraction of personal facts from conversations.
+type ExtractionConfig struct {
+	Enabled       bool    `yaml:"enabled,omitempty"`        // Extract facts after every completed turn (default: false)
+	Model         string  `yaml:"model,omitempty"`          // Anthropic model that proposes facts (default: claude-3.5-haiku-latest)
+	MinConfidence float64 `yaml:"min_confidence,omitempty"` // Facts below this go to the inbox for confirmation (default: 0.8)
+}
+
+// RetentionPolicyConfig controls how long one kind of memory is kept and how its importance fades.
+type RetentionPolicyConfig struct {
+	TTL          string  `yaml:"ttl,omitempty"`           // Expire this long after storing, e.g. "720h" (empty = never)
+	HalfLife     string  `yaml:"half_life,omitempty"`     // Importance halves over this period (empty = no decay)
+	ArchiveBelow float64 `yaml:"archive_below,omitempty"` // Archive once importance decays below this
+	Delete       bool    `yaml:"delete,omitempty"`        // Delete expired memories instead of archiving them
+}
+
+// LLMCassetteConfig controls recording and replaying of LLM traffic.
+type LLMCassetteConfig struct {
+	Dir  string `yaml:"dir,omitempty"`  // Directory holding cassette files (empty disables cassettes)
+	Mode string `yaml:"mode,omitempty"` // "record" or "replay" (default: replay)
+}
+
 // LLMPreference represents a single LLM provider/model preference for an agent.
 // Agents can specify multiple preferences in order, and the system will use
 // the first available provider from the preference list.
 type LLMPreference struct {
-	Provider    string   `yaml:"provider" json:"provider"`                           // Required: "anthropic", "ollama", or "openai"
+	Provider    string   `yaml:"provider" json:"provider"`                           // Required: "anthropic", "ollama", "openai", "gemini", or "mock"
 	Model       string   `yaml:"model,omitempty" json:"model,omitempty"`             // Optional: uses provider default if omitted
 	Temperature *float64 `yaml:"temperature,omitempty" json:"temperature,omitempty"` // Optional temperature override
 	APIKeyRef   string   `yaml:"api_key_ref,omitempty" json:"api_key_ref,omitempty"` // Future: reference to credential store
+	// ThinkingBudget enables extended thinking/reasoning with the given token budget (0 disables)
+	ThinkingBudget int64 `yaml:"thinking_budget,omitempty" json:"thinking_budget,omitempty"`
+}
+
+// ContextCompressionConfig controls how an agent's conversation context is compressed
+// when it grows too large or the user runs /compress.
+type ContextCompressionConfig struct {
+	Strategy        string `yaml:"strategy,omitempty" json:"strategy,omitempty"`                   // "summarize" (default) or "sliding_window"
+	KeepRecentTurns int    `yaml:"keep_recent_turns,omitempty" json:"keep_recent_turns,omitempty"` // Turns kept verbatim by sliding_window (default: 4)
 }
 
 // AgentConfig represents the configuration for a single agent.
@@ -74,6 +167,11 @@ type AgentConfig struct {
 	Disabled     bool            `yaml:"disabled" json:"disabled"`           // default: false (agent is enabled by default)
 	StartupDelay string          `yaml:"startup_delay" json:"startup_delay"` // e.g., "5m", "30s", "1h" - one-time delay after app launch
 	LLM          []LLMPreference `yaml:"llm,omitempty" json:"llm,omitempty"` // Ordered list of provider/model preferences
+
+	Compression *ContextCompressionConfig `yaml:"compression,omitempty" json:"compression,omitempty"` // Optional context compression strategy
+	Reflection  *ReflectionConfig         `yaml:"reflection,omitempty" json:"reflection,omitempty"`   // Overrides memory.reflection for this agent
+
+	IncludeProfile bool `yaml:"include_profile,omitempty" json:"include_profile,omitempty"` // Append the user profile to the system prompt
 }
 
 // MCPServerConfig represents configuration for an MCP server.
@@ -99,6 +197,17 @@ type ServerConfig struct {
 	Anthropic AnthropicConfig `yaml:"anthropic,omitempty"`
 	Ollama    OllamaConfig    `yaml:"ollama,omitempty"`
 	OpenAI    OpenAIConfig    `yaml:"openai,omitempty"`
+	Gemini    GeminiConfig    `yaml:"gemini,omitempty"`
+	Mock      MockConfig      `yaml:"mock,omitempty"`
+
+	// LLMCassette records or replays all LLM traffic, for offline tests and debugging
+	LLMCassette LLMCassetteConfig `yaml:"llm_cassette,omitempty"`
+
+	// Embedding provider for memory
+	Embeddings EmbeddingsConfig `yaml:"embeddings,omitempty"`
+
+	// Memory maintenance
+	Memory MemoryConfig `yaml:"memory,omitempty"`
 
 	// Agent/Crew configuration
 	LLMProviders []string                    `yaml:"llm_providers,omitempty"`
@@ -281,6 +390,14 @@ func LoadServerConfig(path string) (*ServerConfig, error) {
 			Model:        "llama3.2:3b",
 			Organization: "",
 		},
+		Gemini: GeminiConfig{
+			APIKey:  "",
+			BaseURL: "https://generativelanguage.googleapis.com/v1beta",
+			Model:   "gemini-2.5-flash",
+		},
+		Embeddings: EmbeddingsConfig{
+			Provider: "ollama",
+		},
 		ChatTimeout: 60,
 		Agents:      make(map[string]*AgentConfig),
 		MCPServers:  make(map[string]*MCPServerConfig),
@@ -386,6 +503,13 @@ func LoadServerConfig(path string) (*ServerConfig, error) {
 		if agentCfg.MaxTokens == 0 {
 			agentCfg.MaxTokens = 2048
 		}
+		if agentCfg.Compression != nil {
+			switch agentCfg.Compression.Strategy {
+			case "", "summarize", "sliding_window":
+			default:
+				return nil, fmt.Errorf("invalid compression strategy %q for agent %s: must be summarize or sliding_window", agentCfg.Compression.Strategy, id)
+			}
+		}
 	}
 
 	return &defaults, nil
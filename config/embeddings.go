@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/aschepis/backscratcher/staff/memory"
+	"github.com/aschepis/backscratcher/staff/memory/ngram"
+	"github.com/aschepis/backscratcher/staff/memory/ollama"
+	memopenai "github.com/aschepis/backscratcher/staff/memory/openai"
+)
+
+// Embedding providers accepted by EmbeddingsConfig.Provider.
+const (
+	EmbeddingsProviderOllama = "ollama"
+	EmbeddingsProviderOpenAI = "openai"
+	EmbeddingsProviderNgram  = "ngram"
+)
+
+// LoadEmbeddingsConfig loads the embeddings configuration from server config.
+// The OpenAI provider falls back to the openai block's API key and base URL.
+func LoadEmbeddingsConfig(cfg *ServerConfig) EmbeddingsConfig {
+	var embCfg EmbeddingsConfig
+	if cfg != nil {
+		embCfg = cfg.Embeddings
+	}
+
+	// Apply environment variable overrides
+	if envProvider := os.Getenv("STAFF_EMBEDDINGS_PROVIDER"); envProvider != "" {
+		embCfg.Provider = envProvider
+	}
+	if envModel := os.Getenv("STAFF_EMBEDDINGS_MODEL"); envModel != "" {
+		embCfg.Model = envModel
+	}
+
+	if embCfg.Provider == "" {
+		embCfg.Provider = EmbeddingsProviderOllama
+	}
+	if embCfg.Provider == EmbeddingsProviderOpenAI {
+		apiKey, baseURL, _, _ := LoadOpenAIConfig(cfg)
+		if embCfg.APIKey == "" {
+			embCfg.APIKey = apiKey
+		}
+		if embCfg.BaseURL == "" {
+			embCfg.BaseURL = baseURL
+		}
+	}
+
+	return embCfg
+}
+
+// NewEmbedder creates the memory embedder selected by the configuration.
+func NewEmbedder(cfg *ServerConfig) (memory.Embedder, error) {
+	embCfg := LoadEmbeddingsConfig(cfg)
+	switch embCfg.Provider {
+	case EmbeddingsProviderOllama:
+		model := ollama.ModelMXBAI
+		if embCfg.Model != "" {
+			model = ollama.Model(embCfg.Model)
+		}
+		return ollama.NewEmbedder(model)
+	case EmbeddingsProviderOpenAI:
+		return memopenai.NewEmbedder(embCfg.APIKey, embCfg.BaseURL, embCfg.Model, embCfg.Dimensions)
+	case EmbeddingsProviderNgram:
+		return ngram.NewEmbedder(embCfg.Dimensions), nil
+	default:
+		return nil, fmt.Errorf("unknown embeddings provider %q", embCfg.Provider)
+	}
+}
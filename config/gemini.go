@@ -0,0 +1,61 @@
+package config
+
+import (
+	"os"
+
+	llmgemini "github.com/aschepis/backscratcher/staff/llm/gemini"
+)
+
+// LoadGeminiConfig loads Gemini configuration from server config.
+// It returns the API key, base URL, and model to use for creating a Gemini client.
+func LoadGeminiConfig(cfg *ServerConfig) (apiKey, baseURL, model string) {
+	if cfg == nil {
+		// Return defaults from environment
+		apiKey = getGeminiAPIKeyFromEnv()
+		baseURL = getGeminiBaseURLFromEnv()
+		model = getGeminiModelFromEnv()
+		return
+	}
+
+	apiKey = cfg.Gemini.APIKey
+	baseURL = cfg.Gemini.BaseURL
+	model = cfg.Gemini.Model
+
+	// Apply environment variable overrides
+	if envAPIKey := getGeminiAPIKeyFromEnv(); envAPIKey != "" {
+		apiKey = envAPIKey
+	}
+	if envBaseURL := getGeminiBaseURLFromEnv(); envBaseURL != "" {
+		baseURL = envBaseURL
+	}
+	if envModel := getGeminiModelFromEnv(); envModel != "" {
+		model = envModel
+	}
+
+	return apiKey, baseURL, model
+}
+
+// NewGeminiClient creates a new Gemini LLM client from the configuration.
+func NewGeminiClient(cfg *ServerConfig) (*llmgemini.GeminiClient, error) {
+	apiKey, baseURL, model := LoadGeminiConfig(cfg)
+	return llmgemini.NewGeminiClient(apiKey, baseURL, model, nil)
+}
+
+// getGeminiAPIKeyFromEnv gets the Gemini API key from environment variables.
+// GEMINI_API_KEY takes precedence over GOOGLE_API_KEY.
+func getGeminiAPIKeyFromEnv() string {
+	if apiKey := os.Getenv("GEMINI_API_KEY"); apiKey != "" {
+		return apiKey
+	}
+	return os.Getenv("GOOGLE_API_KEY")
+}
+
+// getGeminiBaseURLFromEnv gets the Gemini base URL from environment variable.
+func getGeminiBaseURLFromEnv() string {
+	return os.Getenv("GEMINI_BASE_URL")
+}
+
+// getGeminiModelFromEnv gets the Gemini model from environment variable.
+func getGeminiModelFromEnv() string {
+	return os.Getenv("GEMINI_MODEL")
+}
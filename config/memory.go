@@ -0,0 +1,254 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aschepis/backscratcher/staff/ingest"
+	"github.com/aschepis/backscratcher/staff/memory"
+)
+
+// Default memory maintenance intervals.
+const (
+	DefaultConsolidateInterval = 6 * time.Hour
+	DefaultRetentionInterval   = time.Hour
+	DefaultProfileInterval     = time.Hour
+	DefaultDocsInterval        = 5 * time.Minute
+)
+
+// LoadMemoryConfig loads memory maintenance settings from server config. It returns the
+// deduplication settings for new memories and how often existing memories are
+// consolidated, where 0 disables consolidation.
+func LoadMemoryConfig(cfg *ServerConfig) (memory.DedupConfig, time.Duration, error) {
+	var memCfg MemoryConfig
+	if cfg != nil {
+		memCfg = cfg.Memory
+	}
+
+	dedup := memory.DefaultDedupConfig()
+	if memCfg.DisableDedup {
+		dedup.Enabled = false
+	}
+	if memCfg.DuplicateThreshold > 0 {
+		dedup.DuplicateThreshold = memCfg.DuplicateThreshold
+	}
+	if memCfg.SupersedeThreshold > 0 {
+		dedup.SupersedeThreshold = memCfg.SupersedeThreshold
+	}
+
+	interval := DefaultConsolidateInterval
+	if memCfg.ConsolidateInterval != "" {
+		d, err := time.ParseDuration(memCfg.ConsolidateInterval)
+		if err != nil {
+			return dedup, 0, fmt.Errorf("invalid memory.consolidate_interval %q: %w", memCfg.ConsolidateInterval, err)
+		}
+		interval = d
+	}
+	if !dedup.Enabled {
+		interval = 0
+	}
+
+	return dedup, interval, nil
+}
+
+// LoadRetentionConfig loads memory retention policies from server config. Configured
+// policies replace the defaults for the same kind of memory. It also returns how often
+// retention runs, where 0 disables it.
+func LoadRetentionConfig(cfg *ServerConfig) (memory.RetentionConfig, time.Duration, error) {
+	var memCfg MemoryConfig
+	if cfg != nil {
+		memCfg = cfg.Memory
+	}
+
+	retention := memory.DefaultRetentionConfig()
+	for kind, p := range memCfg.Retention {
+		ttl, err := parseOptionalDuration(p.TTL)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid memory.retention.%s.ttl: %w", kind, err)
+		}
+		halfLife, err := parseOptionalDuration(p.HalfLife)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid memory.retention.%s.half_life: %w", kind, err)
+		}
+		retention[kind] = memory.RetentionPolicy{
+			TTL:          ttl,
+			HalfLife:     halfLife,
+			ArchiveBelow: p.ArchiveBelow,
+			Delete:       p.Delete,
+		}
+	}
+
+	interval, err := parseOptionalDuration(memCfg.RetentionInterval)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid memory.retention_interval: %w", err)
+	}
+	if memCfg.RetentionInterval == "" {
+		interval = DefaultRetentionInterval
+	}
+
+	return retention, interval, nil
+}
+
+// DefaultExtractionModel is the model that proposes facts when extraction is enabled
+// without naming one.
+const DefaultExtractionModel = "claude-3.5-haiku-latest"
+
+// LoadExtractionConfig loads fact extraction settings from server config. It returns
+// whether extraction is enabled, the extraction model and the confidence below which
+// facts need confirmation (0 for the default).
+func LoadExtractionConfig(cfg *ServerConfig) (enabled bool, model string, minConfidence float64, err error) {
+	if cfg == nil || !cfg.Memory.Extraction.Enabled {
+		return false, "", 0, nil
+	}
+	ext := cfg.Memory.Extraction
+	if ext.MinConfidence < 0 || ext.MinConfidence > 1 {
+		return false, "", 0, fmt.Errorf("invalid memory.extraction.min_confidence %v: must be between 0 and 1", ext.MinConfidence)
+	}
+	model = ext.Model
+	if model == "" {
+		model = DefaultExtractionModel
+	}
+	return true, model, ext.MinConfidence, nil
+}
+
+// LoadReflectionConfig resolves the reflection policy of every agent, applying each
+// agent's overrides to memory.reflection. Agents whose interval is zero are left out.
+func LoadReflectionConfig(cfg *ServerConfig) (map[string]memory.ReflectionPolicy, error) {
+	policies := make(map[string]memory.ReflectionPolicy)
+	if cfg == nil {
+		return policies, nil
+	}
+
+	for id, agentCfg := range cfg.Agents {
+		if agentCfg == nil || agentCfg.Disabled {
+			continue
+		}
+		merged := cfg.Memory.Reflection
+		if o := agentCfg.Reflection; o != nil {
+			if o.Interval != "" {
+				merged.Interval = o.Interval
+			}
+			if o.Lookback != "" {
+				merged.Lookback = o.Lookback
+			}
+			if o.Scope != "" {
+				merged.Scope = o.Scope
+			}
+		}
+
+		interval, err := parseOptionalDuration(merged.Interval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reflection interval for agent %s: %w", id, err)
+		}
+		lookback, err := parseOptionalDuration(merged.Lookback)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reflection lookback for agent %s: %w", id, err)
+		}
+		scope := memory.ScopeGlobal
+		switch merged.Scope {
+		case "", string(memory.ScopeGlobal):
+		case string(memory.ScopeAgent):
+			scope = memory.ScopeAgent
+		default:
+			return nil, fmt.Errorf("invalid reflection scope %q for agent %s: must be agent or global", merged.Scope, id)
+		}
+		if interval <= 0 {
+			continue
+		}
+		if lookback <= 0 {
+			lookback = memory.DefaultReflectionLookback
+		}
+		policies[id] = memory.ReflectionPolicy{Interval: interval, Lookback: lookback, Scope: scope}
+	}
+	return policies, nil
+}
+
+// LoadProfileInterval returns how often the user profile is rebuilt from personal
+// memories, where 0 disables rebuilding.
+func LoadProfileInterval(cfg *ServerConfig) (time.Duration, error) {
+	if cfg == nil || cfg.Memory.ProfileInterval == "" {
+		return DefaultProfileInterval, nil
+	}
+	interval, err := time.ParseDuration(cfg.Memory.ProfileInterval)
+	if err != nil {
+		return 0, fmt.Errorf("invalid memory.profile_interval %q: %w", cfg.Memory.ProfileInterval, err)
+	}
+	return interval, nil
+}
+
+// LoadMemoryGroups returns the memory sharing groups keyed by name, with their member
+// agent IDs. Every member must be a configured agent.
+func LoadMemoryGroups(cfg *ServerConfig) (map[string][]string, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	groups := make(map[string][]string, len(cfg.Memory.Groups))
+	for name, members := range cfg.Memory.Groups {
+		if strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("memory group name cannot be empty")
+		}
+		if len(members) == 0 {
+			return nil, fmt.Errorf("memory group %s has no members", name)
+		}
+		for _, agentID := range members {
+			if _, ok := cfg.Agents[agentID]; !ok {
+				return nil, fmt.Errorf("memory group %s: unknown agent %q", name, agentID)
+			}
+		}
+		groups[name] = members
+	}
+	return groups, nil
+}
+
+// LoadDocsConfig returns the document ingestion settings and how often the directories are
+// rescanned. It returns a nil config when no directories are configured.
+func LoadDocsConfig(cfg *ServerConfig) (*ingest.Config, time.Duration, error) {
+	if cfg == nil || len(cfg.Memory.Docs.Dirs) == 0 {
+		return nil, 0, nil
+	}
+	docs := cfg.Memory.Docs
+	interval, err := parseOptionalDuration(docs.Interval)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid memory.docs.interval %q: %w", docs.Interval, err)
+	}
+	if interval <= 0 {
+		interval = DefaultDocsInterval
+	}
+	if docs.ChunkSize < 0 || docs.ChunkOverlap < 0 {
+		return nil, 0, fmt.Errorf("memory.docs chunk_size and chunk_overlap cannot be negative")
+	}
+	if docs.ChunkSize > 0 && docs.ChunkOverlap >= docs.ChunkSize {
+		return nil, 0, fmt.Errorf("memory.docs.chunk_overlap must be smaller than chunk_size")
+	}
+	dirs := make([]string, len(docs.Dirs))
+	for i, dir := range docs.Dirs {
+		dirs[i] = expandPath(dir)
+	}
+	return &ingest.Config{
+		Dirs:         dirs,
+		ChunkSize:    docs.ChunkSize,
+		ChunkOverlap: docs.ChunkOverlap,
+	}, interval, nil
+}
+
+// LoadTimezone returns the user's timezone, used to resolve relative times such as
+// "yesterday" in memory searches. It defaults to the system's local timezone.
+func LoadTimezone(cfg *ServerConfig) (*time.Location, error) {
+	if cfg == nil || cfg.Memory.Timezone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(cfg.Memory.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid memory.timezone %q: %w", cfg.Memory.Timezone, err)
+	}
+	return loc, nil
+}
+
+// parseOptionalDuration parses a duration, treating an empty string as zero.
+func parseOptionalDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(s)
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"os"
+)
+
+// LoadMockConfig loads the mock provider configuration from server config.
+// It returns the path of the script the mock provider replays.
+func LoadMockConfig(cfg *ServerConfig) (scriptPath string) {
+	if cfg != nil {
+		scriptPath = cfg.Mock.Script
+	}
+
+	// Apply environment variable override
+	if envScript := getMockScriptFromEnv(); envScript != "" {
+		scriptPath = envScript
+	}
+
+	return expandPath(scriptPath)
+}
+
+// getMockScriptFromEnv gets the mock script path from environment variable.
+func getMockScriptFromEnv() string {
+	return os.Getenv("STAFF_MOCK_SCRIPT")
+}
@@ -38,7 +38,7 @@ func LoadOllamaConfig(cfg *ServerConfig) (host, model string) {
 // NewOllamaClient creates a new Ollama LLM client from the configuration.
 func NewOllamaClient(cfg *ServerConfig) (*llmollama.OllamaClient, error) {
 	host, model := LoadOllamaConfig(cfg)
-	return llmollama.NewOllamaClient(host, model)
+	return llmollama.NewOllamaClient(host, model, nil)
 }
 
 // getOllamaHostFromEnv gets the Ollama host from environment variable.
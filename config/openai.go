@@ -43,7 +43,7 @@ func LoadOpenAIConfig(cfg *ServerConfig) (apiKey, baseURL, model, organization s
 // NewOpenAIClient creates a new OpenAI LLM client from the configuration.
 func NewOpenAIClient(cfg *ServerConfig) (*llmopenai.OpenAIClient, error) {
 	apiKey, baseURL, model, organization := LoadOpenAIConfig(cfg)
-	return llmopenai.NewOpenAIClient(apiKey, baseURL, model, organization)
+	return llmopenai.NewOpenAIClient(apiKey, baseURL, model, organization, nil)
 }
 
 // getOpenAIAPIKeyFromEnv gets the OpenAI API key from environment variable.
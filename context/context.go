@@ -20,3 +20,26 @@ func GetDebugCallback(ctx stdctx.Context) (func(string), bool) {
 	cb, ok := ctx.Value(debugCallbackKey{}).(func(string))
 	return cb, ok
 }
+
+// toolCallKey is the context key for the tool call being executed.
+type toolCallKey struct{}
+
+// ToolCall identifies a tool call made by an agent.
+type ToolCall struct {
+	AgentID  string
+	ThreadID string
+	ToolID   string
+	ToolName string
+}
+
+// WithToolCall adds the tool call being executed to the context.
+func WithToolCall(ctx stdctx.Context, call ToolCall) stdctx.Context {
+	return stdctx.WithValue(ctx, toolCallKey{}, call)
+}
+
+// GetToolCall retrieves the tool call being executed from the context.
+// Returns the call and a bool indicating if it was set.
+func GetToolCall(ctx stdctx.Context) (ToolCall, bool) {
+	call, ok := ctx.Value(toolCallKey{}).(ToolCall)
+	return call, ok
+}
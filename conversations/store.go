@@ -9,9 +9,14 @@ import (
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
+
+	"github.com/aschepis/backscratcher/staff/llm"
 )
 
-const roleSystem = "system"
+const (
+	roleSystem    = "system"
+	roleReasoning = "reasoning"
+)
 
 // Store handles persistence of conversation messages.
 // It implements agent.MessagePersister.
@@ -42,6 +47,60 @@ func (s *Store) AppendUserMessage(ctx context.Context, agentID, threadID, conten
 	return err
 }
 
+// AppendUserMessageWithAttachments saves a user message together with the images or documents
+// sent with it, so the attachments are part of the message when the thread is reloaded.
+func (s *Store) AppendUserMessageWithAttachments(ctx context.Context, agentID, threadID, content string, attachments []llm.MediaBlock) error {
+	if len(attachments) == 0 {
+		return s.AppendUserMessage(ctx, agentID, threadID, content)
+	}
+	records := make([]attachmentRecord, 0, len(attachments))
+	for _, a := range attachments {
+		records = append(records, attachmentRecord(a))
+	}
+	attachmentsJSON, err := json.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("marshal attachments: %w", err)
+	}
+
+	now := time.Now().Unix()
+	query := sq.Insert("conversations").
+		Columns("agent_id", "thread_id", "role", "content", "tool_name", "attachments", "created_at").
+		Values(agentID, threadID, "user", content, nil, string(attachmentsJSON), now)
+
+	queryStr, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, queryStr, args...)
+	return err
+}
+
+// attachmentRecord is the stored form of an attachment.
+type attachmentRecord struct {
+	MediaType string `json:"media_type"`
+	Data      string `json:"data,omitempty"`
+	Path      string `json:"path,omitempty"`
+	Name      string `json:"name,omitempty"`
+}
+
+// DecodeAttachments parses the attachments column of a user message.
+// An empty column decodes to no attachments.
+func DecodeAttachments(raw string) ([]llm.MediaBlock, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	var records []attachmentRecord
+	if err := json.Unmarshal([]byte(raw), &records); err != nil {
+		return nil, fmt.Errorf("decode attachments: %w", err)
+	}
+	attachments := make([]llm.MediaBlock, 0, len(records))
+	for _, r := range records {
+		attachments = append(attachments, llm.MediaBlock(r))
+	}
+	return attachments, nil
+}
+
 // AppendAssistantMessage saves an assistant text-only message to the conversation history.
 func (s *Store) AppendAssistantMessage(ctx context.Context, agentID, threadID, content string) error {
 	now := time.Now().Unix()
@@ -58,6 +117,29 @@ func (s *Store) AppendAssistantMessage(ctx context.Context, agentID, threadID, c
 	return err
 }
 
+// AppendReasoning saves the assistant's reasoning (extended thinking) to the conversation history.
+// Reasoning is stored in its own row so it never mixes with the answer text. It is shown in
+// the UI; only signed reasoning is replayed to the model, since providers that sign their
+// reasoning require it back with the tool calls it led to.
+func (s *Store) AppendReasoning(ctx context.Context, agentID, threadID, content, signature string) error {
+	var signatureVal interface{}
+	if signature != "" {
+		signatureVal = signature
+	}
+	now := time.Now().Unix()
+	query := sq.Insert("conversations").
+		Columns("agent_id", "thread_id", "role", "content", "tool_name", "signature", "created_at").
+		Values(agentID, threadID, roleReasoning, content, nil, signatureVal, now)
+
+	queryStr, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, queryStr, args...)
+	return err
+}
+
 // AppendToolCall saves an assistant message with tool use blocks to the conversation history.
 // toolID is the unique ID for this tool call.
 // toolName is the name of the tool being called.
@@ -155,3 +237,117 @@ func (s *Store) AppendSystemMessage(ctx context.Context, agentID, threadID, cont
 	_, err = s.db.ExecContext(ctx, queryStr, args...)
 	return err
 }
+
+// PinLastUserMessage marks the most recent user text message in a thread as pinned.
+// Pinned messages are kept verbatim when the context is compressed.
+// Returns the pinned message content, or an error if the thread has no user messages.
+func (s *Store) PinLastUserMessage(ctx context.Context, agentID, threadID string) (string, error) {
+	query := sq.Select("id", "content").
+		From("conversations").
+		Where(sq.Eq{"agent_id": agentID}).
+		Where(sq.Eq{"thread_id": threadID}).
+		Where(sq.Eq{"role": "user"}).
+		Where(sq.Eq{"tool_name": nil}).
+		OrderBy("created_at DESC", "id DESC").
+		Limit(1)
+
+	queryStr, args, err := query.ToSql()
+	if err != nil {
+		return "", fmt.Errorf("build query: %w", err)
+	}
+
+	var id int64
+	var content string
+	if err := s.db.QueryRowContext(ctx, queryStr, args...).Scan(&id, &content); err != nil {
+		if err == sql.ErrNoRows {
+			return "", fmt.Errorf("no user message to pin in thread %s", threadID)
+		}
+		return "", fmt.Errorf("find last user message: %w", err)
+	}
+
+	updateStr, updateArgs, err := sq.Update("conversations").
+		Set("pinned", 1).
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return "", fmt.Errorf("build update: %w", err)
+	}
+
+	if _, err := s.db.ExecContext(ctx, updateStr, updateArgs...); err != nil {
+		return "", fmt.Errorf("pin message: %w", err)
+	}
+	return content, nil
+}
+
+// Message is a stored conversation row.
+type Message struct {
+	ID        int64
+	Role      string
+	Content   string
+	ToolName  string
+	ToolID    string
+	CreatedAt time.Time
+}
+
+// MessagesAround returns the message with the given ID together with up to n messages on
+// either side of it in the same thread, oldest first. It returns no messages if the ID is
+// not part of the thread.
+func (s *Store) MessagesAround(ctx context.Context, agentID, threadID string, messageID int64, n int) ([]Message, error) {
+	thread := sq.Select("id", "role", "content", "tool_name", "tool_id", "created_at").
+		From("conversations").
+		Where(sq.Eq{"agent_id": agentID}).
+		Where(sq.Eq{"thread_id": threadID})
+
+	before, err := s.queryMessages(ctx, thread.
+		Where(sq.LtOrEq{"id": messageID}).
+		OrderBy("id DESC").
+		Limit(uint64(n+1))) //nolint:gosec // n is a small window size
+	if err != nil {
+		return nil, err
+	}
+	if len(before) == 0 || before[0].ID != messageID {
+		return nil, nil
+	}
+	after, err := s.queryMessages(ctx, thread.
+		Where(sq.Gt{"id": messageID}).
+		OrderBy("id ASC").
+		Limit(uint64(n))) //nolint:gosec // n is a small window size
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]Message, 0, len(before)+len(after))
+	for i := len(before) - 1; i >= 0; i-- {
+		messages = append(messages, before[i])
+	}
+	return append(messages, after...), nil
+}
+
+// queryMessages runs a select over conversation rows.
+func (s *Store) queryMessages(ctx context.Context, query sq.SelectBuilder) ([]Message, error) {
+	queryStr, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, queryStr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query messages: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck // No remedy for rows close errors
+
+	var messages []Message
+	for rows.Next() {
+		var m Message
+		var toolName, toolID sql.NullString
+		var createdAt int64
+		if err := rows.Scan(&m.ID, &m.Role, &m.Content, &toolName, &toolID, &createdAt); err != nil {
+			return nil, fmt.Errorf("scan message: %w", err)
+		}
+		m.ToolName = toolName.String
+		m.ToolID = toolID.String
+		m.CreatedAt = time.Unix(createdAt, 0)
+		messages = append(messages, m)
+	}
+	return messages, rows.Err()
+}
@@ -0,0 +1,83 @@
+package ingest
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/aschepis/backscratcher/staff/memory"
+)
+
+// Default chunk sizes, in bytes of text.
+const (
+	DefaultChunkSize    = 1000
+	DefaultChunkOverlap = 200
+)
+
+// Chunk splits text into chunks of at most size bytes, each starting overlap bytes before
+// the end of the previous one so a passage cut at a boundary is whole in one of them.
+// Chunks end at a paragraph, line or word break where there is one in their second half.
+func Chunk(text string, size, overlap int) []memory.DocChunk {
+	if size <= 0 {
+		size = DefaultChunkSize
+	}
+	if overlap < 0 || overlap >= size {
+		overlap = 0
+	}
+
+	var chunks []memory.DocChunk
+	for start := 0; start < len(text); {
+		end := len(text)
+		if start+size < len(text) {
+			end = breakBefore(text, start+max(1, size/2), start+size)
+		}
+
+		// Trim surrounding whitespace, keeping the offset on the first character kept
+		body := text[start:end]
+		trimmed := strings.TrimLeftFunc(body, unicode.IsSpace)
+		offset := start + len(body) - len(trimmed)
+		if trimmed = strings.TrimRightFunc(trimmed, unicode.IsSpace); trimmed != "" {
+			chunks = append(chunks, memory.DocChunk{Offset: offset, Text: trimmed})
+		}
+		if end == len(text) {
+			break
+		}
+
+		next := end - overlap
+		if next <= start {
+			next = end
+		}
+		start = wordStart(text, next, end)
+	}
+	return chunks
+}
+
+// breakBefore returns the best place to end a chunk at or before limit and after min:
+// a paragraph break, then a line break, then a space, and otherwise limit itself.
+func breakBefore(text string, min, limit int) int {
+	window := text[min:limit]
+	for _, sep := range []string{"\n\n", "\n", " "} {
+		if i := strings.LastIndex(window, sep); i >= 0 {
+			return min + i + len(sep)
+		}
+	}
+	for limit > min && !utf8.RuneStart(text[limit]) {
+		limit--
+	}
+	return limit
+}
+
+// wordStart moves pos forward to the start of the next word, but not past limit, so an
+// overlapping chunk doesn't begin mid-word.
+func wordStart(text string, pos, limit int) int {
+	if pos == 0 || unicode.IsSpace(rune(text[pos-1])) {
+		return pos
+	}
+	if i := strings.IndexAny(text[pos:limit], " \n\t"); i >= 0 {
+		return pos + i + 1
+	}
+	for pos < limit && !utf8.RuneStart(text[pos]) {
+		pos++
+	}
+	return pos
+}
@@ -0,0 +1,125 @@
+// Package ingest indexes workspace documents as doc_ref memories, so agents can search
+// local files without a separate retrieval server.
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Supported reports whether the ingester can extract text from the file at path.
+func Supported(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown", ".txt", ".text", ".html", ".htm", ".pdf":
+		return true
+	}
+	return false
+}
+
+// ExtractText returns the text of a document, chosen by the extension of path. Markdown
+// and plain text are returned as is, so chunk offsets are byte offsets in the file. HTML
+// and PDF text is extracted, so offsets into it don't match the file; see IsPlainText.
+func ExtractText(ctx context.Context, path string, data []byte) (string, error) {
+	if IsPlainText(path) {
+		return string(data), nil
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".htm":
+		return extractHTML(data)
+	case ".pdf":
+		return extractPDF(ctx, path, data)
+	}
+	return "", fmt.Errorf("unsupported document type: %s", filepath.Ext(path))
+}
+
+// IsPlainText reports whether ExtractText returns the file at path unchanged.
+func IsPlainText(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown", ".txt", ".text":
+		return true
+	}
+	return false
+}
+
+// htmlBlocks are elements that start a new line of text.
+var htmlBlocks = map[string]bool{
+	"p": true, "div": true, "br": true, "li": true, "tr": true, "pre": true, "blockquote": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"section": true, "article": true, "header": true, "footer": true, "table": true,
+}
+
+// extractHTML returns the visible text of an HTML document, one block element per line.
+func extractHTML(data []byte) (string, error) {
+	var (
+		b    strings.Builder
+		skip int
+	)
+	newline := func() {
+		if b.Len() > 0 && !strings.HasSuffix(b.String(), "\n") {
+			b.WriteString("\n")
+		}
+	}
+
+	z := html.NewTokenizer(bytes.NewReader(data))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			if err := z.Err(); err != io.EOF {
+				return "", fmt.Errorf("parse html: %w", err)
+			}
+			return strings.TrimSpace(b.String()), nil
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := z.TagName()
+			switch tag := string(name); {
+			case tag == "script" || tag == "style" || tag == "noscript":
+				skip++
+			case htmlBlocks[tag]:
+				newline()
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			switch tag := string(name); {
+			case tag == "script" || tag == "style" || tag == "noscript":
+				if skip > 0 {
+					skip--
+				}
+			case htmlBlocks[tag]:
+				newline()
+			}
+		case html.TextToken:
+			if skip > 0 {
+				continue
+			}
+			if text := strings.Join(strings.Fields(string(z.Text())), " "); text != "" {
+				if b.Len() > 0 && !strings.HasSuffix(b.String(), "\n") {
+					b.WriteString(" ")
+				}
+				b.WriteString(text)
+			}
+		}
+	}
+}
+
+// extractPDF returns the text of a PDF. It uses pdftotext from poppler when it is
+// installed, and otherwise reads the text operators of the page streams, which covers
+// simple PDFs but not ones with embedded font encodings.
+func extractPDF(ctx context.Context, path string, data []byte) (string, error) {
+	if bin, err := exec.LookPath("pdftotext"); err == nil {
+		out, err := exec.CommandContext(ctx, bin, "-q", "-enc", "UTF-8", path, "-").Output()
+		if err == nil {
+			return string(out), nil
+		}
+	}
+	text := extractPDFText(data)
+	if strings.TrimSpace(text) == "" {
+		return "", fmt.Errorf("no extractable text in %s", filepath.Base(path))
+	}
+	return text, nil
+}
@@ -0,0 +1,248 @@
+package ingest
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aschepis/backscratcher/staff/memory"
+	"github.com/aschepis/backscratcher/staff/migrations"
+	"github.com/rs/zerolog"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestChunk_OffsetsAndOverlap(t *testing.T) {
+	var words []string
+	for i := 0; i < 200; i++ {
+		words = append(words, fmt.Sprintf("word%03d", i))
+	}
+	text := strings.Join(words, " ")
+
+	chunks := Chunk(text, 100, 30)
+	if len(chunks) < 2 {
+		t.Fatalf("expected several chunks, got %d", len(chunks))
+	}
+	for i, c := range chunks {
+		if len(c.Text) > 100 {
+			t.Errorf("chunk %d is %d bytes, want at most 100", i, len(c.Text))
+		}
+		if got := text[c.Offset : c.Offset+len(c.Text)]; got != c.Text {
+			t.Errorf("chunk %d offset %d does not point at its text", i, c.Offset)
+		}
+		if strings.HasPrefix(c.Text, "ord") || strings.HasSuffix(c.Text, "wor") {
+			t.Errorf("chunk %d splits a word: %q", i, c.Text)
+		}
+		if i > 0 {
+			prev := chunks[i-1]
+			if c.Offset >= prev.Offset+len(prev.Text) {
+				t.Errorf("chunk %d does not overlap chunk %d", i, i-1)
+			}
+		}
+	}
+	last := chunks[len(chunks)-1]
+	if !strings.HasSuffix(last.Text, "word199") {
+		t.Errorf("last chunk should end the text, got %q", last.Text)
+	}
+}
+
+func TestChunk_PrefersParagraphBreaks(t *testing.T) {
+	text := strings.Repeat("a", 60) + "\n\n" + strings.Repeat("b", 60)
+	chunks := Chunk(text, 100, 0)
+	if len(chunks) != 2 {
+		t.Fatalf("expected 2 chunks, got %d: %+v", len(chunks), chunks)
+	}
+	if chunks[0].Text != strings.Repeat("a", 60) || chunks[1].Offset != 62 {
+		t.Errorf("expected a break at the paragraph, got %+v", chunks)
+	}
+}
+
+func TestExtractHTML(t *testing.T) {
+	page := `<html><head><title>T</title><style>p { color: red }</style></head>
+<body><h1>Release   notes</h1><p>Fixed the <b>scheduler</b>.</p><script>alert(1)</script><p>Second</p></body></html>`
+	text, err := ExtractText(context.Background(), "notes.html", []byte(page))
+	if err != nil {
+		t.Fatalf("ExtractText: %v", err)
+	}
+	if strings.Contains(text, "color") || strings.Contains(text, "alert") {
+		t.Errorf("script and style content should be dropped: %q", text)
+	}
+	for _, want := range []string{"Release notes\n", "Fixed the scheduler .", "\nSecond"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in %q", want, text)
+		}
+	}
+}
+
+func TestExtractPDFText(t *testing.T) {
+	plain := "BT /F1 12 Tf 72 712 Td (Quarterly report) Tj 0 -14 Td [(Revenue) -250 (grew)] TJ ET"
+
+	var deflated bytes.Buffer
+	zw := zlib.NewWriter(&deflated)
+	_, _ = zw.Write([]byte(`BT (Compressed \(page\) two) Tj ET`))
+	_ = zw.Close()
+
+	var pdf bytes.Buffer
+	pdf.WriteString("%PDF-1.4\n")
+	fmt.Fprintf(&pdf, "4 0 obj << /Length %d >>\nstream\n%s\nendstream\nendobj\n", len(plain), plain)
+	fmt.Fprintf(&pdf, "5 0 obj << /Length %d /Filter /FlateDecode >>\nstream\n", deflated.Len())
+	pdf.Write(deflated.Bytes())
+	pdf.WriteString("\nendstream\nendobj\n%%EOF\n")
+
+	text := extractPDFText(pdf.Bytes())
+	want := "Quarterly report\nRevenue grew\n\nCompressed (page) two"
+	if text != want {
+		t.Errorf("extractPDFText = %q, want %q", text, want)
+	}
+}
+
+func TestPDFStreams_CapsInflatedSize(t *testing.T) {
+	// A small Flate stream that would inflate past the cap
+	var deflated bytes.Buffer
+	zw := zlib.NewWriter(&deflated)
+	_, _ = zw.Write(make([]byte, maxPDFStreamSize+1024))
+	_ = zw.Close()
+
+	var pdf bytes.Buffer
+	fmt.Fprintf(&pdf, "%%PDF-1.4\n1 0 obj << /Length %d /Filter /FlateDecode >>\nstream\n", deflated.Len())
+	pdf.Write(deflated.Bytes())
+	pdf.WriteString("\nendstream\nendobj\n%%EOF\n")
+
+	streams := pdfStreams(pdf.Bytes())
+	if len(streams) != 1 || len(streams[0]) != maxPDFStreamSize {
+		t.Fatalf("expected one stream inflated to %d bytes, got %d streams", maxPDFStreamSize, len(streams))
+	}
+}
+
+func TestIngester_CitesExtractedTextByChunk(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	dir := t.TempDir()
+	page := filepath.Join(dir, "runbook.html")
+	if err := os.WriteFile(page, []byte("<html><head><title>Runbook</title></head><body><p>Rotate the frobnicator keys monthly.</p></body></html>"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ingester, err := NewIngester(store, Config{Dirs: []string{dir}}, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewIngester: %v", err)
+	}
+	if _, err := ingester.Run(ctx); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	results, err := store.SearchDocs(ctx, "frobnicator", 5)
+	if err != nil {
+		t.Fatalf("SearchDocs: %v", err)
+	}
+	if len(results) != 1 || !results[0].Extracted {
+		t.Fatalf("expected one result from extracted text, got %+v", results)
+	}
+	if results[0].Citation() != page+"#chunk-0" {
+		t.Errorf("citation = %q", results[0].Citation())
+	}
+}
+
+func TestIngester_Run(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	dir := t.TempDir()
+
+	write := func(name, content string) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	guide := write("guide.md", "# Deploying\n\nRun the migration before restarting the frobnicator service.")
+	notes := write("sub/notes.txt", "The backup window is at 3am.")
+	write(".hidden/secret.md", "ignored frobnicator")
+	write("image.png", "not a document")
+
+	ingester, err := NewIngester(store, Config{Dirs: []string{dir}}, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewIngester: %v", err)
+	}
+
+	result, err := ingester.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result != (Result{Indexed: 2}) {
+		t.Fatalf("first run = %+v, want 2 indexed", result)
+	}
+
+	results, err := store.SearchDocs(ctx, "frobnicator", 5)
+	if err != nil {
+		t.Fatalf("SearchDocs: %v", err)
+	}
+	if len(results) != 1 || results[0].Path != guide {
+		t.Fatalf("expected one result from %s, got %+v", guide, results)
+	}
+	if results[0].Citation() != guide+":0" {
+		t.Errorf("citation = %q", results[0].Citation())
+	}
+
+	if result, err = ingester.Run(ctx); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result != (Result{Unchanged: 2}) {
+		t.Fatalf("rerun = %+v, want 2 unchanged", result)
+	}
+
+	write("guide.md", "# Deploying\n\nRestart the widget service after deploying.")
+	if err := os.Remove(notes); err != nil {
+		t.Fatal(err)
+	}
+	if result, err = ingester.Run(ctx); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result != (Result{Indexed: 1, Removed: 1}) {
+		t.Fatalf("run after changes = %+v, want 1 indexed and 1 removed", result)
+	}
+
+	if results, _ = store.SearchDocs(ctx, "frobnicator", 5); len(results) != 0 {
+		t.Errorf("stale chunks of the old version are still searchable: %+v", results)
+	}
+	if results, _ = store.SearchDocs(ctx, "widget", 5); len(results) != 1 {
+		t.Errorf("expected the new version to be searchable, got %+v", results)
+	}
+	docs, err := store.Documents(ctx)
+	if err != nil {
+		t.Fatalf("Documents: %v", err)
+	}
+	if len(docs) != 1 || docs[0].Path != guide {
+		t.Errorf("expected only %s to remain, got %+v", guide, docs)
+	}
+}
+
+// newTestStore returns a store on a migrated in-memory database.
+func newTestStore(t *testing.T) *memory.Store {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+
+	if err := migrations.RunMigrations(db, filepath.Join("..", "migrations"), zerolog.Nop()); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+	store, err := memory.NewStore(db, nil, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	return store
+}
@@ -0,0 +1,183 @@
+package ingest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aschepis/backscratcher/staff/memory"
+	"github.com/rs/zerolog"
+)
+
+// maxDocumentSize is the largest file the ingester reads; bigger files are skipped.
+const maxDocumentSize = 20 << 20
+
+// Config selects the directories to ingest and how their files are chunked.
+type Config struct {
+	Dirs         []string
+	ChunkSize    int // Bytes of text per chunk (default DefaultChunkSize)
+	ChunkOverlap int // Bytes shared by neighbouring chunks (default DefaultChunkOverlap)
+}
+
+// Result summarizes an ingestion pass.
+type Result struct {
+	Indexed   int // New or changed files
+	Unchanged int
+	Removed   int // Documents whose files were deleted
+	Failed    int
+}
+
+// Ingester indexes the Markdown, text, HTML and PDF files under a set of directories as
+// doc_ref memories. Files are identified by content hash, so only new and changed files
+// are re-indexed.
+type Ingester struct {
+	store  *memory.Store
+	cfg    Config
+	logger zerolog.Logger
+}
+
+// NewIngester creates an ingester for the directories in cfg.
+func NewIngester(store *memory.Store, cfg Config, logger zerolog.Logger) (*Ingester, error) {
+	if store == nil {
+		return nil, fmt.Errorf("store cannot be nil")
+	}
+	if len(cfg.Dirs) == 0 {
+		return nil, fmt.Errorf("no directories to ingest")
+	}
+	dirs := make([]string, len(cfg.Dirs))
+	for i, dir := range cfg.Dirs {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, fmt.Errorf("resolve %s: %w", dir, err)
+		}
+		dirs[i] = abs
+	}
+	cfg.Dirs = dirs
+	if cfg.ChunkSize <= 0 {
+		cfg.ChunkSize = DefaultChunkSize
+	}
+	if cfg.ChunkOverlap <= 0 {
+		cfg.ChunkOverlap = DefaultChunkOverlap
+	}
+	return &Ingester{store: store, cfg: cfg, logger: logger}, nil
+}
+
+// Run indexes new and changed files under the configured directories and removes the
+// documents of files that no longer exist. A file that fails is logged and counted, and
+// the others are still indexed.
+func (in *Ingester) Run(ctx context.Context) (Result, error) {
+	var result Result
+	seen := make(map[string]bool)
+
+	for _, dir := range in.cfg.Dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				in.logger.Warn().Err(err).Str("path", path).Msg("Cannot read path for ingestion")
+				return nil
+			}
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			if d.IsDir() {
+				if path != dir && strings.HasPrefix(d.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !d.Type().IsRegular() || !Supported(path) {
+				return nil
+			}
+			seen[path] = true
+
+			changed, err := in.ingestFile(ctx, path)
+			switch {
+			case err != nil:
+				result.Failed++
+				in.logger.Warn().Err(err).Str("path", path).Msg("Failed to ingest document")
+			case changed:
+				result.Indexed++
+			default:
+				result.Unchanged++
+			}
+			return nil
+		})
+		if err != nil {
+			return result, err
+		}
+	}
+
+	docs, err := in.store.Documents(ctx)
+	if err != nil {
+		return result, err
+	}
+	for _, doc := range docs {
+		if seen[doc.Path] || !in.watches(doc.Path) {
+			continue
+		}
+		if _, err := os.Stat(doc.Path); err == nil {
+			continue
+		}
+		if err := in.store.RemoveDocument(ctx, doc.Path); err != nil {
+			return result, fmt.Errorf("remove %s: %w", doc.Path, err)
+		}
+		result.Removed++
+	}
+	return result, nil
+}
+
+// ingestFile indexes one file unless its content is already indexed, reporting whether it
+// was indexed.
+func (in *Ingester) ingestFile(ctx context.Context, path string) (bool, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+	if info.Size() > maxDocumentSize {
+		return false, fmt.Errorf("file is larger than %d bytes", maxDocumentSize)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	doc, err := in.store.Document(ctx, path)
+	if err != nil {
+		return false, err
+	}
+	if doc != nil && doc.ContentHash == hash {
+		return false, nil
+	}
+
+	text, err := ExtractText(ctx, path, data)
+	if err != nil {
+		return false, err
+	}
+	chunks := Chunk(text, in.cfg.ChunkSize, in.cfg.ChunkOverlap)
+	if !IsPlainText(path) {
+		for i := range chunks {
+			chunks[i].Extracted = true
+		}
+	}
+	if err := in.store.IndexDocument(ctx, path, hash, chunks); err != nil {
+		return false, err
+	}
+	in.logger.Debug().Str("path", path).Int("chunks", len(chunks)).Msg("Indexed document")
+	return true, nil
+}
+
+// watches reports whether path is under one of the ingested directories.
+func (in *Ingester) watches(path string) bool {
+	for _, dir := range in.cfg.Dirs {
+		if rel, err := filepath.Rel(dir, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,272 @@
+package ingest
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxPDFStreamSize caps how much a single Flate-encoded stream is inflated, so a small
+// compressed stream can't expand to exhaust memory.
+const maxPDFStreamSize = 32 << 20
+
+// extractPDFText returns the text shown by the content streams of a PDF, separated by
+// blank lines. Strings are decoded as single-byte text, which is right for the standard
+// fonts most simple PDFs use.
+func extractPDFText(data []byte) string {
+	var parts []string
+	for _, stream := range pdfStreams(data) {
+		if !bytes.Contains(stream, []byte("BT")) {
+			continue
+		}
+		if text := strings.TrimSpace(pdfContentText(stream)); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// pdfStreams returns the contents of every stream in a PDF, inflating Flate-encoded ones.
+func pdfStreams(data []byte) [][]byte {
+	var streams [][]byte
+	for pos := 0; ; {
+		i := bytes.Index(data[pos:], []byte("stream"))
+		if i < 0 {
+			return streams
+		}
+		start := pos + i + len("stream")
+		// "endstream" also contains "stream"; skip it
+		if bytes.HasSuffix(data[:pos+i], []byte("end")) {
+			pos = start
+			continue
+		}
+		if bytes.HasPrefix(data[start:], []byte("\r\n")) {
+			start += 2
+		} else if bytes.HasPrefix(data[start:], []byte("\n")) {
+			start++
+		}
+		end := bytes.Index(data[start:], []byte("endstream"))
+		if end < 0 {
+			return streams
+		}
+		body := data[start : start+end]
+		dictStart := bytes.LastIndex(data[:pos+i], []byte("obj"))
+		if dictStart < 0 {
+			dictStart = 0
+		}
+		if bytes.Contains(data[dictStart:pos+i], []byte("/FlateDecode")) {
+			if r, err := zlib.NewReader(bytes.NewReader(body)); err == nil {
+				// Truncated streams still yield what was inflated before the error
+				inflated, _ := io.ReadAll(io.LimitReader(r, maxPDFStreamSize))
+				body = inflated
+			}
+		}
+		streams = append(streams, body)
+		pos = start + end + len("endstream")
+	}
+}
+
+// pdfContentText interprets the text operators of a content stream.
+func pdfContentText(content []byte) string {
+	var (
+		out      strings.Builder
+		operands []interface{} // string, float64 or []interface{} for arrays
+		arrays   [][]interface{}
+	)
+	push := func(v interface{}) {
+		if n := len(arrays); n > 0 {
+			arrays[n-1] = append(arrays[n-1], v)
+			return
+		}
+		operands = append(operands, v)
+	}
+	newline := func() {
+		if out.Len() > 0 && !strings.HasSuffix(out.String(), "\n") {
+			out.WriteString("\n")
+		}
+	}
+	show := func(v interface{}) {
+		switch v := v.(type) {
+		case string:
+			writeLatin1(&out, v)
+		case []interface{}:
+			for _, part := range v {
+				switch part := part.(type) {
+				case string:
+					writeLatin1(&out, part)
+				case float64:
+					// Large negative adjustments separate words
+					if part < -200 {
+						out.WriteString(" ")
+					}
+				}
+			}
+		}
+	}
+	last := func() interface{} {
+		if len(operands) == 0 {
+			return nil
+		}
+		return operands[len(operands)-1]
+	}
+
+	for i := 0; i < len(content); {
+		c := content[i]
+		switch {
+		case c == '%':
+			for i < len(content) && content[i] != '\n' && content[i] != '\r' {
+				i++
+			}
+		case c == '(':
+			s, n := pdfLiteralString(content[i:])
+			push(s)
+			i += n
+		case c == '<' && i+1 < len(content) && content[i+1] == '<':
+			i += 2
+		case c == '>' && i+1 < len(content) && content[i+1] == '>':
+			i += 2
+		case c == '<':
+			end := bytes.IndexByte(content[i:], '>')
+			if end < 0 {
+				return out.String()
+			}
+			push(pdfHexString(content[i+1 : i+end]))
+			i += end + 1
+		case c == '[':
+			arrays = append(arrays, nil)
+			i++
+		case c == ']':
+			if n := len(arrays); n > 0 {
+				arr := arrays[n-1]
+				arrays = arrays[:n-1]
+				push(arr)
+			}
+			i++
+		case isPDFSpace(c):
+			i++
+		default:
+			j := i + 1
+			for j < len(content) && !isPDFSpace(content[j]) && !isPDFDelimiter(content[j]) {
+				j++
+			}
+			token := string(content[i:j])
+			i = j
+			if c == '/' {
+				push(token)
+				continue
+			}
+			if f, err := strconv.ParseFloat(token, 64); err == nil {
+				push(f)
+				continue
+			}
+			switch token {
+			case "Tj", "TJ":
+				show(last())
+			case "'", "\"":
+				newline()
+				show(last())
+			case "T*", "ET":
+				newline()
+			case "Td", "TD":
+				if ty, ok := last().(float64); ok && ty != 0 {
+					newline()
+				} else if out.Len() > 0 && !strings.HasSuffix(out.String(), "\n") {
+					out.WriteString(" ")
+				}
+			}
+			operands = operands[:0]
+		}
+	}
+	return out.String()
+}
+
+// pdfLiteralString decodes a parenthesized string at the start of b, returning it and the
+// number of bytes consumed.
+func pdfLiteralString(b []byte) (string, int) {
+	var (
+		s     strings.Builder
+		depth = 0
+	)
+	for i := 0; i < len(b); i++ {
+		switch c := b[i]; c {
+		case '(':
+			if depth > 0 {
+				s.WriteByte(c)
+			}
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return s.String(), i + 1
+			}
+			s.WriteByte(c)
+		case '\\':
+			i++
+			if i >= len(b) {
+				return s.String(), i
+			}
+			switch e := b[i]; e {
+			case 'n':
+				s.WriteByte('\n')
+			case 'r':
+				s.WriteByte('\r')
+			case 't':
+				s.WriteByte('\t')
+			case 'b', 'f':
+			case '\r', '\n':
+				// Line continuation
+			default:
+				if e >= '0' && e <= '7' {
+					j := i
+					for j < len(b) && j < i+3 && b[j] >= '0' && b[j] <= '7' {
+						j++
+					}
+					v, _ := strconv.ParseUint(string(b[i:j]), 8, 8)
+					s.WriteByte(byte(v))
+					i = j - 1
+				} else {
+					s.WriteByte(e)
+				}
+			}
+		default:
+			s.WriteByte(c)
+		}
+	}
+	return s.String(), len(b)
+}
+
+// pdfHexString decodes the digits of a <hex> string.
+func pdfHexString(b []byte) string {
+	digits := strings.Map(func(r rune) rune {
+		if strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return r
+		}
+		return -1
+	}, string(b))
+	if len(digits)%2 == 1 {
+		digits += "0"
+	}
+	var s strings.Builder
+	for i := 0; i+1 < len(digits); i += 2 {
+		v, _ := strconv.ParseUint(digits[i:i+2], 16, 8)
+		s.WriteByte(byte(v))
+	}
+	return s.String()
+}
+
+// writeLatin1 writes the bytes of s as Latin-1 characters.
+func writeLatin1(b *strings.Builder, s string) {
+	for i := 0; i < len(s); i++ {
+		b.WriteRune(rune(s[i]))
+	}
+}
+
+func isPDFSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' || c == 0
+}
+
+func isPDFDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
@@ -2,6 +2,7 @@ package anthropic
 
 import (
 	"encoding/json"
+	"fmt"
 
 	anthropic "github.com/anthropics/anthropic-sdk-go"
 	"github.com/aschepis/backscratcher/staff/llm"
@@ -53,6 +54,25 @@ func FromMessageParam(msg anthropic.MessageParam) (llm.Message, error) {
 				},
 			})
 		}
+		// Check for thinking blocks
+		if blockUnion.OfThinking != nil {
+			content = append(content, llm.NewReasoningContentBlock(llm.ReasoningBlock{
+				Text:      blockUnion.OfThinking.Thinking,
+				Signature: blockUnion.OfThinking.Signature,
+			}))
+		}
+		if blockUnion.OfRedactedThinking != nil {
+			content = append(content, llm.NewReasoningContentBlock(llm.ReasoningBlock{
+				RedactedData: blockUnion.OfRedactedThinking.Data,
+			}))
+		}
+		// Check for inline base64 image blocks
+		if blockUnion.OfImage != nil && blockUnion.OfImage.Source.OfBase64 != nil {
+			content = append(content, llm.NewMediaBlock(llm.MediaBlock{
+				MediaType: string(blockUnion.OfImage.Source.OfBase64.MediaType),
+				Data:      blockUnion.OfImage.Source.OfBase64.Data,
+			}))
+		}
 		// Check for tool result blocks
 		if blockUnion.OfToolResult != nil {
 			// Extract content string from ContentUnion slice
@@ -101,11 +121,30 @@ func ToMessageParam(msg llm.Message) (anthropic.MessageParam, error) {
 			}
 		case llm.ContentBlockTypeToolResult:
 			if block.ToolResult != nil {
-				contentBlocks = append(contentBlocks, anthropic.NewToolResultBlock(
-					block.ToolResult.ID,
-					block.ToolResult.Content,
-					block.ToolResult.IsError,
-				))
+				toolResult, err := toToolResultBlock(block.ToolResult)
+				if err != nil {
+					return anthropic.MessageParam{}, err
+				}
+				contentBlocks = append(contentBlocks, toolResult)
+			}
+		case llm.ContentBlockTypeReasoning:
+			// Thinking must be returned exactly as received, so reasoning without a
+			// signature (e.g. produced by another provider) cannot be sent back.
+			if block.Reasoning != nil {
+				switch {
+				case block.Reasoning.RedactedData != "":
+					contentBlocks = append(contentBlocks, anthropic.NewRedactedThinkingBlock(block.Reasoning.RedactedData))
+				case block.Reasoning.Signature != "":
+					contentBlocks = append(contentBlocks, anthropic.NewThinkingBlock(block.Reasoning.Signature, block.Reasoning.Text))
+				}
+			}
+		case llm.ContentBlockTypeImage, llm.ContentBlockTypeDocument:
+			if block.Media != nil {
+				mediaBlock, err := toMediaBlock(block.Media)
+				if err != nil {
+					return anthropic.MessageParam{}, err
+				}
+				contentBlocks = append(contentBlocks, mediaBlock)
 			}
 		}
 	}
@@ -120,6 +159,56 @@ func ToMessageParam(msg llm.Message) (anthropic.MessageParam, error) {
 	}
 }
 
+// toMediaBlock converts an llm.MediaBlock to an Anthropic image or document block.
+// Only PDF documents are supported; other document types are replaced with a text note.
+func toMediaBlock(media *llm.MediaBlock) (anthropic.ContentBlockParamUnion, error) {
+	if !llm.IsImageMediaType(media.MediaType) && media.MediaType != "application/pdf" {
+		return anthropic.NewTextBlock(llm.UnsupportedMediaNote(media)), nil
+	}
+
+	data, err := media.Base64()
+	if err != nil {
+		return anthropic.ContentBlockParamUnion{}, fmt.Errorf("failed to load %s: %w", media.DisplayName(), err)
+	}
+
+	if llm.IsImageMediaType(media.MediaType) {
+		return anthropic.NewImageBlockBase64(media.MediaType, data), nil
+	}
+	return anthropic.NewDocumentBlock(anthropic.Base64PDFSourceParam{Data: data}), nil
+}
+
+// toToolResultBlock converts an llm.ToolResultBlock to an Anthropic tool result block,
+// including any images or documents returned by the tool.
+func toToolResultBlock(result *llm.ToolResultBlock) (anthropic.ContentBlockParamUnion, error) {
+	if len(result.Media) == 0 {
+		return anthropic.NewToolResultBlock(result.ID, result.Content, result.IsError), nil
+	}
+
+	content := []anthropic.ToolResultBlockParamContentUnion{
+		{OfText: &anthropic.TextBlockParam{Text: result.Content}},
+	}
+	for i := range result.Media {
+		mediaBlock, err := toMediaBlock(&result.Media[i])
+		if err != nil {
+			return anthropic.ContentBlockParamUnion{}, err
+		}
+		switch {
+		case mediaBlock.OfImage != nil:
+			content = append(content, anthropic.ToolResultBlockParamContentUnion{OfImage: mediaBlock.OfImage})
+		case mediaBlock.OfDocument != nil:
+			content = append(content, anthropic.ToolResultBlockParamContentUnion{OfDocument: mediaBlock.OfDocument})
+		case mediaBlock.OfText != nil:
+			content = append(content, anthropic.ToolResultBlockParamContentUnion{OfText: mediaBlock.OfText})
+		}
+	}
+
+	return anthropic.ContentBlockParamUnion{OfToolResult: &anthropic.ToolResultBlockParam{
+		ToolUseID: result.ID,
+		Content:   content,
+		IsError:   anthropic.Bool(result.IsError),
+	}}, nil
+}
+
 // FromMessageParams converts a slice of Anthropic MessageParams to llm.Messages.
 func FromMessageParams(msgs []anthropic.MessageParam) ([]llm.Message, error) {
 	result := make([]llm.Message, 0, len(msgs))
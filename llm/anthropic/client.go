@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 
 	anthropic "github.com/anthropics/anthropic-sdk-go"
 	"github.com/anthropics/anthropic-sdk-go/option"
@@ -18,12 +19,17 @@ type AnthropicClient struct {
 }
 
 // NewAnthropicClient creates a new AnthropicClient with the given API key.
-func NewAnthropicClient(apiKey string, logger zerolog.Logger) (*AnthropicClient, error) {
+// If httpClient is nil, the SDK's default HTTP client is used.
+func NewAnthropicClient(apiKey string, httpClient *http.Client, logger zerolog.Logger) (*AnthropicClient, error) {
 	if apiKey == "" {
 		return nil, fmt.Errorf("api key is required")
 	}
 
-	client := anthropic.NewClient(option.WithAPIKey(apiKey))
+	opts := []option.RequestOption{option.WithAPIKey(apiKey)}
+	if httpClient != nil {
+		opts = append(opts, option.WithHTTPClient(httpClient))
+	}
+	client := anthropic.NewClient(opts...)
 	return &AnthropicClient{
 		client: &client,
 		logger: logger,
@@ -56,6 +62,7 @@ func (c *AnthropicClient) Synchronous(ctx context.Context, req *llm.Request) (*l
 		System:    systemBlocks,
 		Tools:     tools,
 	}
+	applyThinking(&params, req.ThinkingBudget)
 
 	// Make API call
 	message, err := c.client.Messages.New(ctx, params)
@@ -72,6 +79,15 @@ func (c *AnthropicClient) Synchronous(ctx context.Context, req *llm.Request) (*l
 				Type: llm.ContentBlockTypeText,
 				Text: block.Text,
 			})
+		case anthropic.ThinkingBlock:
+			content = append(content, llm.NewReasoningContentBlock(llm.ReasoningBlock{
+				Text:      block.Thinking,
+				Signature: block.Signature,
+			}))
+		case anthropic.RedactedThinkingBlock:
+			content = append(content, llm.NewReasoningContentBlock(llm.ReasoningBlock{
+				RedactedData: block.Data,
+			}))
 		case anthropic.ToolUseBlock:
 			// Extract input as map[string]interface{}
 			var input map[string]interface{}
@@ -155,6 +171,7 @@ func (c *AnthropicClient) Stream(ctx context.Context, req *llm.Request) (llm.Str
 		System:    systemBlocks,
 		Tools:     tools,
 	}
+	applyThinking(&params, req.ThinkingBudget)
 
 	// Create streaming request
 	stream := c.client.Messages.NewStreaming(ctx, params)
@@ -163,6 +180,25 @@ func (c *AnthropicClient) Stream(ctx context.Context, req *llm.Request) (llm.Str
 	return newAnthropicStream(ctx, stream, c.logger), nil
 }
 
+// minThinkingBudget is the smallest thinking budget the Anthropic API accepts.
+const minThinkingBudget = 1024
+
+// applyThinking enables extended thinking when a budget is set. The API requires
+// max_tokens to exceed the budget, so max_tokens is raised to leave the configured
+// amount of room for the answer itself.
+func applyThinking(params *anthropic.MessageNewParams, budget int64) {
+	if budget <= 0 {
+		return
+	}
+	if budget < minThinkingBudget {
+		budget = minThinkingBudget
+	}
+	if params.MaxTokens <= budget {
+		params.MaxTokens += budget
+	}
+	params.Thinking = anthropic.ThinkingConfigParamOfEnabled(budget)
+}
+
 // buildSystemBlocks creates system text blocks with prompt caching enabled if appropriate.
 // According to Anthropic's prompt caching documentation, placing cache_control on the system block
 // caches the full prefix: tools, system, and messages (in that order) up to and including the
@@ -116,6 +116,7 @@ func (s *anthropicStream) startStream() {
 	var currentToolCall *llm.ToolUseBlock
 	var toolInputBuilder strings.Builder
 	var usage *llm.Usage
+	var stopReason string
 
 	// Process stream events
 	for s.stream.Next() {
@@ -135,6 +136,26 @@ func (s *anthropicStream) startStream() {
 				switch block := contentBlock.(type) {
 				case anthropic.TextBlock:
 					// Text block starting - no action needed yet
+				case anthropic.ThinkingBlock:
+					// Thinking block starting - text and signature arrive as deltas
+					s.events = append(s.events, &llm.StreamEvent{
+						Type: llm.StreamEventTypeContentBlock,
+						Delta: &llm.StreamDelta{
+							Type:      llm.StreamDeltaTypeReasoning,
+							Reasoning: &llm.ReasoningBlock{Text: block.Thinking, Signature: block.Signature},
+						},
+					})
+					s.cond.Broadcast() // Signal that a new event is available
+				case anthropic.RedactedThinkingBlock:
+					// Redacted thinking arrives whole in the start event
+					s.events = append(s.events, &llm.StreamEvent{
+						Type: llm.StreamEventTypeContentBlock,
+						Delta: &llm.StreamDelta{
+							Type:      llm.StreamDeltaTypeReasoning,
+							Reasoning: &llm.ReasoningBlock{RedactedData: block.Data},
+						},
+					})
+					s.cond.Broadcast() // Signal that a new event is available
 				case anthropic.ToolUseBlock:
 					// Tool use block starting
 					toolUseID := block.ID
@@ -178,6 +199,26 @@ func (s *anthropicStream) startStream() {
 						})
 						s.cond.Broadcast() // Signal that a new event is available
 					}
+				case anthropic.ThinkingDelta:
+					if d.Thinking != "" {
+						s.events = append(s.events, &llm.StreamEvent{
+							Type: llm.StreamEventTypeContentDelta,
+							Delta: &llm.StreamDelta{
+								Type:      llm.StreamDeltaTypeReasoning,
+								Reasoning: &llm.ReasoningBlock{Text: d.Thinking},
+							},
+						})
+						s.cond.Broadcast() // Signal that a new event is available
+					}
+				case anthropic.SignatureDelta:
+					s.events = append(s.events, &llm.StreamEvent{
+						Type: llm.StreamEventTypeContentDelta,
+						Delta: &llm.StreamDelta{
+							Type:      llm.StreamDeltaTypeReasoning,
+							Reasoning: &llm.ReasoningBlock{Signature: d.Signature},
+						},
+					})
+					s.cond.Broadcast() // Signal that a new event is available
 				case anthropic.InputJSONDelta:
 					// Tool input delta
 					if currentToolCall != nil && d.PartialJSON != "" {
@@ -214,7 +255,8 @@ func (s *anthropicStream) startStream() {
 			}
 
 		case anthropic.MessageDeltaEvent:
-			// Message delta - contains usage information
+			// Message delta - contains usage information and the stop reason
+			stopReason = string(evt.Delta.StopReason)
 			usage = &llm.Usage{
 				InputTokens:              evt.Usage.InputTokens,
 				OutputTokens:             evt.Usage.OutputTokens,
@@ -252,19 +294,21 @@ func (s *anthropicStream) startStream() {
 
 			// Emit message delta with usage
 			s.events = append(s.events, &llm.StreamEvent{
-				Type:  llm.StreamEventTypeMessageDelta,
-				Delta: nil,
-				Usage: usage,
-				Done:  false,
+				Type:       llm.StreamEventTypeMessageDelta,
+				Delta:      nil,
+				Usage:      usage,
+				Done:       false,
+				StopReason: stopReason,
 			})
 			s.cond.Broadcast() // Signal that a new event is available
 
 			// Emit stop event
 			s.events = append(s.events, &llm.StreamEvent{
-				Type:  llm.StreamEventTypeStop,
-				Delta: nil,
-				Usage: usage,
-				Done:  true,
+				Type:       llm.StreamEventTypeStop,
+				Delta:      nil,
+				Usage:      usage,
+				Done:       true,
+				StopReason: stopReason,
 			})
 
 			s.done = true
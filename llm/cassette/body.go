@@ -0,0 +1,50 @@
+package cassette
+
+import (
+	"bytes"
+	"errors"
+	"io"
+)
+
+// recordingBody passes a response body through to the provider client while keeping a
+// copy, and records it once the body has been read to the end. A body closed early is
+// drained first, since stream decoders often stop at the provider's end marker; bodies
+// that fail part-way (such as a cancelled stream) are not recorded.
+type recordingBody struct {
+	body     io.ReadCloser
+	buf      bytes.Buffer
+	done     func(data []byte) error
+	recorded bool
+}
+
+// Read implements io.Reader.
+func (b *recordingBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	b.buf.Write(p[:n])
+	if errors.Is(err, io.EOF) {
+		if recErr := b.finish(); recErr != nil {
+			return n, recErr
+		}
+	}
+	return n, err
+}
+
+// Close implements io.Closer.
+func (b *recordingBody) Close() error {
+	var recErr error
+	if !b.recorded {
+		if _, err := io.Copy(&b.buf, b.body); err == nil {
+			recErr = b.finish()
+		}
+	}
+	return errors.Join(recErr, b.body.Close())
+}
+
+// finish records the body once.
+func (b *recordingBody) finish() error {
+	if b.recorded {
+		return nil
+	}
+	b.recorded = true
+	return b.done(b.buf.Bytes())
+}
@@ -0,0 +1,268 @@
+// Package cassette records LLM provider HTTP traffic to files and replays it later without
+// a provider.
+//
+// Transport is an http.RoundTripper placed in each provider's HTTP client. In record mode
+// it forwards requests and writes each response to <dir>/<hash>.json, where hash identifies
+// the normalized request. In replay mode it answers requests from those files and never
+// touches the network, so the provider adapters encode requests and decode responses and
+// streams exactly as they do against the live API.
+package cassette
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Mode selects whether traffic is recorded or replayed.
+type Mode string
+
+const (
+	ModeRecord Mode = "record"
+	ModeReplay Mode = "replay"
+)
+
+// ErrNoRecording is returned in replay mode for requests that were never recorded.
+var ErrNoRecording = errors.New("no cassette recording for request")
+
+// Config configures a cassette transport.
+type Config struct {
+	Dir     string            // Directory holding cassette files
+	Mode    Mode              // ModeRecord or ModeReplay
+	Secrets []string          // Values to redact in addition to anything that looks like an API key
+	Next    http.RoundTripper // Transport used to reach providers in record mode (default: http.DefaultTransport)
+}
+
+// Recording is the content of a cassette file: a request and every response returned for it.
+// The same request may be sent more than once (for example when a client retries), so
+// responses are replayed in order.
+type Recording struct {
+	Request   RecordedRequest `json:"request"`
+	Responses []Interaction   `json:"responses"`
+}
+
+// RecordedRequest is the part of an HTTP request that identifies it. The host and
+// credentials are left out so recordings replay against any endpoint and API key.
+type RecordedRequest struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"` // URL path and query
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// Interaction is one recorded HTTP response. Streaming responses are stored whole, so
+// replay hands the provider's stream decoder the same bytes it read when recording.
+type Interaction struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body"`
+}
+
+// droppedHeaders are response headers that are not recorded.
+var droppedHeaders = []string{"Content-Length", "Date", "Set-Cookie"}
+
+// Transport records or replays provider HTTP traffic. It implements http.RoundTripper.
+type Transport struct {
+	cfg  Config
+	next http.RoundTripper
+
+	mu      sync.Mutex
+	started map[string]bool // Keys already written during this recording session
+	cursors map[string]int  // Next interaction to replay, by key
+}
+
+// New creates a cassette transport. In record mode the directory is created if needed;
+// in replay mode it must already exist.
+func New(cfg Config) (*Transport, error) {
+	if cfg.Dir == "" {
+		return nil, fmt.Errorf("cassette directory is required")
+	}
+	switch cfg.Mode {
+	case ModeRecord:
+		if err := os.MkdirAll(cfg.Dir, 0o750); err != nil {
+			return nil, fmt.Errorf("failed to create cassette directory: %w", err)
+		}
+	case ModeReplay:
+		if _, err := os.Stat(cfg.Dir); err != nil {
+			return nil, fmt.Errorf("cassette directory not found: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown cassette mode %q (want %q or %q)", cfg.Mode, ModeRecord, ModeReplay)
+	}
+
+	next := cfg.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Transport{
+		cfg:     cfg,
+		next:    next,
+		started: make(map[string]bool),
+		cursors: make(map[string]int),
+	}, nil
+}
+
+// Client returns an HTTP client that sends its requests through the transport.
+func (t *Transport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+	recorded, key, err := requestKey(req, body, t.cfg.Secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.cfg.Mode == ModeReplay {
+		interaction, err := t.replay(key, recorded)
+		if err != nil {
+			return nil, err
+		}
+		return interaction.response(req), nil
+	}
+
+	// Let the transport negotiate compression so recorded bodies are plain text
+	out := req.Clone(req.Context())
+	out.Header.Del("Accept-Encoding")
+	if req.Body != nil {
+		out.Body = io.NopCloser(strings.NewReader(string(body)))
+		out.ContentLength = int64(len(body))
+	}
+	resp, err := t.next.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = &recordingBody{
+		body: resp.Body,
+		done: func(data []byte) error {
+			return t.write(key, recorded, Interaction{
+				Status: resp.StatusCode,
+				Header: t.recordHeaders(resp.Header),
+				Body:   string(redact(data, t.cfg.Secrets)),
+			})
+		},
+	}
+	return resp, nil
+}
+
+// recordHeaders returns the response headers to store, with secrets redacted.
+func (t *Transport) recordHeaders(header http.Header) http.Header {
+	recorded := header.Clone()
+	for _, name := range droppedHeaders {
+		recorded.Del(name)
+	}
+	for name, values := range recorded {
+		for i := range values {
+			values[i] = string(redact([]byte(values[i]), t.cfg.Secrets))
+		}
+		recorded[name] = values
+	}
+	return recorded
+}
+
+// write appends an interaction to the cassette file for key. The first write for a key
+// in a recording session replaces any older recording, so re-recording starts clean.
+func (t *Transport) write(key string, request RecordedRequest, interaction Interaction) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	recording := &Recording{Request: request}
+	if t.started[key] {
+		existing, err := t.load(key)
+		if err != nil {
+			return err
+		}
+		recording = existing
+	}
+	t.started[key] = true
+	recording.Responses = append(recording.Responses, interaction)
+
+	data, err := json.MarshalIndent(recording, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal cassette: %w", err)
+	}
+	if err := os.WriteFile(t.path(key), append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// replay returns the next recorded interaction for key. Once a request's interactions
+// are used up the last one is repeated.
+func (t *Transport) replay(key string, request RecordedRequest) (Interaction, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	recording, err := t.load(key)
+	if errors.Is(err, os.ErrNotExist) {
+		return Interaction{}, fmt.Errorf("%w %s %s (%s)", ErrNoRecording, request.Method, request.Path, key)
+	}
+	if err != nil {
+		return Interaction{}, err
+	}
+	if len(recording.Responses) == 0 {
+		return Interaction{}, fmt.Errorf("%w %s %s (%s has no responses)", ErrNoRecording, request.Method, request.Path, key)
+	}
+
+	index := t.cursors[key]
+	if index >= len(recording.Responses) {
+		index = len(recording.Responses) - 1
+	}
+	t.cursors[key] = index + 1
+	return recording.Responses[index], nil
+}
+
+// load reads the cassette file for key. Must be called with t.mu held.
+func (t *Transport) load(key string) (*Recording, error) {
+	data, err := os.ReadFile(t.path(key)) //#nosec 304 -- cassette files live in a configured directory
+	if err != nil {
+		return nil, err
+	}
+	var recording Recording
+	if err := json.Unmarshal(data, &recording); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", key, err)
+	}
+	return &recording, nil
+}
+
+// path returns the cassette file path for key.
+func (t *Transport) path(key string) string {
+	return filepath.Join(t.cfg.Dir, key+".json")
+}
+
+// response rebuilds the recorded HTTP response for req.
+func (i Interaction) response(req *http.Request) *http.Response {
+	header := i.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", i.Status, http.StatusText(i.Status)),
+		StatusCode:    i.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(i.Body)),
+		ContentLength: int64(len(i.Body)),
+		Request:       req,
+	}
+}
+
+// Ensure Transport implements http.RoundTripper
+var _ http.RoundTripper = (*Transport)(nil)
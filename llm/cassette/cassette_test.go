@@ -0,0 +1,173 @@
+package cassette
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+const testKey = "sk-ant-REDACTED"
+
+// countingServer answers every request with status and body and counts requests.
+func countingServer(t *testing.T, status int, body func(n int32) string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Request-Id", "req_"+testKey)
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, body(n))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func newTransport(t *testing.T, dir string, mode Mode) *Transport {
+	t.Helper()
+	transport, err := New(Config{Dir: dir, Mode: mode, Secrets: []string{"super-secret-value"}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return transport
+}
+
+// post sends a JSON body to url through client and returns the status and response body.
+func post(t *testing.T, client *http.Client, url, body string) (int, string, error) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Api-Key", testKey)
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close() //nolint:errcheck // Test cleanup
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	return resp.StatusCode, string(data), nil
+}
+
+func TestCassette_RecordThenReplay(t *testing.T) {
+	dir := t.TempDir()
+	server, calls := countingServer(t, http.StatusOK, func(int32) string { return `{"text":"hello"}` })
+
+	recorder := newTransport(t, dir, ModeRecord)
+	status, body, err := post(t, recorder.Client(), server.URL+"/v1/messages", `{"model":"m","messages":[{"role":"user","content":"hi"}]}`)
+	if err != nil || status != http.StatusOK || body != `{"text":"hello"}` {
+		t.Fatalf("record: status %d body %q err %v", status, body, err)
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("expected 1 cassette file, got %d", len(files))
+	}
+
+	// Replay matches the same request on any host and with its JSON keys in any order
+	player := newTransport(t, dir, ModeReplay)
+	status, body, err = post(t, player.Client(), "https://api.example.com/v1/messages", `{"messages":[{"content":"hi","role":"user"}],"model":"m"}`)
+	if err != nil || status != http.StatusOK || body != `{"text":"hello"}` {
+		t.Fatalf("replay: status %d body %q err %v", status, body, err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected replay not to reach the server, got %d calls", calls.Load())
+	}
+
+	// Requests that were never recorded fail
+	_, _, err = post(t, player.Client(), server.URL+"/v1/messages", `{"model":"m","messages":[{"role":"user","content":"bye"}]}`)
+	if !errors.Is(err, ErrNoRecording) {
+		t.Errorf("expected ErrNoRecording, got %v", err)
+	}
+}
+
+func TestCassette_ReplaysRepeatedRequestsInOrder(t *testing.T) {
+	dir := t.TempDir()
+	server, _ := countingServer(t, http.StatusOK, func(n int32) string { return strings.Repeat("x", int(n)) })
+
+	recorder := newTransport(t, dir, ModeRecord)
+	for range 2 {
+		if _, _, err := post(t, recorder.Client(), server.URL+"/v1/messages", `{"q":1}`); err != nil {
+			t.Fatalf("record: %v", err)
+		}
+	}
+
+	player := newTransport(t, dir, ModeReplay)
+	for i, want := range []string{"x", "xx", "xx"} {
+		_, body, err := post(t, player.Client(), server.URL+"/v1/messages", `{"q":1}`)
+		if err != nil {
+			t.Fatalf("replay %d: %v", i, err)
+		}
+		if body != want {
+			t.Errorf("replay %d: got %q, want %q", i, body, want)
+		}
+	}
+}
+
+func TestCassette_RecordsErrorResponses(t *testing.T) {
+	dir := t.TempDir()
+	server, _ := countingServer(t, http.StatusTooManyRequests, func(int32) string { return `{"error":"rate limited"}` })
+
+	recorder := newTransport(t, dir, ModeRecord)
+	if status, _, err := post(t, recorder.Client(), server.URL+"/v1/messages", `{}`); err != nil || status != http.StatusTooManyRequests {
+		t.Fatalf("record: status %d err %v", status, err)
+	}
+
+	player := newTransport(t, dir, ModeReplay)
+	status, body, err := post(t, player.Client(), server.URL+"/v1/messages", `{}`)
+	if err != nil || status != http.StatusTooManyRequests || body != `{"error":"rate limited"}` {
+		t.Errorf("replay: status %d body %q err %v", status, body, err)
+	}
+}
+
+func TestCassette_RedactsSecrets(t *testing.T) {
+	dir := t.TempDir()
+	server, _ := countingServer(t, http.StatusOK, func(int32) string { return `{"echo":"super-secret-value"}` })
+
+	recorder := newTransport(t, dir, ModeRecord)
+	request := `{"content":"my key is ` + testKey + ` and super-secret-value"}`
+	if _, _, err := post(t, recorder.Client(), server.URL+"/v1/messages?key=AIzaSyA-abcdefghijklmnopqrstuvwxyz0123456", request); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("expected 1 cassette file, got %d", len(files))
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	for _, secret := range []string{testKey, "super-secret-value", "AIzaSy"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains secret %q:\n%s", secret, data)
+		}
+	}
+
+	// The redacted request still replays, whatever credentials are used
+	player := newTransport(t, dir, ModeReplay)
+	if _, _, err := post(t, player.Client(), "https://api.example.com/v1/messages?key=other", request); err != nil {
+		t.Errorf("replay: %v", err)
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	if _, err := New(Config{Mode: ModeReplay}); err == nil {
+		t.Error("expected error for missing directory")
+	}
+	if _, err := New(Config{Dir: t.TempDir(), Mode: "rewind"}); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+	if _, err := New(Config{Dir: filepath.Join(t.TempDir(), "missing"), Mode: ModeReplay}); err == nil {
+		t.Error("expected error for missing replay directory")
+	}
+}
@@ -0,0 +1,121 @@
+package cassette
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// redacted replaces secrets in recorded traffic.
+const redacted = "[REDACTED]"
+
+// secretPatterns match API keys that can end up in prompts or tool results.
+var secretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`sk-ant-[A-Za-z0-9_\-]{10,}`),        // Anthropic
+	regexp.MustCompile(`sk-[A-Za-z0-9_\-]{20,}`),            // OpenAI
+	regexp.MustCompile(`AIza[0-9A-Za-z_\-]{35}`),            // Google
+	regexp.MustCompile(`(?i)bearer\s+[A-Za-z0-9._\-]{20,}`), // Authorization headers
+}
+
+// credentialParams are query parameters that carry credentials rather than identify a request.
+var credentialParams = []string{"key", "api_key"}
+
+// mediaDigestMinLen is the length above which base64 payloads (images, PDFs) in a request
+// body are replaced by their digest, so large attachments don't bloat the cassette.
+const mediaDigestMinLen = 1024
+
+// base64Pattern matches strings that consist only of base64 characters.
+var base64Pattern = regexp.MustCompile(`^[A-Za-z0-9+/_\-]+=*$`)
+
+// requestKey returns the redacted, normalized form of a request and its hash. JSON bodies
+// are re-encoded with sorted keys so field order doesn't change the key. The hash is
+// computed after redaction so recording and replay agree even when secrets differ
+// between environments.
+func requestKey(req *http.Request, body []byte, secrets []string) (RecordedRequest, string, error) {
+	query := req.URL.Query()
+	for _, param := range credentialParams {
+		query.Del(param)
+	}
+	path := req.URL.Path
+	if encoded := query.Encode(); encoded != "" {
+		path += "?" + encoded
+	}
+
+	normalized, err := normalizeBody(body)
+	if err != nil {
+		return RecordedRequest{}, "", err
+	}
+	normalized = redact(normalized, secrets)
+
+	recorded := RecordedRequest{Method: req.Method, Path: path, Body: normalized}
+	sum := sha256.Sum256([]byte(recorded.Method + "\n" + recorded.Path + "\n" + string(normalized)))
+	return recorded, hex.EncodeToString(sum[:]), nil
+}
+
+// normalizeBody returns a request body as JSON. JSON bodies are decoded and re-encoded
+// with large media replaced by digests; any other body is stored as a JSON string.
+func normalizeBody(body []byte) (json.RawMessage, error) {
+	if len(body) == 0 {
+		return nil, nil
+	}
+	if !json.Valid(body) {
+		data, err := json.Marshal(string(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request body: %w", err)
+		}
+		return data, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("failed to decode request body: %w", err)
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(digestMedia(value)); err != nil {
+		return nil, fmt.Errorf("failed to encode request body: %w", err)
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// digestMedia replaces long base64 strings in a decoded JSON value with a sha256 digest.
+func digestMedia(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = digestMedia(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = digestMedia(item)
+		}
+	case string:
+		if len(v) >= mediaDigestMinLen && base64Pattern.MatchString(v) {
+			sum := sha256.Sum256([]byte(v))
+			return "sha256:" + hex.EncodeToString(sum[:])
+		}
+	}
+	return value
+}
+
+// redact replaces known secrets and anything that looks like an API key.
+func redact(data []byte, secrets []string) []byte {
+	text := string(data)
+	for _, secret := range secrets {
+		if secret != "" {
+			text = strings.ReplaceAll(text, secret, redacted)
+		}
+	}
+	for _, pattern := range secretPatterns {
+		text = pattern.ReplaceAllString(text, redacted)
+	}
+	return []byte(text)
+}
@@ -1,13 +1,13 @@
 // Package llm provides a provider-neutral abstraction layer for Large Language Model (LLM) APIs.
 //
 // This package defines common types, interfaces, and utilities that allow the codebase
-// to work with multiple LLM providers (Anthropic, OpenAI, Ollama, etc.) without being
+// to work with multiple LLM providers (Anthropic, OpenAI, Ollama, Gemini, etc.) without being
 // tightly coupled to any specific provider's SDK.
 //
 // # Core Concepts
 //
 //  1. Messages: The Message type represents a conversation message with role (user, assistant, system)
-//     and content blocks (text, tool use, tool results).
+//     and content blocks (text, tool use, tool results, media, reasoning).
 //
 //  2. Tools: The ToolSpec type represents a tool definition that can be provided to an LLM,
 //     and ToolUseBlock/ToolResultBlock represent tool invocations and their results.
@@ -0,0 +1,250 @@
+package gemini
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aschepis/backscratcher/staff/llm"
+	"github.com/google/uuid"
+)
+
+const (
+	roleUser  = "user"
+	roleModel = "model"
+)
+
+// ToGeminiContents converts llm.Messages to Gemini content turns.
+// Gemini function responses are matched to calls by name, so tool result IDs are
+// resolved against the tool uses earlier in the conversation.
+func ToGeminiContents(msgs []llm.Message) ([]Content, error) {
+	toolNames := make(map[string]string)
+	for _, msg := range msgs {
+		for _, block := range msg.Content {
+			if block.Type == llm.ContentBlockTypeToolUse && block.ToolUse != nil {
+				toolNames[block.ToolUse.ID] = block.ToolUse.Name
+			}
+		}
+	}
+
+	result := make([]Content, 0, len(msgs))
+	for _, msg := range msgs {
+		geminiContent, err := ToGeminiContent(msg, toolNames)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert message: %w", err)
+		}
+		// Gemini rejects turns without parts (e.g. a message holding only unsigned reasoning)
+		if len(geminiContent.Parts) == 0 {
+			continue
+		}
+		result = append(result, geminiContent)
+	}
+	return result, nil
+}
+
+// ToGeminiContent converts a single llm.Message to a Gemini content turn.
+// toolNames maps tool use IDs to function names for converting tool results.
+func ToGeminiContent(msg llm.Message, toolNames map[string]string) (Content, error) {
+	// Gemini only has user and model turns; system prompts go in systemInstruction
+	role := roleUser
+	if msg.Role == llm.RoleAssistant {
+		role = roleModel
+	}
+
+	parts := make([]Part, 0, len(msg.Content))
+	// Thought signatures arrive as reasoning blocks and must be sent back on the part that follows them
+	var pendingSignature string
+	appendPart := func(p Part) {
+		if pendingSignature != "" {
+			p.ThoughtSignature = pendingSignature
+			pendingSignature = ""
+		}
+		parts = append(parts, p)
+	}
+
+	for _, block := range msg.Content {
+		switch block.Type {
+		case llm.ContentBlockTypeText:
+			if block.Text != "" {
+				appendPart(Part{Text: block.Text})
+			}
+		case llm.ContentBlockTypeToolUse:
+			if block.ToolUse != nil {
+				appendPart(Part{FunctionCall: &FunctionCall{
+					Name: block.ToolUse.Name,
+					Args: block.ToolUse.Input,
+				}})
+			}
+		case llm.ContentBlockTypeToolResult:
+			if block.ToolResult != nil {
+				name, ok := toolNames[block.ToolResult.ID]
+				if !ok {
+					return Content{}, fmt.Errorf("no tool call found for tool result %s", block.ToolResult.ID)
+				}
+				appendPart(Part{FunctionResponse: &FunctionResponse{
+					Name:     name,
+					Response: toFunctionResponse(block.ToolResult),
+				}})
+				// Function responses cannot carry media, so it follows as separate parts
+				for i := range block.ToolResult.Media {
+					mediaPart, err := toMediaPart(&block.ToolResult.Media[i])
+					if err != nil {
+						return Content{}, err
+					}
+					appendPart(mediaPart)
+				}
+			}
+		case llm.ContentBlockTypeImage, llm.ContentBlockTypeDocument:
+			if block.Media != nil {
+				mediaPart, err := toMediaPart(block.Media)
+				if err != nil {
+					return Content{}, err
+				}
+				appendPart(mediaPart)
+			}
+		case llm.ContentBlockTypeReasoning:
+			// Thought summaries are not accepted as input; only the signature is replayed
+			if block.Reasoning != nil && block.Reasoning.Signature != "" {
+				pendingSignature = block.Reasoning.Signature
+			}
+		}
+	}
+
+	return Content{Role: role, Parts: parts}, nil
+}
+
+// toFunctionResponse builds the response object for a tool result.
+// Gemini requires a JSON object, so other values are wrapped under "result" or "error".
+func toFunctionResponse(result *llm.ToolResultBlock) map[string]interface{} {
+	var value interface{} = result.Content
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(result.Content), &parsed); err == nil {
+		value = parsed
+	}
+
+	if result.IsError {
+		return map[string]interface{}{"error": value}
+	}
+	if obj, ok := value.(map[string]interface{}); ok {
+		return obj
+	}
+	return map[string]interface{}{"result": value}
+}
+
+// toMediaPart converts an llm.MediaBlock to an inline data part.
+// Gemini accepts images and PDFs inline; other documents are replaced with a text note.
+func toMediaPart(media *llm.MediaBlock) (Part, error) {
+	if !llm.IsImageMediaType(media.MediaType) && media.MediaType != "application/pdf" {
+		return Part{Text: llm.UnsupportedMediaNote(media)}, nil
+	}
+	data, err := media.Base64()
+	if err != nil {
+		return Part{}, fmt.Errorf("failed to load %s: %w", media.DisplayName(), err)
+	}
+	return Part{InlineData: &Blob{MimeType: media.MediaType, Data: data}}, nil
+}
+
+// FromGeminiParts converts the parts of a model turn to llm.ContentBlocks.
+// A thought signature becomes its own reasoning block placed before the part it was
+// attached to, so ToGeminiContent can reattach it when the turn is replayed.
+func FromGeminiParts(parts []Part) []llm.ContentBlock {
+	content := make([]llm.ContentBlock, 0, len(parts))
+	for _, p := range parts {
+		if p.ThoughtSignature != "" {
+			content = append(content, llm.NewReasoningContentBlock(llm.ReasoningBlock{
+				Signature: p.ThoughtSignature,
+			}))
+		}
+		if p.Thought {
+			content = append(content, llm.NewReasoningContentBlock(llm.ReasoningBlock{
+				Text: p.Text,
+			}))
+			continue
+		}
+
+		switch {
+		case p.FunctionCall != nil:
+			content = append(content, llm.ContentBlock{
+				Type:    llm.ContentBlockTypeToolUse,
+				ToolUse: FromGeminiFunctionCall(p.FunctionCall),
+			})
+		case p.InlineData != nil:
+			content = append(content, llm.NewMediaBlock(llm.MediaBlock{
+				MediaType: p.InlineData.MimeType,
+				Data:      p.InlineData.Data,
+			}))
+		case p.Text != "":
+			content = append(content, llm.ContentBlock{
+				Type: llm.ContentBlockTypeText,
+				Text: p.Text,
+			})
+		}
+	}
+	return content
+}
+
+// ToGeminiTools converts llm.ToolSpecs to a Gemini tool with one function declaration per spec.
+func ToGeminiTools(specs []llm.ToolSpec) ([]Tool, error) {
+	declarations := make([]FunctionDeclaration, 0, len(specs))
+	for i := range specs {
+		declaration, err := ToGeminiFunctionDeclaration(&specs[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert tool %s: %w", specs[i].Name, err)
+		}
+		declarations = append(declarations, declaration)
+	}
+	return []Tool{{FunctionDeclarations: declarations}}, nil
+}
+
+// ToGeminiFunctionDeclaration converts a single llm.ToolSpec to a Gemini function declaration.
+func ToGeminiFunctionDeclaration(spec *llm.ToolSpec) (FunctionDeclaration, error) {
+	if spec.Name == "" {
+		return FunctionDeclaration{}, fmt.Errorf("tool name is required")
+	}
+
+	properties := make(map[string]interface{})
+	for k, v := range spec.Schema.Properties {
+		properties[k] = v
+	}
+
+	schemaType := spec.Schema.Type
+	if schemaType == "" {
+		schemaType = "object"
+	}
+	parameters := map[string]interface{}{
+		"type":       schemaType,
+		"properties": properties,
+	}
+	if len(spec.Schema.Required) > 0 {
+		parameters["required"] = spec.Schema.Required
+	}
+	for k, v := range spec.Schema.ExtraFields {
+		parameters[k] = v
+	}
+
+	return FunctionDeclaration{
+		Name:                 spec.Name,
+		Description:          spec.Description,
+		ParametersJSONSchema: parameters,
+	}, nil
+}
+
+// FromGeminiFunctionCall converts a Gemini function call to llm.ToolUseBlock.
+// Gemini usually omits call IDs, so a random one is generated. It must be unique within
+// the thread, since tool calls and results are stored and matched by ID.
+func FromGeminiFunctionCall(call *FunctionCall) *llm.ToolUseBlock {
+	id := call.ID
+	if id == "" {
+		id = "call_" + uuid.NewString()
+	}
+
+	input := call.Args
+	if input == nil {
+		input = make(map[string]interface{})
+	}
+
+	return &llm.ToolUseBlock{
+		ID:    id,
+		Name:  call.Name,
+		Input: input,
+	}
+}
@@ -0,0 +1,47 @@
+package gemini
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aschepis/backscratcher/staff/llm"
+)
+
+func TestFromGeminiParts_RepeatedCallsGetUniqueIDs(t *testing.T) {
+	// Two tool-loop turns in which Gemini calls the same function without call IDs
+	turn := func(path string) []Part {
+		return []Part{{FunctionCall: &FunctionCall{Name: "read_file", Args: map[string]interface{}{"path": path}}}}
+	}
+	first := FromGeminiParts(turn("a.txt"))
+	second := FromGeminiParts(turn("b.txt"))
+	if len(first) != 1 || len(second) != 1 || first[0].ToolUse == nil || second[0].ToolUse == nil {
+		t.Fatalf("expected one tool use per turn, got %+v and %+v", first, second)
+	}
+	firstID, secondID := first[0].ToolUse.ID, second[0].ToolUse.ID
+	if firstID == secondID {
+		t.Fatalf("expected unique IDs across turns, both were %q", firstID)
+	}
+	if !strings.HasPrefix(firstID, "call_") || !strings.HasPrefix(secondID, "call_") {
+		t.Errorf("unexpected ID format: %q, %q", firstID, secondID)
+	}
+
+	contents, err := ToGeminiContents([]llm.Message{
+		llm.NewTextMessage(llm.RoleUser, "Read both files"),
+		{Role: llm.RoleAssistant, Content: first},
+		llm.NewToolResultMessage([]llm.ToolResultBlock{{ID: firstID, Content: `{"text": "alpha"}`}}),
+		{Role: llm.RoleAssistant, Content: second},
+		llm.NewToolResultMessage([]llm.ToolResultBlock{{ID: secondID, Content: `{"text": "beta"}`}}),
+	})
+	if err != nil {
+		t.Fatalf("ToGeminiContents: %v", err)
+	}
+	if len(contents) != 5 {
+		t.Fatalf("expected 5 turns, got %d", len(contents))
+	}
+	for i, want := range map[int]string{2: "alpha", 4: "beta"} {
+		resp := contents[i].Parts[0].FunctionResponse
+		if resp == nil || resp.Name != "read_file" || resp.Response["text"] != want {
+			t.Errorf("turn %d: expected read_file response %q, got %+v", i, want, resp)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package gemini
+
+// Wire types for the Gemini generateContent REST API.
+// Only the fields used by this package are declared.
+
+// GenerateContentRequest is the body of a generateContent or streamGenerateContent call.
+type GenerateContentRequest struct {
+	Contents          []Content         `json:"contents"`
+	SystemInstruction *Content          `json:"systemInstruction,omitempty"`
+	Tools             []Tool            `json:"tools,omitempty"`
+	ToolConfig        *ToolConfig       `json:"toolConfig,omitempty"`
+	GenerationConfig  *GenerationConfig `json:"generationConfig,omitempty"`
+}
+
+// Content is a single turn in the conversation. Role is "user" or "model".
+type Content struct {
+	Role  string `json:"role,omitempty"`
+	Parts []Part `json:"parts"`
+}
+
+// Part is one piece of a content turn. Exactly one of Text, InlineData,
+// FunctionCall or FunctionResponse is set.
+type Part struct {
+	Text             string            `json:"text,omitempty"`
+	Thought          bool              `json:"thought,omitempty"`          // Text is a thought summary
+	ThoughtSignature string            `json:"thoughtSignature,omitempty"` // Opaque signature that must be sent back unchanged
+	InlineData       *Blob             `json:"inlineData,omitempty"`
+	FunctionCall     *FunctionCall     `json:"functionCall,omitempty"`
+	FunctionResponse *FunctionResponse `json:"functionResponse,omitempty"`
+}
+
+// Blob is inline binary data such as an image or a PDF.
+type Blob struct {
+	MimeType string `json:"mimeType"`
+	Data     string `json:"data"` // Base64-encoded
+}
+
+// FunctionCall is a tool invocation requested by the model.
+type FunctionCall struct {
+	ID   string                 `json:"id,omitempty"`
+	Name string                 `json:"name"`
+	Args map[string]interface{} `json:"args,omitempty"`
+}
+
+// FunctionResponse carries a tool result back to the model.
+type FunctionResponse struct {
+	ID       string                 `json:"id,omitempty"`
+	Name     string                 `json:"name"`
+	Response map[string]interface{} `json:"response"`
+}
+
+// Tool groups the function declarations offered to the model.
+type Tool struct {
+	FunctionDeclarations []FunctionDeclaration `json:"functionDeclarations"`
+}
+
+// FunctionDeclaration describes a single callable function.
+type FunctionDeclaration struct {
+	Name                 string                 `json:"name"`
+	Description          string                 `json:"description,omitempty"`
+	ParametersJSONSchema map[string]interface{} `json:"parametersJsonSchema,omitempty"` // Full JSON schema, unlike the OpenAPI subset accepted by "parameters"
+}
+
+// ToolConfig controls how the model chooses to call functions.
+type ToolConfig struct {
+	FunctionCallingConfig FunctionCallingConfig `json:"functionCallingConfig"`
+}
+
+// FunctionCallingConfig sets the function calling mode.
+type FunctionCallingConfig struct {
+	Mode string `json:"mode"` // "AUTO", "ANY" or "NONE"
+}
+
+// GenerationConfig holds sampling and output options.
+type GenerationConfig struct {
+	MaxOutputTokens int64           `json:"maxOutputTokens,omitempty"`
+	Temperature     *float64        `json:"temperature,omitempty"`
+	ThinkingConfig  *ThinkingConfig `json:"thinkingConfig,omitempty"`
+}
+
+// ThinkingConfig enables model thinking with a token budget.
+type ThinkingConfig struct {
+	ThinkingBudget  int64 `json:"thinkingBudget"`
+	IncludeThoughts bool  `json:"includeThoughts,omitempty"`
+}
+
+// GenerateContentResponse is the response body, or a single chunk of a streamed response.
+type GenerateContentResponse struct {
+	Candidates    []Candidate    `json:"candidates"`
+	UsageMetadata *UsageMetadata `json:"usageMetadata,omitempty"`
+}
+
+// Candidate is one generated response.
+type Candidate struct {
+	Content      Content `json:"content"`
+	FinishReason string  `json:"finishReason,omitempty"`
+}
+
+// UsageMetadata reports token counts for a request.
+type UsageMetadata struct {
+	PromptTokenCount        int64 `json:"promptTokenCount"`
+	CandidatesTokenCount    int64 `json:"candidatesTokenCount"`
+	ThoughtsTokenCount      int64 `json:"thoughtsTokenCount"`
+	CachedContentTokenCount int64 `json:"cachedContentTokenCount"`
+}
+
+// errorResponse is the body returned with a non-2xx status.
+type errorResponse struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+		Details []struct {
+			Type       string `json:"@type"`
+			RetryDelay string `json:"retryDelay,omitempty"`
+		} `json:"details,omitempty"`
+	} `json:"error"`
+}
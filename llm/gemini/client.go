@@ -0,0 +1,285 @@
+package gemini
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aschepis/backscratcher/staff/llm"
+)
+
+// DefaultBaseURL is the Gemini API endpoint used when no base URL is configured.
+const DefaultBaseURL = "https://generativelanguage.googleapis.com/v1beta"
+
+// defaultRetryAfter is used for rate limits that don't include a retry delay.
+const defaultRetryAfter = 60 * time.Second
+
+// GeminiClient implements the llm.Client interface for Google's Gemini API.
+type GeminiClient struct {
+	httpClient *http.Client
+	apiKey     string
+	baseURL    string
+	model      string // Default model to use if not specified in request
+}
+
+// NewGeminiClient creates a new GeminiClient.
+// If apiKey is empty, it will return an error.
+// If baseURL is empty, it will use the default Gemini API endpoint.
+// If model is empty, the model must be set on each request.
+// If httpClient is nil, a default HTTP client is used.
+func NewGeminiClient(apiKey, baseURL, model string, httpClient *http.Client) (*GeminiClient, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("api key is required")
+	}
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	if _, err := url.Parse(baseURL); err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+
+	return &GeminiClient{
+		httpClient: httpClient,
+		apiKey:     apiKey,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		model:      model,
+	}, nil
+}
+
+// Synchronous implements llm.Client.Synchronous.
+func (c *GeminiClient) Synchronous(ctx context.Context, req *llm.Request) (*llm.Response, error) {
+	model, body, err := c.buildRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	httpResp, err := c.post(ctx, model, "generateContent", "", body)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close() //nolint:errcheck // No remedy for body close errors
+
+	var genResp GenerateContentResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&genResp); err != nil {
+		return nil, fmt.Errorf("failed to decode gemini response: %w", err)
+	}
+
+	if len(genResp.Candidates) == 0 {
+		return nil, fmt.Errorf("no candidates in response")
+	}
+
+	candidate := genResp.Candidates[0]
+	content := FromGeminiParts(candidate.Content.Parts)
+
+	return &llm.Response{
+		Content:    content,
+		Usage:      convertUsage(genResp.UsageMetadata),
+		StopReason: stopReason(candidate.FinishReason, content),
+	}, nil
+}
+
+// Stream implements llm.Client.Stream.
+func (c *GeminiClient) Stream(ctx context.Context, req *llm.Request) (llm.Stream, error) {
+	model, body, err := c.buildRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// alt=sse returns one GenerateContentResponse per server-sent event
+	httpResp, err := c.post(ctx, model, "streamGenerateContent", "alt=sse", body)
+	if err != nil {
+		return nil, err
+	}
+
+	return newGeminiStream(httpResp.Body), nil
+}
+
+// buildRequest resolves the model and converts an llm.Request to a Gemini request body.
+func (c *GeminiClient) buildRequest(req *llm.Request) (string, *GenerateContentRequest, error) {
+	if req == nil {
+		return "", nil, fmt.Errorf("request is required")
+	}
+
+	// Determine model to use
+	model := req.Model
+	if model == "" {
+		model = c.model
+	}
+	if model == "" {
+		return "", nil, fmt.Errorf("model is required")
+	}
+
+	// Convert messages
+	contents, err := ToGeminiContents(req.Messages)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to convert messages: %w", err)
+	}
+
+	genReq := &GenerateContentRequest{
+		Contents: contents,
+	}
+
+	// Set system instruction if provided
+	if req.System != "" {
+		genReq.SystemInstruction = &Content{Parts: []Part{{Text: req.System}}}
+	}
+
+	// Set tools if provided
+	if len(req.Tools) > 0 {
+		tools, err := ToGeminiTools(req.Tools)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to convert tools: %w", err)
+		}
+		genReq.Tools = tools
+		genReq.ToolConfig = &ToolConfig{FunctionCallingConfig: FunctionCallingConfig{Mode: "AUTO"}}
+	}
+
+	// Set max tokens, temperature and thinking budget if provided
+	genConfig := &GenerationConfig{
+		MaxOutputTokens: req.MaxTokens,
+		Temperature:     req.Temperature,
+	}
+	if req.ThinkingBudget > 0 {
+		genConfig.ThinkingConfig = &ThinkingConfig{
+			ThinkingBudget:  req.ThinkingBudget,
+			IncludeThoughts: true,
+		}
+		// Thinking tokens count against maxOutputTokens, so leave room for the answer
+		if genConfig.MaxOutputTokens > 0 {
+			genConfig.MaxOutputTokens += req.ThinkingBudget
+		}
+	}
+	genReq.GenerationConfig = genConfig
+
+	return model, genReq, nil
+}
+
+// post sends a request to a model method and returns the response if the status is 2xx.
+func (c *GeminiClient) post(ctx context.Context, model, method, query string, body *GenerateContentRequest) (*http.Response, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal gemini request: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/models/%s:%s", c.baseURL, url.PathEscape(model), method)
+	if query != "" {
+		endpoint += "?" + query
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gemini request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("x-goog-api-key", c.apiKey)
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, llm.NewProviderError("Gemini API error", err)
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		defer httpResp.Body.Close() //nolint:errcheck // No remedy for body close errors
+		respBody, _ := io.ReadAll(httpResp.Body)
+		return nil, convertGeminiError(httpResp.StatusCode, respBody)
+	}
+
+	return httpResp, nil
+}
+
+// convertUsage converts Gemini usage metadata to llm.Usage.
+// Thinking tokens are billed as output, so they are included in OutputTokens.
+func convertUsage(meta *UsageMetadata) *llm.Usage {
+	if meta == nil {
+		return &llm.Usage{}
+	}
+	return &llm.Usage{
+		InputTokens:          meta.PromptTokenCount,
+		OutputTokens:         meta.CandidatesTokenCount + meta.ThoughtsTokenCount,
+		CacheReadInputTokens: meta.CachedContentTokenCount,
+	}
+}
+
+// stopReason maps a Gemini finish reason to the stop reasons used by the other providers.
+func stopReason(finishReason string, content []llm.ContentBlock) string {
+	for _, block := range content {
+		if block.Type == llm.ContentBlockTypeToolUse {
+			return "tool_calls"
+		}
+	}
+	switch finishReason {
+	case "MAX_TOKENS":
+		return "max_tokens"
+	default:
+		return "stop"
+	}
+}
+
+// convertGeminiError converts a Gemini API error response to llm.Error types.
+func convertGeminiError(statusCode int, body []byte) error {
+	var errResp errorResponse
+	message := strings.TrimSpace(string(body))
+	if err := json.Unmarshal(body, &errResp); err == nil && errResp.Error.Message != "" {
+		message = errResp.Error.Message
+	}
+	providerErr := fmt.Errorf("gemini API returned status %d: %s", statusCode, message)
+
+	// Map status codes to error types
+	switch statusCode {
+	case http.StatusTooManyRequests:
+		// Rate limit error; the retry delay is reported in a RetryInfo detail
+		retryAfter := defaultRetryAfter
+		for _, detail := range errResp.Error.Details {
+			if detail.RetryDelay == "" {
+				continue
+			}
+			if d, err := time.ParseDuration(detail.RetryDelay); err == nil {
+				retryAfter = d
+			}
+		}
+		return llm.NewRateLimitError(
+			fmt.Sprintf("Gemini rate limit: %s", message),
+			&retryAfter,
+			providerErr,
+		)
+	case http.StatusRequestEntityTooLarge:
+		return llm.NewRequestTooLargeError(
+			fmt.Sprintf("Gemini request too large: %s", message),
+			providerErr,
+		)
+	case http.StatusBadRequest:
+		return &llm.Error{
+			Type:        llm.ErrorTypeInvalidRequest,
+			Message:     fmt.Sprintf("Gemini invalid request: %s", message),
+			Retryable:   false,
+			StatusCode:  statusCode,
+			ProviderErr: providerErr,
+		}
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		// Server errors - potentially retryable
+		return &llm.Error{
+			Type:        llm.ErrorTypeProvider,
+			Message:     fmt.Sprintf("Gemini server error: %s", message),
+			Retryable:   true,
+			StatusCode:  statusCode,
+			ProviderErr: providerErr,
+		}
+	default:
+		return &llm.Error{
+			Type:        llm.ErrorTypeProvider,
+			Message:     fmt.Sprintf("Gemini API error: %s", message),
+			Retryable:   false,
+			StatusCode:  statusCode,
+			ProviderErr: providerErr,
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package gemini
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aschepis/backscratcher/staff/llm"
+)
+
+// newTestServer starts a stand-in for the Gemini API that checks the API key header
+// and passes each decoded request body to handler.
+func newTestServer(t *testing.T, handler func(w http.ResponseWriter, r *http.Request, body GenerateContentRequest)) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("x-goog-api-key"); got != "test-key" {
+			t.Errorf("api key header: got %q, want %q", got, "test-key")
+		}
+		var body GenerateContentRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		handler(w, r, body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGeminiClient_Synchronous(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request, body GenerateContentRequest) {
+		if r.URL.Path != "/models/gemini-2.5-flash:generateContent" {
+			t.Errorf("path: got %q", r.URL.Path)
+		}
+		if body.SystemInstruction == nil || body.SystemInstruction.Parts[0].Text != "be brief" {
+			t.Errorf("system instruction not set: %+v", body.SystemInstruction)
+		}
+		if len(body.Tools) != 1 || body.Tools[0].FunctionDeclarations[0].Name != "get_weather" {
+			t.Errorf("tools not converted: %+v", body.Tools)
+		}
+		if body.GenerationConfig == nil || body.GenerationConfig.MaxOutputTokens != 256 {
+			t.Errorf("generation config: %+v", body.GenerationConfig)
+		}
+
+		_, _ = fmt.Fprint(w, `{
+			"candidates": [{
+				"content": {"role": "model", "parts": [
+					{"text": "Checking the weather."},
+					{"functionCall": {"name": "get_weather", "args": {"city": "Paris"}}, "thoughtSignature": "sig-1"}
+				]},
+				"finishReason": "STOP"
+			}],
+			"usageMetadata": {"promptTokenCount": 12, "candidatesTokenCount": 5, "thoughtsTokenCount": 3}
+		}`)
+	})
+
+	client, err := NewGeminiClient("test-key", server.URL, "gemini-2.5-flash", nil)
+	if err != nil {
+		t.Fatalf("NewGeminiClient: %v", err)
+	}
+
+	resp, err := client.Synchronous(context.Background(), &llm.Request{
+		System:    "be brief",
+		MaxTokens: 256,
+		Messages:  []llm.Message{llm.NewTextMessage(llm.RoleUser, "Weather in Paris?")},
+		Tools: []llm.ToolSpec{{
+			Name:        "get_weather",
+			Description: "Get the weather",
+			Schema: llm.ToolSchema{
+				Type:       "object",
+				Properties: map[string]interface{}{"city": map[string]interface{}{"type": "string"}},
+				Required:   []string{"city"},
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Synchronous: %v", err)
+	}
+
+	if resp.StopReason != "tool_calls" {
+		t.Errorf("StopReason: got %q, want %q", resp.StopReason, "tool_calls")
+	}
+	if resp.Usage.InputTokens != 12 || resp.Usage.OutputTokens != 8 {
+		t.Errorf("Usage: got %+v", resp.Usage)
+	}
+	if len(resp.Content) != 3 {
+		t.Fatalf("expected 3 content blocks, got %d: %+v", len(resp.Content), resp.Content)
+	}
+	if resp.Content[0].Text != "Checking the weather." {
+		t.Errorf("text block: got %+v", resp.Content[0])
+	}
+	if resp.Content[1].Type != llm.ContentBlockTypeReasoning || resp.Content[1].Reasoning.Signature != "sig-1" {
+		t.Errorf("signature block: got %+v", resp.Content[1])
+	}
+	toolUse := resp.Content[2].ToolUse
+	if toolUse == nil || toolUse.Name != "get_weather" || toolUse.Input["city"] != "Paris" || toolUse.ID == "" {
+		t.Errorf("tool use block: got %+v", toolUse)
+	}
+}
+
+func TestGeminiClient_ToolLoopReplay(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request, body GenerateContentRequest) {
+		if len(body.Contents) != 3 {
+			t.Errorf("expected 3 contents, got %d", len(body.Contents))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		call := body.Contents[1]
+		if call.Role != roleModel || call.Parts[0].FunctionCall == nil || call.Parts[0].ThoughtSignature != "sig-1" {
+			t.Errorf("function call turn not replayed with signature: %+v", call)
+		}
+		result := body.Contents[2].Parts[0].FunctionResponse
+		if result == nil || result.Name != "get_weather" || result.Response["temp"] != float64(21) {
+			t.Errorf("function response: got %+v", result)
+		}
+		_, _ = fmt.Fprint(w, `{"candidates": [{"content": {"parts": [{"text": "21C"}]}, "finishReason": "STOP"}]}`)
+	})
+
+	client, err := NewGeminiClient("test-key", server.URL, "gemini-2.5-flash", nil)
+	if err != nil {
+		t.Fatalf("NewGeminiClient: %v", err)
+	}
+
+	toolUse := llm.ToolUseBlock{ID: "call_get_weather_0", Name: "get_weather", Input: map[string]interface{}{"city": "Paris"}}
+	resp, err := client.Synchronous(context.Background(), &llm.Request{
+		Messages: []llm.Message{
+			llm.NewTextMessage(llm.RoleUser, "Weather in Paris?"),
+			{Role: llm.RoleAssistant, Content: []llm.ContentBlock{
+				llm.NewReasoningContentBlock(llm.ReasoningBlock{Signature: "sig-1"}),
+				{Type: llm.ContentBlockTypeToolUse, ToolUse: &toolUse},
+			}},
+			llm.NewToolResultMessage([]llm.ToolResultBlock{{ID: toolUse.ID, Content: `{"temp": 21}`}}),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Synchronous: %v", err)
+	}
+	if resp.StopReason != "stop" || resp.Content[0].Text != "21C" {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+func TestGeminiClient_Stream(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request, body GenerateContentRequest) {
+		if r.URL.Path != "/models/gemini-2.5-flash:streamGenerateContent" || r.URL.Query().Get("alt") != "sse" {
+			t.Errorf("unexpected URL: %s", r.URL)
+		}
+		if tc := body.GenerationConfig.ThinkingConfig; tc == nil || tc.ThinkingBudget != 1024 || !tc.IncludeThoughts {
+			t.Errorf("thinking config: got %+v", tc)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		chunks := []string{
+			`{"candidates": [{"content": {"parts": [{"text": "Let me think.", "thought": true}]}}]}`,
+			`{"candidates": [{"content": {"parts": [{"text": "Hello"}]}}]}`,
+			`{"candidates": [{"content": {"parts": [{"text": ", world"}]}, "finishReason": "STOP"}], "usageMetadata": {"promptTokenCount": 4, "candidatesTokenCount": 2}}`,
+		}
+		for _, chunk := range chunks {
+			_, _ = fmt.Fprintf(w, "data: %s\r\n\r\n", chunk)
+		}
+	})
+
+	client, err := NewGeminiClient("test-key", server.URL, "gemini-2.5-flash", nil)
+	if err != nil {
+		t.Fatalf("NewGeminiClient: %v", err)
+	}
+
+	stream, err := client.Stream(context.Background(), &llm.Request{
+		Messages:       []llm.Message{llm.NewTextMessage(llm.RoleUser, "Hi")},
+		ThinkingBudget: 1024,
+	})
+	if err != nil {
+		t.Fatalf("Stream: %v", err)
+	}
+	defer stream.Close() //nolint:errcheck // Test cleanup
+
+	var text, reasoning strings.Builder
+	var final *llm.StreamEvent
+	for stream.Next() {
+		event := stream.Event()
+		if event.Type == llm.StreamEventTypeStop {
+			final = event
+		}
+		if event.Delta == nil {
+			continue
+		}
+		switch event.Delta.Type {
+		case llm.StreamDeltaTypeText:
+			text.WriteString(event.Delta.Text)
+		case llm.StreamDeltaTypeReasoning:
+			reasoning.WriteString(event.Delta.Reasoning.Text)
+		}
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("stream error: %v", err)
+	}
+
+	if text.String() != "Hello, world" {
+		t.Errorf("text: got %q", text.String())
+	}
+	if reasoning.String() != "Let me think." {
+		t.Errorf("reasoning: got %q", reasoning.String())
+	}
+	if final == nil || !final.Done || final.Usage == nil || final.Usage.InputTokens != 4 {
+		t.Errorf("stop event: got %+v", final)
+	}
+}
+
+func TestGeminiClient_RateLimitError(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request, body GenerateContentRequest) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = fmt.Fprint(w, `{"error": {"code": 429, "message": "quota exceeded", "status": "RESOURCE_EXHAUSTED",
+			"details": [{"@type": "type.googleapis.com/google.rpc.RetryInfo", "retryDelay": "7s"}]}}`)
+	})
+
+	client, err := NewGeminiClient("test-key", server.URL, "gemini-2.5-flash", nil)
+	if err != nil {
+		t.Fatalf("NewGeminiClient: %v", err)
+	}
+
+	_, err = client.Synchronous(context.Background(), &llm.Request{
+		Messages: []llm.Message{llm.NewTextMessage(llm.RoleUser, "Hi")},
+	})
+	if !llm.IsRateLimitError(err) {
+		t.Fatalf("expected rate limit error, got %v", err)
+	}
+	if retryAfter := llm.ExtractRetryAfter(err); retryAfter == nil || *retryAfter != 7*time.Second {
+		t.Errorf("retry after: got %v, want 7s", retryAfter)
+	}
+}
+
+func TestToGeminiContents_UnknownToolResult(t *testing.T) {
+	_, err := ToGeminiContents([]llm.Message{
+		llm.NewToolResultMessage([]llm.ToolResultBlock{{ID: "missing", Content: "{}"}}),
+	})
+	if err == nil {
+		t.Error("expected error for tool result without a matching call")
+	}
+}
@@ -0,0 +1,260 @@
+package gemini
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/aschepis/backscratcher/staff/llm"
+)
+
+// maxEventSize bounds a single server-sent event; chunks carrying inline data can be large.
+const maxEventSize = 16 * 1024 * 1024
+
+// geminiStream implements the llm.Stream interface for Gemini server-sent event responses.
+type geminiStream struct {
+	body    io.ReadCloser
+	events  []*llm.StreamEvent
+	current int
+	mu      sync.Mutex
+	cond    *sync.Cond // Condition variable to wait for events
+	err     error
+	done    bool
+	started bool
+}
+
+// newGeminiStream creates a new geminiStream reading from an SSE response body.
+// The body belongs to a request bound to the caller's context, so cancellation ends the read.
+func newGeminiStream(body io.ReadCloser) *geminiStream {
+	stream := &geminiStream{
+		body:    body,
+		events:  make([]*llm.StreamEvent, 0),
+		current: -1,
+	}
+	stream.cond = sync.NewCond(&stream.mu)
+	return stream
+}
+
+// Next advances to the next event in the stream.
+func (s *geminiStream) Next() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// If we haven't started, read the stream in a goroutine
+	if !s.started {
+		s.started = true
+		go s.readStream()
+	}
+
+	// Move to next event
+	s.current++
+
+	// Wait for events to be available if we've consumed all current events
+	// and the stream isn't done yet
+	for s.current >= len(s.events) && !s.done && s.err == nil {
+		s.cond.Wait()
+	}
+
+	if s.err != nil {
+		return false
+	}
+	return s.current < len(s.events)
+}
+
+// Event returns the current event.
+func (s *geminiStream) Event() *llm.StreamEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.current < 0 || s.current >= len(s.events) {
+		return nil
+	}
+	return s.events[s.current]
+}
+
+// Err returns any error that occurred during streaming.
+func (s *geminiStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Close closes the stream and releases resources.
+func (s *geminiStream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.done = true
+	s.cond.Broadcast()
+	return s.body.Close()
+}
+
+// emit appends events and wakes any waiting reader. Must be called with s.mu held.
+func (s *geminiStream) emit(events ...*llm.StreamEvent) {
+	s.events = append(s.events, events...)
+	s.cond.Broadcast() // Signal that a new event is available
+}
+
+// readStream reads server-sent events from the response body and converts each
+// chunk into stream events.
+func (s *geminiStream) readStream() {
+	defer s.body.Close() //nolint:errcheck // No remedy for body close errors
+
+	s.mu.Lock()
+	s.emit(&llm.StreamEvent{
+		Type:  llm.StreamEventTypeStart,
+		Delta: nil,
+		Usage: nil,
+		Done:  false,
+	})
+	s.mu.Unlock()
+
+	textStarted := false
+	reasoningStarted := false
+	toolCalls := 0
+	var usage *llm.Usage
+	var finishReason string
+
+	scanner := bufio.NewScanner(s.body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEventSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if !bytes.HasPrefix(line, []byte("data:")) {
+			// Blank separators and SSE comments carry no data
+			continue
+		}
+
+		var chunk GenerateContentResponse
+		if err := json.Unmarshal(bytes.TrimSpace(line[len("data:"):]), &chunk); err != nil {
+			s.fail(fmt.Errorf("failed to decode gemini stream chunk: %w", err))
+			return
+		}
+
+		if chunk.UsageMetadata != nil {
+			usage = convertUsage(chunk.UsageMetadata)
+		}
+		if len(chunk.Candidates) == 0 {
+			continue
+		}
+		if chunk.Candidates[0].FinishReason != "" {
+			finishReason = chunk.Candidates[0].FinishReason
+		}
+
+		s.mu.Lock()
+		if s.done {
+			// Closed by the consumer
+			s.mu.Unlock()
+			return
+		}
+		for _, p := range chunk.Candidates[0].Content.Parts {
+			// Signatures start their own reasoning block so they are replayed ahead of the part they belong to
+			if p.ThoughtSignature != "" {
+				s.emit(&llm.StreamEvent{
+					Type: llm.StreamEventTypeContentBlock,
+					Delta: &llm.StreamDelta{
+						Type:      llm.StreamDeltaTypeReasoning,
+						Reasoning: &llm.ReasoningBlock{Signature: p.ThoughtSignature},
+					},
+				})
+				reasoningStarted = true
+			}
+
+			switch {
+			case p.Thought:
+				// Thought summaries stream like text; the first one starts the reasoning block
+				eventType := llm.StreamEventTypeContentDelta
+				if !reasoningStarted {
+					eventType = llm.StreamEventTypeContentBlock
+					reasoningStarted = true
+				}
+				s.emit(&llm.StreamEvent{
+					Type: eventType,
+					Delta: &llm.StreamDelta{
+						Type:      llm.StreamDeltaTypeReasoning,
+						Reasoning: &llm.ReasoningBlock{Text: p.Text},
+					},
+				})
+
+			case p.FunctionCall != nil:
+				// Function calls arrive complete, so the whole input is sent as one delta
+				toolUse := FromGeminiFunctionCall(p.FunctionCall)
+				toolCalls++
+				argsJSON, err := json.Marshal(toolUse.Input)
+				if err != nil {
+					argsJSON = []byte("{}")
+				}
+				s.emit(&llm.StreamEvent{
+					Type: llm.StreamEventTypeContentBlock,
+					Delta: &llm.StreamDelta{
+						Type:    llm.StreamDeltaTypeToolUse,
+						ToolUse: toolUse,
+					},
+				}, &llm.StreamEvent{
+					Type: llm.StreamEventTypeContentDelta,
+					Delta: &llm.StreamDelta{
+						Type:      llm.StreamDeltaTypeToolInput,
+						ToolInput: string(argsJSON),
+					},
+				})
+
+			case p.Text != "":
+				// Emit first content block, then deltas
+				eventType := llm.StreamEventTypeContentDelta
+				if !textStarted {
+					eventType = llm.StreamEventTypeContentBlock
+					textStarted = true
+				}
+				s.emit(&llm.StreamEvent{
+					Type: eventType,
+					Delta: &llm.StreamDelta{
+						Type: llm.StreamDeltaTypeText,
+						Text: p.Text,
+					},
+				})
+			}
+		}
+		s.mu.Unlock()
+	}
+
+	if err := scanner.Err(); err != nil {
+		s.fail(fmt.Errorf("failed to read gemini stream: %w", err))
+		return
+	}
+
+	reason := stopReason(finishReason, nil)
+	if toolCalls > 0 {
+		reason = "tool_calls"
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.emit(&llm.StreamEvent{
+		Type:       llm.StreamEventTypeMessageDelta,
+		Delta:      nil,
+		Usage:      usage,
+		Done:       false,
+		StopReason: reason,
+	}, &llm.StreamEvent{
+		Type:       llm.StreamEventTypeStop,
+		Delta:      nil,
+		Usage:      usage,
+		Done:       true,
+		StopReason: reason,
+	})
+	s.done = true
+}
+
+// fail records a stream error and wakes any waiting reader.
+func (s *geminiStream) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.done {
+		// Errors after Close are expected (the body was closed under us)
+		return
+	}
+	s.err = err
+	s.done = true
+	s.cond.Broadcast() // Signal that stream has an error
+}
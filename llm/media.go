@@ -0,0 +1,140 @@
+package llm
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MaxMediaBytes is the largest image or document that will be attached to a request.
+// Providers reject larger payloads, so we fail early with a clear error instead.
+const MaxMediaBytes = 20 * 1024 * 1024
+
+// MediaBlock holds binary content such as an image or a PDF.
+// The content is either inline (base64 in Data) or a reference to a local file (Path)
+// that is read when the request is sent to the provider.
+type MediaBlock struct {
+	MediaType string // MIME type, e.g. "image/png" or "application/pdf"
+	Data      string // Base64-encoded content
+	Path      string // Local file reference, used when Data is empty
+	Name      string // Optional display name (usually the file name)
+}
+
+// mediaTypesByExt maps file extensions to the media types supported as content blocks.
+var mediaTypesByExt = map[string]string{
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+	".pdf":  "application/pdf",
+}
+
+// MediaTypeForPath returns the media type for a file based on its extension,
+// or an empty string if the file type cannot be sent as a media block.
+func MediaTypeForPath(path string) string {
+	return mediaTypesByExt[strings.ToLower(filepath.Ext(path))]
+}
+
+// IsImageMediaType reports whether mediaType is an image type.
+func IsImageMediaType(mediaType string) bool {
+	return strings.HasPrefix(mediaType, "image/")
+}
+
+// BlockType returns the content block type for this media (image or document).
+func (m *MediaBlock) BlockType() ContentBlockType {
+	if IsImageMediaType(m.MediaType) {
+		return ContentBlockTypeImage
+	}
+	return ContentBlockTypeDocument
+}
+
+// Base64 returns the base64-encoded content, reading the referenced file if needed.
+func (m *MediaBlock) Base64() (string, error) {
+	if m.Data != "" {
+		return m.Data, nil
+	}
+	raw, err := m.readFile()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(raw), nil
+}
+
+// Bytes returns the raw content, decoding inline data or reading the referenced file.
+func (m *MediaBlock) Bytes() ([]byte, error) {
+	if m.Data != "" {
+		raw, err := base64.StdEncoding.DecodeString(m.Data)
+		if err != nil {
+			return nil, fmt.Errorf("decode media data: %w", err)
+		}
+		return raw, nil
+	}
+	return m.readFile()
+}
+
+func (m *MediaBlock) readFile() ([]byte, error) {
+	if m.Path == "" {
+		return nil, fmt.Errorf("media block has neither data nor path")
+	}
+	info, err := os.Stat(m.Path)
+	if err != nil {
+		return nil, fmt.Errorf("stat media file: %w", err)
+	}
+	if info.Size() > MaxMediaBytes {
+		return nil, fmt.Errorf("media file %s is %d bytes, exceeds limit of %d", m.Path, info.Size(), MaxMediaBytes)
+	}
+	raw, err := os.ReadFile(m.Path)
+	if err != nil {
+		return nil, fmt.Errorf("read media file: %w", err)
+	}
+	return raw, nil
+}
+
+// DisplayName returns a short human-readable name for the media.
+func (m *MediaBlock) DisplayName() string {
+	if m.Name != "" {
+		return m.Name
+	}
+	if m.Path != "" {
+		return filepath.Base(m.Path)
+	}
+	return m.MediaType
+}
+
+// NewMediaBlock creates an image or document content block for the given media.
+func NewMediaBlock(media MediaBlock) ContentBlock {
+	return ContentBlock{
+		Type:  media.BlockType(),
+		Media: &media,
+	}
+}
+
+// NewMediaBlockFromFile creates a content block that references a local image or PDF.
+// The file is read lazily when the request is sent.
+func NewMediaBlockFromFile(path string) (ContentBlock, error) {
+	mediaType := MediaTypeForPath(path)
+	if mediaType == "" {
+		return ContentBlock{}, fmt.Errorf("unsupported media file type: %s", filepath.Ext(path))
+	}
+	return NewMediaBlock(MediaBlock{
+		MediaType: mediaType,
+		Path:      path,
+		Name:      filepath.Base(path),
+	}), nil
+}
+
+// MediaToolResult is implemented by tool results that carry images or documents for the model.
+// The tool loop attaches the media to the tool_result block and serializes the rest of the
+// value as the textual result.
+type MediaToolResult interface {
+	ToolResultMedia() []MediaBlock
+}
+
+// UnsupportedMediaNote returns placeholder text for media a provider cannot accept,
+// so the model still knows something was attached.
+func UnsupportedMediaNote(m *MediaBlock) string {
+	return fmt.Sprintf("[attachment %q (%s) omitted: not supported by this provider]", m.DisplayName(), m.MediaType)
+}
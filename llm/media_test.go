@@ -0,0 +1,68 @@
+package llm
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMediaTypeForPath(t *testing.T) {
+	tests := map[string]string{
+		"scan.PNG":    "image/png",
+		"photo.jpeg":  "image/jpeg",
+		"receipt.pdf": "application/pdf",
+		"notes.txt":   "",
+		"noext":       "",
+	}
+	for path, want := range tests {
+		if got := MediaTypeForPath(path); got != want {
+			t.Errorf("MediaTypeForPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestNewMediaBlockFromFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "screenshot.png")
+	if err := os.WriteFile(path, []byte("fake png"), 0o600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	block, err := NewMediaBlockFromFile(path)
+	if err != nil {
+		t.Fatalf("NewMediaBlockFromFile failed: %v", err)
+	}
+	if block.Type != ContentBlockTypeImage {
+		t.Errorf("Expected image block type, got %v", block.Type)
+	}
+	if block.Media.Data != "" {
+		t.Errorf("Expected file reference, got inline data")
+	}
+
+	data, err := block.Media.Base64()
+	if err != nil {
+		t.Fatalf("Base64 failed: %v", err)
+	}
+	if data != base64.StdEncoding.EncodeToString([]byte("fake png")) {
+		t.Errorf("Unexpected base64 data %q", data)
+	}
+
+	if _, err := NewMediaBlockFromFile(filepath.Join(dir, "notes.txt")); err == nil {
+		t.Errorf("Expected error for unsupported file type")
+	}
+}
+
+func TestMediaBlockDocumentType(t *testing.T) {
+	block := NewMediaBlock(MediaBlock{MediaType: "application/pdf", Data: "JVBERi0="})
+	if block.Type != ContentBlockTypeDocument {
+		t.Errorf("Expected document block type, got %v", block.Type)
+	}
+	raw, err := block.Media.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes failed: %v", err)
+	}
+	if string(raw) != "%PDF-" {
+		t.Errorf("Unexpected decoded bytes %q", raw)
+	}
+}
@@ -0,0 +1,171 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aschepis/backscratcher/staff/llm"
+)
+
+// MockClient implements the llm.Client interface by replaying turns from a Script.
+// A client serves a single agent and advances through that agent's turns one call at a time.
+type MockClient struct {
+	script  *Script
+	agentID string
+	mu      sync.Mutex
+	next    int // Index of the next turn to replay
+	served  int // Turns replayed so far, across loops
+}
+
+// NewMockClient creates a new MockClient replaying the script's turns for agentID.
+// A script with no turns for the agent and no default turns still yields a client, so one
+// unscripted agent does not stop the rest of the crew; calls to that client fail.
+func NewMockClient(script *Script, agentID string) (*MockClient, error) {
+	if script == nil {
+		return nil, fmt.Errorf("mock script is required")
+	}
+
+	return &MockClient{
+		script:  script,
+		agentID: agentID,
+	}, nil
+}
+
+// Synchronous implements llm.Client.Synchronous.
+func (c *MockClient) Synchronous(ctx context.Context, req *llm.Request) (*llm.Response, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is required")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	turn, seq, err := c.nextTurn()
+	if err != nil {
+		return nil, err
+	}
+
+	content := turnContent(turn, seq)
+	return &llm.Response{
+		Content:    content,
+		Usage:      turnUsage(req, turn),
+		StopReason: stopReason(turn),
+	}, nil
+}
+
+// Stream implements llm.Client.Stream.
+func (c *MockClient) Stream(ctx context.Context, req *llm.Request) (llm.Stream, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is required")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	turn, seq, err := c.nextTurn()
+	if err != nil {
+		return nil, err
+	}
+
+	return newMockStream(ctx, turnEvents(turn, seq, turnUsage(req, turn)), c.script.ChunkDelay), nil
+}
+
+// nextTurn returns the next turn for the client's agent and its 1-based sequence number
+// among the turns this client has replayed, which keeps generated tool call IDs unique
+// when a looping script repeats a turn. Turns with an Error set are consumed and returned
+// as errors.
+func (c *MockClient) nextTurn() (Turn, int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	turns := c.script.turnsFor(c.agentID)
+	if len(turns) == 0 {
+		return Turn{}, 0, fmt.Errorf("mock script has no turns for agent %s", c.agentID)
+	}
+	if c.next >= len(turns) {
+		if !c.script.Loop {
+			return Turn{}, 0, fmt.Errorf("mock script for agent %s has no turn %d (script has %d)", c.agentID, c.next+1, len(turns))
+		}
+		c.next = 0
+	}
+
+	turn := turns[c.next]
+	c.next++
+	c.served++
+	if turn.Error != "" {
+		return Turn{}, c.served, llm.NewProviderError(fmt.Sprintf("mock script error: %s", turn.Error), nil)
+	}
+	return turn, c.served, nil
+}
+
+// turnContent converts a turn to response content blocks.
+func turnContent(turn Turn, seq int) []llm.ContentBlock {
+	content := make([]llm.ContentBlock, 0, len(turn.ToolCalls)+2)
+	if turn.Reasoning != "" {
+		content = append(content, llm.NewReasoningContentBlock(llm.ReasoningBlock{Text: turn.Reasoning}))
+	}
+	if turn.Text != "" {
+		content = append(content, llm.ContentBlock{
+			Type: llm.ContentBlockTypeText,
+			Text: turn.Text,
+		})
+	}
+	for i := range turn.ToolCalls {
+		content = append(content, llm.ContentBlock{
+			Type:    llm.ContentBlockTypeToolUse,
+			ToolUse: toolUseBlock(turn.ToolCalls[i], seq, i),
+		})
+	}
+	return content
+}
+
+// toolUseBlock converts a scripted tool call to llm.ToolUseBlock, generating an ID if needed.
+func toolUseBlock(call ToolCall, seq, callIndex int) *llm.ToolUseBlock {
+	id := call.ID
+	if id == "" {
+		id = fmt.Sprintf("mock_%s_%d_%d", call.Name, seq, callIndex+1)
+	}
+
+	input := make(map[string]interface{}, len(call.Input))
+	for k, v := range call.Input {
+		input[k] = v
+	}
+
+	return &llm.ToolUseBlock{
+		ID:    id,
+		Name:  call.Name,
+		Input: input,
+	}
+}
+
+// stopReason returns the turn's stop reason, defaulting to the one implied by its content.
+func stopReason(turn Turn) string {
+	if turn.StopReason != "" {
+		return turn.StopReason
+	}
+	if len(turn.ToolCalls) > 0 {
+		return "tool_calls"
+	}
+	return "stop"
+}
+
+// turnUsage estimates token usage at roughly four characters per token,
+// so usage stats and context compression behave plausibly offline.
+func turnUsage(req *llm.Request, turn Turn) *llm.Usage {
+	input := len(req.System)
+	for _, msg := range req.Messages {
+		for _, block := range msg.Content {
+			input += len(block.Text)
+			if block.ToolResult != nil {
+				input += len(block.ToolResult.Content)
+			}
+		}
+	}
+	output := len(turn.Text) + len(turn.Reasoning)
+
+	return &llm.Usage{
+		InputTokens:  int64((input + 3) / 4),
+		OutputTokens: int64((output + 3) / 4),
+	}
+}
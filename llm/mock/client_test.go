@@ -0,0 +1,236 @@
+package mock
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aschepis/backscratcher/staff/llm"
+)
+
+const testScript = `
+agents:
+  researcher:
+    - reasoning: "I should search."
+      text: "Let me check."
+      tool_calls:
+        - name: memory_search
+          input: {query: "deadlines"}
+    - text: "The deadline is Friday."
+  default:
+    - text: "Hello from the mock provider."
+`
+
+func mustParse(t *testing.T, yamlText string) *Script {
+	t.Helper()
+	script, err := ParseScript([]byte(yamlText))
+	if err != nil {
+		t.Fatalf("ParseScript: %v", err)
+	}
+	return script
+}
+
+func userRequest(text string) *llm.Request {
+	return &llm.Request{Messages: []llm.Message{llm.NewTextMessage(llm.RoleUser, text)}}
+}
+
+func TestMockClient_SynchronousReplaysTurns(t *testing.T) {
+	client, err := NewMockClient(mustParse(t, testScript), "researcher")
+	if err != nil {
+		t.Fatalf("NewMockClient: %v", err)
+	}
+	ctx := context.Background()
+
+	resp, err := client.Synchronous(ctx, userRequest("When is the deadline?"))
+	if err != nil {
+		t.Fatalf("turn 1: %v", err)
+	}
+	if resp.StopReason != "tool_calls" {
+		t.Errorf("turn 1 StopReason: got %q, want %q", resp.StopReason, "tool_calls")
+	}
+	if len(resp.Content) != 3 {
+		t.Fatalf("turn 1: expected 3 content blocks, got %d", len(resp.Content))
+	}
+	if resp.Content[0].Reasoning == nil || resp.Content[0].Reasoning.Text != "I should search." {
+		t.Errorf("turn 1 reasoning: got %+v", resp.Content[0])
+	}
+	toolUse := resp.Content[2].ToolUse
+	if toolUse == nil || toolUse.Name != "memory_search" || toolUse.Input["query"] != "deadlines" || toolUse.ID == "" {
+		t.Errorf("turn 1 tool use: got %+v", toolUse)
+	}
+
+	resp, err = client.Synchronous(ctx, userRequest("tool result"))
+	if err != nil {
+		t.Fatalf("turn 2: %v", err)
+	}
+	if resp.StopReason != "stop" || resp.Content[0].Text != "The deadline is Friday." {
+		t.Errorf("turn 2: got %+v", resp)
+	}
+
+	if _, err := client.Synchronous(ctx, userRequest("again")); err == nil {
+		t.Error("expected error once the script is exhausted")
+	}
+}
+
+func TestMockClient_DefaultAgentAndLoop(t *testing.T) {
+	script := mustParse(t, testScript)
+	script.Loop = true
+
+	client, err := NewMockClient(script, "unknown-agent")
+	if err != nil {
+		t.Fatalf("NewMockClient: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		resp, err := client.Synchronous(context.Background(), userRequest("hi"))
+		if err != nil {
+			t.Fatalf("call %d: %v", i+1, err)
+		}
+		if resp.Content[0].Text != "Hello from the mock provider." {
+			t.Errorf("call %d: got %q", i+1, resp.Content[0].Text)
+		}
+	}
+}
+
+func TestMockClient_LoopGeneratesUniqueToolCallIDs(t *testing.T) {
+	script := mustParse(t, testScript)
+	script.Loop = true
+
+	client, err := NewMockClient(script, "researcher")
+	if err != nil {
+		t.Fatalf("NewMockClient: %v", err)
+	}
+
+	// Turns 1 and 3 replay the same scripted tool call
+	seen := make(map[string]bool)
+	for i := 0; i < 4; i++ {
+		resp, err := client.Synchronous(context.Background(), userRequest("hi"))
+		if err != nil {
+			t.Fatalf("call %d: %v", i+1, err)
+		}
+		for _, block := range resp.Content {
+			if block.ToolUse == nil {
+				continue
+			}
+			if seen[block.ToolUse.ID] {
+				t.Errorf("call %d: tool call ID %q repeated", i+1, block.ToolUse.ID)
+			}
+			seen[block.ToolUse.ID] = true
+		}
+	}
+	if len(seen) != 2 {
+		t.Errorf("expected 2 tool calls over two loops, got %d", len(seen))
+	}
+}
+
+func TestMockClient_Stream(t *testing.T) {
+	client, err := NewMockClient(mustParse(t, testScript), "researcher")
+	if err != nil {
+		t.Fatalf("NewMockClient: %v", err)
+	}
+
+	stream, err := client.Stream(context.Background(), userRequest("When is the deadline?"))
+	if err != nil {
+		t.Fatalf("Stream: %v", err)
+	}
+	defer stream.Close() //nolint:errcheck // Test cleanup
+
+	var text, reasoning, toolInput strings.Builder
+	var toolUse *llm.ToolUseBlock
+	var stopped bool
+	for stream.Next() {
+		event := stream.Event()
+		if event.Type == llm.StreamEventTypeStop {
+			stopped = event.Done
+		}
+		if event.Delta == nil {
+			continue
+		}
+		switch event.Delta.Type {
+		case llm.StreamDeltaTypeText:
+			text.WriteString(event.Delta.Text)
+		case llm.StreamDeltaTypeReasoning:
+			reasoning.WriteString(event.Delta.Reasoning.Text)
+		case llm.StreamDeltaTypeToolUse:
+			toolUse = event.Delta.ToolUse
+		case llm.StreamDeltaTypeToolInput:
+			toolInput.WriteString(event.Delta.ToolInput)
+		}
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("stream error: %v", err)
+	}
+
+	if text.String() != "Let me check." {
+		t.Errorf("text: got %q", text.String())
+	}
+	if reasoning.String() != "I should search." {
+		t.Errorf("reasoning: got %q", reasoning.String())
+	}
+	if toolUse == nil || toolUse.Name != "memory_search" {
+		t.Errorf("tool use: got %+v", toolUse)
+	}
+	if toolInput.String() != `{"query":"deadlines"}` {
+		t.Errorf("tool input: got %q", toolInput.String())
+	}
+	if !stopped {
+		t.Error("expected a final stop event")
+	}
+}
+
+func TestMockClient_ScriptedError(t *testing.T) {
+	client, err := NewMockClient(mustParse(t, `
+agents:
+  flaky:
+    - error: "upstream unavailable"
+    - text: "recovered"
+`), "flaky")
+	if err != nil {
+		t.Fatalf("NewMockClient: %v", err)
+	}
+
+	if _, err := client.Synchronous(context.Background(), userRequest("hi")); err == nil || !strings.Contains(err.Error(), "upstream unavailable") {
+		t.Errorf("expected scripted error, got %v", err)
+	}
+	resp, err := client.Synchronous(context.Background(), userRequest("hi"))
+	if err != nil || resp.Content[0].Text != "recovered" {
+		t.Errorf("expected recovery on the next turn, got %+v, %v", resp, err)
+	}
+}
+
+func TestMockClient_UnscriptedAgentFailsOnlyWhenCalled(t *testing.T) {
+	client, err := NewMockClient(mustParse(t, `
+agents:
+  researcher:
+    - text: "scripted"
+`), "writer")
+	if err != nil {
+		t.Fatalf("NewMockClient: %v", err)
+	}
+
+	if _, err := client.Synchronous(context.Background(), userRequest("hi")); err == nil || !strings.Contains(err.Error(), "no turns for agent writer") {
+		t.Errorf("expected an error for the unscripted agent, got %v", err)
+	}
+	if _, err := client.Stream(context.Background(), userRequest("hi")); err == nil {
+		t.Error("expected stream to fail for the unscripted agent")
+	}
+}
+
+func TestParseScript_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+	}{
+		{name: "no agents", script: "loop: true"},
+		{name: "tool call without name", script: "agents:\n  a:\n    - tool_calls:\n        - input: {x: 1}"},
+		{name: "malformed yaml", script: "agents: [unclosed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseScript([]byte(tt.script)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package mock
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultAgent is the script key used for agents that have no turns of their own.
+const DefaultAgent = "default"
+
+// Script is a set of canned LLM turns keyed by agent ID.
+// Each LLM call made for an agent consumes that agent's next turn, so a tool loop
+// that calls a tool and then answers needs two turns.
+//
+// Example:
+//
+//	chunk_delay: 20ms
+//	loop: true
+//	agents:
+//	  researcher:
+//	    - text: "Let me check my notes."
+//	      tool_calls:
+//	        - name: memory_search
+//	          input: {query: "project deadlines"}
+//	    - text: "The deadline is Friday."
+//	  default:
+//	    - text: "Hello from the mock provider."
+type Script struct {
+	// ChunkDelay is the pause between streamed chunks, to make the TUI look realistic.
+	ChunkDelay time.Duration `yaml:"chunk_delay,omitempty"`
+	// Loop restarts an agent's turns from the beginning once they are used up.
+	// Without it, calls past the last turn fail.
+	Loop   bool              `yaml:"loop,omitempty"`
+	Agents map[string][]Turn `yaml:"agents"`
+}
+
+// Turn is a single canned assistant response.
+type Turn struct {
+	Reasoning  string     `yaml:"reasoning,omitempty"`   // Optional reasoning emitted before the answer
+	Text       string     `yaml:"text,omitempty"`        // Assistant text
+	ToolCalls  []ToolCall `yaml:"tool_calls,omitempty"`  // Tool calls the assistant requests
+	StopReason string     `yaml:"stop_reason,omitempty"` // Defaults to "tool_calls" or "stop"
+	Error      string     `yaml:"error,omitempty"`       // Fail the call with this message instead of responding
+}
+
+// ToolCall is a canned tool invocation.
+type ToolCall struct {
+	ID    string                 `yaml:"id,omitempty"` // Generated when omitted
+	Name  string                 `yaml:"name"`
+	Input map[string]interface{} `yaml:"input,omitempty"`
+}
+
+// LoadScript reads and validates a script from a YAML file.
+func LoadScript(path string) (*Script, error) {
+	data, err := os.ReadFile(path) //#nosec 304 -- intentional file read for mock script
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mock script: %w", err)
+	}
+	return ParseScript(data)
+}
+
+// ParseScript parses and validates a script from YAML.
+func ParseScript(data []byte) (*Script, error) {
+	var script Script
+	if err := yaml.Unmarshal(data, &script); err != nil {
+		return nil, fmt.Errorf("failed to parse mock script: %w", err)
+	}
+	if len(script.Agents) == 0 {
+		return nil, fmt.Errorf("mock script has no agents")
+	}
+	for agentID, turns := range script.Agents {
+		for i, turn := range turns {
+			for j, call := range turn.ToolCalls {
+				if call.Name == "" {
+					return nil, fmt.Errorf("mock script agent %s turn %d: tool call %d has no name", agentID, i+1, j+1)
+				}
+			}
+		}
+	}
+	return &script, nil
+}
+
+// turnsFor returns the turns for an agent, falling back to the default agent.
+func (s *Script) turnsFor(agentID string) []Turn {
+	if turns, ok := s.Agents[agentID]; ok {
+		return turns
+	}
+	return s.Agents[DefaultAgent]
+}
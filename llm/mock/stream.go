@@ -0,0 +1,156 @@
+package mock
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aschepis/backscratcher/staff/llm"
+)
+
+// mockStream implements the llm.Stream interface over a precomputed list of events.
+type mockStream struct {
+	ctx     context.Context
+	events  []*llm.StreamEvent
+	delay   time.Duration // Pause before each event after the first
+	current int
+	mu      sync.Mutex
+	err     error
+	done    bool
+}
+
+// newMockStream creates a new mockStream.
+func newMockStream(ctx context.Context, events []*llm.StreamEvent, delay time.Duration) *mockStream {
+	return &mockStream{
+		ctx:     ctx,
+		events:  events,
+		delay:   delay,
+		current: -1,
+	}
+}
+
+// Next advances to the next event in the stream.
+func (s *mockStream) Next() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil || s.done {
+		return false
+	}
+
+	if s.delay > 0 && s.current >= 0 {
+		timer := time.NewTimer(s.delay)
+		select {
+		case <-s.ctx.Done():
+			timer.Stop()
+			s.err = s.ctx.Err()
+			return false
+		case <-timer.C:
+		}
+	} else if err := s.ctx.Err(); err != nil {
+		s.err = err
+		return false
+	}
+
+	s.current++
+	return s.current < len(s.events)
+}
+
+// Event returns the current event.
+func (s *mockStream) Event() *llm.StreamEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.current < 0 || s.current >= len(s.events) {
+		return nil
+	}
+	return s.events[s.current]
+}
+
+// Err returns any error that occurred during streaming.
+func (s *mockStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Close closes the stream and releases resources.
+func (s *mockStream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.done = true
+	return nil
+}
+
+// turnEvents converts a turn to the event sequence a real provider would stream:
+// reasoning, then text split into word chunks, then tool calls with their input.
+func turnEvents(turn Turn, seq int, usage *llm.Usage) []*llm.StreamEvent {
+	events := []*llm.StreamEvent{{Type: llm.StreamEventTypeStart}}
+
+	for i, chunk := range chunks(turn.Reasoning) {
+		eventType := llm.StreamEventTypeContentDelta
+		if i == 0 {
+			eventType = llm.StreamEventTypeContentBlock
+		}
+		events = append(events, &llm.StreamEvent{
+			Type: eventType,
+			Delta: &llm.StreamDelta{
+				Type:      llm.StreamDeltaTypeReasoning,
+				Reasoning: &llm.ReasoningBlock{Text: chunk},
+			},
+		})
+	}
+
+	for i, chunk := range chunks(turn.Text) {
+		eventType := llm.StreamEventTypeContentDelta
+		if i == 0 {
+			eventType = llm.StreamEventTypeContentBlock
+		}
+		events = append(events, &llm.StreamEvent{
+			Type: eventType,
+			Delta: &llm.StreamDelta{
+				Type: llm.StreamDeltaTypeText,
+				Text: chunk,
+			},
+		})
+	}
+
+	for i := range turn.ToolCalls {
+		toolUse := toolUseBlock(turn.ToolCalls[i], seq, i)
+		argsJSON, err := json.Marshal(toolUse.Input)
+		if err != nil {
+			argsJSON = []byte("{}")
+		}
+		events = append(events, &llm.StreamEvent{
+			Type: llm.StreamEventTypeContentBlock,
+			Delta: &llm.StreamDelta{
+				Type:    llm.StreamDeltaTypeToolUse,
+				ToolUse: toolUse,
+			},
+		}, &llm.StreamEvent{
+			Type: llm.StreamEventTypeContentDelta,
+			Delta: &llm.StreamDelta{
+				Type:      llm.StreamDeltaTypeToolInput,
+				ToolInput: string(argsJSON),
+			},
+		})
+	}
+
+	return append(events,
+		&llm.StreamEvent{Type: llm.StreamEventTypeMessageDelta, Usage: usage, StopReason: stopReason(turn)},
+		&llm.StreamEvent{Type: llm.StreamEventTypeStop, Usage: usage, Done: true, StopReason: stopReason(turn)},
+	)
+}
+
+// chunks splits text into word-sized pieces that concatenate back to the original.
+func chunks(text string) []string {
+	var result []string
+	for _, chunk := range strings.SplitAfter(text, " ") {
+		if chunk != "" {
+			result = append(result, chunk)
+		}
+	}
+	return result
+}
@@ -231,7 +231,9 @@ func ToOllamaMessage(ctx context.Context, msg llm.Message, toolSpecMap map[strin
 	// Convert content blocks to Ollama format
 	// Ollama messages can have text content or tool calls
 	var content string
+	var thinking string
 	var toolCalls []api.ToolCall
+	var media []*llm.MediaBlock
 
 	for _, block := range msg.Content {
 		switch block.Type {
@@ -240,6 +242,14 @@ func ToOllamaMessage(ctx context.Context, msg llm.Message, toolSpecMap map[strin
 				content += "\n"
 			}
 			content += block.Text
+		case llm.ContentBlockTypeReasoning:
+			// Thinking models expect their earlier reasoning back on assistant messages
+			if block.Reasoning != nil && block.Reasoning.Text != "" && msg.Role == llm.RoleAssistant {
+				if thinking != "" {
+					thinking += "\n"
+				}
+				thinking += block.Reasoning.Text
+			}
 		case llm.ContentBlockTypeToolUse:
 			if block.ToolUse != nil {
 				// Convert tool use to Ollama tool call format
@@ -293,14 +303,40 @@ func ToOllamaMessage(ctx context.Context, msg llm.Message, toolSpecMap map[strin
 					content += "\n"
 				}
 				content += block.ToolResult.Content
+				for i := range block.ToolResult.Media {
+					media = append(media, &block.ToolResult.Media[i])
+				}
+			}
+		case llm.ContentBlockTypeImage, llm.ContentBlockTypeDocument:
+			if block.Media != nil {
+				media = append(media, block.Media)
 			}
 		}
 	}
 
+	// Ollama accepts raw image bytes on any message; documents are not supported
+	var images []api.ImageData
+	for _, m := range media {
+		if !llm.IsImageMediaType(m.MediaType) {
+			if content != "" {
+				content += "\n"
+			}
+			content += llm.UnsupportedMediaNote(m)
+			continue
+		}
+		raw, err := m.Bytes()
+		if err != nil {
+			return api.Message{}, fmt.Errorf("failed to load %s: %w", m.DisplayName(), err)
+		}
+		images = append(images, api.ImageData(raw))
+	}
+
 	ollamaMsg := api.Message{
 		Role:      string(msg.Role),
 		Content:   content,
+		Thinking:  thinking,
 		ToolCalls: toolCalls,
+		Images:    images,
 	}
 
 	return ollamaMsg, nil
@@ -322,6 +358,11 @@ func FromOllamaMessage(ctx context.Context, msg *api.Message) (llm.Message, erro
 
 	content := make([]llm.ContentBlock, 0)
 
+	// Add reasoning if present
+	if msg.Thinking != "" {
+		content = append(content, llm.NewReasoningContentBlock(llm.ReasoningBlock{Text: msg.Thinking}))
+	}
+
 	// Add text content if present
 	if msg.Content != "" {
 		content = append(content, llm.ContentBlock{
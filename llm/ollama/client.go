@@ -9,6 +9,7 @@ import (
 
 	"github.com/aschepis/backscratcher/staff/llm"
 	"github.com/ollama/ollama/api"
+	"github.com/ollama/ollama/envconfig"
 )
 
 // OllamaClient implements the llm.Client interface for Ollama's API.
@@ -20,7 +21,8 @@ type OllamaClient struct {
 // NewOllamaClient creates a new OllamaClient.
 // If host is empty, it will use the default from environment (OLLAMA_HOST or http://localhost:11434).
 // If model is empty, it will use the default from environment or config.
-func NewOllamaClient(host, model string) (*OllamaClient, error) {
+// If httpClient is nil, a default HTTP client is used.
+func NewOllamaClient(host, model string, httpClient *http.Client) (*OllamaClient, error) {
 	var client *api.Client
 	var err error
 
@@ -31,8 +33,13 @@ func NewOllamaClient(host, model string) (*OllamaClient, error) {
 			return nil, fmt.Errorf("invalid host: %w", err)
 		}
 		// Create HTTP client (use default client)
-		httpClient := &http.Client{}
+		if httpClient == nil {
+			httpClient = &http.Client{}
+		}
 		client = api.NewClient(baseURL, httpClient)
+	} else if httpClient != nil {
+		// Use environment host with the given HTTP client
+		client = api.NewClient(envconfig.Host(), httpClient)
 	} else {
 		// Use environment-based client
 		client, err = api.ClientFromEnvironment()
@@ -122,6 +129,11 @@ func (c *OllamaClient) Synchronous(ctx context.Context, req *llm.Request) (*llm.
 		chatReq.Options["temperature"] = *req.Temperature
 	}
 
+	// Enable thinking if a budget is provided
+	if effort := llm.ReasoningEffort(req.ThinkingBudget); effort != "" {
+		chatReq.Think = thinkValue(chatReq.Model, effort)
+	}
+
 	// Make API call
 	var chatResp api.ChatResponse
 	err = c.client.Chat(ctx, chatReq, func(resp api.ChatResponse) error {
@@ -135,6 +147,13 @@ func (c *OllamaClient) Synchronous(ctx context.Context, req *llm.Request) (*llm.
 	// Convert response
 	content := make([]llm.ContentBlock, 0)
 
+	// Handle reasoning content
+	if chatResp.Message.Thinking != "" {
+		content = append(content, llm.NewReasoningContentBlock(llm.ReasoningBlock{
+			Text: chatResp.Message.Thinking,
+		}))
+	}
+
 	// Handle message content
 	if chatResp.Message.Content != "" {
 		content = append(content, llm.ContentBlock{
@@ -167,19 +186,25 @@ func (c *OllamaClient) Synchronous(ctx context.Context, req *llm.Request) (*llm.
 		usage.OutputTokens = int64(chatResp.EvalCount)
 	}
 
-	// Determine stop reason
-	stopReason := "end_turn"
-	if chatResp.Done {
-		stopReason = "stop"
-	}
-
 	return &llm.Response{
 		Content:    content,
 		Usage:      usage,
-		StopReason: stopReason,
+		StopReason: stopReason(chatResp),
 	}, nil
 }
 
+// stopReason converts Ollama's done state to the stop reason reported by llm.Response.
+func stopReason(resp api.ChatResponse) string {
+	switch {
+	case !resp.Done:
+		return "end_turn"
+	case resp.DoneReason == "length":
+		return "max_tokens"
+	default:
+		return "stop"
+	}
+}
+
 // Stream implements llm.Client.Stream.
 func (c *OllamaClient) Stream(ctx context.Context, req *llm.Request) (llm.Stream, error) {
 	if req == nil {
@@ -244,6 +269,20 @@ func (c *OllamaClient) Stream(ctx context.Context, req *llm.Request) (llm.Stream
 		chatReq.Options["temperature"] = *req.Temperature
 	}
 
+	// Enable thinking if a budget is provided
+	if effort := llm.ReasoningEffort(req.ThinkingBudget); effort != "" {
+		chatReq.Think = thinkValue(chatReq.Model, effort)
+	}
+
 	// Create and return stream
 	return newOllamaStream(ctx, c.client, chatReq), nil
 }
+
+// thinkValue builds the think option for a chat request. Most thinking models only
+// accept a boolean; gpt-oss models take an effort level instead.
+func thinkValue(model, effort string) *api.ThinkValue {
+	if strings.HasPrefix(model, "gpt-oss") {
+		return &api.ThinkValue{Value: effort}
+	}
+	return &api.ThinkValue{Value: true}
+}
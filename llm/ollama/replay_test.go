@@ -0,0 +1,109 @@
+package ollama
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aschepis/backscratcher/staff/llm"
+	"github.com/aschepis/backscratcher/staff/llm/cassette"
+)
+
+// newReplayClient returns a client answered from the cassettes in testdata. A change to
+// how requests are encoded changes their cassette key, so replay fails with
+// cassette.ErrNoRecording until the cassettes are re-recorded.
+func newReplayClient(t *testing.T) *OllamaClient {
+	t.Helper()
+	transport, err := cassette.New(cassette.Config{Dir: "testdata/cassettes", Mode: cassette.ModeReplay})
+	if err != nil {
+		t.Fatalf("cassette.New: %v", err)
+	}
+	client, err := NewOllamaClient("http://localhost:11434", "", transport.Client())
+	if err != nil {
+		t.Fatalf("NewOllamaClient: %v", err)
+	}
+	return client
+}
+
+func weatherRequest() *llm.Request {
+	return &llm.Request{
+		Model:     "qwen3:8b",
+		System:    "You answer weather questions using the get_weather tool.",
+		Messages:  []llm.Message{llm.NewTextMessage(llm.RoleUser, "What's the weather in Paris?")},
+		MaxTokens: 256,
+		Tools: []llm.ToolSpec{{
+			Name:        "get_weather",
+			Description: "Get the current weather for a city",
+			Schema: llm.ToolSchema{
+				Type:       "object",
+				Properties: map[string]interface{}{"city": map[string]interface{}{"type": "string", "description": "City name"}},
+				Required:   []string{"city"},
+			},
+		}},
+	}
+}
+
+func TestOllamaClient_ReplaySynchronous(t *testing.T) {
+	resp, err := newReplayClient(t).Synchronous(context.Background(), weatherRequest())
+	if err != nil {
+		t.Fatalf("Synchronous: %v", err)
+	}
+
+	if len(resp.Content) != 2 {
+		t.Fatalf("expected text and tool use, got %+v", resp.Content)
+	}
+	if resp.Content[0].Type != llm.ContentBlockTypeText || resp.Content[0].Text != "Let me check the weather in Paris." {
+		t.Errorf("text block: %+v", resp.Content[0])
+	}
+	toolUse := resp.Content[1].ToolUse
+	if toolUse == nil || toolUse.Name != "get_weather" || toolUse.Input["city"] != "Paris" {
+		t.Errorf("tool use block: %+v", toolUse)
+	}
+	if resp.StopReason != "stop" {
+		t.Errorf("stop reason: got %q", resp.StopReason)
+	}
+	if resp.Usage == nil || resp.Usage.InputTokens != 96 || resp.Usage.OutputTokens != 22 {
+		t.Errorf("usage: %+v", resp.Usage)
+	}
+}
+
+func TestOllamaClient_ReplayStream(t *testing.T) {
+	stream, err := newReplayClient(t).Stream(context.Background(), weatherRequest())
+	if err != nil {
+		t.Fatalf("Stream: %v", err)
+	}
+	defer stream.Close() //nolint:errcheck // Test cleanup
+
+	var text strings.Builder
+	var toolUses []*llm.ToolUseBlock
+	var last *llm.StreamEvent
+	for stream.Next() {
+		event := stream.Event()
+		last = event
+		if event.Delta == nil {
+			continue
+		}
+		switch event.Delta.Type {
+		case llm.StreamDeltaTypeText:
+			text.WriteString(event.Delta.Text)
+		case llm.StreamDeltaTypeToolUse:
+			toolUses = append(toolUses, event.Delta.ToolUse)
+		}
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("stream error: %v", err)
+	}
+
+	if text.String() != "Let me check the weather in Paris." {
+		t.Errorf("text: got %q", text.String())
+	}
+	if len(toolUses) != 1 || toolUses[0].Name != "get_weather" || toolUses[0].Input["city"] != "Paris" {
+		t.Errorf("tool uses: %+v", toolUses)
+	}
+	if last == nil || last.Type != llm.StreamEventTypeStop || last.StopReason != "stop" {
+		t.Fatalf("expected a stop event last, got %+v", last)
+	}
+	if last.Usage == nil || last.Usage.InputTokens != 96 || last.Usage.OutputTokens != 25 {
+		t.Errorf("usage: %+v", last.Usage)
+	}
+}
@@ -114,6 +114,7 @@ func (s *ollamaStream) startStream() {
 	var accumulatedText strings.Builder
 	var currentToolCall *llm.ToolUseBlock
 	var isFirstContentBlock bool = true
+	reasoningStarted := false
 
 	// Call Chat with streaming callback
 	err := s.client.Chat(s.ctx, s.req, func(resp api.ChatResponse) error {
@@ -123,6 +124,25 @@ func (s *ollamaStream) startStream() {
 		// Update response
 		s.response = &resp
 
+		// Handle thinking deltas; the first one starts the reasoning block
+		if resp.Message.Thinking != "" {
+			eventType := llm.StreamEventTypeContentDelta
+			if !reasoningStarted {
+				eventType = llm.StreamEventTypeContentBlock
+				reasoningStarted = true
+			}
+			s.events = append(s.events, &llm.StreamEvent{
+				Type: eventType,
+				Delta: &llm.StreamDelta{
+					Type:      llm.StreamDeltaTypeReasoning,
+					Reasoning: &llm.ReasoningBlock{Text: resp.Message.Thinking},
+				},
+				Usage: nil,
+				Done:  false,
+			})
+			s.cond.Broadcast() // Signal that a new event is available
+		}
+
 		// Handle message content deltas
 		// Ollama sends incremental deltas (just the new token), not cumulative content
 		if resp.Message.Content != "" {
@@ -233,19 +253,21 @@ func (s *ollamaStream) startStream() {
 
 			// Emit message delta with usage
 			s.events = append(s.events, &llm.StreamEvent{
-				Type:  llm.StreamEventTypeMessageDelta,
-				Delta: nil,
-				Usage: usage,
-				Done:  false,
+				Type:       llm.StreamEventTypeMessageDelta,
+				Delta:      nil,
+				Usage:      usage,
+				Done:       false,
+				StopReason: stopReason(resp),
 			})
 			s.cond.Broadcast() // Signal that a new event is available
 
 			// Emit stop event
 			s.events = append(s.events, &llm.StreamEvent{
-				Type:  llm.StreamEventTypeStop,
-				Delta: nil,
-				Usage: usage,
-				Done:  true,
+				Type:       llm.StreamEventTypeStop,
+				Delta:      nil,
+				Usage:      usage,
+				Done:       true,
+				StopReason: stopReason(resp),
 			})
 
 			s.done = true
@@ -10,10 +10,32 @@ import (
 )
 
 // ToOpenAIMessages converts llm.Messages to OpenAI chat message format.
+// Tool results become tool messages answering their calls. Tool messages cannot carry
+// images, so images returned by tools follow in a user message.
 func ToOpenAIMessages(msgs []llm.Message) ([]openai.ChatCompletionMessage, error) {
 	result := make([]openai.ChatCompletionMessage, 0, len(msgs))
 	for _, msg := range msgs {
-		openaiMsg, err := ToOpenAIMessage(msg)
+		var rest []llm.ContentBlock
+		for _, block := range msg.Content {
+			if block.Type != llm.ContentBlockTypeToolResult || block.ToolResult == nil {
+				rest = append(rest, block)
+				continue
+			}
+			toolMsg, images := toToolMessage(block.ToolResult)
+			result = append(result, toolMsg)
+			if len(images) > 0 {
+				rest = append(rest, llm.ContentBlock{
+					Type: llm.ContentBlockTypeText,
+					Text: fmt.Sprintf("Images returned by tool call %s:", block.ToolResult.ID),
+				})
+				rest = append(rest, images...)
+			}
+		}
+		if len(rest) == 0 && len(msg.Content) > 0 {
+			continue
+		}
+
+		openaiMsg, err := ToOpenAIMessage(llm.Message{Role: msg.Role, Content: rest})
 		if err != nil {
 			return nil, fmt.Errorf("failed to convert message: %w", err)
 		}
@@ -22,6 +44,29 @@ func ToOpenAIMessages(msgs []llm.Message) ([]openai.ChatCompletionMessage, error
 	return result, nil
 }
 
+// toToolMessage converts a tool result to a tool message, returning the images it
+// carries as separate content blocks. Other media is replaced with a text note.
+func toToolMessage(result *llm.ToolResultBlock) (openai.ChatCompletionMessage, []llm.ContentBlock) {
+	content := result.Content
+	var images []llm.ContentBlock
+	for i := range result.Media {
+		m := &result.Media[i]
+		if llm.IsImageMediaType(m.MediaType) {
+			images = append(images, llm.ContentBlock{Type: llm.ContentBlockTypeImage, Media: m})
+			continue
+		}
+		if content != "" {
+			content += "\n"
+		}
+		content += llm.UnsupportedMediaNote(m)
+	}
+	return openai.ChatCompletionMessage{
+		Role:       openai.ChatMessageRoleTool,
+		ToolCallID: result.ID,
+		Content:    content,
+	}, images
+}
+
 // ToOpenAIMessage converts a single llm.Message to OpenAI format.
 func ToOpenAIMessage(msg llm.Message) (openai.ChatCompletionMessage, error) {
 	// Convert role
@@ -41,6 +86,7 @@ func ToOpenAIMessage(msg llm.Message) (openai.ChatCompletionMessage, error) {
 	// OpenAI messages can have text content or tool calls
 	var content string
 	var toolCalls []openai.ToolCall
+	var media []*llm.MediaBlock
 
 	for _, block := range msg.Content {
 		switch block.Type {
@@ -68,14 +114,24 @@ func ToOpenAIMessage(msg llm.Message) (openai.ChatCompletionMessage, error) {
 				toolCalls = append(toolCalls, toolCall)
 			}
 		case llm.ContentBlockTypeToolResult:
-			// Tool results are typically sent as separate user messages in OpenAI
-			// We'll handle this at a higher level if needed
+			// ToOpenAIMessages sends tool results as tool messages; this inlines them for
+			// callers converting a single message
 			if block.ToolResult != nil {
 				if content != "" {
 					content += "\n"
 				}
 				content += block.ToolResult.Content
+				for i := range block.ToolResult.Media {
+					media = append(media, &block.ToolResult.Media[i])
+				}
 			}
+		case llm.ContentBlockTypeImage, llm.ContentBlockTypeDocument:
+			if block.Media != nil {
+				media = append(media, block.Media)
+			}
+		case llm.ContentBlockTypeReasoning:
+			// Chat Completions does not accept prior reasoning as input, and some
+			// compatible servers reject reasoning_content on requests, so it is dropped.
 		}
 	}
 
@@ -83,6 +139,22 @@ func ToOpenAIMessage(msg llm.Message) (openai.ChatCompletionMessage, error) {
 		Role: role,
 	}
 
+	// Images are only accepted on user messages and must be sent as multi-part content
+	if len(media) > 0 && role == openai.ChatMessageRoleUser {
+		parts, err := toMultiContent(content, media)
+		if err != nil {
+			return openai.ChatCompletionMessage{}, err
+		}
+		openaiMsg.MultiContent = parts
+		return openaiMsg, nil
+	}
+	for _, m := range media {
+		if content != "" {
+			content += "\n"
+		}
+		content += llm.UnsupportedMediaNote(m)
+	}
+
 	// Set content or tool calls based on what we have
 	if len(toolCalls) > 0 {
 		openaiMsg.ToolCalls = toolCalls
@@ -97,6 +169,45 @@ func ToOpenAIMessage(msg llm.Message) (openai.ChatCompletionMessage, error) {
 	return openaiMsg, nil
 }
 
+// toMultiContent builds multi-part message content from text and media.
+// Images are sent inline as data URLs; documents are not supported by the chat API
+// and are replaced with a text note.
+func toMultiContent(text string, media []*llm.MediaBlock) ([]openai.ChatMessagePart, error) {
+	parts := make([]openai.ChatMessagePart, 0, len(media)+1)
+	for _, m := range media {
+		if !llm.IsImageMediaType(m.MediaType) {
+			if text != "" {
+				text += "\n"
+			}
+			text += llm.UnsupportedMediaNote(m)
+		}
+	}
+	if text != "" {
+		parts = append(parts, openai.ChatMessagePart{
+			Type: openai.ChatMessagePartTypeText,
+			Text: text,
+		})
+	}
+
+	for _, m := range media {
+		if !llm.IsImageMediaType(m.MediaType) {
+			continue
+		}
+		data, err := m.Base64()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", m.DisplayName(), err)
+		}
+		parts = append(parts, openai.ChatMessagePart{
+			Type: openai.ChatMessagePartTypeImageURL,
+			ImageURL: &openai.ChatMessageImageURL{
+				URL:    fmt.Sprintf("data:%s;base64,%s", m.MediaType, data),
+				Detail: openai.ImageURLDetailAuto,
+			},
+		})
+	}
+	return parts, nil
+}
+
 // ToOpenAITools converts llm.ToolSpecs to OpenAI function format.
 // OpenAI uses a JSON schema format for function definitions.
 func ToOpenAITools(specs []llm.ToolSpec) ([]openai.Tool, error) {
@@ -0,0 +1,79 @@
+package openai
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aschepis/backscratcher/staff/llm"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+func TestToOpenAIMessages_ToolResultImagesFollowAsUserMessage(t *testing.T) {
+	msgs := []llm.Message{
+		{
+			Role: llm.RoleAssistant,
+			Content: []llm.ContentBlock{{
+				Type:    llm.ContentBlockTypeToolUse,
+				ToolUse: &llm.ToolUseBlock{ID: "call_1", Name: "read_file", Input: map[string]interface{}{"path": "chart.png"}},
+			}},
+		},
+		{
+			Role: llm.RoleUser,
+			Content: []llm.ContentBlock{{
+				Type: llm.ContentBlockTypeToolResult,
+				ToolResult: &llm.ToolResultBlock{
+					ID:      "call_1",
+					Content: `{"path":"chart.png"}`,
+					Media: []llm.MediaBlock{
+						{MediaType: "image/png", Data: "iVBORw0KGgo=", Name: "chart.png"},
+						{MediaType: "application/msword", Data: "AAAA", Name: "notes.doc"},
+					},
+				},
+			}},
+		},
+	}
+
+	got, err := ToOpenAIMessages(msgs)
+	if err != nil {
+		t.Fatalf("ToOpenAIMessages: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected assistant, tool and user messages, got %d: %+v", len(got), got)
+	}
+
+	tool := got[1]
+	if tool.Role != openai.ChatMessageRoleTool || tool.ToolCallID != "call_1" {
+		t.Errorf("expected a tool message answering call_1, got role %q id %q", tool.Role, tool.ToolCallID)
+	}
+	if !strings.HasPrefix(tool.Content, `{"path":"chart.png"}`) || !strings.Contains(tool.Content, "notes.doc") {
+		t.Errorf("expected tool content with a note for the document, got %q", tool.Content)
+	}
+
+	user := got[2]
+	if user.Role != openai.ChatMessageRoleUser || len(user.MultiContent) != 2 {
+		t.Fatalf("expected a user message with text and one image, got %+v", user)
+	}
+	if !strings.Contains(user.MultiContent[0].Text, "call_1") {
+		t.Errorf("expected the image to be attributed to call_1, got %q", user.MultiContent[0].Text)
+	}
+	image := user.MultiContent[1]
+	if image.Type != openai.ChatMessagePartTypeImageURL || image.ImageURL.URL != "data:image/png;base64,iVBORw0KGgo=" {
+		t.Errorf("unexpected image part: %+v", image)
+	}
+}
+
+func TestToOpenAIMessages_ToolResultWithoutMedia(t *testing.T) {
+	got, err := ToOpenAIMessages([]llm.Message{{
+		Role: llm.RoleUser,
+		Content: []llm.ContentBlock{{
+			Type:       llm.ContentBlockTypeToolResult,
+			ToolResult: &llm.ToolResultBlock{ID: "call_2", Content: "ok"},
+		}},
+	}})
+	if err != nil {
+		t.Fatalf("ToOpenAIMessages: %v", err)
+	}
+	if len(got) != 1 || got[0].Role != openai.ChatMessageRoleTool || got[0].Content != "ok" {
+		t.Errorf("expected a single tool message, got %+v", got)
+	}
+}
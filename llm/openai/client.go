@@ -25,7 +25,8 @@ type OpenAIClient struct {
 // If apiKey is empty, it will return an error.
 // If baseURL is empty, it will use the default OpenAI API endpoint.
 // If model is empty, it will use the default from config or request.
-func NewOpenAIClient(apiKey, baseURL, model, organization string) (*OpenAIClient, error) {
+// If httpClient is nil, the library's default HTTP client is used.
+func NewOpenAIClient(apiKey, baseURL, model, organization string, httpClient *http.Client) (*OpenAIClient, error) {
 	if apiKey == "" {
 		return nil, fmt.Errorf("api key is required")
 	}
@@ -42,6 +43,10 @@ func NewOpenAIClient(apiKey, baseURL, model, organization string) (*OpenAIClient
 		config.OrgID = organization
 	}
 
+	if httpClient != nil {
+		config.HTTPClient = httpClient
+	}
+
 	client := openai.NewClientWithConfig(config)
 
 	return &OpenAIClient{
@@ -103,10 +108,8 @@ func (c *OpenAIClient) Synchronous(ctx context.Context, req *llm.Request) (*llm.
 		chatReq.ToolChoice = "auto"
 	}
 
-	// Set max tokens if provided
-	if req.MaxTokens > 0 {
-		chatReq.MaxTokens = int(req.MaxTokens)
-	}
+	// Set max tokens and reasoning effort if provided
+	applyTokenLimits(&chatReq, req)
 
 	// Set temperature if provided
 	if req.Temperature != nil {
@@ -127,6 +130,13 @@ func (c *OpenAIClient) Synchronous(ctx context.Context, req *llm.Request) (*llm.
 	choice := chatResp.Choices[0]
 	content := make([]llm.ContentBlock, 0)
 
+	// Handle reasoning content (returned by some OpenAI-compatible servers)
+	if choice.Message.ReasoningContent != "" {
+		content = append(content, llm.NewReasoningContentBlock(llm.ReasoningBlock{
+			Text: choice.Message.ReasoningContent,
+		}))
+	}
+
 	// Handle message content
 	if choice.Message.Content != "" {
 		content = append(content, llm.ContentBlock{
@@ -153,26 +163,25 @@ func (c *OpenAIClient) Synchronous(ctx context.Context, req *llm.Request) (*llm.
 		OutputTokens: int64(chatResp.Usage.CompletionTokens),
 	}
 
-	// Determine stop reason
-	stopReason := "stop"
-	switch reason := choice.FinishReason; reason {
-	case openai.FinishReasonLength:
-		stopReason = "max_tokens"
-	case openai.FinishReasonToolCalls:
-		stopReason = "tool_calls"
-	case openai.FinishReasonStop:
-		stopReason = "stop"
-	default:
-		// leave as default "stop"
-	}
-
 	return &llm.Response{
 		Content:    content,
 		Usage:      usage,
-		StopReason: stopReason,
+		StopReason: stopReason(choice.FinishReason),
 	}, nil
 }
 
+// stopReason converts an OpenAI finish reason to the stop reason reported by llm.Response.
+func stopReason(reason openai.FinishReason) string {
+	switch reason {
+	case openai.FinishReasonLength:
+		return "max_tokens"
+	case openai.FinishReasonToolCalls:
+		return "tool_calls"
+	default:
+		return "stop"
+	}
+}
+
 // Stream implements llm.Client.Stream.
 func (c *OpenAIClient) Stream(ctx context.Context, req *llm.Request) (llm.Stream, error) {
 	if req == nil {
@@ -225,10 +234,8 @@ func (c *OpenAIClient) Stream(ctx context.Context, req *llm.Request) (llm.Stream
 		chatReq.ToolChoice = "auto"
 	}
 
-	// Set max tokens if provided
-	if req.MaxTokens > 0 {
-		chatReq.MaxTokens = int(req.MaxTokens)
-	}
+	// Set max tokens and reasoning effort if provided
+	applyTokenLimits(&chatReq, req)
 
 	// Set temperature if provided
 	if req.Temperature != nil {
@@ -245,6 +252,24 @@ func (c *OpenAIClient) Stream(ctx context.Context, req *llm.Request) (llm.Stream
 	return newOpenAIStream(ctx, stream), nil
 }
 
+// applyTokenLimits sets the token limit and reasoning effort on a chat request.
+// Reasoning models reject max_tokens, so max_completion_tokens is used when a
+// thinking budget is set; that limit covers reasoning and answer tokens combined.
+func applyTokenLimits(chatReq *openai.ChatCompletionRequest, req *llm.Request) {
+	effort := llm.ReasoningEffort(req.ThinkingBudget)
+	if effort == "" {
+		if req.MaxTokens > 0 {
+			chatReq.MaxTokens = int(req.MaxTokens)
+		}
+		return
+	}
+
+	chatReq.ReasoningEffort = effort
+	if req.MaxTokens > 0 {
+		chatReq.MaxCompletionTokens = int(req.MaxTokens + req.ThinkingBudget)
+	}
+}
+
 // convertOpenAIError converts OpenAI API errors to llm.Error types.
 func convertOpenAIError(err error) error {
 	if err == nil {
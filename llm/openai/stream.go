@@ -43,8 +43,9 @@ func (s *openaiStream) Next() bool {
 		s.startStream()
 	}
 
-	// If there's an error or we're done, return false
-	if s.err != nil || s.done {
+	// If there's an error, return false. The whole response is read before the first
+	// event is returned, so events are still handed out after the stream is done.
+	if s.err != nil {
 		return false
 	}
 
@@ -97,6 +98,7 @@ func (s *openaiStream) startStream() {
 	var currentToolCall *llm.ToolUseBlock
 	var toolInputBuilder strings.Builder
 	var usage *llm.Usage
+	reasoningStarted := false
 
 	// Process stream events
 	for {
@@ -119,6 +121,24 @@ func (s *openaiStream) startStream() {
 
 		choice := response.Choices[0]
 
+		// Handle reasoning deltas; the first one starts the reasoning block
+		if choice.Delta.ReasoningContent != "" {
+			eventType := llm.StreamEventTypeContentDelta
+			if !reasoningStarted {
+				eventType = llm.StreamEventTypeContentBlock
+				reasoningStarted = true
+			}
+			s.events = append(s.events, &llm.StreamEvent{
+				Type: eventType,
+				Delta: &llm.StreamDelta{
+					Type:      llm.StreamDeltaTypeReasoning,
+					Reasoning: &llm.ReasoningBlock{Text: choice.Delta.ReasoningContent},
+				},
+				Usage: nil,
+				Done:  false,
+			})
+		}
+
 		// Handle content deltas
 		if choice.Delta.Content != "" {
 			delta := choice.Delta.Content
@@ -141,8 +161,9 @@ func (s *openaiStream) startStream() {
 			// Check if this is a new tool call or continuation
 			if toolCallDelta.Index != nil {
 
-				// If we have a current tool call and the index changed, finish the previous one
-				if currentToolCall != nil && currentToolCall.ID != toolCallDelta.ID {
+				// If a delta starts a different tool call, finish the previous one. Only the
+				// first delta of a call carries its ID; the rest continue the current call.
+				if currentToolCall != nil && toolCallDelta.ID != "" && currentToolCall.ID != toolCallDelta.ID {
 					// Finish previous tool call
 					var input map[string]interface{}
 					if toolInputBuilder.Len() > 0 {
@@ -213,7 +234,7 @@ func (s *openaiStream) startStream() {
 			}
 
 			// Extract usage if available
-			if response.Usage.TotalTokens > 0 {
+			if response.Usage != nil && response.Usage.TotalTokens > 0 {
 				usage = &llm.Usage{
 					InputTokens:  int64(response.Usage.PromptTokens),
 					OutputTokens: int64(response.Usage.CompletionTokens),
@@ -222,15 +243,17 @@ func (s *openaiStream) startStream() {
 
 			// Emit message delta with usage
 			s.events = append(s.events, &llm.StreamEvent{
-				Type:  llm.StreamEventTypeMessageDelta,
-				Delta: nil,
-				Usage: usage,
-				Done:  false,
+				Type:       llm.StreamEventTypeMessageDelta,
+				Delta:      nil,
+				Usage:      usage,
+				Done:       false,
+				StopReason: stopReason(choice.FinishReason),
 			}, &llm.StreamEvent{ // Emit stop event
-				Type:  llm.StreamEventTypeStop,
-				Delta: nil,
-				Usage: usage,
-				Done:  true,
+				Type:       llm.StreamEventTypeStop,
+				Delta:      nil,
+				Usage:      usage,
+				Done:       true,
+				StopReason: stopReason(choice.FinishReason),
 			})
 
 			s.done = true
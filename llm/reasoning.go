@@ -0,0 +1,23 @@
+package llm
+
+// Reasoning effort levels used by providers that take an effort instead of a token budget.
+const (
+	ReasoningEffortLow    = "low"
+	ReasoningEffortMedium = "medium"
+	ReasoningEffortHigh   = "high"
+)
+
+// ReasoningEffort maps a thinking budget in tokens to an effort level.
+// Returns an empty string when the budget is zero, meaning reasoning is not requested.
+func ReasoningEffort(budget int64) string {
+	switch {
+	case budget <= 0:
+		return ""
+	case budget <= 4096:
+		return ReasoningEffortLow
+	case budget <= 16384:
+		return ReasoningEffortMedium
+	default:
+		return ReasoningEffortHigh
+	}
+}
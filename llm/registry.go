@@ -10,6 +10,8 @@ const (
 	ProviderAnthropic = "anthropic"
 	ProviderOllama    = "ollama"
 	ProviderOpenAI    = "openai"
+	ProviderGemini    = "gemini"
+	ProviderMock      = "mock"
 )
 
 // AgentLLMConfig represents the LLM configuration portion of an agent config.
@@ -20,10 +22,11 @@ type AgentLLMConfig struct {
 
 // LLMPreference represents a single provider/model preference.
 type LLMPreference struct {
-	Provider    string
-	Model       string
-	Temperature *float64
-	APIKeyRef   string
+	Provider       string
+	Model          string
+	Temperature    *float64
+	APIKeyRef      string
+	ThinkingBudget int64
 }
 
 // ClientKey uniquely identifies an LLM client configuration.
@@ -32,8 +35,11 @@ type ClientKey struct {
 	Model        string
 	APIKey       string // For credential-based providers
 	Host         string // For Ollama
-	BaseURL      string // For OpenAI
+	BaseURL      string // For OpenAI and Gemini
 	Organization string // For OpenAI
+	ScriptPath   string // For Mock
+	AgentID      string // For Mock, whose scripts are keyed by agent
+	Replay       bool   // Requests are answered from LLM cassettes, never by the provider
 }
 
 // ProviderConfig holds the configuration needed for provider registry.
@@ -46,6 +52,14 @@ type ProviderConfig struct {
 	OpenAIBaseURL   string
 	OpenAIModel     string
 	OpenAIOrg       string
+	GeminiAPIKey    string
+	GeminiBaseURL   string
+	GeminiModel     string
+	MockScriptPath  string
+
+	// Replay is set when LLM traffic is replayed from cassettes. Providers then need no
+	// credentials, since requests never reach them.
+	Replay bool
 }
 
 // ProviderRegistry manages LLM provider selection and configuration resolution.
@@ -106,7 +120,7 @@ func (r *ProviderRegistry) ResolveAgentLLMConfig(agentID string, agentCfg AgentL
 			}
 
 			// Resolve provider-specific config
-			key, err := r.resolveProviderConfig(pref.Provider, pref.Model)
+			key, err := r.resolveProviderConfig(agentID, pref.Provider, pref.Model)
 			if err != nil {
 				// Log warning and continue to next preference
 				continue
@@ -137,7 +151,7 @@ func (r *ProviderRegistry) ResolveAgentLLMConfig(agentID string, agentCfg AgentL
 
 	// Don't use agent's model field - it may be provider-specific
 	// Use provider's default model instead
-	key, err := r.resolveProviderConfig(firstProvider, "")
+	key, err := r.resolveProviderConfig(agentID, firstProvider, "")
 	if err != nil {
 		return nil, fmt.Errorf("agent %s: failed to resolve config for provider %s: %w", agentID, firstProvider, err)
 	}
@@ -148,6 +162,13 @@ func (r *ProviderRegistry) ResolveAgentLLMConfig(agentID string, agentCfg AgentL
 // isProviderConfiguredUnlocked is the unlocked version of IsProviderConfigured.
 // Must be called with r.mu already locked.
 func (r *ProviderRegistry) isProviderConfiguredUnlocked(provider string) bool {
+	if r.config.Replay {
+		// Recorded HTTP traffic stands in for the provider; mock has no HTTP traffic
+		switch provider {
+		case ProviderAnthropic, ProviderOllama, ProviderOpenAI, ProviderGemini:
+			return true
+		}
+	}
 	switch provider {
 	case ProviderAnthropic:
 		// Check config only
@@ -162,22 +183,28 @@ func (r *ProviderRegistry) isProviderConfiguredUnlocked(provider string) bool {
 			apiKey = os.Getenv("OPENAI_API_KEY")
 		}
 		return apiKey != ""
+	case ProviderGemini:
+		return geminiAPIKey(r.config) != ""
+	case ProviderMock:
+		// Mock replays a script, so it only needs a script path
+		return r.config.MockScriptPath != ""
 	default:
 		return false
 	}
 }
 
 // resolveProviderConfig resolves provider-specific configuration and returns a ClientKey.
-func (r *ProviderRegistry) resolveProviderConfig(provider, modelOverride string) (*ClientKey, error) {
+func (r *ProviderRegistry) resolveProviderConfig(agentID, provider, modelOverride string) (*ClientKey, error) {
 	key := &ClientKey{
 		Provider: provider,
 		Model:    modelOverride,
+		Replay:   r.config.Replay,
 	}
 
 	switch provider {
 	case ProviderAnthropic:
 		// Get API key from config
-		if r.config.AnthropicAPIKey == "" {
+		if r.config.AnthropicAPIKey == "" && !r.config.Replay {
 			return nil, fmt.Errorf("anthropic API key not configured")
 		}
 		key.APIKey = r.config.AnthropicAPIKey
@@ -218,7 +245,7 @@ func (r *ProviderRegistry) resolveProviderConfig(provider, modelOverride string)
 		if apiKey == "" {
 			apiKey = os.Getenv("OPENAI_API_KEY")
 		}
-		if apiKey == "" {
+		if apiKey == "" && !r.config.Replay {
 			return nil, fmt.Errorf("openai API key not configured")
 		}
 		key.APIKey = apiKey
@@ -247,6 +274,44 @@ func (r *ProviderRegistry) resolveProviderConfig(provider, modelOverride string)
 			key.Model = defaultModel
 		}
 
+	case ProviderGemini:
+		// Get API key from config or environment
+		apiKey := geminiAPIKey(r.config)
+		if apiKey == "" && !r.config.Replay {
+			return nil, fmt.Errorf("gemini API key not configured")
+		}
+		key.APIKey = apiKey
+
+		// Get base URL from config or environment (empty uses the public endpoint)
+		baseURL := r.config.GeminiBaseURL
+		if baseURL == "" {
+			baseURL = os.Getenv("GEMINI_BASE_URL")
+		}
+		key.BaseURL = baseURL
+
+		// Get model from config or environment, or use override
+		defaultModel := r.config.GeminiModel
+		if defaultModel == "" {
+			defaultModel = os.Getenv("GEMINI_MODEL")
+		}
+		if defaultModel == "" {
+			defaultModel = "gemini-2.5-flash" // Default Gemini model
+		}
+		if key.Model == "" {
+			key.Model = defaultModel
+		}
+
+	case ProviderMock:
+		if r.config.MockScriptPath == "" {
+			return nil, fmt.Errorf("mock script path not configured")
+		}
+		key.ScriptPath = r.config.MockScriptPath
+		// Each agent replays its own turns, so clients must not be shared between agents
+		key.AgentID = agentID
+		if key.Model == "" {
+			key.Model = "mock"
+		}
+
 	default:
 		return nil, fmt.Errorf("unknown provider: %s", provider)
 	}
@@ -254,6 +319,18 @@ func (r *ProviderRegistry) resolveProviderConfig(provider, modelOverride string)
 	return key, nil
 }
 
+// geminiAPIKey returns the Gemini API key from config, falling back to the
+// GEMINI_API_KEY and GOOGLE_API_KEY environment variables.
+func geminiAPIKey(cfg *ProviderConfig) string {
+	if cfg.GeminiAPIKey != "" {
+		return cfg.GeminiAPIKey
+	}
+	if apiKey := os.Getenv("GEMINI_API_KEY"); apiKey != "" {
+		return apiKey
+	}
+	return os.Getenv("GOOGLE_API_KEY")
+}
+
 // getEnabledProvidersList returns a list of enabled providers (for error messages).
 func (r *ProviderRegistry) getEnabledProvidersList() []string {
 	var providers []string
@@ -126,3 +126,76 @@ func TestProviderRegistry_ResolveAgentLLMConfig_NoAvailableProvider(t *testing.T
 		t.Error("Expected error when no providers are enabled")
 	}
 }
+
+func TestProviderRegistry_Gemini(t *testing.T) {
+	t.Setenv("GEMINI_API_KEY", "")
+	t.Setenv("GOOGLE_API_KEY", "")
+	t.Setenv("GEMINI_MODEL", "")
+
+	registry := NewProviderRegistry(&ProviderConfig{}, []string{ProviderGemini})
+	if registry.IsProviderConfigured(ProviderGemini) {
+		t.Error("gemini should not be configured without API key")
+	}
+
+	// GOOGLE_API_KEY is accepted as a fallback
+	t.Setenv("GOOGLE_API_KEY", "env-key")
+	if !registry.IsProviderConfigured(ProviderGemini) {
+		t.Error("gemini should be configured from GOOGLE_API_KEY")
+	}
+
+	key, err := registry.ResolveAgentLLMConfig("test-agent", AgentLLMConfig{
+		LLMPreferences: []LLMPreference{{Provider: ProviderGemini}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to resolve config: %v", err)
+	}
+	if key.APIKey != "env-key" {
+		t.Errorf("Expected API key 'env-key', got '%s'", key.APIKey)
+	}
+	if key.Model != "gemini-2.5-flash" {
+		t.Errorf("Expected default model 'gemini-2.5-flash', got '%s'", key.Model)
+	}
+}
+
+func TestProviderRegistry_Mock(t *testing.T) {
+	registry := NewProviderRegistry(&ProviderConfig{}, []string{ProviderMock})
+	if registry.IsProviderConfigured(ProviderMock) {
+		t.Error("mock should not be configured without a script")
+	}
+
+	registry = NewProviderRegistry(&ProviderConfig{MockScriptPath: "/tmp/script.yaml"}, []string{ProviderMock})
+	key, err := registry.ResolveAgentLLMConfig("test-agent", AgentLLMConfig{})
+	if err != nil {
+		t.Fatalf("Failed to resolve config: %v", err)
+	}
+
+	// Scripts are keyed by agent, so the key must carry the agent ID
+	if key.AgentID != "test-agent" {
+		t.Errorf("Expected agent ID 'test-agent', got '%s'", key.AgentID)
+	}
+	if key.ScriptPath != "/tmp/script.yaml" {
+		t.Errorf("Expected script path '/tmp/script.yaml', got '%s'", key.ScriptPath)
+	}
+}
+
+func TestProviderRegistry_ReplayNeedsNoCredentials(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "")
+	t.Setenv("GEMINI_API_KEY", "")
+	t.Setenv("GOOGLE_API_KEY", "")
+	registry := NewProviderRegistry(&ProviderConfig{Replay: true}, []string{"anthropic", "openai", "gemini"})
+
+	for _, provider := range []string{"anthropic", "openai", "gemini"} {
+		if !registry.IsProviderConfigured(provider) {
+			t.Errorf("%s should be configured when replaying", provider)
+		}
+		key, err := registry.ResolveAgentLLMConfig("agent", AgentLLMConfig{
+			LLMPreferences: []LLMPreference{{Provider: provider, Model: "some-model"}},
+		})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", provider, err)
+		}
+		if !key.Replay || key.APIKey != "" {
+			t.Errorf("%s: expected a credential-free replay key, got %+v", provider, key)
+		}
+	}
+}
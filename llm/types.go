@@ -18,15 +18,18 @@ const (
 type Message struct {
 	Role    MessageRole
 	Content []ContentBlock
+	Pinned  bool // Pinned messages survive context compression verbatim; providers ignore this flag
 }
 
 // ContentBlock represents a single content block within a message.
-// It can be text, a tool use, or a tool result.
+// It can be text, a tool use, a tool result, media (image or document), or model reasoning.
 type ContentBlock struct {
 	Type       ContentBlockType
 	Text       string           // For text blocks
 	ToolUse    *ToolUseBlock    // For tool use blocks
 	ToolResult *ToolResultBlock // For tool result blocks
+	Media      *MediaBlock      // For image and document blocks
+	Reasoning  *ReasoningBlock  // For reasoning blocks
 }
 
 // ContentBlockType represents the type of content block.
@@ -36,8 +39,20 @@ const (
 	ContentBlockTypeText       ContentBlockType = "text"
 	ContentBlockTypeToolUse    ContentBlockType = "tool_use"
 	ContentBlockTypeToolResult ContentBlockType = "tool_result"
+	ContentBlockTypeImage      ContentBlockType = "image"
+	ContentBlockTypeDocument   ContentBlockType = "document"
+	ContentBlockTypeReasoning  ContentBlockType = "reasoning"
 )
 
+// ReasoningBlock represents the model's thinking that precedes its answer.
+// Some providers require reasoning to be sent back unchanged within a tool loop,
+// so the opaque Signature and RedactedData fields must be preserved as received.
+type ReasoningBlock struct {
+	Text         string // Reasoning text (empty for redacted reasoning)
+	Signature    string // Provider signature verifying the reasoning (Anthropic)
+	RedactedData string // Encrypted reasoning the provider did not expose (Anthropic)
+}
+
 // ToolUseBlock represents a tool invocation request from the assistant.
 type ToolUseBlock struct {
 	ID    string
@@ -50,6 +65,7 @@ type ToolResultBlock struct {
 	ID      string
 	Content string // JSON-serialized result
 	IsError bool
+	Media   []MediaBlock // Optional images or documents returned by the tool
 }
 
 // ToolSpec represents a tool definition that can be provided to an LLM.
@@ -75,6 +91,9 @@ type Request struct {
 	Tools       []ToolSpec
 	MaxTokens   int64
 	Temperature *float64 // Optional temperature override
+	// ThinkingBudget is the token budget for extended thinking/reasoning.
+	// Zero disables reasoning; providers without token budgets map it to an effort level.
+	ThinkingBudget int64
 }
 
 // Response represents a complete LLM API response.
@@ -96,9 +115,10 @@ type Usage struct {
 // StreamDelta represents a single delta in a streaming response.
 type StreamDelta struct {
 	Type      StreamDeltaType
-	Text      string        // For text deltas
-	ToolUse   *ToolUseBlock // For tool use start
-	ToolInput string        // For tool input JSON deltas
+	Text      string          // For text deltas
+	ToolUse   *ToolUseBlock   // For tool use start
+	ToolInput string          // For tool input JSON deltas
+	Reasoning *ReasoningBlock // For reasoning deltas; a content_block event starts a new reasoning block
 }
 
 // StreamDeltaType represents the type of streaming delta.
@@ -108,14 +128,16 @@ const (
 	StreamDeltaTypeText      StreamDeltaType = "text"
 	StreamDeltaTypeToolUse   StreamDeltaType = "tool_use"
 	StreamDeltaTypeToolInput StreamDeltaType = "tool_input"
+	StreamDeltaTypeReasoning StreamDeltaType = "reasoning"
 )
 
 // StreamEvent represents a complete streaming event.
 type StreamEvent struct {
-	Type  StreamEventType
-	Delta *StreamDelta
-	Usage *Usage
-	Done  bool
+	Type       StreamEventType
+	Delta      *StreamDelta
+	Usage      *Usage
+	Done       bool
+	StopReason string // Why generation stopped; set on message_delta and stop events
 }
 
 // StreamEventType represents the type of streaming event.
@@ -172,6 +194,14 @@ func NewToolResultMessage(toolResults []ToolResultBlock) Message {
 	}
 }
 
+// NewReasoningContentBlock creates a reasoning content block.
+func NewReasoningContentBlock(reasoning ReasoningBlock) ContentBlock {
+	return ContentBlock{
+		Type:      ContentBlockTypeReasoning,
+		Reasoning: &reasoning,
+	}
+}
+
 // ToJSON marshals a message to JSON for debugging/logging purposes.
 func (m Message) ToJSON() ([]byte, error) {
 	return json.Marshal(m)
@@ -83,3 +83,17 @@ func TestMessageToJSON(t *testing.T) {
 		t.Errorf("Expected role %v, got %v", msg.Role, decoded.Role)
 	}
 }
+
+func TestReasoningEffort(t *testing.T) {
+	tests := map[int64]string{
+		0:     "",
+		1024:  ReasoningEffortLow,
+		8000:  ReasoningEffortMedium,
+		32000: ReasoningEffortHigh,
+	}
+	for budget, want := range tests {
+		if got := ReasoningEffort(budget); got != want {
+			t.Errorf("ReasoningEffort(%d) = %q, want %q", budget, got, want)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// ErrArtifactNotFound is returned when an artifact or artifact version does not exist.
+var ErrArtifactNotFound = errors.New("artifact not found")
+
+// defaultArtifactListLimit caps ListArtifacts when no limit is given.
+const defaultArtifactListLimit = 50
+
+// ArtifactFilter selects artifacts for ListArtifacts.
+type ArtifactFilter struct {
+	// Artifacts visible to this agent: global ones and its own agent-scoped ones (nil = all)
+	AgentID  *string
+	Scope    Scope   // Only this scope (empty = any)
+	ThreadID *string // Only artifacts linked to this thread
+	Query    string  // Case-insensitive substring of the title or body
+	Limit    int     // Maximum number of artifacts (0 = default)
+}
+
+// ArtifactUpdate revises an artifact. Nil fields are left unchanged.
+type ArtifactUpdate struct {
+	Title    *string
+	Body     *string
+	Metadata map[string]interface{} // Merged into the existing metadata; nil values remove keys
+}
+
+// selectArtifactColumns is the column list scanned by scanArtifact.
+var selectArtifactColumns = []string{
+	"id", "agent_id", "thread_id", "scope", "title", "body", "metadata", "version", "created_at", "updated_at",
+}
+
+// GetArtifact returns the current revision of an artifact.
+func (s *Store) GetArtifact(ctx context.Context, id int64) (*Artifact, error) {
+	query, args, err := StatementBuilder().
+		Select(selectArtifactColumns...).
+		From("artifacts").
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+	a, err := scanArtifact(s.db.QueryRowContext(ctx, query, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("artifact %d: %w", id, ErrArtifactNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load artifact: %w", err)
+	}
+	return a, nil
+}
+
+// ListArtifacts returns artifacts matching f, most recently updated first.
+func (s *Store) ListArtifacts(ctx context.Context, f ArtifactFilter) ([]*Artifact, error) {
+	limit := f.Limit
+	if limit <= 0 {
+		limit = defaultArtifactListLimit
+	}
+	q := StatementBuilder().
+		Select(selectArtifactColumns...).
+		From("artifacts").
+		OrderBy("updated_at DESC", "id DESC").
+		Limit(uint64(limit)) //nolint:gosec // limit is positive
+	if f.AgentID != nil {
+		q = q.Where(sq.Or{
+			sq.Eq{"scope": string(ScopeGlobal)},
+			sq.Eq{"agent_id": *f.AgentID},
+		})
+	}
+	if f.Scope != "" {
+		q = q.Where(sq.Eq{"scope": string(f.Scope)})
+	}
+	if f.ThreadID != nil {
+		q = q.Where(sq.Eq{"thread_id": *f.ThreadID})
+	}
+	if text := strings.TrimSpace(f.Query); text != "" {
+		pattern := "%" + strings.ToLower(text) + "%"
+		q = q.Where(sq.Or{
+			sq.Like{"LOWER(title)": pattern},
+			sq.Like{"LOWER(body)": pattern},
+		})
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query artifacts: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck // No remedy for rows close errors
+
+	var artifacts []*Artifact
+	for rows.Next() {
+		a, err := scanArtifact(rows)
+		if err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, a)
+	}
+	return artifacts, rows.Err()
+}
+
+// UpdateArtifact records a new revision of an artifact written by agentID (nil for the
+// user) and returns it. Earlier revisions stay available from ArtifactVersions.
+func (s *Store) UpdateArtifact(ctx context.Context, id int64, agentID *string, upd ArtifactUpdate) (*Artifact, error) {
+	existing, err := s.GetArtifact(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *existing
+	if upd.Title != nil {
+		if strings.TrimSpace(*upd.Title) == "" {
+			return nil, errors.New("title is empty")
+		}
+		updated.Title = *upd.Title
+	}
+	if upd.Body != nil {
+		if strings.TrimSpace(*upd.Body) == "" {
+			return nil, errors.New("body is empty")
+		}
+		updated.Body = *upd.Body
+	}
+	if len(upd.Metadata) > 0 {
+		updated.Metadata = make(map[string]interface{}, len(existing.Metadata)+len(upd.Metadata))
+		for k, v := range existing.Metadata {
+			updated.Metadata[k] = v
+		}
+		for k, v := range upd.Metadata {
+			if v == nil {
+				delete(updated.Metadata, k)
+				continue
+			}
+			updated.Metadata[k] = v
+		}
+	}
+	metaJSON, err := marshalOptional(updated.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("marshal metadata: %w", err)
+	}
+	nowUnix := now()
+	updated.UpdatedAt = time.Unix(nowUnix, 0)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Bump the version in the database so concurrent updates get distinct revisions
+	if err := tx.QueryRowContext(ctx, `
+UPDATE artifacts
+SET title = ?, body = ?, metadata = ?, version = version + 1, updated_at = ?
+WHERE id = ?
+RETURNING version
+`, updated.Title, updated.Body, metaJSON, nowUnix, id).Scan(&updated.Version); err != nil {
+		return nil, fmt.Errorf("update artifact: %w", err)
+	}
+	var author interface{}
+	if agentID != nil {
+		author = *agentID
+	}
+	if err := insertArtifactVersion(ctx, tx, id, updated.Version, author, updated.Title, updated.Body, metaJSON, nowUnix); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().
+		Int64("id", id).
+		Int("version", updated.Version).
+		Str("agent_id", derefString(agentID)).
+		Msg("Artifact updated")
+	return &updated, nil
+}
+
+// ArtifactVersions returns every revision of an artifact, oldest first.
+func (s *Store) ArtifactVersions(ctx context.Context, id int64) ([]ArtifactVersion, error) {
+	rows, err := s.db.QueryContext(ctx, `
+SELECT artifact_id, version, agent_id, title, body, metadata, created_at
+FROM artifact_versions
+WHERE artifact_id = ?
+ORDER BY version
+`, id)
+	if err != nil {
+		return nil, fmt.Errorf("query artifact versions: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck // No remedy for rows close errors
+
+	var versions []ArtifactVersion
+	for rows.Next() {
+		v, err := scanArtifactVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, *v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("artifact %d: %w", id, ErrArtifactNotFound)
+	}
+	return versions, nil
+}
+
+// GetArtifactVersion returns one revision of an artifact.
+func (s *Store) GetArtifactVersion(ctx context.Context, id int64, version int) (*ArtifactVersion, error) {
+	v, err := scanArtifactVersion(s.db.QueryRowContext(ctx, `
+SELECT artifact_id, version, agent_id, title, body, metadata, created_at
+FROM artifact_versions
+WHERE artifact_id = ? AND version = ?
+`, id, version))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("artifact %d version %d: %w", id, version, ErrArtifactNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load artifact version: %w", err)
+	}
+	return v, nil
+}
+
+// insertArtifactVersion records a revision of an artifact within tx.
+func insertArtifactVersion(
+	ctx context.Context,
+	tx *sql.Tx,
+	artifactID int64,
+	version int,
+	agentID interface{},
+	title, body string,
+	metaJSON []byte,
+	createdAt int64,
+) error {
+	if _, err := tx.ExecContext(ctx, `
+INSERT INTO artifact_versions (artifact_id, version, agent_id, title, body, metadata, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+`, artifactID, version, agentID, title, body, metaJSON, createdAt); err != nil {
+		return fmt.Errorf("insert artifact version: %w", err)
+	}
+	return nil
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanArtifact reads an artifact selected with selectArtifactColumns.
+func scanArtifact(row rowScanner) (*Artifact, error) {
+	var (
+		a                    Artifact
+		agentID, threadID    sql.NullString
+		scope                string
+		title, metaJSON      sql.NullString
+		createdAt, updatedAt int64
+	)
+	if err := row.Scan(&a.ID, &agentID, &threadID, &scope, &title, &a.Body, &metaJSON,
+		&a.Version, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	if agentID.Valid {
+		a.AgentID = &agentID.String
+	}
+	if threadID.Valid {
+		a.ThreadID = &threadID.String
+	}
+	a.Scope = Scope(scope)
+	a.Title = title.String
+	a.Metadata = unmarshalMetadata(metaJSON)
+	a.CreatedAt = time.Unix(createdAt, 0)
+	a.UpdatedAt = time.Unix(updatedAt, 0)
+	return &a, nil
+}
+
+// scanArtifactVersion reads an artifact_versions row.
+func scanArtifactVersion(row rowScanner) (*ArtifactVersion, error) {
+	var (
+		v               ArtifactVersion
+		agentID         sql.NullString
+		title, metaJSON sql.NullString
+		createdAt       int64
+	)
+	if err := row.Scan(&v.ArtifactID, &v.Version, &agentID, &title, &v.Body, &metaJSON, &createdAt); err != nil {
+		return nil, err
+	}
+	if agentID.Valid {
+		v.AgentID = &agentID.String
+	}
+	v.Title = title.String
+	v.Metadata = unmarshalMetadata(metaJSON)
+	v.CreatedAt = time.Unix(createdAt, 0)
+	return &v, nil
+}
+
+// unmarshalMetadata decodes a metadata column, ignoring malformed JSON.
+func unmarshalMetadata(metaJSON sql.NullString) map[string]interface{} {
+	if !metaJSON.Valid || metaJSON.String == "" {
+		return nil
+	}
+	var meta map[string]interface{}
+	if err := json.Unmarshal([]byte(metaJSON.String), &meta); err != nil {
+		return nil
+	}
+	return meta
+}
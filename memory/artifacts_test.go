@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestUpdateArtifact_KeepsVersions(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	store, err := NewStore(db, nil, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	author := "researcher"
+	created, err := store.CreateArtifact(ctx, ScopeGlobal, &author, nil, "Weekly report", "Draft", nil)
+	if err != nil {
+		t.Fatalf("CreateArtifact: %v", err)
+	}
+	if created.Version != 1 {
+		t.Fatalf("expected version 1, got %d", created.Version)
+	}
+
+	editor := "reviewer"
+	body := "Final"
+	updated, err := store.UpdateArtifact(ctx, created.ID, &editor, ArtifactUpdate{
+		Body:     &body,
+		Metadata: map[string]interface{}{"status": "done"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateArtifact: %v", err)
+	}
+	if updated.Version != 2 || updated.Body != "Final" || updated.Title != "Weekly report" {
+		t.Fatalf("unexpected updated artifact: %+v", updated)
+	}
+
+	current, err := store.GetArtifact(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetArtifact: %v", err)
+	}
+	if current.Version != 2 || current.Body != "Final" || current.Metadata["status"] != "done" {
+		t.Fatalf("unexpected current artifact: %+v", current)
+	}
+
+	versions, err := store.ArtifactVersions(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("ArtifactVersions: %v", err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("expected 2 versions, got %d", len(versions))
+	}
+	if versions[0].Body != "Draft" || *versions[0].AgentID != author {
+		t.Errorf("unexpected first version: %+v", versions[0])
+	}
+	if versions[1].Body != "Final" || *versions[1].AgentID != editor {
+		t.Errorf("unexpected second version: %+v", versions[1])
+	}
+
+	if _, err := store.GetArtifactVersion(ctx, created.ID, 3); !errors.Is(err, ErrArtifactNotFound) {
+		t.Errorf("expected ErrArtifactNotFound for a missing version, got %v", err)
+	}
+}
+
+func TestMemoryRouter_ArtifactVisibility(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	store, err := NewStore(db, nil, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	router := NewMemoryRouter(store, Config{}, zerolog.Nop())
+
+	owner := "owner"
+	shared, err := router.AddArtifact(ctx, &owner, "Handoff", "Shared notes", nil)
+	if err != nil {
+		t.Fatalf("AddArtifact: %v", err)
+	}
+	private, err := router.AddAgentArtifact(ctx, owner, "Scratchpad", "Private notes", nil)
+	if err != nil {
+		t.Fatalf("AddAgentArtifact: %v", err)
+	}
+
+	if _, err := router.ReadArtifact(ctx, "other", shared.ID); err != nil {
+		t.Errorf("expected shared artifact to be readable: %v", err)
+	}
+	if _, err := router.ReadArtifact(ctx, "other", private.ID); !errors.Is(err, ErrArtifactNotFound) {
+		t.Errorf("expected private artifact to be hidden, got %v", err)
+	}
+	if _, err := router.ReadArtifact(ctx, owner, private.ID); err != nil {
+		t.Errorf("expected owner to read private artifact: %v", err)
+	}
+
+	others, err := router.ListArtifacts(ctx, "other", "", 0)
+	if err != nil {
+		t.Fatalf("ListArtifacts: %v", err)
+	}
+	if len(others) != 1 || others[0].ID != shared.ID {
+		t.Errorf("expected only the shared artifact, got %d artifacts", len(others))
+	}
+	matches, err := router.ListArtifacts(ctx, owner, "private", 0)
+	if err != nil {
+		t.Fatalf("ListArtifacts: %v", err)
+	}
+	if len(matches) != 1 || matches[0].ID != private.ID {
+		t.Errorf("expected the query to match the private artifact, got %d artifacts", len(matches))
+	}
+}
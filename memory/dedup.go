@@ -0,0 +1,486 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DedupConfig controls how new facts and personal memories are reconciled with existing ones.
+// A new memory nearly identical to an existing item in the same scope is merged into it. One
+// that is close but not identical, such as a correction or an updated preference, supersedes
+// the existing item when they share a tag, or when either is untagged and both have the same
+// memory type. Related facts with neither are kept side by side.
+type DedupConfig struct {
+	Enabled            bool
+	DuplicateThreshold float64 // Cosine similarity at or above which memories are merged
+	SupersedeThreshold float64 // Cosine similarity at or above which the new memory replaces the old
+}
+
+// DefaultDedupConfig returns the dedup settings a new Store starts with.
+func DefaultDedupConfig() DedupConfig {
+	return DedupConfig{
+		Enabled:            true,
+		DuplicateThreshold: 0.95,
+		SupersedeThreshold: 0.85,
+	}
+}
+
+// SetDedupConfig changes how new memories are reconciled with existing ones.
+func (s *Store) SetDedupConfig(cfg DedupConfig) {
+	s.dedup = cfg
+}
+
+// Version reasons recorded in memory_item_versions.
+const (
+	versionMerged     = "merged"
+	versionSuperseded = "superseded"
+	versionEdited     = "edited"
+)
+
+// dedupAction is what to do with a memory that matches an existing item.
+type dedupAction int
+
+const (
+	dedupNone      dedupAction = iota
+	dedupMerge                 // Update the existing item in place
+	dedupSupersede             // Store a new item that replaces the existing one
+)
+
+// dedupCandidate describes a memory to match against existing items.
+type dedupCandidate struct {
+	id         int64 // Set for stored items, which are only matched against older ones
+	typ        MemoryType
+	scope      Scope
+	agentID    *string
+	group      string
+	memoryType string
+	tags       []string
+	embedding  []float32
+}
+
+// ConsolidationResult summarizes a consolidation pass.
+type ConsolidationResult struct {
+	Scanned    int
+	Merged     int
+	Superseded int
+}
+
+// findDuplicate looks for an active item that c duplicates or replaces. Only facts and
+// personal memories are deduplicated; episodes record events that legitimately repeat.
+func (s *Store) findDuplicate(ctx context.Context, c dedupCandidate) (*MemoryItem, dedupAction, error) {
+	if !s.dedup.Enabled || len(c.embedding) == 0 || (c.typ != MemoryTypeFact && c.typ != MemoryTypeProfile) {
+		return nil, dedupNone, nil
+	}
+
+	q := &SearchQuery{
+		QueryEmbedding: c.embedding,
+		AgentID:        c.agentID,
+		IncludeGlobal:  c.scope == ScopeGlobal,
+		Types:          []MemoryType{c.typ},
+	}
+	if c.scope == ScopeGlobal {
+		q.AgentID = nil
+	}
+	if c.scope == ScopeGroup {
+		q.AgentID = nil
+		q.Groups = []string{c.group}
+	}
+	if c.memoryType != "" {
+		q.MemoryTypes = []string{c.memoryType}
+	}
+	matches, err := s.searchByVector(ctx, q, 10)
+	if err != nil {
+		return nil, dedupNone, fmt.Errorf("find similar memories: %w", err)
+	}
+
+	for _, m := range matches {
+		if m.Item.Scope != c.scope || derefString(m.Item.AgentID) != derefString(c.agentID) || m.Item.Group != c.group {
+			continue
+		}
+		if c.id != 0 && m.Item.ID >= c.id {
+			continue
+		}
+		switch {
+		case m.Score >= s.dedup.DuplicateThreshold:
+			return m.Item, dedupMerge, nil
+		case m.Score >= s.dedup.SupersedeThreshold && sameSubject(m.Item, c):
+			return m.Item, dedupSupersede, nil
+		}
+	}
+	return nil, dedupNone, nil
+}
+
+// mergeInto replaces an existing item's content with a near-duplicate restatement. The
+// previous content is kept as a version; tags and metadata are combined and the higher
+// importance wins.
+func (s *Store) mergeInto(
+	ctx context.Context,
+	existing *MemoryItem,
+	content, rawContent string,
+	tags []string,
+	importance float64,
+	embedding []float32,
+	metadata map[string]interface{},
+	within writeHook,
+) (MemoryItem, error) {
+	merged := *existing
+	merged.Content = content
+	if rawContent != "" {
+		merged.RawContent = rawContent
+	}
+	merged.Tags = unionTags(existing.Tags, tags)
+	merged.Importance = max(existing.Importance, importance)
+	merged.Embedding = embedding
+	merged.EmbeddingModel, merged.EmbeddingDim = "", 0
+	if s.embedder != nil && len(embedding) > 0 {
+		merged.EmbeddingModel, merged.EmbeddingDim = s.embedder.Name(), len(embedding)
+	}
+	if len(metadata) > 0 {
+		merged.Metadata = make(map[string]interface{}, len(existing.Metadata)+len(metadata))
+		for k, v := range existing.Metadata {
+			merged.Metadata[k] = v
+		}
+		for k, v := range metadata {
+			merged.Metadata[k] = v
+		}
+	}
+	nowUnix := now()
+	merged.UpdatedAt = time.Unix(nowUnix, 0)
+	// Restating a memory renews it
+	expiresAt := s.expiresAt(merged.Type, merged.MemoryType, nowUnix)
+	merged.ExpiresAt = nil
+	if exp, ok := expiresAt.(int64); ok {
+		t := time.Unix(exp, 0)
+		merged.ExpiresAt = &t
+	}
+
+	metaJSON, err := marshalOptional(merged.Metadata)
+	if err != nil {
+		return MemoryItem{}, fmt.Errorf("marshal metadata: %w", err)
+	}
+	tagsJSON, err := marshalOptional(merged.Tags)
+	if err != nil {
+		return MemoryItem{}, fmt.Errorf("marshal tags: %w", err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return MemoryItem{}, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := recordVersion(ctx, tx, existing.ID, existing, versionMerged, nowUnix); err != nil {
+		return MemoryItem{}, err
+	}
+
+	embModel, embDim := s.embeddingInfo(embedding)
+	if _, err := tx.ExecContext(ctx, `
+UPDATE memory_items
+SET content = ?, raw_content = ?, tags_json = ?, importance = ?, metadata = ?,
+    embedding = ?, embedding_model = ?, embedding_dim = ?, updated_at = ?,
+    expires_at = ?, decayed_at = ?
+WHERE id = ?
+`, merged.Content, nullIfEmpty(merged.RawContent), tagsJSON, merged.Importance, metaJSON,
+		EncodeEmbedding(embedding), embModel, embDim, nowUnix,
+		expiresAt, nowUnix, existing.ID); err != nil {
+		return MemoryItem{}, fmt.Errorf("update merged memory_item: %w", err)
+	}
+	if within != nil {
+		if err := within(ctx, tx, existing.ID); err != nil {
+			return MemoryItem{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return MemoryItem{}, err
+	}
+	s.indexItem(existing.ID, embedding)
+
+	s.logger.Info().
+		Int64("id", existing.ID).
+		Str("content", truncateString(content, 40)).
+		Msg("Merged duplicate memory into existing item")
+	return merged, nil
+}
+
+// supersede marks old as replaced by the item newID within tx, carrying old's version
+// history over to the new item.
+func supersede(ctx context.Context, tx *sql.Tx, old *MemoryItem, newID int64, reason string, nowUnix int64) error {
+	if _, err := tx.ExecContext(ctx, `
+INSERT INTO memory_item_versions
+    (item_id, source_item_id, content, raw_content, tags_json, importance, reason, created_at, replaced_at)
+SELECT ?, source_item_id, content, raw_content, tags_json, importance, reason, created_at, replaced_at
+FROM memory_item_versions
+WHERE item_id = ?
+`, newID, old.ID); err != nil {
+		return fmt.Errorf("copy memory versions: %w", err)
+	}
+	if err := recordVersion(ctx, tx, newID, old, reason, nowUnix); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+UPDATE memory_items SET superseded_by = ?, updated_at = ? WHERE id = ?
+`, newID, nowUnix, old.ID); err != nil {
+		return fmt.Errorf("mark memory_item superseded: %w", err)
+	}
+	return nil
+}
+
+// recordVersion saves from's current content as an earlier version of itemID.
+func recordVersion(ctx context.Context, tx *sql.Tx, itemID int64, from *MemoryItem, reason string, replacedAt int64) error {
+	tagsJSON, err := marshalOptional(from.Tags)
+	if err != nil {
+		return fmt.Errorf("marshal tags: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+INSERT INTO memory_item_versions
+    (item_id, source_item_id, content, raw_content, tags_json, importance, reason, created_at, replaced_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+`, itemID, from.ID, from.Content, nullIfEmpty(from.RawContent), tagsJSON, from.Importance,
+		reason, from.UpdatedAt.Unix(), replacedAt); err != nil {
+		return fmt.Errorf("insert memory version: %w", err)
+	}
+	return nil
+}
+
+// MemoryHistory returns the earlier versions of a memory item, oldest first. It includes
+// restatements merged into the item and the items it superseded.
+func (s *Store) MemoryHistory(ctx context.Context, id int64) ([]MemoryVersion, error) {
+	rows, err := s.db.QueryContext(ctx, `
+SELECT item_id, source_item_id, content, raw_content, tags_json, importance, reason, created_at, replaced_at
+FROM memory_item_versions
+WHERE item_id = ?
+ORDER BY replaced_at, id
+`, id)
+	if err != nil {
+		return nil, fmt.Errorf("query memory versions: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck // No remedy for rows close errors
+
+	var versions []MemoryVersion
+	for rows.Next() {
+		var (
+			v                     MemoryVersion
+			rawContent, tagsJSON  sql.NullString
+			createdAt, replacedAt int64
+		)
+		if err := rows.Scan(&v.ItemID, &v.SourceItemID, &v.Content, &rawContent, &tagsJSON,
+			&v.Importance, &v.Reason, &createdAt, &replacedAt); err != nil {
+			return nil, err
+		}
+		v.RawContent = rawContent.String
+		if tagsJSON.Valid && tagsJSON.String != "" {
+			_ = json.Unmarshal([]byte(tagsJSON.String), &v.Tags)
+		}
+		v.CreatedAt = time.Unix(createdAt, 0)
+		v.ReplacedAt = time.Unix(replacedAt, 0)
+		versions = append(versions, v)
+	}
+	return versions, rows.Err()
+}
+
+// ConsolidateMemories deduplicates memories that are already stored, oldest first. When two
+// active items match, the older one is superseded by the newer one, which keeps the older
+// item's history, the higher importance and, for duplicates, the union of their tags.
+func (s *Store) ConsolidateMemories(ctx context.Context) (ConsolidationResult, error) {
+	var result ConsolidationResult
+	if !s.dedup.Enabled {
+		return result, nil
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+SELECT id FROM memory_items
+WHERE superseded_by IS NULL AND embedding IS NOT NULL AND type IN (?, ?)
+  AND (memory_type IS NULL OR memory_type != ?)
+ORDER BY id
+`, string(MemoryTypeFact), string(MemoryTypeProfile), ProfileMemoryType)
+	if err != nil {
+		return result, fmt.Errorf("query memories to consolidate: %w", err)
+	}
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return result, err
+		}
+		ids = append(ids, id)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return result, err
+	}
+
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		items, err := s.loadItemsByIDs(ctx, []int64{id})
+		if err != nil {
+			return result, err
+		}
+		if len(items) == 0 || items[0].SupersededBy != nil {
+			continue
+		}
+		item := items[0]
+		result.Scanned++
+
+		older, action, err := s.findDuplicate(ctx, dedupCandidate{
+			id:         item.ID,
+			typ:        item.Type,
+			scope:      item.Scope,
+			agentID:    item.AgentID,
+			group:      item.Group,
+			memoryType: item.MemoryType,
+			tags:       item.Tags,
+			embedding:  item.Embedding,
+		})
+		if err != nil {
+			return result, err
+		}
+		if action == dedupNone || !s.comparableEmbedding(older, item.Embedding) {
+			continue
+		}
+		if err := s.absorb(ctx, older, item, action); err != nil {
+			return result, err
+		}
+		if action == dedupMerge {
+			result.Merged++
+		} else {
+			result.Superseded++
+		}
+	}
+
+	s.logger.Info().
+		Int("scanned", result.Scanned).
+		Int("merged", result.Merged).
+		Int("superseded", result.Superseded).
+		Msg("Memory consolidation complete")
+	return result, nil
+}
+
+// absorb supersedes older with newer during consolidation.
+func (s *Store) absorb(ctx context.Context, older, newer *MemoryItem, action dedupAction) error {
+	reason := versionSuperseded
+	tags := newer.Tags
+	if action == dedupMerge {
+		reason = versionMerged
+		tags = unionTags(older.Tags, newer.Tags)
+	}
+	tagsJSON, err := marshalOptional(tags)
+	if err != nil {
+		return fmt.Errorf("marshal tags: %w", err)
+	}
+	nowUnix := now()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := supersede(ctx, tx, older, newer.ID, reason, nowUnix); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+UPDATE memory_items SET importance = ?, tags_json = ? WHERE id = ?
+`, max(older.Importance, newer.Importance), tagsJSON, newer.ID); err != nil {
+		return fmt.Errorf("update consolidated memory_item: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.unindexItem(older.ID)
+
+	s.logger.Debug().
+		Int64("older", older.ID).
+		Int64("newer", newer.ID).
+		Str("reason", reason).
+		Msg("Consolidated memory items")
+	return nil
+}
+
+// RunConsolidation consolidates memories every interval until ctx is cancelled.
+func (s *Store) RunConsolidation(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.ConsolidateMemories(ctx); err != nil && ctx.Err() == nil {
+				s.logger.Error().Err(err).Msg("Memory consolidation failed")
+			}
+		}
+	}
+}
+
+// sameSubject reports whether c is about the same thing as an existing item closely enough
+// to replace it. Tagged memories must share a tag; otherwise both need the same memory type,
+// so untagged facts never supersede each other on similarity alone.
+func sameSubject(existing *MemoryItem, c dedupCandidate) bool {
+	if len(existing.Tags) > 0 && len(c.tags) > 0 {
+		return tagsOverlap(existing.Tags, c.tags)
+	}
+	return c.memoryType != "" && existing.MemoryType == c.memoryType
+}
+
+// tagsOverlap reports whether two tag lists share a tag.
+func tagsOverlap(a, b []string) bool {
+	seen := make(map[string]bool, len(a))
+	for _, t := range a {
+		seen[strings.ToLower(strings.TrimSpace(t))] = true
+	}
+	for _, t := range b {
+		if seen[strings.ToLower(strings.TrimSpace(t))] {
+			return true
+		}
+	}
+	return false
+}
+
+// unionTags combines tag lists, keeping the first spelling of each tag.
+func unionTags(a, b []string) []string {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+	seen := make(map[string]bool, len(a)+len(b))
+	out := make([]string, 0, len(a)+len(b))
+	for _, t := range append(append([]string(nil), a...), b...) {
+		key := strings.ToLower(strings.TrimSpace(t))
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, t)
+	}
+	return out
+}
+
+// marshalOptional encodes v as JSON, or returns nil for an empty map or slice.
+func marshalOptional(v interface{}) ([]byte, error) {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		if len(x) == 0 {
+			return nil, nil
+		}
+	case []string:
+		if len(x) == 0 {
+			return nil, nil
+		}
+	}
+	return json.Marshal(v)
+}
+
+// nullIfEmpty returns nil for an empty string so it is stored as NULL.
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,276 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// mapEmbedder returns fixed vectors so tests control similarity exactly.
+type mapEmbedder map[string][]float32
+
+func (e mapEmbedder) Name() string { return "map" }
+
+func (e mapEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	v, ok := e[text]
+	if !ok {
+		return nil, fmt.Errorf("no vector for %q", text)
+	}
+	return v, nil
+}
+
+func countActiveItems(t *testing.T, store *Store) int {
+	t.Helper()
+	var n int
+	if err := store.db.QueryRow(`SELECT COUNT(*) FROM memory_items WHERE superseded_by IS NULL`).Scan(&n); err != nil {
+		t.Fatalf("count items: %v", err)
+	}
+	return n
+}
+
+func TestStorePersonalMemory_MergesDuplicates(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	store, err := NewStore(db, mapEmbedder{
+		"The user prefers morning meetings.":        {1, 0, 0},
+		"The user likes to have meetings mornings.": {0.99, 0.1, 0},
+	}, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	first, err := store.StorePersonalMemory(ctx, "agent", "I prefer morning meetings", "The user prefers morning meetings.",
+		"preference", []string{"meetings"}, nil, 0.6, nil)
+	if err != nil {
+		t.Fatalf("StorePersonalMemory: %v", err)
+	}
+	second, err := store.StorePersonalMemory(ctx, "agent", "mornings are best for meetings", "The user likes to have meetings mornings.",
+		"preference", []string{"schedule"}, nil, 0.9, nil)
+	if err != nil {
+		t.Fatalf("StorePersonalMemory: %v", err)
+	}
+
+	if second.ID != first.ID {
+		t.Fatalf("expected duplicate merged into item %d, got new item %d", first.ID, second.ID)
+	}
+	if n := countActiveItems(t, store); n != 1 {
+		t.Errorf("expected 1 stored item, got %d", n)
+	}
+	if second.Content != "The user likes to have meetings mornings." || second.Importance != 0.9 {
+		t.Errorf("expected latest content and highest importance, got %q (%.1f)", second.Content, second.Importance)
+	}
+	if len(second.Tags) != 2 {
+		t.Errorf("expected tags to be combined, got %v", second.Tags)
+	}
+
+	history, err := store.MemoryHistory(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("MemoryHistory: %v", err)
+	}
+	if len(history) != 1 || history[0].Content != "The user prefers morning meetings." || history[0].Reason != versionMerged {
+		t.Errorf("expected merged version with original content, got %+v", history)
+	}
+}
+
+func TestStorePersonalMemory_SupersedesRelatedMemories(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	embedder := mapEmbedder{
+		"The standup is at 9am.":      {1, 0, 0},
+		"The standup moved to 10am.":  {0.9, 0.43, 0},
+		"The standup is now at 11am.": {0.8, 0.3, 0.52},
+		"query":                       {1, 0.2, 0},
+	}
+	store, err := NewStore(db, embedder, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	agentID := "agent"
+	remember := func(content string) MemoryItem {
+		t.Helper()
+		item, err := store.StorePersonalMemory(ctx, agentID, "", content, "", []string{"standup"}, nil, 0.5, nil)
+		if err != nil {
+			t.Fatalf("StorePersonalMemory: %v", err)
+		}
+		return item
+	}
+
+	old := remember("The standup is at 9am.")
+	correction := remember("The standup moved to 10am.")
+	if correction.ID == old.ID {
+		t.Fatalf("expected a new item for the correction")
+	}
+
+	results, err := store.SearchMemory(ctx, &SearchQuery{QueryEmbedding: embedder["query"], AgentID: &agentID})
+	if err != nil {
+		t.Fatalf("SearchMemory: %v", err)
+	}
+	if len(results) != 1 || results[0].Item.ID != correction.ID {
+		t.Fatalf("expected only the correction in search results, got %d results", len(results))
+	}
+
+	withHistory, err := store.SearchMemory(ctx, &SearchQuery{QueryEmbedding: embedder["query"], AgentID: &agentID, IncludeSuperseded: true})
+	if err != nil {
+		t.Fatalf("SearchMemory: %v", err)
+	}
+	for _, r := range withHistory {
+		if r.Item.ID == old.ID && (r.Item.SupersededBy == nil || *r.Item.SupersededBy != correction.ID) {
+			t.Errorf("expected old item superseded by %d, got %v", correction.ID, r.Item.SupersededBy)
+		}
+	}
+
+	// A further correction carries the whole history forward
+	latest := remember("The standup is now at 11am.")
+	history, err := store.MemoryHistory(ctx, latest.ID)
+	if err != nil {
+		t.Fatalf("MemoryHistory: %v", err)
+	}
+	if len(history) != 2 || history[0].SourceItemID != old.ID || history[1].SourceItemID != correction.ID {
+		t.Errorf("expected history of both earlier items, got %+v", history)
+	}
+}
+
+func TestDedup_KeepsRelatedUntaggedFacts(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	store, err := NewStore(db, mapEmbedder{
+		"Alice leads the payments team.": {1, 0, 0},
+		"Alice leads the billing team.":  {0.9, 0.43, 0},
+		"The user likes green tea.":      {0, 1, 0},
+		"The user likes jasmine tea.":    {0, 0.9, 0.43},
+	}, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	// Two distinct facts about 0.9 apart, as reflection summaries often are
+	for _, fact := range []string{"Alice leads the payments team.", "Alice leads the billing team."} {
+		if _, err := store.RememberGlobalFact(ctx, fact, 0.5, nil); err != nil {
+			t.Fatalf("RememberGlobalFact: %v", err)
+		}
+	}
+	if n := countActiveItems(t, store); n != 2 {
+		t.Fatalf("expected both untagged facts kept, got %d items", n)
+	}
+
+	// Untagged personal memories of the same memory type still supersede
+	old, err := store.StorePersonalMemory(ctx, "agent", "", "The user likes green tea.", "preference", nil, nil, 0.5, nil)
+	if err != nil {
+		t.Fatalf("StorePersonalMemory: %v", err)
+	}
+	updated, err := store.StorePersonalMemory(ctx, "agent", "", "The user likes jasmine tea.", "preference", nil, nil, 0.5, nil)
+	if err != nil {
+		t.Fatalf("StorePersonalMemory: %v", err)
+	}
+	history, err := store.MemoryHistory(ctx, updated.ID)
+	if err != nil {
+		t.Fatalf("MemoryHistory: %v", err)
+	}
+	if len(history) != 1 || history[0].SourceItemID != old.ID || history[0].Reason != versionSuperseded {
+		t.Errorf("expected the earlier preference superseded, got %+v", history)
+	}
+}
+
+func TestDedup_SkipsEpisodesAndUnrelatedTags(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	store, err := NewStore(db, mapEmbedder{
+		"Ran the nightly build.":        {1, 0, 0},
+		"The user enjoys jazz.":         {0, 1, 0},
+		"The user enjoys jazz concerts": {0, 0.9, 0.43},
+	}, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := store.RememberAgentEpisode(ctx, "agent", "thread", "Ran the nightly build.", 0.3, nil); err != nil {
+			t.Fatalf("RememberAgentEpisode: %v", err)
+		}
+	}
+	if _, err := store.StorePersonalMemory(ctx, "agent", "", "The user enjoys jazz.", "preference", []string{"music"}, nil, 0, nil); err != nil {
+		t.Fatalf("StorePersonalMemory: %v", err)
+	}
+	if _, err := store.StorePersonalMemory(ctx, "agent", "", "The user enjoys jazz concerts", "preference", []string{"events"}, nil, 0, nil); err != nil {
+		t.Fatalf("StorePersonalMemory: %v", err)
+	}
+
+	if n := countActiveItems(t, store); n != 4 {
+		t.Errorf("expected repeated episodes and differently tagged memories kept, got %d items", n)
+	}
+}
+
+func TestConsolidateMemories(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	store, err := NewStore(db, mapEmbedder{
+		"The user prefers morning meetings.":       {1, 0, 0},
+		"The user prefers meetings in the morning": {0.99, 0.1, 0},
+		"The user has a dog.":                      {0, 1, 0},
+	}, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	// Simulate data stored before deduplication existed
+	store.SetDedupConfig(DedupConfig{})
+	older, err := store.RememberAgentFact(ctx, "agent", "The user prefers morning meetings.", 0.9, nil)
+	if err != nil {
+		t.Fatalf("RememberAgentFact: %v", err)
+	}
+	newer, err := store.RememberAgentFact(ctx, "agent", "The user prefers meetings in the morning", 0.4, nil)
+	if err != nil {
+		t.Fatalf("RememberAgentFact: %v", err)
+	}
+	if _, err := store.RememberAgentFact(ctx, "agent", "The user has a dog.", 0.5, nil); err != nil {
+		t.Fatalf("RememberAgentFact: %v", err)
+	}
+
+	store.SetDedupConfig(DefaultDedupConfig())
+	result, err := store.ConsolidateMemories(ctx)
+	if err != nil {
+		t.Fatalf("ConsolidateMemories: %v", err)
+	}
+	if result.Merged != 1 || result.Superseded != 0 {
+		t.Fatalf("expected one merge, got %+v", result)
+	}
+	if n := countActiveItems(t, store); n != 2 {
+		t.Errorf("expected 2 active items after consolidation, got %d", n)
+	}
+
+	items, err := store.loadItemsByIDs(ctx, []int64{newer.ID})
+	if err != nil || len(items) != 1 {
+		t.Fatalf("load consolidated item: %v", err)
+	}
+	if items[0].Importance != 0.9 {
+		t.Errorf("expected higher importance kept, got %.1f", items[0].Importance)
+	}
+	history, err := store.MemoryHistory(ctx, newer.ID)
+	if err != nil {
+		t.Fatalf("MemoryHistory: %v", err)
+	}
+	if len(history) != 1 || history[0].SourceItemID != older.ID {
+		t.Errorf("expected older item in history, got %+v", history)
+	}
+
+	// A second pass finds nothing new
+	result, err = store.ConsolidateMemories(ctx)
+	if err != nil {
+		t.Fatalf("ConsolidateMemories: %v", err)
+	}
+	if result.Merged != 0 || result.Superseded != 0 {
+		t.Errorf("expected no changes on second pass, got %+v", result)
+	}
+}
@@ -0,0 +1,273 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// DocChunk is a piece of a document's text, to be stored as a doc_ref memory.
+type DocChunk struct {
+	Offset    int    // Byte offset of the chunk in the document's text
+	Text      string // Chunk content, overlapping the chunks on either side
+	Extracted bool   // The text was extracted from the file, so Offset is not a file offset
+}
+
+// Document is an ingested workspace file.
+type Document struct {
+	Path        string
+	ContentHash string // sha256 of the file, hex encoded
+	Chunks      int
+	IndexedAt   time.Time
+}
+
+// DocResult is a document chunk found by SearchDocs.
+type DocResult struct {
+	ID        int64
+	Path      string
+	Offset    int  // Byte offset of the chunk in the document's text
+	Chunk     int  // Position of the chunk in the document, from 0
+	Extracted bool // Offset is into text extracted from the file, such as HTML or PDF
+	Text      string
+	Score     float64
+	Match     *TextMatch
+}
+
+// Citation identifies the chunk as path:offset, or as path#chunk-N when the offset is into
+// extracted text and would not point at the right place in the file.
+func (r DocResult) Citation() string {
+	if r.Extracted {
+		return fmt.Sprintf("%s#chunk-%d", r.Path, r.Chunk)
+	}
+	return fmt.Sprintf("%s:%d", r.Path, r.Offset)
+}
+
+// Document returns the indexed state of the document at path, or nil if it has not been
+// ingested.
+func (s *Store) Document(ctx context.Context, path string) (*Document, error) {
+	var (
+		doc       = Document{Path: path}
+		indexedAt int64
+	)
+	err := s.db.QueryRowContext(ctx, `
+SELECT content_hash, chunks, indexed_at FROM documents WHERE path = ?
+`, path).Scan(&doc.ContentHash, &doc.Chunks, &indexedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query document: %w", err)
+	}
+	doc.IndexedAt = time.Unix(indexedAt, 0)
+	return &doc, nil
+}
+
+// Documents returns every ingested document, ordered by path.
+func (s *Store) Documents(ctx context.Context) ([]Document, error) {
+	rows, err := s.db.QueryContext(ctx, `
+SELECT path, content_hash, chunks, indexed_at FROM documents ORDER BY path
+`)
+	if err != nil {
+		return nil, fmt.Errorf("query documents: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck // No remedy for rows close errors
+
+	var docs []Document
+	for rows.Next() {
+		var (
+			doc       Document
+			indexedAt int64
+		)
+		if err := rows.Scan(&doc.Path, &doc.ContentHash, &doc.Chunks, &indexedAt); err != nil {
+			return nil, err
+		}
+		doc.IndexedAt = time.Unix(indexedAt, 0)
+		docs = append(docs, doc)
+	}
+	return docs, rows.Err()
+}
+
+// IndexDocument stores the chunks of a document as global doc_ref memories, replacing
+// those of any earlier version. Each chunk records the document path, its offset and its
+// position in the metadata.
+func (s *Store) IndexDocument(ctx context.Context, path, contentHash string, chunks []DocChunk) error {
+	var embeddings [][]float32
+	if s.embedder != nil && len(chunks) > 0 {
+		texts := lo.Map(chunks, func(c DocChunk, _ int) string { return c.Text })
+		var err error
+		embeddings, err = EmbedBatch(ctx, s.embedder, texts)
+		if err != nil {
+			return fmt.Errorf("embed chunks: %w", err)
+		}
+	}
+
+	oldIDs, err := s.documentChunkIDs(ctx, path)
+	if err != nil {
+		return err
+	}
+
+	nowUnix := now()
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM memory_items WHERE type = 'doc_ref' AND json_extract(metadata, '$.path') = ?`, path); err != nil {
+		return fmt.Errorf("delete old chunks: %w", err)
+	}
+
+	newIDs := make([]int64, len(chunks))
+	for i, c := range chunks {
+		var embedding []float32
+		if embeddings != nil {
+			embedding = embeddings[i]
+		}
+		fields := map[string]interface{}{
+			"path":         path,
+			"offset":       c.Offset,
+			"chunk":        i,
+			"content_hash": contentHash,
+		}
+		if c.Extracted {
+			fields["extracted"] = true
+		}
+		meta, err := json.Marshal(fields)
+		if err != nil {
+			return fmt.Errorf("marshal metadata: %w", err)
+		}
+		embModel, embDim := s.embeddingInfo(embedding)
+		queryStr, args, err := StatementBuilder().
+			Insert("memory_items").
+			Columns("scope", "type", "content", "embedding", "metadata",
+				"created_at", "updated_at", "importance", "embedding_model", "embedding_dim").
+			Values(string(ScopeGlobal), string(MemoryTypeDocRef), c.Text, EncodeEmbedding(embedding), meta,
+				nowUnix, nowUnix, 0.5, embModel, embDim).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("build insert query: %w", err)
+		}
+		res, err := tx.ExecContext(ctx, queryStr, args...)
+		if err != nil {
+			return fmt.Errorf("insert chunk: %w", err)
+		}
+		if newIDs[i], err = res.LastInsertId(); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+INSERT INTO documents (path, content_hash, chunks, indexed_at) VALUES (?, ?, ?, ?)
+ON CONFLICT(path) DO UPDATE SET content_hash = excluded.content_hash, chunks = excluded.chunks, indexed_at = excluded.indexed_at
+`, path, contentHash, len(chunks), nowUnix); err != nil {
+		return fmt.Errorf("record document: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	for _, id := range oldIDs {
+		s.unindexItem(id)
+	}
+	for i, id := range newIDs {
+		if embeddings != nil {
+			s.indexItem(id, embeddings[i])
+		}
+	}
+	return nil
+}
+
+// RemoveDocument deletes an ingested document and its chunks.
+func (s *Store) RemoveDocument(ctx context.Context, path string) error {
+	ids, err := s.documentChunkIDs(ctx, path)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM memory_items WHERE type = 'doc_ref' AND json_extract(metadata, '$.path') = ?`, path); err != nil {
+		return fmt.Errorf("delete chunks: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM documents WHERE path = ?`, path); err != nil {
+		return fmt.Errorf("delete document: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		s.unindexItem(id)
+	}
+	return nil
+}
+
+// SearchDocs searches ingested document chunks with hybrid keyword and vector search.
+func (s *Store) SearchDocs(ctx context.Context, query string, limit int) ([]DocResult, error) {
+	var embedding []float32
+	if s.embedder != nil {
+		var err error
+		embedding, err = s.embedder.Embed(ctx, query)
+		if err != nil {
+			s.logger.Warn().Err(err).Msg("SearchDocs: embedding failed, using keyword search only")
+			embedding = nil
+		}
+	}
+
+	results, err := s.SearchMemory(ctx, &SearchQuery{
+		QueryText:      query,
+		QueryEmbedding: embedding,
+		IncludeGlobal:  true,
+		Types:          []MemoryType{MemoryTypeDocRef},
+		Limit:          limit,
+		UseHybrid:      true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(results, func(r SearchResult, _ int) DocResult {
+		path, _ := r.Item.Metadata["path"].(string)
+		offset, _ := r.Item.Metadata["offset"].(float64)
+		chunk, _ := r.Item.Metadata["chunk"].(float64)
+		extracted, _ := r.Item.Metadata["extracted"].(bool)
+		return DocResult{
+			ID:        r.Item.ID,
+			Path:      path,
+			Offset:    int(offset),
+			Chunk:     int(chunk),
+			Extracted: extracted,
+			Text:      r.Item.Content,
+			Score:     r.Score,
+			Match:     r.Match,
+		}
+	}), nil
+}
+
+// documentChunkIDs returns the IDs of the chunks stored for a document.
+func (s *Store) documentChunkIDs(ctx context.Context, path string) ([]int64, error) {
+	rows, err := s.db.QueryContext(ctx, `
+SELECT id FROM memory_items WHERE type = 'doc_ref' AND json_extract(metadata, '$.path') = ?
+`, path)
+	if err != nil {
+		return nil, fmt.Errorf("query document chunks: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck // No remedy for rows close errors
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,185 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrMemoryNotFound is returned when a memory item does not exist.
+var ErrMemoryNotFound = errors.New("memory not found")
+
+// MemoryUpdate changes parts of a memory item. Nil fields are left unchanged.
+type MemoryUpdate struct {
+	Content    *string
+	Importance *float64
+	MemoryType *string
+	Tags       []string               // Replaces the tags when non-nil; an empty slice clears them
+	Metadata   map[string]interface{} // Merged into the existing metadata; nil values remove keys
+}
+
+// GetMemory returns a memory item by ID, including superseded and archived items.
+func (s *Store) GetMemory(ctx context.Context, id int64) (*MemoryItem, error) {
+	items, err := s.loadItemsByIDs(ctx, []int64{id})
+	if err != nil {
+		return nil, fmt.Errorf("load memory_item: %w", err)
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("memory %d: %w", id, ErrMemoryNotFound)
+	}
+	return items[0], nil
+}
+
+// UpdateMemory edits a memory item in place. A changed content is re-embedded and
+// re-indexed for keyword and vector search, and the previous content is kept as an
+// "edited" version in the item's history.
+func (s *Store) UpdateMemory(ctx context.Context, id int64, upd MemoryUpdate) (*MemoryItem, error) {
+	existing, err := s.GetMemory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *existing
+	contentChanged := false
+	if upd.Content != nil {
+		content := strings.TrimSpace(*upd.Content)
+		if content == "" {
+			return nil, errors.New("content is empty")
+		}
+		contentChanged = content != existing.Content
+		updated.Content = content
+	}
+	if upd.Importance != nil {
+		updated.Importance = *upd.Importance
+	}
+	if upd.MemoryType != nil {
+		updated.MemoryType = strings.TrimSpace(*upd.MemoryType)
+	}
+	if upd.Tags != nil {
+		updated.Tags = unionTags(nil, upd.Tags)
+	}
+	if len(upd.Metadata) > 0 {
+		updated.Metadata = make(map[string]interface{}, len(existing.Metadata)+len(upd.Metadata))
+		for k, v := range existing.Metadata {
+			updated.Metadata[k] = v
+		}
+		for k, v := range upd.Metadata {
+			if v == nil {
+				delete(updated.Metadata, k)
+				continue
+			}
+			updated.Metadata[k] = v
+		}
+	}
+
+	if contentChanged && s.embedder != nil {
+		emb, err := s.embedder.Embed(ctx, updated.Content)
+		if err != nil {
+			return nil, fmt.Errorf("embed updated content: %w", err)
+		}
+		updated.Embedding = emb
+		updated.EmbeddingModel, updated.EmbeddingDim = s.embedder.Name(), len(emb)
+	} else if contentChanged {
+		// A stale vector would keep matching the old content
+		updated.Embedding = nil
+		updated.EmbeddingModel, updated.EmbeddingDim = "", 0
+	}
+
+	metaJSON, err := marshalOptional(updated.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("marshal metadata: %w", err)
+	}
+	tagsJSON, err := marshalOptional(updated.Tags)
+	if err != nil {
+		return nil, fmt.Errorf("marshal tags: %w", err)
+	}
+	nowUnix := now()
+	updated.UpdatedAt = time.Unix(nowUnix, 0)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if contentChanged {
+		if err := recordVersion(ctx, tx, id, existing, versionEdited, nowUnix); err != nil {
+			return nil, err
+		}
+	}
+	embModel, embDim := s.embeddingInfo(updated.Embedding)
+	if _, err := tx.ExecContext(ctx, `
+UPDATE memory_items
+SET content = ?, importance = ?, memory_type = ?, tags_json = ?, metadata = ?,
+    embedding = ?, embedding_model = ?, embedding_dim = ?, updated_at = ?
+WHERE id = ?
+`, updated.Content, updated.Importance, nullIfEmpty(updated.MemoryType), tagsJSON, metaJSON,
+		EncodeEmbedding(updated.Embedding), embModel, embDim, nowUnix, id); err != nil {
+		return nil, fmt.Errorf("update memory_item: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	if contentChanged && updated.SupersededBy == nil && updated.ArchivedAt == nil {
+		s.unindexItem(id)
+		s.indexItem(id, updated.Embedding)
+	}
+
+	s.logger.Info().
+		Int64("id", id).
+		Bool("contentChanged", contentChanged).
+		Msg("Updated memory item")
+	return &updated, nil
+}
+
+// DeleteMemory removes a memory item along with its search index entries and history.
+// Items it had superseded are archived rather than resurfacing in search.
+func (s *Store) DeleteMemory(ctx context.Context, id int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var exists int
+	err = tx.QueryRowContext(ctx, `SELECT 1 FROM memory_items WHERE id = ?`, id).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("memory %d: %w", id, ErrMemoryNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("query memory_item: %w", err)
+	}
+
+	if err := deleteItems(ctx, tx, []int64{id}, now()); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.unindexItem(id)
+
+	s.logger.Info().Int64("id", id).Msg("Deleted memory item")
+	return nil
+}
+
+// deleteItems removes memory items and their versions within tx. Items
+// superseded by a deleted item are archived, since their link to it is lost.
+func deleteItems(ctx context.Context, tx *sql.Tx, ids []int64, nowUnix int64) error {
+	if err := execForIDs(ctx, tx, `UPDATE memory_items SET archived_at = ? WHERE archived_at IS NULL AND superseded_by`, ids, nowUnix); err != nil {
+		return fmt.Errorf("archive superseded items: %w", err)
+	}
+	if err := execForIDs(ctx, tx, `UPDATE memory_items SET superseded_by = NULL WHERE superseded_by`, ids); err != nil {
+		return fmt.Errorf("unlink superseded items: %w", err)
+	}
+	if err := execForIDs(ctx, tx, `DELETE FROM memory_item_versions WHERE item_id`, ids); err != nil {
+		return fmt.Errorf("delete memory versions: %w", err)
+	}
+	if err := execForIDs(ctx, tx, `DELETE FROM memory_items WHERE id`, ids); err != nil {
+		return fmt.Errorf("delete memory_items: %w", err)
+	}
+	return nil
+}
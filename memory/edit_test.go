@@ -0,0 +1,121 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestUpdateMemory_ReplacesSearchableContent(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	store, err := NewStore(db, nil, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	item, err := store.StorePersonalMemory(ctx, "agent", "", "The user lives in Boston.", "biographical", []string{"home"}, nil, 0.5, nil)
+	if err != nil {
+		t.Fatalf("StorePersonalMemory: %v", err)
+	}
+
+	content := "The user lives in Denver."
+	importance := 0.9
+	updated, err := store.UpdateMemory(ctx, item.ID, MemoryUpdate{
+		Content:    &content,
+		Importance: &importance,
+		Tags:       []string{"home", "city"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateMemory: %v", err)
+	}
+	if updated.Content != content || updated.Importance != importance || len(updated.Tags) != 2 {
+		t.Fatalf("unexpected updated item: %+v", updated)
+	}
+
+	agentID := "agent"
+	results, err := store.SearchMemory(ctx, &SearchQuery{QueryText: "Denver", AgentID: &agentID})
+	if err != nil {
+		t.Fatalf("SearchMemory: %v", err)
+	}
+	if len(results) != 1 || results[0].Item.ID != item.ID {
+		t.Fatalf("expected the updated memory to match its new content, got %d results", len(results))
+	}
+	results, err = store.SearchMemory(ctx, &SearchQuery{QueryText: "Boston", AgentID: &agentID})
+	if err != nil {
+		t.Fatalf("SearchMemory: %v", err)
+	}
+	if len(results) != 0 {
+		t.Fatalf("expected the old content to no longer match, got %d results", len(results))
+	}
+
+	history, err := store.MemoryHistory(ctx, item.ID)
+	if err != nil {
+		t.Fatalf("MemoryHistory: %v", err)
+	}
+	if len(history) != 1 || history[0].Reason != versionEdited || history[0].Content != "The user lives in Boston." {
+		t.Fatalf("expected the previous content as an edited version, got %+v", history)
+	}
+}
+
+func TestDeleteMemory_RemovesItemAndFTS(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	store, err := NewStore(db, nil, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	item, err := store.RememberAgentFact(ctx, "agent", "The user's locker code is 1234", 0.5, nil)
+	if err != nil {
+		t.Fatalf("RememberAgentFact: %v", err)
+	}
+
+	if err := store.DeleteMemory(ctx, item.ID); err != nil {
+		t.Fatalf("DeleteMemory: %v", err)
+	}
+	if _, err := store.GetMemory(ctx, item.ID); !errors.Is(err, ErrMemoryNotFound) {
+		t.Fatalf("expected ErrMemoryNotFound after delete, got %v", err)
+	}
+	if err := store.DeleteMemory(ctx, item.ID); !errors.Is(err, ErrMemoryNotFound) {
+		t.Fatalf("expected ErrMemoryNotFound deleting twice, got %v", err)
+	}
+
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM memory_items_fts WHERE rowid = ?`, item.ID).Scan(&n); err != nil {
+		t.Fatalf("count fts rows: %v", err)
+	}
+	if n != 0 {
+		t.Errorf("expected FTS row to be deleted, found %d", n)
+	}
+}
+
+func TestMemoryRouter_ForgetMemoryChecksOwner(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	store, err := NewStore(db, nil, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	router := NewMemoryRouter(store, Config{}, zerolog.Nop())
+
+	item, err := store.RememberAgentFact(ctx, "owner", "The user prefers tea", 0.5, nil)
+	if err != nil {
+		t.Fatalf("RememberAgentFact: %v", err)
+	}
+
+	if err := router.ForgetMemory(ctx, "other", item.ID); !errors.Is(err, ErrMemoryNotOwned) {
+		t.Fatalf("expected ErrMemoryNotOwned, got %v", err)
+	}
+	if err := router.ForgetMemory(ctx, "owner", item.ID); err != nil {
+		t.Fatalf("ForgetMemory: %v", err)
+	}
+}
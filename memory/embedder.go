@@ -4,12 +4,46 @@ import (
 	"context"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"math"
 )
 
 // Embedder is a pluggable interface for getting embeddings for text.
 type Embedder interface {
 	Embed(ctx context.Context, text string) ([]float32, error)
+	// Name identifies the provider and model producing the vectors, e.g. "ollama:mxbai-embed-large".
+	// Vectors from embedders with different names are not comparable.
+	Name() string
+}
+
+// BatchEmbedder is implemented by embedders that can embed several texts in one request.
+type BatchEmbedder interface {
+	Embedder
+	// EmbedBatch returns one vector per text, in order.
+	EmbedBatch(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// EmbedBatch embeds texts with a single request if e supports batching, or one at a time.
+func EmbedBatch(ctx context.Context, e Embedder, texts []string) ([][]float32, error) {
+	if be, ok := e.(BatchEmbedder); ok {
+		vecs, err := be.EmbedBatch(ctx, texts)
+		if err != nil {
+			return nil, err
+		}
+		if len(vecs) != len(texts) {
+			return nil, fmt.Errorf("embedder returned %d vectors for %d texts", len(vecs), len(texts))
+		}
+		return vecs, nil
+	}
+	vecs := make([][]float32, len(texts))
+	for i, text := range texts {
+		vec, err := e.Embed(ctx, text)
+		if err != nil {
+			return nil, err
+		}
+		vecs[i] = vec
+	}
+	return vecs, nil
 }
 
 // EncodeEmbedding encodes a []float32 into a []byte for storage.
@@ -0,0 +1,467 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/samber/lo"
+)
+
+// EntityKind is the kind of thing an entity is.
+type EntityKind string
+
+const (
+	EntityPerson       EntityKind = "person"
+	EntityProject      EntityKind = "project"
+	EntityOrganization EntityKind = "organization"
+	EntityPlace        EntityKind = "place"
+)
+
+// ErrEntityNotFound is returned when no entity matches, or none that a visible memory mentions.
+var ErrEntityNotFound = errors.New("entity not found")
+
+const (
+	maxEntityName        = 100 // Longer names are not entities the normalizer should have found
+	maxEntitiesPerMemory = 16
+)
+
+// Entity is a person, project, organization or place mentioned by memories. Entities are
+// identified by kind and case-insensitive name.
+type Entity struct {
+	ID        int64
+	Name      string
+	Kind      EntityKind
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// EntityRef names an entity mentioned by a memory, as found by the normalizer.
+type EntityRef struct {
+	Name string     `json:"name"`
+	Kind EntityKind `json:"kind"`
+}
+
+// RelationRef is a relation between two entities named in the same memory, such as
+// {"Sam", "works_on", "Q3 launch"}.
+type RelationRef struct {
+	Subject   string `json:"subject"`
+	Predicate string `json:"predicate"`
+	Object    string `json:"object"`
+}
+
+// Relation is a relation between entities stated by a memory.
+type Relation struct {
+	Subject   Entity
+	Predicate string
+	Object    Entity
+	MemoryID  int64
+}
+
+// String formats the relation as "subject predicate object".
+func (r Relation) String() string {
+	return fmt.Sprintf("%s %s %s", r.Subject.Name, r.Predicate, r.Object.Name)
+}
+
+// RelatedEntity is an entity connected to the ones asked about, by a relation or by being
+// mentioned in the same memory.
+type RelatedEntity struct {
+	Entity
+	Links int // Relations and shared memories connecting it
+}
+
+// EntityContext is what the memories visible to a query say about a set of entities.
+type EntityContext struct {
+	Entities  []Entity
+	Memories  []MemoryItem    // Memories mentioning all of Entities, most recently updated first
+	Relations []Relation      // Relations involving any of Entities
+	Related   []RelatedEntity // Other entities in Relations and Memories, most linked first
+}
+
+// LinkEntities records the entities a memory mentions and the relations between them it
+// states, creating entities that are new. Relations whose subject or object is not among
+// entities are ignored, as are invalid entities.
+func (s *Store) LinkEntities(ctx context.Context, memoryID int64, entities []EntityRef, relations []RelationRef) error {
+	entities = sanitizeEntities(entities)
+	if len(entities) == 0 {
+		return nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := linkEntities(ctx, tx, memoryID, entities, relations); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// linkEntities is LinkEntities within tx.
+func linkEntities(ctx context.Context, tx *sql.Tx, memoryID int64, entities []EntityRef, relations []RelationRef) error {
+	entities = sanitizeEntities(entities)
+	if len(entities) == 0 {
+		return nil
+	}
+	relations = sanitizeRelations(relations, entities)
+
+	nowUnix := now()
+	ids := make(map[string]int64, len(entities))
+	for _, ref := range entities {
+		var id int64
+		err := tx.QueryRowContext(ctx, `
+INSERT INTO entities (name, kind, created_at, updated_at) VALUES (?, ?, ?, ?)
+ON CONFLICT(kind, name) DO UPDATE SET updated_at = excluded.updated_at
+RETURNING id
+`, ref.Name, string(ref.Kind), nowUnix, nowUnix).Scan(&id)
+		if err != nil {
+			return fmt.Errorf("upsert entity %q: %w", ref.Name, err)
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT OR IGNORE INTO entity_mentions (entity_id, memory_id) VALUES (?, ?)`, id, memoryID); err != nil {
+			return fmt.Errorf("insert entity mention: %w", err)
+		}
+		// A name given two kinds resolves to the first in relations
+		if _, ok := ids[strings.ToLower(ref.Name)]; !ok {
+			ids[strings.ToLower(ref.Name)] = id
+		}
+	}
+
+	for _, rel := range relations {
+		if _, err := tx.ExecContext(ctx, `
+INSERT OR IGNORE INTO relations (subject_id, predicate, object_id, memory_id, created_at) VALUES (?, ?, ?, ?, ?)
+`, ids[strings.ToLower(rel.Subject)], rel.Predicate, ids[strings.ToLower(rel.Object)], memoryID, nowUnix); err != nil {
+			return fmt.Errorf("insert relation: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetEntity returns an entity by ID.
+func (s *Store) GetEntity(ctx context.Context, id int64) (*Entity, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, name, kind, created_at, updated_at FROM entities WHERE id = ?`, id)
+	if err != nil {
+		return nil, fmt.Errorf("query entity: %w", err)
+	}
+	entities, err := scanEntities(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(entities) == 0 {
+		return nil, fmt.Errorf("entity %d: %w", id, ErrEntityNotFound)
+	}
+	return &entities[0], nil
+}
+
+// FindEntities returns the entities named name, ignoring case, optionally only those of one
+// kind. When none has that exact name, entities whose names contain it are returned instead.
+// If q is not nil, only entities mentioned by a memory visible to q are returned.
+func (s *Store) FindEntities(ctx context.Context, q *SearchQuery, name string, kind EntityKind) ([]Entity, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, nil
+	}
+	find := func(cond sq.Sqlizer) ([]Entity, error) {
+		query := StatementBuilder().
+			Select("id", "name", "kind", "created_at", "updated_at").
+			From("entities").
+			Where(cond).
+			OrderBy("name", "kind").
+			Limit(20)
+		if kind != "" {
+			query = query.Where(sq.Eq{"kind": string(kind)})
+		}
+		if q != nil {
+			mentioned := StatementBuilder().
+				Select("entity_id").
+				From("entity_mentions").
+				Where(sq.Expr("memory_id IN (?)", s.visibleMemoryIDs(q)))
+			query = query.Where(sq.Expr("id IN (?)", mentioned))
+		}
+		queryStr, args, err := query.ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("build query: %w", err)
+		}
+		rows, err := s.db.QueryContext(ctx, queryStr, args...)
+		if err != nil {
+			return nil, fmt.Errorf("query entities: %w", err)
+		}
+		return scanEntities(rows)
+	}
+
+	entities, err := find(sq.Eq{"name": name})
+	if err != nil || len(entities) > 0 {
+		return entities, err
+	}
+	return find(sq.Like{"name": "%" + name + "%"})
+}
+
+// EntityContext gathers what the memories visible to q say about entities: the memories
+// mentioning every one of them, the relations involving any of them, and the other
+// entities those connect to. Each element of entitySets is one entity, given as the IDs of
+// every entity it may be, and a memory must mention at least one ID from each set. q
+// selects visible memories as for SearchMemory, and q.Limit caps the memories returned.
+func (s *Store) EntityContext(ctx context.Context, q *SearchQuery, entitySets ...[]int64) (*EntityContext, error) {
+	var asked []int64
+	for _, set := range entitySets {
+		asked = append(asked, set...)
+	}
+	asked = lo.Uniq(asked)
+	result := &EntityContext{}
+	if len(asked) == 0 {
+		return result, nil
+	}
+	for _, id := range asked {
+		entity, err := s.GetEntity(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		result.Entities = append(result.Entities, *entity)
+	}
+
+	// Memories mentioning an entity of every set
+	where := sq.And{buildFilterWhere(q)}
+	for _, set := range entitySets {
+		mentions := StatementBuilder().Select("memory_id").From("entity_mentions").Where(sq.Eq{"entity_id": set})
+		where = append(where, sq.Expr("id IN (?)", mentions))
+	}
+	query := StatementBuilder().
+		Select(SelectMemoryItemsColumns()...).
+		From("memory_items").
+		Where(where).
+		OrderBy("updated_at DESC")
+	if q.Limit > 0 {
+		query = query.Limit(uint64(q.Limit))
+	}
+	queryStr, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+	rows, err := s.db.QueryContext(ctx, queryStr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query entity memories: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck // No remedy for rows close errors
+	for rows.Next() {
+		item, err := loadMemoryItemFromRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		result.Memories = append(result.Memories, *item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if result.Relations, err = s.entityRelations(ctx, q, asked); err != nil {
+		return nil, err
+	}
+
+	// Other entities, linked by relations and by shared memories
+	isAsked := lo.SliceToMap(asked, func(id int64) (int64, bool) { return id, true })
+	links := make(map[int64]*RelatedEntity)
+	link := func(e Entity) {
+		if isAsked[e.ID] {
+			return
+		}
+		if links[e.ID] == nil {
+			links[e.ID] = &RelatedEntity{Entity: e}
+		}
+		links[e.ID].Links++
+	}
+	for _, rel := range result.Relations {
+		link(rel.Subject)
+		link(rel.Object)
+	}
+	mentioned, err := s.MemoryEntities(ctx, lo.Map(result.Memories, func(m MemoryItem, _ int) int64 { return m.ID }))
+	if err != nil {
+		return nil, err
+	}
+	for _, entities := range mentioned {
+		for _, e := range entities {
+			link(e)
+		}
+	}
+	for _, r := range links {
+		result.Related = append(result.Related, *r)
+	}
+	sort.Slice(result.Related, func(i, j int) bool {
+		if result.Related[i].Links != result.Related[j].Links {
+			return result.Related[i].Links > result.Related[j].Links
+		}
+		return result.Related[i].Name < result.Related[j].Name
+	})
+	return result, nil
+}
+
+// MemoryEntities returns the entities mentioned by each of the given memories.
+func (s *Store) MemoryEntities(ctx context.Context, memoryIDs []int64) (map[int64][]Entity, error) {
+	result := make(map[int64][]Entity)
+	if len(memoryIDs) == 0 {
+		return result, nil
+	}
+	queryStr, args, err := StatementBuilder().
+		Select("m.memory_id", "e.id", "e.name", "e.kind", "e.created_at", "e.updated_at").
+		From("entity_mentions m").
+		Join("entities e ON e.id = m.entity_id").
+		Where(sq.Eq{"m.memory_id": memoryIDs}).
+		OrderBy("e.name").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+	rows, err := s.db.QueryContext(ctx, queryStr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query memory entities: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck // No remedy for rows close errors
+	for rows.Next() {
+		var (
+			memoryID             int64
+			e                    Entity
+			kind                 string
+			createdAt, updatedAt int64
+		)
+		if err := rows.Scan(&memoryID, &e.ID, &e.Name, &kind, &createdAt, &updatedAt); err != nil {
+			return nil, err
+		}
+		e.Kind = EntityKind(kind)
+		e.CreatedAt, e.UpdatedAt = time.Unix(createdAt, 0), time.Unix(updatedAt, 0)
+		result[memoryID] = append(result[memoryID], e)
+	}
+	return result, rows.Err()
+}
+
+// entityRelations returns the relations involving any of entityIDs that are stated by
+// memories visible to q.
+func (s *Store) entityRelations(ctx context.Context, q *SearchQuery, entityIDs []int64) ([]Relation, error) {
+	queryStr, args, err := StatementBuilder().
+		Select("r.predicate", "r.memory_id",
+			"s.id", "s.name", "s.kind", "s.created_at", "s.updated_at",
+			"o.id", "o.name", "o.kind", "o.created_at", "o.updated_at").
+		From("relations r").
+		Join("entities s ON s.id = r.subject_id").
+		Join("entities o ON o.id = r.object_id").
+		Where(sq.Or{sq.Eq{"r.subject_id": entityIDs}, sq.Eq{"r.object_id": entityIDs}}).
+		Where(sq.Expr("r.memory_id IN (?)", s.visibleMemoryIDs(q))).
+		OrderBy("r.created_at DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+	rows, err := s.db.QueryContext(ctx, queryStr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query relations: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck // No remedy for rows close errors
+
+	var relations []Relation
+	seen := make(map[string]bool)
+	for rows.Next() {
+		var (
+			rel                            Relation
+			subjectKind, objectKind        string
+			subjectCreated, subjectUpdated int64
+			objectCreated, objectUpdated   int64
+		)
+		if err := rows.Scan(&rel.Predicate, &rel.MemoryID,
+			&rel.Subject.ID, &rel.Subject.Name, &subjectKind, &subjectCreated, &subjectUpdated,
+			&rel.Object.ID, &rel.Object.Name, &objectKind, &objectCreated, &objectUpdated); err != nil {
+			return nil, err
+		}
+		// The same relation stated by several memories is reported once, from the newest
+		key := fmt.Sprintf("%d %s %d", rel.Subject.ID, rel.Predicate, rel.Object.ID)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		rel.Subject.Kind, rel.Object.Kind = EntityKind(subjectKind), EntityKind(objectKind)
+		rel.Subject.CreatedAt, rel.Subject.UpdatedAt = time.Unix(subjectCreated, 0), time.Unix(subjectUpdated, 0)
+		rel.Object.CreatedAt, rel.Object.UpdatedAt = time.Unix(objectCreated, 0), time.Unix(objectUpdated, 0)
+		relations = append(relations, rel)
+	}
+	return relations, rows.Err()
+}
+
+// visibleMemoryIDs selects the IDs of the memories visible to q.
+func (s *Store) visibleMemoryIDs(q *SearchQuery) sq.SelectBuilder {
+	return StatementBuilder().
+		Select("id").
+		From("memory_items").
+		Where(buildFilterWhere(q))
+}
+
+// scanEntities reads entity rows of id, name, kind, created_at and updated_at, closing rows.
+func scanEntities(rows *sql.Rows) ([]Entity, error) {
+	defer rows.Close() //nolint:errcheck // No remedy for rows close errors
+	var entities []Entity
+	for rows.Next() {
+		var (
+			e                    Entity
+			kind                 string
+			createdAt, updatedAt int64
+		)
+		if err := rows.Scan(&e.ID, &e.Name, &kind, &createdAt, &updatedAt); err != nil {
+			return nil, err
+		}
+		e.Kind = EntityKind(kind)
+		e.CreatedAt, e.UpdatedAt = time.Unix(createdAt, 0), time.Unix(updatedAt, 0)
+		entities = append(entities, e)
+	}
+	return entities, rows.Err()
+}
+
+// ValidEntityKind reports whether kind is one of the entity kinds.
+func ValidEntityKind(kind EntityKind) bool {
+	switch kind {
+	case EntityPerson, EntityProject, EntityOrganization, EntityPlace:
+		return true
+	}
+	return false
+}
+
+// sanitizeEntities trims entity names and drops duplicates, entities of unknown kinds, and
+// names that are empty, too long or look like secrets.
+func sanitizeEntities(refs []EntityRef) []EntityRef {
+	seen := make(map[string]bool)
+	out := lo.FilterMap(refs, func(ref EntityRef, _ int) (EntityRef, bool) {
+		ref.Name = strings.Join(strings.Fields(ref.Name), " ")
+		ref.Kind = EntityKind(strings.ToLower(strings.TrimSpace(string(ref.Kind))))
+		if ref.Name == "" || len(ref.Name) > maxEntityName || !ValidEntityKind(ref.Kind) {
+			return ref, false
+		}
+		if secretLike.MatchString(ref.Name) {
+			return ref, false
+		}
+		key := string(ref.Kind) + ":" + strings.ToLower(ref.Name)
+		if seen[key] {
+			return ref, false
+		}
+		seen[key] = true
+		return ref, true
+	})
+	if len(out) > maxEntitiesPerMemory {
+		out = out[:maxEntitiesPerMemory]
+	}
+	return out
+}
+
+// sanitizeRelations normalizes predicates to lowercase words joined by underscores and
+// keeps only relations between two different entities of entities.
+func sanitizeRelations(relations []RelationRef, entities []EntityRef) []RelationRef {
+	names := lo.SliceToMap(entities, func(e EntityRef) (string, bool) { return strings.ToLower(e.Name), true })
+	return lo.FilterMap(relations, func(rel RelationRef, _ int) (RelationRef, bool) {
+		rel.Subject = strings.Join(strings.Fields(rel.Subject), " ")
+		rel.Object = strings.Join(strings.Fields(rel.Object), " ")
+		rel.Predicate = strings.Trim(tagSanitizer.ReplaceAllString(strings.ToLower(rel.Predicate), "_"), "_-")
+		if rel.Predicate == "" || strings.EqualFold(rel.Subject, rel.Object) {
+			return rel, false
+		}
+		return rel, names[strings.ToLower(rel.Subject)] && names[strings.ToLower(rel.Object)]
+	})
+}
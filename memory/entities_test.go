@@ -0,0 +1,161 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestMemoryRouter_EntityGraph(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	store, err := NewStore(db, newSemanticEmbedder(8), zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	store.SetDedupConfig(DedupConfig{Enabled: false})
+	router := NewMemoryRouter(store, Config{}, zerolog.Nop())
+
+	remember := func(agentID, content string, entities []EntityRef, relations []RelationRef) MemoryItem {
+		t.Helper()
+		item, err := router.StorePersonalMemory(ctx, agentID, content, content, "project", nil, nil, 0.5, nil)
+		if err != nil {
+			t.Fatalf("StorePersonalMemory: %v", err)
+		}
+		if err := router.LinkEntities(ctx, item.ID, entities, relations); err != nil {
+			t.Fatalf("LinkEntities: %v", err)
+		}
+		return item
+	}
+	sam := EntityRef{Name: "Sam", Kind: EntityPerson}
+	launch := EntityRef{Name: "Q3 launch", Kind: EntityProject}
+
+	both := remember("agent-1", "Sam leads the Q3 launch.", []EntityRef{sam, launch},
+		[]RelationRef{{Subject: "sam", Predicate: "Leads", Object: "Q3 Launch"}})
+	remember("agent-1", "Sam prefers morning meetings.", []EntityRef{{Name: " sam ", Kind: "Person"}}, nil)
+	remember("agent-1", "The Q3 launch moved to September.", []EntityRef{launch, {Name: "Acme", Kind: EntityOrganization}}, nil)
+	private := remember("agent-2", "Sam is interviewing at Globex.", []EntityRef{sam, {Name: "Globex", Kind: EntityOrganization}},
+		[]RelationRef{{Subject: "Sam", Predicate: "interviewing_at", Object: "Globex"}})
+
+	ec, err := router.RelatedMemories(ctx, "agent-1", []string{"SAM", "q3 launch"}, 10)
+	if err != nil {
+		t.Fatalf("RelatedMemories: %v", err)
+	}
+	if len(ec.Memories) != 1 || ec.Memories[0].ID != both.ID {
+		t.Fatalf("expected only the memory mentioning both entities, got %+v", ec.Memories)
+	}
+	if len(ec.Relations) != 1 || ec.Relations[0].String() != "Sam leads Q3 launch" {
+		t.Errorf("expected the relation between them, got %+v", ec.Relations)
+	}
+	if len(ec.Related) != 0 {
+		t.Errorf("expected no other entities in the shared memory, got %+v", ec.Related)
+	}
+
+	lookup, err := router.LookupEntities(ctx, "agent-1", "sam", "", 10)
+	if err != nil {
+		t.Fatalf("LookupEntities: %v", err)
+	}
+	if len(lookup) != 1 || len(lookup[0].Memories) != 2 {
+		t.Fatalf("expected Sam once with two visible memories, got %+v", lookup)
+	}
+	for _, rel := range lookup[0].Relations {
+		if rel.MemoryID == private.ID {
+			t.Errorf("relation from another agent's private memory is visible: %v", rel)
+		}
+	}
+	if len(lookup[0].Related) != 1 || lookup[0].Related[0].Name != "Q3 launch" || lookup[0].Related[0].Links != 2 {
+		t.Errorf("expected Q3 launch related by a relation and a shared memory, got %+v", lookup[0].Related)
+	}
+
+	// Entities only another agent's memories mention are not found
+	if lookup, err := router.LookupEntities(ctx, "agent-1", "Globex", "", 10); err != nil || len(lookup) != 0 {
+		t.Errorf("expected no visible Globex entity, got %+v, %v", lookup, err)
+	}
+	if _, err := router.RelatedMemories(ctx, "agent-1", []string{"Sam", "Globex"}, 10); !errors.Is(err, ErrEntityNotFound) {
+		t.Errorf("expected ErrEntityNotFound, got %v", err)
+	}
+	if lookup, _ := router.LookupEntities(ctx, "agent-2", "Globex", EntityOrganization, 10); len(lookup) != 1 {
+		t.Errorf("expected the owner to find Globex, got %+v", lookup)
+	}
+
+	// Deleting a memory removes its links, and entities nothing else mentions
+	if err := store.DeleteMemory(ctx, private.ID); err != nil {
+		t.Fatalf("DeleteMemory: %v", err)
+	}
+	if found, _ := store.FindEntities(ctx, nil, "Globex", ""); len(found) != 0 {
+		t.Errorf("expected Globex to be removed with its only memory, got %+v", found)
+	}
+	if found, _ := store.FindEntities(ctx, nil, "Sam", ""); len(found) != 1 {
+		t.Errorf("expected Sam to remain, got %+v", found)
+	}
+}
+
+// entityNormalizer is a stubNormalizer that also reports the capitalized words of a
+// statement as people.
+type entityNormalizer struct{ stubNormalizer }
+
+func (n entityNormalizer) NormalizeMemory(ctx context.Context, rawText string) (NormalizedMemory, error) {
+	m, err := n.stubNormalizer.NormalizeMemory(ctx, rawText)
+	for _, word := range strings.Fields(rawText) {
+		if word != "I" && strings.ToUpper(word[:1]) == word[:1] {
+			m.Entities = append(m.Entities, EntityRef{Name: strings.Trim(word, "."), Kind: EntityPerson})
+		}
+	}
+	return m, err
+}
+
+func TestRememberExtractedFacts_LinksEntities(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	store, err := NewStore(db, newSemanticEmbedder(8), zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	facts := []ExtractedFact{{Statement: "I play tennis with Priya every week.", Confidence: 0.9}}
+	result, err := store.RememberExtractedFacts(ctx, "agent-1", "thread-1", facts, entityNormalizer{}, 0.8)
+	if err != nil {
+		t.Fatalf("RememberExtractedFacts: %v", err)
+	}
+	if len(result.Stored) != 1 {
+		t.Fatalf("expected 1 stored fact, got %d", len(result.Stored))
+	}
+	entities, err := store.MemoryEntities(ctx, []int64{result.Stored[0].ID})
+	if err != nil {
+		t.Fatalf("MemoryEntities: %v", err)
+	}
+	got := entities[result.Stored[0].ID]
+	if len(got) != 1 || got[0].Name != "Priya" || got[0].Kind != EntityPerson {
+		t.Errorf("expected the fact to mention Priya, got %+v", got)
+	}
+}
+
+func TestSanitizeEntitiesAndRelations(t *testing.T) {
+	entities := sanitizeEntities([]EntityRef{
+		{Name: "  Sam   Lee ", Kind: "PERSON"},
+		{Name: "sam lee", Kind: EntityPerson},
+		{Name: "Mars", Kind: "planet"},
+		{Name: "", Kind: EntityPlace},
+		{Name: "Berlin", Kind: EntityPlace},
+	})
+	if len(entities) != 2 || entities[0] != (EntityRef{Name: "Sam Lee", Kind: EntityPerson}) {
+		t.Fatalf("unexpected entities: %+v", entities)
+	}
+
+	relations := sanitizeRelations([]RelationRef{
+		{Subject: "Sam Lee", Predicate: "Lives In", Object: "berlin"},
+		{Subject: "Sam Lee", Predicate: "knows", Object: "Alex"},
+		{Subject: "Berlin", Predicate: "", Object: "Sam Lee"},
+		{Subject: "Berlin", Predicate: "is", Object: "berlin"},
+	}, entities)
+	if len(relations) != 1 || relations[0].Predicate != "lives_in" {
+		t.Errorf("unexpected relations: %+v", relations)
+	}
+}
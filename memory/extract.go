@@ -0,0 +1,273 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/rs/zerolog"
+)
+
+// ExtractedFact is a personal fact or preference proposed by a FactExtractor.
+type ExtractedFact struct {
+	Statement  string  `json:"statement"`
+	Confidence float64 `json:"confidence"` // 0..1, how sure the extractor is that the fact is true and durable
+}
+
+// FactExtractor proposes personal facts stated in a conversation transcript.
+type FactExtractor interface {
+	ExtractFacts(ctx context.Context, transcript string) ([]ExtractedFact, error)
+}
+
+// PersonalNormalizer turns a raw statement into the normalized text, memory type, tags and
+// mentioned entities of a personal memory. Normalizer implements it.
+type PersonalNormalizer interface {
+	NormalizeMemory(ctx context.Context, rawText string) (NormalizedMemory, error)
+}
+
+// PendingFact is an extracted fact whose confidence was too low to store without asking.
+type PendingFact struct {
+	ExtractedFact
+	Normalized string        `json:"normalized"`
+	MemoryType string        `json:"memory_type"`
+	Tags       []string      `json:"tags,omitempty"`
+	Entities   []EntityRef   `json:"entities,omitempty"`
+	Relations  []RelationRef `json:"relations,omitempty"`
+}
+
+// ExtractionResult reports what RememberExtractedFacts did with each fact.
+type ExtractionResult struct {
+	Stored  []MemoryItem
+	Pending []PendingFact
+}
+
+// RememberExtractedFacts normalizes facts extracted from a thread and stores those with at
+// least minConfidence as the agent's personal memories, deduplicated like any other, linked
+// to the entities they mention. The rest are returned as pending so the user can be asked
+// to confirm them.
+func (s *Store) RememberExtractedFacts(
+	ctx context.Context,
+	agentID, threadID string,
+	facts []ExtractedFact,
+	normalizer PersonalNormalizer,
+	minConfidence float64,
+) (ExtractionResult, error) {
+	var result ExtractionResult
+	ctx = WithProvenance(ctx, Provenance{AgentID: agentID, ThreadID: threadID, Source: ProvenanceExtraction})
+
+	for _, fact := range facts {
+		statement := strings.TrimSpace(fact.Statement)
+		if statement == "" {
+			continue
+		}
+		normalized, err := normalizer.NormalizeMemory(ctx, statement)
+		if err != nil {
+			return result, fmt.Errorf("normalize extracted fact: %w", err)
+		}
+
+		if fact.Confidence < minConfidence {
+			result.Pending = append(result.Pending, PendingFact{
+				ExtractedFact: fact,
+				Normalized:    normalized.Text,
+				MemoryType:    normalized.Type,
+				Tags:          normalized.Tags,
+				Entities:      normalized.Entities,
+				Relations:     normalized.Relations,
+			})
+			continue
+		}
+
+		item, err := s.storeExtractedFact(ctx, agentID, threadID, statement, normalized, fact.Confidence,
+			map[string]interface{}{"confidence": fact.Confidence}, nil)
+		if err != nil {
+			return result, err
+		}
+		result.Stored = append(result.Stored, item)
+	}
+	return result, nil
+}
+
+// storeExtractedFact stores a normalized fact as the agent's personal memory and links the
+// entities it mentions, in one transaction together with within if it is set.
+func (s *Store) storeExtractedFact(
+	ctx context.Context,
+	agentID, threadID, statement string,
+	normalized NormalizedMemory,
+	importance float64,
+	metadata map[string]interface{},
+	within writeHook,
+) (MemoryItem, error) {
+	var threadPtr *string
+	if threadID != "" {
+		threadPtr = &threadID
+	}
+	item, err := s.storePersonalMemory(ctx, agentID, statement, normalized.Text, normalized.Type, normalized.Tags, threadPtr,
+		importance, metadata, func(ctx context.Context, tx *sql.Tx, memoryID int64) error {
+			if err := linkEntities(ctx, tx, memoryID, normalized.Entities, normalized.Relations); err != nil {
+				return fmt.Errorf("link entities of extracted fact: %w", err)
+			}
+			if within != nil {
+				return within(ctx, tx, memoryID)
+			}
+			return nil
+		})
+	if err != nil {
+		return MemoryItem{}, fmt.Errorf("store extracted fact: %w", err)
+	}
+	return item, nil
+}
+
+// AnthropicFactExtractor implements FactExtractor using Claude via the Messages API.
+type AnthropicFactExtractor struct {
+	APIKey     string
+	Model      string
+	MaxTokens  int
+	HTTPClient *http.Client
+	logger     zerolog.Logger
+}
+
+// NewAnthropicFactExtractor returns a configured fact extractor.
+func NewAnthropicFactExtractor(model, apiKey string, maxTokens int, logger zerolog.Logger) *AnthropicFactExtractor {
+	if maxTokens <= 0 {
+		maxTokens = 512
+	}
+	return &AnthropicFactExtractor{
+		APIKey:    apiKey,
+		Model:     model,
+		MaxTokens: maxTokens,
+		HTTPClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		logger: logger.With().Str("component", "factExtractor").Logger(),
+	}
+}
+
+// ExtractFacts asks the model for durable facts and preferences about the user in transcript.
+func (e *AnthropicFactExtractor) ExtractFacts(ctx context.Context, transcript string) ([]ExtractedFact, error) {
+	if e.APIKey == "" {
+		return nil, fmt.Errorf("fact extractor: missing API key")
+	}
+	if e.Model == "" {
+		return nil, fmt.Errorf("fact extractor: model name is required")
+	}
+	if strings.TrimSpace(transcript) == "" {
+		return nil, nil
+	}
+
+	systemPrompt := `You extract long-term personal memories from conversations between a user and an AI assistant.
+
+Find facts the user states or clearly implies about themselves: preferences, habits, goals, values, biographical details and ongoing projects. Ignore requests, small talk, anything about the assistant, and details that only matter for the current task.
+
+Output MUST be valid JSON with this exact shape and no extra keys:
+{
+  "facts": [{"statement": string, "confidence": number}]
+}
+
+Requirements:
+- "statement" is a short sentence in the user's own terms, e.g. "I prefer tea over coffee".
+- "confidence" is between 0 and 1: how sure you are that the fact is true and still useful in a month.
+  Use 0.9 or more only for facts the user stated directly.
+- Do NOT include secrets (API keys, passwords, tokens).
+- Return {"facts": []} if there is nothing worth remembering.
+
+You must output ONLY the JSON object. Do not include explanations, comments, or surrounding text.`
+
+	payload := map[string]interface{}{
+		"model":       e.Model,
+		"max_tokens":  e.MaxTokens,
+		"temperature": 0.0,
+		"system":      systemPrompt,
+		"messages": []map[string]interface{}{
+			{
+				"role":    "user",
+				"content": "Extract personal memories from this conversation:\n\n" + transcript,
+			},
+		},
+	}
+	bodyBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("fact extractor: marshal request: %w", err)
+	}
+
+	// Create backoff configuration
+	eb := backoff.NewExponentialBackOff()
+	eb.InitialInterval = 1 * time.Second
+	eb.Multiplier = 2.0
+	eb.MaxInterval = 60 * time.Second
+	eb.MaxElapsedTime = 5 * time.Minute
+	eb.RandomizationFactor = 0.2 // 20% jitter
+	eb.Reset()
+
+	var facts []ExtractedFact
+	operation := func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.anthropic.com/v1/messages", bytes.NewReader(bodyBytes))
+		if err != nil {
+			return backoff.Permanent(fmt.Errorf("fact extractor: create request: %w", err))
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("x-api-key", e.APIKey)
+		req.Header.Set("anthropic-version", "2023-06-01")
+
+		resp, err := e.HTTPClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("fact extractor: request failed: %w", err)
+		}
+		defer resp.Body.Close() //nolint:errcheck // Body close error can be ignored
+
+		if resp.StatusCode >= 400 {
+			var apiErr map[string]interface{}
+			_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+
+			if resp.StatusCode == 429 {
+				if retryAfter := extractRetryAfterFromResponseSummarizer(resp); retryAfter > 0 {
+					eb.InitialInterval = retryAfter
+					eb.Reset()
+				}
+				e.logger.Warn().Msg("Fact extractor: Rate limit encountered, retrying")
+				return fmt.Errorf("fact extractor: rate limit: %s: %v", resp.Status, apiErr)
+			}
+			// Don't retry on 4xx errors (except 429)
+			if resp.StatusCode < 500 {
+				return backoff.Permanent(fmt.Errorf("fact extractor: API error %s: %v", resp.Status, apiErr))
+			}
+			return fmt.Errorf("fact extractor: server error %s: %v", resp.Status, apiErr)
+		}
+
+		var msgResp struct {
+			Content []struct {
+				Type string `json:"type"`
+				Text string `json:"text"`
+			} `json:"content"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&msgResp); err != nil {
+			return fmt.Errorf("fact extractor: decode response: %w", err)
+		}
+		if len(msgResp.Content) == 0 {
+			return fmt.Errorf("fact extractor: empty content in response")
+		}
+
+		var out struct {
+			Facts []ExtractedFact `json:"facts"`
+		}
+		if err := json.Unmarshal([]byte(strings.TrimSpace(msgResp.Content[0].Text)), &out); err != nil {
+			return backoff.Permanent(fmt.Errorf("fact extractor: parse model JSON: %w", err))
+		}
+		facts = out.Facts
+		return nil
+	}
+
+	if err := backoff.Retry(operation, backoff.WithContext(backoff.WithMaxRetries(eb, 5), ctx)); err != nil {
+		return nil, err
+	}
+	for i := range facts {
+		facts[i].Statement = stripSecrets(strings.TrimSpace(facts[i].Statement))
+		facts[i].Confidence = max(0, min(1, facts[i].Confidence))
+	}
+	return facts, nil
+}
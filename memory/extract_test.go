@@ -0,0 +1,194 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// stubNormalizer restates facts in the third person and tags them all "test".
+type stubNormalizer struct{}
+
+func (stubNormalizer) NormalizeMemory(ctx context.Context, rawText string) (NormalizedMemory, error) {
+	return NormalizedMemory{
+		Text: "The user says: " + strings.TrimPrefix(rawText, "I "),
+		Type: "preference",
+		Tags: []string{"test"},
+	}, nil
+}
+
+func TestRememberExtractedFacts_StoresConfidentFactsAndDeduplicates(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	store, err := NewStore(db, newSemanticEmbedder(8), zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	facts := []ExtractedFact{
+		{Statement: "I prefer tea over coffee", Confidence: 0.95},
+		{Statement: "I might move to Lisbon", Confidence: 0.4},
+		{Statement: "  ", Confidence: 1},
+	}
+	result, err := store.RememberExtractedFacts(ctx, "agent-1", "thread-1", facts, stubNormalizer{}, 0.8)
+	if err != nil {
+		t.Fatalf("RememberExtractedFacts: %v", err)
+	}
+	if len(result.Stored) != 1 || len(result.Pending) != 1 {
+		t.Fatalf("expected 1 stored and 1 pending fact, got %d and %d", len(result.Stored), len(result.Pending))
+	}
+	if result.Pending[0].Statement != "I might move to Lisbon" || result.Pending[0].Normalized == "" {
+		t.Errorf("unexpected pending fact: %+v", result.Pending[0])
+	}
+
+	stored, err := store.GetMemory(ctx, result.Stored[0].ID)
+	if err != nil {
+		t.Fatalf("GetMemory: %v", err)
+	}
+	if stored.MemoryType != "preference" || stored.Provenance == nil || stored.Provenance.Source != ProvenanceExtraction {
+		t.Errorf("unexpected stored memory: type %q, provenance %+v", stored.MemoryType, stored.Provenance)
+	}
+
+	// Extracting the same fact again merges it into the existing memory
+	again, err := store.RememberExtractedFacts(ctx, "agent-1", "thread-1", facts[:1], stubNormalizer{}, 0.8)
+	if err != nil {
+		t.Fatalf("RememberExtractedFacts: %v", err)
+	}
+	if len(again.Stored) != 1 || again.Stored[0].ID != stored.ID {
+		t.Errorf("expected the fact to merge into memory %d, got %+v", stored.ID, again.Stored)
+	}
+}
+
+func TestPendingFact_StoredWhenConfirmed(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	store, err := NewStore(db, newSemanticEmbedder(8), zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	facts := []ExtractedFact{
+		{Statement: "I might move to Lisbon", Confidence: 0.4},
+		{Statement: "I could take up the cello", Confidence: 0.3},
+	}
+	result, err := store.RememberExtractedFacts(ctx, "agent-1", "thread-1", facts, stubNormalizer{}, 0.8)
+	if err != nil {
+		t.Fatalf("RememberExtractedFacts: %v", err)
+	}
+	if len(result.Pending) != 2 {
+		t.Fatalf("expected 2 pending facts, got %d", len(result.Pending))
+	}
+	var inboxIDs []int64
+	for _, pending := range result.Pending {
+		res, err := db.ExecContext(ctx, `INSERT INTO inbox (agent_id, thread_id, message, requires_response, created_at, updated_at)
+VALUES ('agent-1', 'thread-1', 'Is this right?', 1, 0, 0)`)
+		if err != nil {
+			t.Fatalf("insert inbox item: %v", err)
+		}
+		id, _ := res.LastInsertId()
+		if err := store.AddPendingFact(ctx, id, "agent-1", "thread-1", pending); err != nil {
+			t.Fatalf("AddPendingFact: %v", err)
+		}
+		inboxIDs = append(inboxIDs, id)
+	}
+	if n := countActiveItems(t, store); n != 0 {
+		t.Fatalf("expected nothing stored before confirmation, got %d items", n)
+	}
+
+	item, err := store.ConfirmPendingFact(ctx, inboxIDs[0])
+	if err != nil {
+		t.Fatalf("ConfirmPendingFact: %v", err)
+	}
+	stored, err := store.GetMemory(ctx, item.ID)
+	if err != nil {
+		t.Fatalf("GetMemory: %v", err)
+	}
+	if stored.Content != result.Pending[0].Normalized || stored.RawContent != "I might move to Lisbon" || stored.Metadata["confirmed"] != true {
+		t.Errorf("unexpected confirmed memory: %+v", stored)
+	}
+	if stored.Provenance == nil || stored.Provenance.Source != ProvenanceExtraction || stored.Provenance.ThreadID != "thread-1" {
+		t.Errorf("expected extraction provenance, got %+v", stored.Provenance)
+	}
+	if _, err := store.ConfirmPendingFact(ctx, inboxIDs[0]); !errors.Is(err, ErrNoPendingFact) {
+		t.Errorf("expected a second confirmation to find nothing, got %v", err)
+	}
+
+	// Declining drops the fact, and deleting an inbox item drops its pending fact
+	if err := store.DiscardPendingFact(ctx, inboxIDs[1]); err != nil {
+		t.Fatalf("DiscardPendingFact: %v", err)
+	}
+	if n := countActiveItems(t, store); n != 1 {
+		t.Errorf("expected only the confirmed fact stored, got %d items", n)
+	}
+	if err := store.AddPendingFact(ctx, inboxIDs[1], "agent-1", "thread-1", result.Pending[1]); err != nil {
+		t.Fatalf("AddPendingFact: %v", err)
+	}
+	if _, err := db.ExecContext(ctx, `DELETE FROM inbox`); err != nil {
+		t.Fatalf("clear inbox: %v", err)
+	}
+	if _, err := store.ConfirmPendingFact(ctx, inboxIDs[1]); !errors.Is(err, ErrNoPendingFact) {
+		t.Errorf("expected the pending fact removed with its inbox item, got %v", err)
+	}
+}
+
+func TestPendingFact_ConfirmRollsBackWhenNotRemoved(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	store, err := NewStore(db, newSemanticEmbedder(8), zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	res, err := db.ExecContext(ctx, `INSERT INTO inbox (agent_id, thread_id, message, requires_response, created_at, updated_at)
+VALUES ('agent-1', 'thread-1', 'Is this right?', 1, 0, 0)`)
+	if err != nil {
+		t.Fatalf("insert inbox item: %v", err)
+	}
+	inboxID, _ := res.LastInsertId()
+	fact := PendingFact{
+		ExtractedFact: ExtractedFact{Statement: "I work with Dana at Acme", Confidence: 0.5},
+		Normalized:    "The user works with Dana at Acme",
+		MemoryType:    "fact",
+		Entities:      []EntityRef{{Name: "Dana", Kind: EntityPerson}, {Name: "Acme", Kind: EntityOrganization}},
+	}
+	if err := store.AddPendingFact(ctx, inboxID, "agent-1", "thread-1", fact); err != nil {
+		t.Fatalf("AddPendingFact: %v", err)
+	}
+
+	// When the pending fact can't be removed, nothing the confirmation wrote is kept
+	if _, err := db.ExecContext(ctx, `CREATE TRIGGER keep_pending BEFORE DELETE ON pending_facts BEGIN SELECT RAISE(ABORT, 'kept'); END`); err != nil {
+		t.Fatalf("create trigger: %v", err)
+	}
+	if _, err := store.ConfirmPendingFact(ctx, inboxID); err == nil {
+		t.Fatal("expected confirmation to fail")
+	}
+	if n := countActiveItems(t, store); n != 0 {
+		t.Errorf("expected the memory rolled back, got %d items", n)
+	}
+	var entities int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM entities`).Scan(&entities); err != nil {
+		t.Fatalf("count entities: %v", err)
+	}
+	if entities != 0 {
+		t.Errorf("expected the entity links rolled back, got %d entities", entities)
+	}
+
+	// Once it can be removed, the fact is stored exactly once
+	if _, err := db.ExecContext(ctx, `DROP TRIGGER keep_pending`); err != nil {
+		t.Fatalf("drop trigger: %v", err)
+	}
+	if _, err := store.ConfirmPendingFact(ctx, inboxID); err != nil {
+		t.Fatalf("ConfirmPendingFact: %v", err)
+	}
+	if n := countActiveItems(t, store); n != 1 {
+		t.Errorf("expected 1 stored memory, got %d", n)
+	}
+}
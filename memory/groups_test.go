@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestMemoryRouter_GroupFactsVisibleToMembersOnly(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	store, err := NewStore(db, newSemanticEmbedder(8), zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	store.SetDedupConfig(DedupConfig{Enabled: false})
+	router := NewMemoryRouter(store, Config{
+		Groups: map[string][]string{"finance-team": {"budget", "tax"}},
+	}, zerolog.Nop())
+
+	if _, err := router.AddGroupFact(ctx, "travel", "finance-team", "The fiscal year ends in March.", nil); !errors.Is(err, ErrNotGroupMember) {
+		t.Fatalf("expected ErrNotGroupMember for a non-member, got %v", err)
+	}
+	fact, err := router.AddGroupFact(ctx, "budget", "finance-team", "The fiscal year ends in March.", nil)
+	if err != nil {
+		t.Fatalf("AddGroupFact: %v", err)
+	}
+	if fact.Scope != ScopeGroup || fact.Group != "finance-team" || fact.AgentID != nil {
+		t.Fatalf("unexpected group fact: %+v", fact)
+	}
+	if _, err := router.AddAgentFact(ctx, "tax", "Tax returns are due in April.", nil); err != nil {
+		t.Fatalf("AddAgentFact: %v", err)
+	}
+
+	search := func(agentID string) []SearchResult {
+		t.Helper()
+		results, err := router.QueryAgentMemory(ctx, agentID, AgentQueryOptions{Text: "fiscal", Limit: 10})
+		if err != nil {
+			t.Fatalf("QueryAgentMemory(%s): %v", agentID, err)
+		}
+		return results
+	}
+	if results := search("tax"); len(results) != 1 || results[0].Item.ID != fact.ID || results[0].Item.Group != "finance-team" {
+		t.Errorf("expected the group fact for a member, got %+v", results)
+	}
+	if results := search("travel"); len(results) != 0 {
+		t.Errorf("expected no group facts for a non-member, got %d results", len(results))
+	}
+
+	// Members may edit group memories; other agents may not
+	content := "The fiscal year ends in June."
+	if _, err := router.UpdateMemory(ctx, "tax", fact.ID, MemoryUpdate{Content: &content}); err != nil {
+		t.Errorf("UpdateMemory by member: %v", err)
+	}
+	if err := router.ForgetMemory(ctx, "travel", fact.ID); !errors.Is(err, ErrNotGroupMember) {
+		t.Errorf("expected ErrNotGroupMember when a non-member forgets a group fact, got %v", err)
+	}
+}
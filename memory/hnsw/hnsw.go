@@ -0,0 +1,435 @@
+// Package hnsw implements a Hierarchical Navigable Small World graph for approximate
+// nearest-neighbor search over embedding vectors using cosine similarity.
+//
+// See Malkov & Yashunin, "Efficient and robust approximate nearest neighbor search
+// using Hierarchical Navigable Small World graphs" (2016).
+package hnsw
+
+import (
+	"container/heap"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+// Default graph parameters. They favour recall over build speed, which suits memory
+// stores of up to a few hundred thousand items.
+const (
+	DefaultM              = 16  // Neighbors per node on upper layers (twice this on layer 0)
+	DefaultEfConstruction = 200 // Candidate list size while inserting
+	DefaultEfSearch       = 100 // Minimum candidate list size while searching
+)
+
+// Result is a search hit.
+type Result struct {
+	ID    int64
+	Score float64 // Cosine similarity
+}
+
+// node is a vector in the graph. Neighbors[l] lists the node's neighbors on layer l.
+type node struct {
+	ID        int64
+	Vector    []float32 // Normalized to unit length
+	Neighbors [][]int32
+	Deleted   bool // Tombstoned nodes are still traversed but never returned
+}
+
+// Graph is an HNSW index. It is safe for concurrent use.
+type Graph struct {
+	mu             sync.RWMutex
+	m              int
+	efConstruction int
+	efSearch       int
+	levelMult      float64
+	rng            *rand.Rand
+
+	dim      int
+	nodes    []node
+	ids      map[int64]int32 // Live node for each ID
+	entry    int32           // Entry point, -1 when empty
+	maxLevel int
+}
+
+// New creates an empty graph with default parameters.
+func New() *Graph {
+	return &Graph{
+		m:              DefaultM,
+		efConstruction: DefaultEfConstruction,
+		efSearch:       DefaultEfSearch,
+		levelMult:      1 / math.Log(float64(DefaultM)),
+		rng:            rand.New(rand.NewSource(1)), //nolint:gosec // Level assignment needs no cryptographic randomness
+		ids:            make(map[int64]int32),
+		entry:          -1,
+	}
+}
+
+// Len returns the number of live vectors in the graph.
+func (g *Graph) Len() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return len(g.ids)
+}
+
+// Dim returns the vector dimension, or 0 for an empty graph.
+func (g *Graph) Dim() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.dim
+}
+
+// Contains reports whether id has a live vector in the graph.
+func (g *Graph) Contains(id int64) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	_, ok := g.ids[id]
+	return ok
+}
+
+// Add inserts a vector. Adding an ID that is already present replaces its vector.
+func (g *Graph) Add(id int64, vector []float32) error {
+	vec := normalize(vector)
+	if vec == nil {
+		return fmt.Errorf("cannot index zero vector for id %d", id)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.dim == 0 {
+		g.dim = len(vec)
+	} else if len(vec) != g.dim {
+		return fmt.Errorf("vector dimension %d does not match index dimension %d", len(vec), g.dim)
+	}
+	if old, ok := g.ids[id]; ok {
+		g.nodes[old].Deleted = true
+	}
+
+	level := int(math.Floor(-math.Log(1-g.rng.Float64()) * g.levelMult))
+	idx := int32(len(g.nodes)) //nolint:gosec // Index sizes stay far below 2^31
+	g.nodes = append(g.nodes, node{
+		ID:        id,
+		Vector:    vec,
+		Neighbors: make([][]int32, level+1),
+	})
+	g.ids[id] = idx
+
+	if g.entry < 0 {
+		g.entry = idx
+		g.maxLevel = level
+		return nil
+	}
+
+	// Greedy descent through the layers above the new node's level
+	ep := g.entry
+	for l := g.maxLevel; l > level; l-- {
+		ep = g.greedyClosest(vec, ep, l)
+	}
+
+	// Connect the node on each of its layers
+	for l := min(level, g.maxLevel); l >= 0; l-- {
+		candidates := g.searchLayer(vec, ep, g.efConstruction, l)
+		neighbors := g.selectNeighbors(candidates, g.maxNeighbors(l))
+		g.nodes[idx].Neighbors[l] = neighbors
+		for _, n := range neighbors {
+			g.connect(n, idx, l)
+		}
+		ep = candidates[0].idx
+	}
+
+	if level > g.maxLevel {
+		g.maxLevel = level
+		g.entry = idx
+	}
+	return nil
+}
+
+// Delete removes id from search results. The node stays in the graph to keep it
+// navigable until the index is rebuilt.
+func (g *Graph) Delete(id int64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if idx, ok := g.ids[id]; ok {
+		g.nodes[idx].Deleted = true
+		delete(g.ids, id)
+	}
+}
+
+// Search returns up to k live vectors most similar to query, best first.
+func (g *Graph) Search(query []float32, k int) []Result {
+	vec := normalize(query)
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if vec == nil || g.entry < 0 || len(vec) != g.dim || k <= 0 {
+		return nil
+	}
+
+	ep := g.entry
+	for l := g.maxLevel; l > 0; l-- {
+		ep = g.greedyClosest(vec, ep, l)
+	}
+
+	candidates := g.searchLayer(vec, ep, max(g.efSearch, k), 0)
+	results := make([]Result, 0, k)
+	for _, c := range candidates {
+		if g.nodes[c.idx].Deleted {
+			continue
+		}
+		results = append(results, Result{ID: g.nodes[c.idx].ID, Score: 1 - c.dist})
+		if len(results) == k {
+			break
+		}
+	}
+	return results
+}
+
+// maxNeighbors returns the neighbor limit for a layer.
+func (g *Graph) maxNeighbors(layer int) int {
+	if layer == 0 {
+		return 2 * g.m
+	}
+	return g.m
+}
+
+// connect adds a link from node from to node to on a layer, pruning from's neighbor
+// list with selectNeighbors if it grows past the limit.
+func (g *Graph) connect(from, to int32, layer int) {
+	neighbors := append(g.nodes[from].Neighbors[layer], to)
+	if limit := g.maxNeighbors(layer); len(neighbors) > limit {
+		base := g.nodes[from].Vector
+		scored := make([]candidate, len(neighbors))
+		for i, n := range neighbors {
+			scored[i] = candidate{idx: n, dist: distance(base, g.nodes[n].Vector)}
+		}
+		sort.Slice(scored, func(i, j int) bool { return scored[i].dist < scored[j].dist })
+		neighbors = g.selectNeighbors(scored, limit)
+	}
+	g.nodes[from].Neighbors[layer] = neighbors
+}
+
+// selectNeighbors picks up to n neighbors from candidates, which must be sorted by
+// distance, using the heuristic from the HNSW paper: a candidate closer to an already
+// selected neighbor than to the base node is passed over in favor of more distant
+// candidates. Plain closest-n selection lets a dense cluster crowd out the only links
+// to outlying nodes, leaving them unreachable. Passed-over candidates fill any
+// remaining slots.
+func (g *Graph) selectNeighbors(candidates []candidate, n int) []int32 {
+	out := make([]int32, 0, min(n, len(candidates)))
+	var skipped []int32
+	for _, c := range candidates {
+		if len(out) == n {
+			break
+		}
+		diverse := true
+		for _, sel := range out {
+			if distance(g.nodes[c.idx].Vector, g.nodes[sel].Vector) < c.dist {
+				diverse = false
+				break
+			}
+		}
+		if diverse {
+			out = append(out, c.idx)
+		} else {
+			skipped = append(skipped, c.idx)
+		}
+	}
+	for _, idx := range skipped {
+		if len(out) == n {
+			break
+		}
+		out = append(out, idx)
+	}
+	return out
+}
+
+// greedyClosest walks a layer from ep toward the node closest to vec.
+func (g *Graph) greedyClosest(vec []float32, ep int32, layer int) int32 {
+	best := ep
+	bestDist := distance(vec, g.nodes[ep].Vector)
+	for changed := true; changed; {
+		changed = false
+		for _, n := range g.neighbors(best, layer) {
+			if d := distance(vec, g.nodes[n].Vector); d < bestDist {
+				best, bestDist, changed = n, d, true
+			}
+		}
+	}
+	return best
+}
+
+// searchLayer returns up to ef nodes closest to vec on a layer, sorted by distance.
+func (g *Graph) searchLayer(vec []float32, ep int32, ef, layer int) []candidate {
+	visited := map[int32]bool{ep: true}
+	start := candidate{idx: ep, dist: distance(vec, g.nodes[ep].Vector)}
+	toVisit := &minHeap{start}
+	found := &maxHeap{start}
+
+	for toVisit.Len() > 0 {
+		c := heap.Pop(toVisit).(candidate)
+		if c.dist > (*found)[0].dist && found.Len() >= ef {
+			break
+		}
+		for _, n := range g.neighbors(c.idx, layer) {
+			if visited[n] {
+				continue
+			}
+			visited[n] = true
+			d := distance(vec, g.nodes[n].Vector)
+			if found.Len() < ef || d < (*found)[0].dist {
+				heap.Push(toVisit, candidate{idx: n, dist: d})
+				heap.Push(found, candidate{idx: n, dist: d})
+				if found.Len() > ef {
+					heap.Pop(found)
+				}
+			}
+		}
+	}
+
+	result := make([]candidate, found.Len())
+	for i := len(result) - 1; i >= 0; i-- {
+		result[i] = heap.Pop(found).(candidate)
+	}
+	return result
+}
+
+// neighbors returns a node's neighbors on a layer, or nil if the node doesn't reach it.
+func (g *Graph) neighbors(idx int32, layer int) []int32 {
+	if layer >= len(g.nodes[idx].Neighbors) {
+		return nil
+	}
+	return g.nodes[idx].Neighbors[layer]
+}
+
+// snapshot is the serialized form of a graph.
+type snapshot struct {
+	M              int
+	EfConstruction int
+	EfSearch       int
+	Dim            int
+	Nodes          []node
+	Entry          int32
+	MaxLevel       int
+}
+
+// Save writes the graph to w.
+func (g *Graph) Save(w io.Writer) error {
+	return g.Encode(gob.NewEncoder(w))
+}
+
+// Encode writes the graph with enc, so it can follow other values in the same gob stream.
+func (g *Graph) Encode(enc *gob.Encoder) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	err := enc.Encode(snapshot{
+		M:              g.m,
+		EfConstruction: g.efConstruction,
+		EfSearch:       g.efSearch,
+		Dim:            g.dim,
+		Nodes:          g.nodes,
+		Entry:          g.entry,
+		MaxLevel:       g.maxLevel,
+	})
+	if err != nil {
+		return fmt.Errorf("encode hnsw graph: %w", err)
+	}
+	return nil
+}
+
+// Load reads a graph written by Save.
+func Load(r io.Reader) (*Graph, error) {
+	return Decode(gob.NewDecoder(r))
+}
+
+// Decode reads a graph written by Encode. The decoder buffers its input, so values that
+// follow others in a gob stream must be read with the same decoder.
+func Decode(dec *gob.Decoder) (*Graph, error) {
+	var snap snapshot
+	if err := dec.Decode(&snap); err != nil {
+		return nil, fmt.Errorf("decode hnsw graph: %w", err)
+	}
+	if snap.M <= 1 {
+		return nil, fmt.Errorf("invalid hnsw graph parameter M=%d", snap.M)
+	}
+
+	g := New()
+	g.m = snap.M
+	g.efConstruction = snap.EfConstruction
+	g.efSearch = snap.EfSearch
+	g.levelMult = 1 / math.Log(float64(snap.M))
+	g.dim = snap.Dim
+	g.nodes = snap.Nodes
+	g.entry = snap.Entry
+	g.maxLevel = snap.MaxLevel
+	for i := range g.nodes {
+		if !g.nodes[i].Deleted {
+			g.ids[g.nodes[i].ID] = int32(i) //nolint:gosec // Index sizes stay far below 2^31
+		}
+	}
+	// Seed level assignment from the graph size so reloaded graphs don't repeat levels
+	g.rng = rand.New(rand.NewSource(int64(len(g.nodes)) + 1)) //nolint:gosec // See New
+	return g, nil
+}
+
+// normalize returns vec scaled to unit length, or nil for a zero or empty vector.
+func normalize(vec []float32) []float32 {
+	var norm float64
+	for _, v := range vec {
+		norm += float64(v) * float64(v)
+	}
+	if norm == 0 {
+		return nil
+	}
+	norm = math.Sqrt(norm)
+	out := make([]float32, len(vec))
+	for i, v := range vec {
+		out[i] = float32(float64(v) / norm)
+	}
+	return out
+}
+
+// distance is the cosine distance between two unit vectors.
+func distance(a, b []float32) float64 {
+	var dot float32
+	for i := range a {
+		dot += a[i] * b[i]
+	}
+	return 1 - float64(dot)
+}
+
+// candidate is a node and its distance from the query.
+type candidate struct {
+	idx  int32
+	dist float64
+}
+
+// minHeap orders candidates nearest first.
+type minHeap []candidate
+
+func (h minHeap) Len() int           { return len(h) }
+func (h minHeap) Less(i, j int) bool { return h[i].dist < h[j].dist }
+func (h minHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *minHeap) Push(x any)        { *h = append(*h, x.(candidate)) }
+func (h *minHeap) Pop() any {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
+
+// maxHeap orders candidates farthest first.
+type maxHeap []candidate
+
+func (h maxHeap) Len() int           { return len(h) }
+func (h maxHeap) Less(i, j int) bool { return h[i].dist > h[j].dist }
+func (h maxHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *maxHeap) Push(x any)        { *h = append(*h, x.(candidate)) }
+func (h *maxHeap) Pop() any {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
@@ -0,0 +1,123 @@
+package hnsw
+
+import (
+	"bytes"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func randomVectors(n, dim int, seed int64) [][]float32 {
+	rng := rand.New(rand.NewSource(seed))
+	vecs := make([][]float32, n)
+	for i := range vecs {
+		vecs[i] = make([]float32, dim)
+		for j := range vecs[i] {
+			vecs[i][j] = float32(rng.NormFloat64())
+		}
+	}
+	return vecs
+}
+
+// bruteForce returns the IDs of the k vectors most similar to query.
+func bruteForce(vecs [][]float32, query []float32, k int) []int64 {
+	q := normalize(query)
+	type scored struct {
+		id   int64
+		dist float64
+	}
+	all := make([]scored, len(vecs))
+	for i, v := range vecs {
+		all[i] = scored{id: int64(i), dist: distance(q, normalize(v))}
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].dist < all[j].dist })
+	ids := make([]int64, k)
+	for i := range ids {
+		ids[i] = all[i].id
+	}
+	return ids
+}
+
+func TestGraph_Recall(t *testing.T) {
+	const n, dim, k = 2000, 32, 10
+	vecs := randomVectors(n, dim, 42)
+	g := New()
+	for i, v := range vecs {
+		if err := g.Add(int64(i), v); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	queries := randomVectors(50, dim, 7)
+	hits := 0
+	for _, q := range queries {
+		want := make(map[int64]bool)
+		for _, id := range bruteForce(vecs, q, k) {
+			want[id] = true
+		}
+		for _, r := range g.Search(q, k) {
+			if want[r.ID] {
+				hits++
+			}
+		}
+	}
+	recall := float64(hits) / float64(len(queries)*k)
+	if recall < 0.95 {
+		t.Errorf("recall@%d too low: %.3f", k, recall)
+	}
+}
+
+func TestGraph_SaveLoadDeleteReplace(t *testing.T) {
+	vecs := randomVectors(200, 8, 1)
+	g := New()
+	for i, v := range vecs {
+		if err := g.Add(int64(i), v); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := g.Save(&buf); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	loaded, err := Load(&buf)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if loaded.Len() != 200 || loaded.Dim() != 8 {
+		t.Fatalf("loaded graph: len=%d dim=%d", loaded.Len(), loaded.Dim())
+	}
+
+	// A vector's nearest neighbor is itself
+	if got := loaded.Search(vecs[17], 1); len(got) != 1 || got[0].ID != 17 {
+		t.Errorf("expected id 17 as nearest, got %+v", got)
+	}
+
+	loaded.Delete(17)
+	if got := loaded.Search(vecs[17], 5); len(got) == 0 || got[0].ID == 17 {
+		t.Errorf("deleted id returned: %+v", got)
+	}
+
+	// Replacing moves the ID to the new vector
+	if err := loaded.Add(3, vecs[150]); err != nil {
+		t.Fatalf("Add replace: %v", err)
+	}
+	got := loaded.Search(vecs[150], 2)
+	ids := map[int64]bool{}
+	for _, r := range got {
+		ids[r.ID] = true
+	}
+	if !ids[3] || !ids[150] {
+		t.Errorf("expected ids 3 and 150 for replaced vector, got %+v", got)
+	}
+	if loaded.Len() != 199 {
+		t.Errorf("expected 199 live vectors, got %d", loaded.Len())
+	}
+
+	if err := loaded.Add(999, make([]float32, 8)); err == nil {
+		t.Error("expected error for zero vector")
+	}
+	if err := loaded.Add(999, []float32{1, 2}); err == nil {
+		t.Error("expected error for dimension mismatch")
+	}
+}
@@ -12,12 +12,14 @@ const (
 	MemoryTypeDocRef  MemoryType = "doc_ref"
 )
 
-// Scope indicates whether a memory is agent-local or globally shared.
+// Scope indicates whether a memory is agent-local, shared with a group of agents, or
+// globally shared.
 type Scope string
 
 const (
 	ScopeAgent  Scope = "agent"
 	ScopeGlobal Scope = "global"
+	ScopeGroup  Scope = "group"
 )
 
 // MemoryItem is a single unit of memory (fact, episode, etc.).
@@ -25,7 +27,7 @@ type MemoryItem struct {
 	ID         int64                  `json:"id"`
 	AgentID    *string                `json:"agent_id,omitempty"`  // nil for global
 	ThreadID   *string                `json:"thread_id,omitempty"` // optional task/thread linkage
-	Scope      Scope                  `json:"scope"`               // "agent" or "global"
+	Scope      Scope                  `json:"scope"`               // "agent", "group" or "global"
 	Type       MemoryType             `json:"type"`
 	Content    string                 `json:"content"`
 	Embedding  []float32              `json:"embedding,omitempty"`
@@ -33,10 +35,31 @@ type MemoryItem struct {
 	CreatedAt  time.Time              `json:"created_at"`
 	UpdatedAt  time.Time              `json:"updated_at"`
 	Importance float64                `json:"importance"`
+	// Sharing group whose agents can see the memory; set only for ScopeGroup
+	Group string `json:"group,omitempty"`
+	// Embedder that produced Embedding (see Embedder.Name) and its dimension
+	EmbeddingModel string `json:"embedding_model,omitempty"`
+	EmbeddingDim   int    `json:"embedding_dim,omitempty"`
+	// Newer item that replaced this one; superseded items are excluded from search
+	SupersededBy *int64 `json:"superseded_by,omitempty"`
+	// Retention: when the item expires, and when it was archived (excluded from search)
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
 	// Normalization-enriched fields for personal memories
 	RawContent string   `json:"raw_content,omitempty"` // original user/agent statement
 	MemoryType string   `json:"memory_type,omitempty"` // preference, biographical, habit, goal, value, project, other
 	Tags       []string `json:"tags,omitempty"`        // denormalized view of tags_json
+	// Where the memory came from; nil if it was not stored during an agent conversation
+	Provenance *Provenance `json:"provenance,omitempty"`
+}
+
+// Provenance records the conversation a memory was learned from.
+type Provenance struct {
+	AgentID   string `json:"agent_id,omitempty"`   // Agent that stored the memory
+	ThreadID  string `json:"thread_id,omitempty"`  // Conversation thread
+	MessageID int64  `json:"message_id,omitempty"` // User message in the thread that led to the memory (conversations.id)
+	ToolID    string `json:"tool_id,omitempty"`    // Tool call that stored the memory
+	Source    string `json:"source,omitempty"`     // Tool name, or "reflection"
 }
 
 // Artifact is a durable document / handoff object.
@@ -48,10 +71,22 @@ type Artifact struct {
 	Title     string                 `json:"title"`
 	Body      string                 `json:"body"`
 	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	Version   int                    `json:"version"` // Current revision, starting at 1
 	CreatedAt time.Time              `json:"created_at"`
 	UpdatedAt time.Time              `json:"updated_at"`
 }
 
+// ArtifactVersion is one revision of an artifact.
+type ArtifactVersion struct {
+	ArtifactID int64                  `json:"artifact_id"`
+	Version    int                    `json:"version"`
+	AgentID    *string                `json:"agent_id,omitempty"` // who wrote this revision
+	Title      string                 `json:"title"`
+	Body       string                 `json:"body"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
 // SearchQuery controls how we search memory.
 type SearchQuery struct {
 	QueryText      string
@@ -62,17 +97,51 @@ type SearchQuery struct {
 	Before         *time.Time
 	AgentID        *string
 	IncludeGlobal  bool
+	Groups         []string // Sharing groups whose memories are included
 	Limit          int
 	UseHybrid      bool
 	Tags           []string // Tags to match against memory tags (intersection)
 	UseFTS         *bool    // Whether to use FTS (nil = default true when query text exists, false = explicitly disabled, true = explicitly enabled)
 	MemoryTypes    []string // Filter by normalized memory types (preference, biographical, etc.)
+	Ranking        *Ranking // How hybrid results are fused and boosted (nil = DefaultRanking())
+	// Include items replaced by newer versions (default false)
+	IncludeSuperseded bool
+	// Include items archived by the retention job (default false)
+	IncludeArchived bool
+}
+
+// MemoryVersion is an earlier version of a memory item, recorded when the item was merged
+// with a near-duplicate, replaced an older item on the same topic, or was edited.
+type MemoryVersion struct {
+	ItemID       int64     `json:"item_id"`        // Current item the version belongs to
+	SourceItemID int64     `json:"source_item_id"` // Item that held this content
+	Content      string    `json:"content"`
+	RawContent   string    `json:"raw_content,omitempty"`
+	Tags         []string  `json:"tags,omitempty"`
+	Importance   float64   `json:"importance"`
+	Reason       string    `json:"reason"` // "merged", "superseded" or "edited"
+	CreatedAt    time.Time `json:"created_at"`
+	ReplacedAt   time.Time `json:"replaced_at"`
 }
 
 // SearchResult includes a MemoryItem plus a relevance score.
 type SearchResult struct {
 	Item  *MemoryItem
 	Score float64
+	Match *TextMatch // Why the item matched the query text; nil if it didn't
+}
+
+// Markers around matched terms in TextMatch fragments.
+const (
+	MatchStart = "**"
+	MatchEnd   = "**"
+)
+
+// TextMatch describes a full-text match. Matched terms are wrapped in MatchStart and MatchEnd.
+type TextMatch struct {
+	BM25      float64 // Relevance of the match; higher is better
+	Snippet   string  // Short fragment of the best matching field: content, raw content or tags
+	Highlight string  // The item's full content
 }
 
 // Summarizer is used by the reflection pipeline.
@@ -0,0 +1,89 @@
+// Package ngram implements an offline memory.Embedder using hashed n-gram features.
+//
+// The vectors capture lexical overlap (shared words and character trigrams) rather than
+// meaning, so they are a fallback for deployments without an embedding model, not a
+// replacement for one. They need no network access and are fully deterministic.
+package ngram
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/aschepis/backscratcher/staff/memory"
+)
+
+// DefaultDimensions is used when no dimension is configured.
+const DefaultDimensions = 512
+
+const (
+	wordWeight    = 1.0 // Weight of a whole-word feature
+	trigramWeight = 0.5 // Weight of a character trigram feature
+)
+
+type embedder struct {
+	dimensions int
+}
+
+// NewEmbedder creates an n-gram embedder producing vectors of the given dimension.
+func NewEmbedder(dimensions int) memory.Embedder {
+	if dimensions <= 0 {
+		dimensions = DefaultDimensions
+	}
+	return &embedder{dimensions: dimensions}
+}
+
+// Embed implements memory.Embedder.Embed. The result is L2-normalized; text with no
+// letters or digits yields a zero vector.
+func (e *embedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	vec := make([]float64, e.dimensions)
+	for _, word := range tokenize(text) {
+		e.add(vec, "w:"+word, wordWeight)
+		padded := []rune("#" + word + "#")
+		for i := 0; i+3 <= len(padded); i++ {
+			e.add(vec, "t:"+string(padded[i:i+3]), trigramWeight)
+		}
+	}
+
+	var norm float64
+	for _, v := range vec {
+		norm += v * v
+	}
+	result := make([]float32, e.dimensions)
+	if norm == 0 {
+		return result, nil
+	}
+	norm = math.Sqrt(norm)
+	for i, v := range vec {
+		result[i] = float32(v / norm)
+	}
+	return result, nil
+}
+
+// Name implements memory.Embedder.Name.
+func (e *embedder) Name() string {
+	return fmt.Sprintf("ngram:%d", e.dimensions)
+}
+
+// add hashes a feature into the vector. One hash bit picks the sign so that
+// collisions cancel out on average instead of accumulating.
+func (e *embedder) add(vec []float64, feature string, weight float64) {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(feature)) // hash.Hash never returns an error
+	sum := h.Sum64()
+	index := int(sum % uint64(e.dimensions)) //nolint:gosec // Bounded by dimensions
+	if sum&(1<<63) != 0 {
+		weight = -weight
+	}
+	vec[index] += weight
+}
+
+// tokenize lowercases text and splits it into runs of letters and digits.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
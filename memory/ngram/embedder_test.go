@@ -0,0 +1,61 @@
+package ngram
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/aschepis/backscratcher/staff/memory"
+)
+
+func TestEmbedder_SimilarTextScoresHigher(t *testing.T) {
+	e := NewEmbedder(256)
+	ctx := context.Background()
+
+	embed := func(text string) []float32 {
+		t.Helper()
+		vec, err := e.Embed(ctx, text)
+		if err != nil {
+			t.Fatalf("Embed(%q): %v", text, err)
+		}
+		if len(vec) != 256 {
+			t.Fatalf("expected 256 dimensions, got %d", len(vec))
+		}
+		return vec
+	}
+
+	query := embed("running a marathon")
+	related := memory.CosineSimilarity(query, embed("Adam runs marathons every spring"))
+	unrelated := memory.CosineSimilarity(query, embed("The tax return is due Friday"))
+	if related <= unrelated {
+		t.Errorf("expected related text to score higher: related=%.3f unrelated=%.3f", related, unrelated)
+	}
+}
+
+func TestEmbedder_DeterministicAndNormalized(t *testing.T) {
+	e := NewEmbedder(0)
+	if e.Name() != "ngram:512" {
+		t.Errorf("Name: got %q", e.Name())
+	}
+
+	a, _ := e.Embed(context.Background(), "Hello, World!")
+	b, _ := e.Embed(context.Background(), "hello world")
+	if memory.CosineSimilarity(a, b) < 0.999 {
+		t.Error("expected case and punctuation to be ignored")
+	}
+
+	var norm float64
+	for _, v := range a {
+		norm += float64(v) * float64(v)
+	}
+	if math.Abs(norm-1) > 1e-5 {
+		t.Errorf("expected unit vector, got squared norm %f", norm)
+	}
+
+	empty, _ := e.Embed(context.Background(), "  ...  ")
+	for _, v := range empty {
+		if v != 0 {
+			t.Fatal("expected zero vector for text without words")
+		}
+	}
+}
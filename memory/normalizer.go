@@ -41,6 +41,15 @@ func NewNormalizer(model, apiKey string, maxTokens int, logger zerolog.Logger) *
 	}
 }
 
+// NormalizedMemory is a raw statement in the structured form of a personal memory.
+type NormalizedMemory struct {
+	Text      string
+	Type      string
+	Tags      []string
+	Entities  []EntityRef   // People, projects, organizations and places the statement mentions
+	Relations []RelationRef // Relations between Entities the statement states
+}
+
 // Normalize takes raw free-form text and returns normalized text, memory type, and tags.
 //
 // Contract (from 4_memory_normalization.md):
@@ -48,15 +57,26 @@ func NewNormalizer(model, apiKey string, maxTokens int, logger zerolog.Logger) *
 //   - type: one of "preference","biographical","habit","goal","value","project","other"
 //   - tags: 3–8 lowercase tokens, no spaces; if empty, falls back to ["misc"]
 func (n *Normalizer) Normalize(ctx context.Context, rawText string) (string, string, []string, error) {
+	m, err := n.NormalizeMemory(ctx, rawText)
+	if err != nil {
+		return "", "", nil, err
+	}
+	return m.Text, m.Type, m.Tags, nil
+}
+
+// NormalizeMemory normalizes raw text like Normalize, and also finds the entities it
+// mentions and the relations between them. Entities and relations that break the
+// contract are dropped rather than failing the call.
+func (n *Normalizer) NormalizeMemory(ctx context.Context, rawText string) (NormalizedMemory, error) {
 	rawText = strings.TrimSpace(rawText)
 	if rawText == "" {
-		return "", "", nil, fmt.Errorf("normalizer: raw text is empty")
+		return NormalizedMemory{}, fmt.Errorf("normalizer: raw text is empty")
 	}
 	if n.APIKey == "" {
-		return "", "", nil, fmt.Errorf("normalizer: missing API key")
+		return NormalizedMemory{}, fmt.Errorf("normalizer: missing API key")
 	}
 	if n.Model == "" {
-		return "", "", nil, fmt.Errorf("normalizer: model name is required")
+		return NormalizedMemory{}, fmt.Errorf("normalizer: model name is required")
 	}
 
 	systemPrompt := `You are a memory normalization module for a personal AI assistant.
@@ -67,7 +87,9 @@ Output MUST be valid JSON with this exact shape and no extra keys:
 {
   "normalized": string,
   "type": string,
-  "tags": string[]
+  "tags": string[],
+  "entities": [{"name": string, "kind": string}],
+  "relations": [{"subject": string, "predicate": string, "object": string}]
 }
 
 Requirements:
@@ -77,6 +99,13 @@ Requirements:
   "preference", "biographical", "habit", "goal", "value", "project", "other"
 - "tags" must be 3-8 short, lowercase tokens without spaces.
   - Examples: "music", "running", "programming_languages", "sleep_schedule".
+- "entities" lists the specific people, projects, organizations and places the statement names,
+  other than the user. "kind" must be exactly one of "person", "project", "organization", "place".
+  Use the name as written, e.g. {"name": "Sam", "kind": "person"}, {"name": "Q3 launch", "kind": "project"}.
+- "relations" links two of those entities, using their names exactly as in "entities",
+  e.g. {"subject": "Sam", "predicate": "leads", "object": "Q3 launch"}.
+  "predicate" is a short lowercase verb phrase with underscores, e.g. "works_on", "manages", "located_in".
+- Use [] for "entities" or "relations" when there are none.
 - Do NOT include secrets (API keys, passwords, tokens) in "normalized" or "tags".
 - If the input is not suitable as a long-term memory, still respond with best-effort JSON.
 
@@ -108,40 +137,38 @@ You must output ONLY the JSON object. Do not include explanations, comments, or
 
 	bodyBytes, err := json.Marshal(payload)
 	if err != nil {
-		return "", "", nil, fmt.Errorf("normalizer: marshal request: %w", err)
+		return NormalizedMemory{}, fmt.Errorf("normalizer: marshal request: %w", err)
 	}
 
-	normalized, memType, tags, err := n.callAnthropic(ctx, bodyBytes)
+	m, err := n.callAnthropic(ctx, bodyBytes)
 	if err != nil {
-		return "", "", nil, err
+		return NormalizedMemory{}, err
 	}
 
 	// Post-processing and contract enforcement.
-	if strings.TrimSpace(normalized) == "" {
-		normalized = rawText
+	if strings.TrimSpace(m.Text) == "" {
+		m.Text = rawText
 	}
-	memType = sanitizeMemoryType(memType)
-	tags = sanitizeTags(tags)
-	if len(tags) == 0 {
-		tags = []string{"misc"}
+	m.Type = sanitizeMemoryType(m.Type)
+	m.Tags = sanitizeTags(m.Tags)
+	if len(m.Tags) == 0 {
+		m.Tags = []string{"misc"}
 	}
-	normalized = stripSecrets(normalized)
-	for i := range tags {
-		tags[i] = stripSecrets(tags[i])
+	m.Text = stripSecrets(m.Text)
+	for i := range m.Tags {
+		m.Tags[i] = stripSecrets(m.Tags[i])
 	}
+	m.Entities = sanitizeEntities(m.Entities)
+	m.Relations = sanitizeRelations(m.Relations, m.Entities)
 
-	return normalized, memType, tags, nil
+	return m, nil
 }
 
 // callAnthropic handles the HTTP call and response parsing, including retry logic with exponential backoff for rate limits.
-func (n *Normalizer) callAnthropic(ctx context.Context, body []byte) (string, string, []string, error) {
+func (n *Normalizer) callAnthropic(ctx context.Context, body []byte) (NormalizedMemory, error) {
 	const endpoint = "https://api.anthropic.com/v1/messages"
 
-	var result struct {
-		normalized string
-		memType    string
-		tags       []string
-	}
+	var result NormalizedMemory
 	var retryAfter time.Duration
 
 	// Create backoff configuration
@@ -216,26 +243,32 @@ func (n *Normalizer) callAnthropic(ctx context.Context, body []byte) (string, st
 
 		rawJSON := strings.TrimSpace(msgResp.Content[0].Text)
 		var out struct {
-			Normalized string   `json:"normalized"`
-			Type       string   `json:"type"`
-			Tags       []string `json:"tags"`
+			Normalized string        `json:"normalized"`
+			Type       string        `json:"type"`
+			Tags       []string      `json:"tags"`
+			Entities   []EntityRef   `json:"entities"`
+			Relations  []RelationRef `json:"relations"`
 		}
 		if err := json.Unmarshal([]byte(rawJSON), &out); err != nil {
 			return fmt.Errorf("normalizer: parse model JSON: %w", err)
 		}
 
-		result.normalized = out.Normalized
-		result.memType = out.Type
-		result.tags = out.Tags
+		result = NormalizedMemory{
+			Text:      out.Normalized,
+			Type:      out.Type,
+			Tags:      out.Tags,
+			Entities:  out.Entities,
+			Relations: out.Relations,
+		}
 		return nil
 	}
 
 	err := backoff.Retry(operation, backoff.WithContext(b, ctx))
 	if err != nil {
-		return "", "", nil, err
+		return NormalizedMemory{}, err
 	}
 
-	return result.normalized, result.memType, result.tags, nil
+	return result, nil
 }
 
 var (
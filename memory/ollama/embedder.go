@@ -37,3 +37,20 @@ func (e *embedder) Embed(ctx context.Context, text string) ([]float32, error) {
 	}
 	return resp.Embeddings[0], nil
 }
+
+// EmbedBatch implements memory.BatchEmbedder.EmbedBatch.
+func (e *embedder) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	resp, err := e.client.Embed(ctx, &api.EmbedRequest{
+		Model: string(e.model),
+		Input: texts,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed texts: %w", err)
+	}
+	return resp.Embeddings, nil
+}
+
+// Name implements memory.Embedder.Name.
+func (e *embedder) Name() string {
+	return "ollama:" + string(e.model)
+}
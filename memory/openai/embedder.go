@@ -0,0 +1,86 @@
+// Package openai implements memory.Embedder with the OpenAI embeddings API.
+// Any server exposing an OpenAI-compatible /embeddings endpoint can be used by
+// setting a custom base URL.
+package openai
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aschepis/backscratcher/staff/memory"
+	"github.com/sashabaranov/go-openai"
+)
+
+// DefaultModel is used when no model is configured.
+const DefaultModel = "text-embedding-3-small"
+
+type embedder struct {
+	client     *openai.Client
+	model      string
+	dimensions int
+}
+
+// NewEmbedder creates an OpenAI embedder. baseURL may be empty to use the official API.
+// dimensions shortens the returned vectors on models that support it; 0 keeps the model default.
+func NewEmbedder(apiKey, baseURL, model string, dimensions int) (memory.Embedder, error) {
+	if baseURL == "" && apiKey == "" {
+		return nil, fmt.Errorf("openai embeddings require an API key")
+	}
+	if model == "" {
+		model = DefaultModel
+	}
+
+	clientConfig := openai.DefaultConfig(apiKey)
+	if baseURL != "" {
+		clientConfig.BaseURL = baseURL
+	}
+	return &embedder{
+		client:     openai.NewClientWithConfig(clientConfig),
+		model:      model,
+		dimensions: dimensions,
+	}, nil
+}
+
+// Embed implements memory.Embedder.Embed.
+func (e *embedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	resp, err := e.client.CreateEmbeddings(ctx, openai.EmbeddingRequest{
+		Input:      []string{text},
+		Model:      openai.EmbeddingModel(e.model),
+		Dimensions: e.dimensions,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed text: %w", err)
+	}
+	if len(resp.Data) == 0 {
+		return nil, fmt.Errorf("embeddings response contained no vectors")
+	}
+	return resp.Data[0].Embedding, nil
+}
+
+// EmbedBatch implements memory.BatchEmbedder.EmbedBatch.
+func (e *embedder) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	resp, err := e.client.CreateEmbeddings(ctx, openai.EmbeddingRequest{
+		Input:      texts,
+		Model:      openai.EmbeddingModel(e.model),
+		Dimensions: e.dimensions,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed texts: %w", err)
+	}
+	vecs := make([][]float32, len(texts))
+	for _, d := range resp.Data {
+		if d.Index < 0 || d.Index >= len(vecs) {
+			return nil, fmt.Errorf("embeddings response has out of range index %d", d.Index)
+		}
+		vecs[d.Index] = d.Embedding
+	}
+	return vecs, nil
+}
+
+// Name implements memory.Embedder.Name.
+func (e *embedder) Name() string {
+	if e.dimensions > 0 {
+		return fmt.Sprintf("openai:%s@%d", e.model, e.dimensions)
+	}
+	return "openai:" + e.model
+}
@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrNoPendingFact is returned when an inbox item has no fact waiting for confirmation.
+var ErrNoPendingFact = errors.New("no pending fact for inbox item")
+
+// AddPendingFact records an uncertain fact extracted from a thread until the user confirms
+// or declines the inbox item that asks about it.
+func (s *Store) AddPendingFact(ctx context.Context, inboxID int64, agentID, threadID string, fact PendingFact) error {
+	factJSON, err := json.Marshal(fact)
+	if err != nil {
+		return fmt.Errorf("marshal pending fact: %w", err)
+	}
+	var threadVal interface{}
+	if threadID != "" {
+		threadVal = threadID
+	}
+	if _, err := s.db.ExecContext(ctx, `
+INSERT INTO pending_facts (inbox_id, agent_id, thread_id, fact, created_at)
+VALUES (?, ?, ?, ?, ?)
+`, inboxID, agentID, threadVal, string(factJSON), now()); err != nil {
+		return fmt.Errorf("insert pending fact: %w", err)
+	}
+	return nil
+}
+
+// ConfirmPendingFact stores the fact waiting on an inbox item as the agent's personal
+// memory, the same way a confident extracted fact is stored, and returns it. The memory is
+// stored and the pending fact removed in one transaction, so a fact is stored at most once.
+func (s *Store) ConfirmPendingFact(ctx context.Context, inboxID int64) (MemoryItem, error) {
+	var (
+		agentID  string
+		threadID sql.NullString
+		factJSON string
+	)
+	err := s.db.QueryRowContext(ctx, `
+SELECT agent_id, thread_id, fact FROM pending_facts WHERE inbox_id = ?
+`, inboxID).Scan(&agentID, &threadID, &factJSON)
+	if errors.Is(err, sql.ErrNoRows) {
+		return MemoryItem{}, ErrNoPendingFact
+	}
+	if err != nil {
+		return MemoryItem{}, fmt.Errorf("load pending fact: %w", err)
+	}
+	var fact PendingFact
+	if err := json.Unmarshal([]byte(factJSON), &fact); err != nil {
+		return MemoryItem{}, fmt.Errorf("decode pending fact: %w", err)
+	}
+
+	ctx = WithProvenance(ctx, Provenance{AgentID: agentID, ThreadID: threadID.String, Source: ProvenanceExtraction})
+	item, err := s.storeExtractedFact(ctx, agentID, threadID.String, fact.Statement, NormalizedMemory{
+		Text:      fact.Normalized,
+		Type:      fact.MemoryType,
+		Tags:      fact.Tags,
+		Entities:  fact.Entities,
+		Relations: fact.Relations,
+	}, fact.Confidence, map[string]interface{}{"confidence": fact.Confidence, "confirmed": true},
+		func(ctx context.Context, tx *sql.Tx, _ int64) error {
+			return deletePendingFact(ctx, tx, inboxID)
+		})
+	if err != nil {
+		return MemoryItem{}, err
+	}
+	return item, nil
+}
+
+// DiscardPendingFact drops the fact waiting on an inbox item without storing it.
+func (s *Store) DiscardPendingFact(ctx context.Context, inboxID int64) error {
+	return deletePendingFact(ctx, s.db, inboxID)
+}
+
+// execer is satisfied by *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// deletePendingFact deletes the fact waiting on an inbox item, or returns ErrNoPendingFact
+// if there is none.
+func deletePendingFact(ctx context.Context, db execer, inboxID int64) error {
+	res, err := db.ExecContext(ctx, `DELETE FROM pending_facts WHERE inbox_id = ?`, inboxID)
+	if err != nil {
+		return fmt.Errorf("delete pending fact: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNoPendingFact
+	}
+	return nil
+}
@@ -0,0 +1,613 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// Memory export files are JSONL: a header line followed by one record per line.
+const (
+	exportFormat = "staff-memory"
+	// ExportVersion is the version of the export format written by ExportMemory.
+	// ImportMemory reads this version and older ones.
+	ExportVersion = 1
+)
+
+// importEmbedBatch is how many memories are re-embedded per embedding request on import.
+const importEmbedBatch = 32
+
+// Record kinds in an export file.
+const (
+	exportKindMemory   = "memory"
+	exportKindArtifact = "artifact"
+)
+
+// exportHeader is the first line of an export file.
+type exportHeader struct {
+	Format     string    `json:"format"`
+	Version    int       `json:"version"`
+	Embedder   string    `json:"embedder,omitempty"` // Embedder of included vectors
+	ExportedAt time.Time `json:"exported_at"`
+}
+
+// exportRecord is one line after the header.
+type exportRecord struct {
+	Kind     string            `json:"kind"`
+	Memory   *MemoryItem       `json:"memory,omitempty"`
+	Artifact *Artifact         `json:"artifact,omitempty"`
+	Versions []ArtifactVersion `json:"versions,omitempty"` // Artifact revisions, oldest first
+}
+
+// ExportOptions controls ExportMemory.
+type ExportOptions struct {
+	IncludeEmbeddings bool // Include vectors so importers with the same embedder needn't re-embed
+}
+
+// ExportResult counts what ExportMemory wrote.
+type ExportResult struct {
+	Memories  int
+	Artifacts int
+}
+
+// ExportMemory writes every memory item and artifact to w as versioned JSONL, including
+// superseded and archived items so their links survive a round trip.
+func (s *Store) ExportMemory(ctx context.Context, w io.Writer, opts ExportOptions) (ExportResult, error) {
+	var result ExportResult
+	enc := json.NewEncoder(w)
+
+	header := exportHeader{Format: exportFormat, Version: ExportVersion, ExportedAt: time.Now().UTC()}
+	if opts.IncludeEmbeddings && s.embedder != nil {
+		header.Embedder = s.embedder.Name()
+	}
+	if err := enc.Encode(header); err != nil {
+		return result, fmt.Errorf("write header: %w", err)
+	}
+
+	query, args, err := StatementBuilder().
+		Select(SelectMemoryItemsColumns()...).
+		From("memory_items").
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return result, fmt.Errorf("build query: %w", err)
+	}
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return result, fmt.Errorf("query memory_items: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck // No remedy for rows close errors
+
+	for rows.Next() {
+		item, err := loadMemoryItemFromRow(rows)
+		if err != nil {
+			return result, err
+		}
+		if !opts.IncludeEmbeddings {
+			item.Embedding = nil
+			item.EmbeddingModel, item.EmbeddingDim = "", 0
+		}
+		if err := enc.Encode(exportRecord{Kind: exportKindMemory, Memory: item}); err != nil {
+			return result, fmt.Errorf("write memory %d: %w", item.ID, err)
+		}
+		result.Memories++
+	}
+	if err := rows.Err(); err != nil {
+		return result, err
+	}
+
+	artifacts, err := s.allArtifacts(ctx)
+	if err != nil {
+		return result, err
+	}
+	for _, a := range artifacts {
+		versions, err := s.ArtifactVersions(ctx, a.ID)
+		if err != nil && !errors.Is(err, ErrArtifactNotFound) {
+			return result, err
+		}
+		if err := enc.Encode(exportRecord{Kind: exportKindArtifact, Artifact: a, Versions: versions}); err != nil {
+			return result, fmt.Errorf("write artifact %d: %w", a.ID, err)
+		}
+		result.Artifacts++
+	}
+
+	s.logger.Info().
+		Int("memories", result.Memories).
+		Int("artifacts", result.Artifacts).
+		Bool("embeddings", opts.IncludeEmbeddings).
+		Msg("Memory exported")
+	return result, nil
+}
+
+// allArtifacts returns every artifact, oldest first.
+func (s *Store) allArtifacts(ctx context.Context) ([]*Artifact, error) {
+	query, args, err := StatementBuilder().
+		Select(selectArtifactColumns...).
+		From("artifacts").
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query artifacts: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck // No remedy for rows close errors
+
+	var artifacts []*Artifact
+	for rows.Next() {
+		a, err := scanArtifact(rows)
+		if err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, a)
+	}
+	return artifacts, rows.Err()
+}
+
+// ImportStrategy decides what happens to existing memories when importing.
+type ImportStrategy string
+
+const (
+	// ImportMerge adds imported memories and artifacts alongside existing ones, skipping
+	// exact duplicates.
+	ImportMerge ImportStrategy = "merge"
+	// ImportOverwrite first removes the existing memories and artifacts of every agent in
+	// the import, and the unowned ones if the import has any.
+	ImportOverwrite ImportStrategy = "overwrite"
+)
+
+// ImportOptions controls ImportMemory.
+type ImportOptions struct {
+	Strategy ImportStrategy    // Default ImportMerge
+	AgentMap map[string]string // Renames agent IDs from the export; unmapped IDs are kept
+}
+
+// ImportResult counts what ImportMemory did.
+type ImportResult struct {
+	Memories   int // Memory items added
+	Artifacts  int // Artifacts added
+	Skipped    int // Exact duplicates of existing memories or artifacts
+	Reembedded int // Memory items embedded with the current embedder
+	Removed    int // Existing memories and artifacts removed by ImportOverwrite
+}
+
+// ImportMemory reads an export written by ExportMemory. Items whose vectors came from a
+// different embedder, or have a different dimension, are re-embedded with the store's
+// embedder. The import is applied in a single transaction.
+func (s *Store) ImportMemory(ctx context.Context, r io.Reader, opts ImportOptions) (ImportResult, error) {
+	var result ImportResult
+	strategy := opts.Strategy
+	if strategy == "" {
+		strategy = ImportMerge
+	}
+	if strategy != ImportMerge && strategy != ImportOverwrite {
+		return result, fmt.Errorf("unknown import strategy %q", strategy)
+	}
+
+	memories, artifacts, err := readExport(r)
+	if err != nil {
+		return result, err
+	}
+	mapAgent := func(id *string) *string {
+		if id == nil {
+			return nil
+		}
+		if to, ok := opts.AgentMap[*id]; ok {
+			return &to
+		}
+		return id
+	}
+	for _, m := range memories {
+		m.AgentID = mapAgent(m.AgentID)
+	}
+	for _, a := range artifacts {
+		a.Artifact.AgentID = mapAgent(a.Artifact.AgentID)
+		for i := range a.Versions {
+			a.Versions[i].AgentID = mapAgent(a.Versions[i].AgentID)
+		}
+	}
+
+	// Embed before opening the transaction so slow embedders don't hold the database lock
+	reembedded, err := s.reembedForImport(ctx, memories)
+	if err != nil {
+		return result, err
+	}
+	result.Reembedded = reembedded
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return result, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var removedMemories []int64
+	if strategy == ImportOverwrite {
+		removedMemories, result.Removed, err = removeImportedOwners(ctx, tx, memories, artifacts)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	nowUnix := now()
+	newIDs := make(map[int64]int64, len(memories)) // export ID -> database ID
+	var added []*MemoryItem
+	for _, m := range memories {
+		if strategy == ImportMerge {
+			existingID, err := findDuplicateMemory(ctx, tx, m)
+			if err != nil {
+				return result, err
+			}
+			if existingID != 0 {
+				newIDs[m.ID] = existingID
+				result.Skipped++
+				continue
+			}
+		}
+		id, err := insertImportedMemory(ctx, tx, m, nowUnix)
+		if err != nil {
+			return result, fmt.Errorf("import memory %d: %w", m.ID, err)
+		}
+		newIDs[m.ID] = id
+		added = append(added, m)
+	}
+	// Relink superseded items once every item has its new ID
+	for _, m := range added {
+		m.ID = newIDs[m.ID]
+		if m.SupersededBy == nil {
+			continue
+		}
+		to, ok := newIDs[*m.SupersededBy]
+		if !ok {
+			m.SupersededBy = nil
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE memory_items SET superseded_by = ? WHERE id = ?`, to, m.ID); err != nil {
+			return result, fmt.Errorf("link superseded memory: %w", err)
+		}
+		m.SupersededBy = &to
+	}
+	result.Memories = len(added)
+
+	for _, a := range artifacts {
+		if strategy == ImportMerge {
+			dup, err := artifactExists(ctx, tx, a.Artifact)
+			if err != nil {
+				return result, err
+			}
+			if dup {
+				result.Skipped++
+				continue
+			}
+		}
+		if err := insertImportedArtifact(ctx, tx, a.Artifact, a.Versions); err != nil {
+			return result, fmt.Errorf("import artifact %d: %w", a.Artifact.ID, err)
+		}
+		result.Artifacts++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return result, err
+	}
+
+	for _, id := range removedMemories {
+		s.unindexItem(id)
+	}
+	for _, m := range added {
+		if m.SupersededBy == nil && m.ArchivedAt == nil {
+			s.indexItem(m.ID, m.Embedding)
+		}
+	}
+
+	s.logger.Info().
+		Str("strategy", string(strategy)).
+		Int("memories", result.Memories).
+		Int("artifacts", result.Artifacts).
+		Int("skipped", result.Skipped).
+		Int("reembedded", result.Reembedded).
+		Int("removed", result.Removed).
+		Msg("Memory imported")
+	return result, nil
+}
+
+// importedArtifact is an artifact read from an export with its revisions.
+type importedArtifact struct {
+	Artifact *Artifact
+	Versions []ArtifactVersion
+}
+
+// readExport parses an export file.
+func readExport(r io.Reader) ([]*MemoryItem, []importedArtifact, error) {
+	dec := json.NewDecoder(r)
+	var header exportHeader
+	if err := dec.Decode(&header); err != nil {
+		return nil, nil, fmt.Errorf("read export header: %w", err)
+	}
+	if header.Format != exportFormat {
+		return nil, nil, fmt.Errorf("not a memory export (format %q)", header.Format)
+	}
+	if header.Version < 1 || header.Version > ExportVersion {
+		return nil, nil, fmt.Errorf("export version %d is not supported (newest supported: %d)", header.Version, ExportVersion)
+	}
+
+	var (
+		memories  []*MemoryItem
+		artifacts []importedArtifact
+	)
+	for line := 2; ; line++ {
+		var rec exportRecord
+		err := dec.Decode(&rec)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("read export record %d: %w", line, err)
+		}
+		switch {
+		case rec.Kind == exportKindMemory && rec.Memory != nil:
+			memories = append(memories, rec.Memory)
+		case rec.Kind == exportKindArtifact && rec.Artifact != nil:
+			artifacts = append(artifacts, importedArtifact{Artifact: rec.Artifact, Versions: rec.Versions})
+		default:
+			return nil, nil, fmt.Errorf("export record %d: unknown kind %q", line, rec.Kind)
+		}
+	}
+	return memories, artifacts, nil
+}
+
+// reembedForImport embeds items whose vectors don't match the store's embedder, in batches
+// of importEmbedBatch. Without an embedder, vectors are kept as exported.
+func (s *Store) reembedForImport(ctx context.Context, items []*MemoryItem) (int, error) {
+	if s.embedder == nil || len(items) == 0 {
+		return 0, nil
+	}
+	model := s.embedder.Name()
+	probe, err := s.embedder.Embed(ctx, "dimension probe")
+	if err != nil {
+		return 0, fmt.Errorf("embed: %w", err)
+	}
+	dim := len(probe)
+
+	var stale []*MemoryItem
+	for _, item := range items {
+		if len(item.Embedding) == dim && item.EmbeddingModel == model {
+			item.EmbeddingDim = dim
+			continue
+		}
+		stale = append(stale, item)
+	}
+
+	n := 0
+	for len(stale) > 0 {
+		batch := stale[:min(len(stale), importEmbedBatch)]
+		stale = stale[len(batch):]
+		texts := make([]string, len(batch))
+		for i, item := range batch {
+			texts[i] = item.Content
+		}
+		vecs, err := EmbedBatch(ctx, s.embedder, texts)
+		if err != nil {
+			return n, fmt.Errorf("re-embed memories: %w", err)
+		}
+		for i, item := range batch {
+			item.Embedding = vecs[i]
+			item.EmbeddingModel, item.EmbeddingDim = model, len(vecs[i])
+		}
+		n += len(batch)
+	}
+	return n, nil
+}
+
+// removeImportedOwners deletes the existing memories and artifacts of the owners present in
+// an import. It returns the removed memory IDs and the total number of rows removed.
+func removeImportedOwners(ctx context.Context, tx *sql.Tx, memories []*MemoryItem, artifacts []importedArtifact) ([]int64, int, error) {
+	var (
+		owners     []interface{}
+		seen       = make(map[string]bool)
+		unowned    bool
+		addOwner   func(*string)
+		removedIDs []int64
+	)
+	addOwner = func(id *string) {
+		if id == nil {
+			unowned = true
+			return
+		}
+		if !seen[*id] {
+			seen[*id] = true
+			owners = append(owners, *id)
+		}
+	}
+	for _, m := range memories {
+		addOwner(m.AgentID)
+	}
+	for _, a := range artifacts {
+		addOwner(a.Artifact.AgentID)
+	}
+	ownedBy := sq.Or{sq.Eq{"agent_id": owners}}
+	if unowned {
+		ownedBy = append(ownedBy, sq.Eq{"agent_id": nil})
+	}
+
+	query, args, err := StatementBuilder().Select("id").From("memory_items").Where(ownedBy).ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("build query: %w", err)
+	}
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("query existing memories: %w", err)
+	}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return nil, 0, err
+		}
+		removedIDs = append(removedIDs, id)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	if err := deleteItems(ctx, tx, removedIDs, now()); err != nil {
+		return nil, 0, err
+	}
+
+	// Foreign keys aren't enforced, so revisions are removed explicitly
+	owned := StatementBuilder().Select("id").From("artifacts").Where(ownedBy)
+	query, args, err = StatementBuilder().Delete("artifact_versions").
+		Where(sq.Expr("artifact_id IN (?)", owned)).
+		ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("build query: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return nil, 0, fmt.Errorf("delete existing artifact versions: %w", err)
+	}
+	query, args, err = StatementBuilder().Delete("artifacts").Where(ownedBy).ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("build query: %w", err)
+	}
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("delete existing artifacts: %w", err)
+	}
+	removedArtifacts, _ := res.RowsAffected()
+	return removedIDs, len(removedIDs) + int(removedArtifacts), nil
+}
+
+// findDuplicateMemory returns the ID of an existing item identical to m, or 0.
+func findDuplicateMemory(ctx context.Context, tx *sql.Tx, m *MemoryItem) (int64, error) {
+	var id int64
+	err := tx.QueryRowContext(ctx, `
+SELECT id FROM memory_items
+WHERE agent_id IS ? AND group_name IS ? AND scope = ? AND type = ? AND content = ?
+ORDER BY id
+LIMIT 1
+`, agentValue(m.AgentID), nullIfEmpty(m.Group), string(m.Scope), string(m.Type), m.Content).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("query duplicate memory: %w", err)
+	}
+	return id, nil
+}
+
+// insertImportedMemory inserts an exported memory item. Its superseded_by
+// link is restored separately, once every item has been inserted.
+func insertImportedMemory(ctx context.Context, tx *sql.Tx, m *MemoryItem, nowUnix int64) (int64, error) {
+	// Message IDs refer to the exporting database's conversations, which aren't exported
+	var source *Provenance
+	if m.Provenance != nil {
+		p := *m.Provenance
+		p.MessageID = 0
+		source = &p
+	}
+	metaJSON, err := marshalOptional(m.Metadata)
+	if err != nil {
+		return 0, fmt.Errorf("marshal metadata: %w", err)
+	}
+	tagsJSON, err := marshalOptional(m.Tags)
+	if err != nil {
+		return 0, fmt.Errorf("marshal tags: %w", err)
+	}
+	var embModel, embDim interface{}
+	if len(m.Embedding) > 0 && m.EmbeddingModel != "" {
+		embModel, embDim = m.EmbeddingModel, len(m.Embedding)
+	}
+
+	query, args, err := StatementBuilder().
+		Insert("memory_items").
+		Columns("agent_id", "thread_id", "scope", "type", "content",
+			"embedding", "metadata", "created_at", "updated_at", "importance",
+			"raw_content", "memory_type", "tags_json", "embedding_model", "embedding_dim",
+			"expires_at", "archived_at", "decayed_at", "group_name").
+		Columns(sourceColumnNames...).
+		Values(append([]interface{}{agentValue(m.AgentID), agentValue(m.ThreadID), string(m.Scope), string(m.Type), m.Content,
+			EncodeEmbedding(m.Embedding), metaJSON, m.CreatedAt.Unix(), m.UpdatedAt.Unix(), m.Importance,
+			nullIfEmpty(m.RawContent), nullIfEmpty(m.MemoryType), tagsJSON, embModel, embDim,
+			unixOrNil(m.ExpiresAt), unixOrNil(m.ArchivedAt), nowUnix, nullIfEmpty(m.Group)}, sourceValues(source)...)...).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("build insert query: %w", err)
+	}
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("insert memory_item: %w", err)
+	}
+	return res.LastInsertId()
+}
+
+// artifactExists reports whether an artifact with the same owner, title and body exists.
+func artifactExists(ctx context.Context, tx *sql.Tx, a *Artifact) (bool, error) {
+	var n int
+	if err := tx.QueryRowContext(ctx, `
+SELECT COUNT(*) FROM artifacts WHERE agent_id IS ? AND title IS ? AND body = ?
+`, agentValue(a.AgentID), a.Title, a.Body).Scan(&n); err != nil {
+		return false, fmt.Errorf("query duplicate artifact: %w", err)
+	}
+	return n > 0, nil
+}
+
+// insertImportedArtifact inserts an exported artifact and its revisions.
+func insertImportedArtifact(ctx context.Context, tx *sql.Tx, a *Artifact, versions []ArtifactVersion) error {
+	metaJSON, err := marshalOptional(a.Metadata)
+	if err != nil {
+		return fmt.Errorf("marshal metadata: %w", err)
+	}
+	version := max(a.Version, 1)
+	res, err := tx.ExecContext(ctx, `
+INSERT INTO artifacts (agent_id, thread_id, scope, title, body, metadata, version, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+`, agentValue(a.AgentID), agentValue(a.ThreadID), string(a.Scope), a.Title, a.Body, metaJSON,
+		version, a.CreatedAt.Unix(), a.UpdatedAt.Unix())
+	if err != nil {
+		return fmt.Errorf("insert artifact: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	if len(versions) == 0 {
+		// Exports from before versioning only have the current revision
+		versions = []ArtifactVersion{{
+			Version: version, AgentID: a.AgentID, Title: a.Title, Body: a.Body,
+			Metadata: a.Metadata, CreatedAt: a.UpdatedAt,
+		}}
+	}
+	for _, v := range versions {
+		vMeta, err := marshalOptional(v.Metadata)
+		if err != nil {
+			return fmt.Errorf("marshal metadata: %w", err)
+		}
+		if err := insertArtifactVersion(ctx, tx, id, v.Version, agentValue(v.AgentID), v.Title, v.Body, vMeta, v.CreatedAt.Unix()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// agentValue returns a nullable string column value.
+func agentValue(s *string) interface{} {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
+
+// unixOrNil returns a nullable Unix timestamp column value.
+func unixOrNil(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.Unix()
+}
@@ -0,0 +1,187 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestImportMemory_RoundTripWithAgentMap(t *testing.T) {
+	ctx := context.Background()
+
+	srcDB := setupTestDB(t)
+	defer srcDB.Close() //nolint:errcheck // Test cleanup
+	src, err := NewStore(srcDB, stubEmbedder{}, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	if _, err := src.StorePersonalMemory(ctx, "alice", "", "The user prefers tea.", "preference", []string{"drinks"}, nil, 0.6, nil); err != nil {
+		t.Fatalf("StorePersonalMemory: %v", err)
+	}
+	if _, err := src.StorePersonalMemory(ctx, "alice", "", "The user's sister is named Ana.", "biographical", nil, nil, 0.7, nil); err != nil {
+		t.Fatalf("StorePersonalMemory: %v", err)
+	}
+	author := "alice"
+	artifact, err := src.CreateArtifact(ctx, ScopeAgent, &author, nil, "Plan", "Draft", nil)
+	if err != nil {
+		t.Fatalf("CreateArtifact: %v", err)
+	}
+	body := "Final"
+	if _, err := src.UpdateArtifact(ctx, artifact.ID, &author, ArtifactUpdate{Body: &body}); err != nil {
+		t.Fatalf("UpdateArtifact: %v", err)
+	}
+
+	var buf bytes.Buffer
+	exported, err := src.ExportMemory(ctx, &buf, ExportOptions{IncludeEmbeddings: true})
+	if err != nil {
+		t.Fatalf("ExportMemory: %v", err)
+	}
+	if exported.Memories != 2 || exported.Artifacts != 1 {
+		t.Fatalf("unexpected export counts: %+v", exported)
+	}
+	data := buf.Bytes()
+
+	dstDB := setupTestDB(t)
+	defer dstDB.Close() //nolint:errcheck // Test cleanup
+	dst, err := NewStore(dstDB, newSemanticEmbedder(16), zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	opts := ImportOptions{AgentMap: map[string]string{"alice": "bob"}}
+	imported, err := dst.ImportMemory(ctx, bytes.NewReader(data), opts)
+	if err != nil {
+		t.Fatalf("ImportMemory: %v", err)
+	}
+	if imported.Memories != 2 || imported.Artifacts != 1 || imported.Reembedded != 2 {
+		t.Fatalf("unexpected import counts: %+v", imported)
+	}
+
+	bob := "bob"
+	results, err := dst.SearchMemory(ctx, &SearchQuery{QueryText: "tea", AgentID: &bob})
+	if err != nil {
+		t.Fatalf("SearchMemory: %v", err)
+	}
+	if len(results) != 1 || results[0].Item.EmbeddingModel != "semantic" || len(results[0].Item.Embedding) != 16 {
+		t.Fatalf("expected the re-embedded memory under the mapped agent, got %d results", len(results))
+	}
+	if len(results[0].Item.Tags) != 1 || results[0].Item.Tags[0] != "drinks" {
+		t.Errorf("expected tags to survive the round trip, got %v", results[0].Item.Tags)
+	}
+
+	artifacts, err := dst.ListArtifacts(ctx, ArtifactFilter{AgentID: &bob})
+	if err != nil {
+		t.Fatalf("ListArtifacts: %v", err)
+	}
+	if len(artifacts) != 1 || artifacts[0].Version != 2 || artifacts[0].Body != "Final" {
+		t.Fatalf("expected the imported artifact at version 2, got %+v", artifacts)
+	}
+	versions, err := dst.ArtifactVersions(ctx, artifacts[0].ID)
+	if err != nil {
+		t.Fatalf("ArtifactVersions: %v", err)
+	}
+	if len(versions) != 2 || versions[0].Body != "Draft" {
+		t.Errorf("expected both artifact versions, got %+v", versions)
+	}
+
+	// Merging the same export again adds nothing
+	merged, err := dst.ImportMemory(ctx, bytes.NewReader(data), opts)
+	if err != nil {
+		t.Fatalf("ImportMemory (merge): %v", err)
+	}
+	if merged.Memories != 0 || merged.Artifacts != 0 || merged.Skipped != 3 {
+		t.Fatalf("expected every record to be skipped, got %+v", merged)
+	}
+
+	// Overwriting replaces bob's memories rather than duplicating them
+	opts.Strategy = ImportOverwrite
+	overwritten, err := dst.ImportMemory(ctx, bytes.NewReader(data), opts)
+	if err != nil {
+		t.Fatalf("ImportMemory (overwrite): %v", err)
+	}
+	if overwritten.Removed != 3 || overwritten.Memories != 2 || overwritten.Artifacts != 1 {
+		t.Fatalf("unexpected overwrite counts: %+v", overwritten)
+	}
+	var count int
+	if err := dstDB.QueryRowContext(ctx, `SELECT COUNT(*) FROM memory_items WHERE agent_id = 'bob'`).Scan(&count); err != nil {
+		t.Fatalf("count memories: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 memories after overwrite, got %d", count)
+	}
+}
+
+func TestImportMemory_RejectsUnknownFormat(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	store, err := NewStore(db, nil, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	input := `{"format":"staff-memory","version":99}` + "\n"
+	if _, err := store.ImportMemory(context.Background(), bytes.NewBufferString(input), ImportOptions{}); err == nil {
+		t.Fatal("expected an unsupported export version to be rejected")
+	}
+}
+
+// batchingEmbedder records the size of each batch it is asked to embed.
+type batchingEmbedder struct {
+	*semanticEmbedder
+	batches []int
+}
+
+func (e *batchingEmbedder) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	e.batches = append(e.batches, len(texts))
+	vecs := make([][]float32, len(texts))
+	for i, text := range texts {
+		vec, err := e.Embed(ctx, text)
+		if err != nil {
+			return nil, err
+		}
+		vecs[i] = vec
+	}
+	return vecs, nil
+}
+
+func TestImportMemory_ReembedsInBatches(t *testing.T) {
+	ctx := context.Background()
+
+	srcDB := setupTestDB(t)
+	defer srcDB.Close() //nolint:errcheck // Test cleanup
+	src, err := NewStore(srcDB, stubEmbedder{}, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	src.SetDedupConfig(DedupConfig{})
+	total := importEmbedBatch + 8
+	for i := 0; i < total; i++ {
+		if _, err := src.RememberGlobalFact(ctx, fmt.Sprintf("Fact number %d.", i), 0.5, nil); err != nil {
+			t.Fatalf("RememberGlobalFact: %v", err)
+		}
+	}
+	var buf bytes.Buffer
+	if _, err := src.ExportMemory(ctx, &buf, ExportOptions{IncludeEmbeddings: true}); err != nil {
+		t.Fatalf("ExportMemory: %v", err)
+	}
+
+	dstDB := setupTestDB(t)
+	defer dstDB.Close() //nolint:errcheck // Test cleanup
+	embedder := &batchingEmbedder{semanticEmbedder: newSemanticEmbedder(16)}
+	dst, err := NewStore(dstDB, embedder, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	imported, err := dst.ImportMemory(ctx, &buf, ImportOptions{})
+	if err != nil {
+		t.Fatalf("ImportMemory: %v", err)
+	}
+	if imported.Reembedded != total {
+		t.Fatalf("expected %d re-embedded memories, got %+v", total, imported)
+	}
+	if len(embedder.batches) != 2 || embedder.batches[0] != importEmbedBatch || embedder.batches[1] != 8 {
+		t.Errorf("expected batches of %d and 8, got %v", importEmbedBatch, embedder.batches)
+	}
+}
@@ -0,0 +1,318 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// ProfileMemoryType is the memory_type of the user profile document. It is stored as a
+// global profile item, apart from the agents' personal memories it is built from.
+const ProfileMemoryType = "user_profile"
+
+// profileNotesHeading starts the part of the profile that is written by hand and kept
+// when the profile is rebuilt.
+const profileNotesHeading = "## Notes"
+
+// profileSynthesizedKey is the profile metadata key recording each section as the
+// synthesizer last wrote it, so sections edited by hand can be told apart and kept.
+const profileSynthesizedKey = "synthesized_sections"
+
+// maxProfileEntries caps how many memories are listed in each profile section.
+const maxProfileEntries = 20
+
+// profileSection groups personal memories under a heading of the profile.
+type profileSection struct {
+	heading string
+	keys    []string // memory_type values and tags that belong in the section
+}
+
+// profilePart is a "## " section of a profile document as it is written.
+type profilePart struct {
+	heading string
+	body    string
+}
+
+var profileSections = []profileSection{
+	{heading: "Biographical", keys: []string{"biographical", "bio"}},
+	{heading: "Preferences", keys: []string{"preference", "preferences", "habit", "habits"}},
+	{heading: "Goals", keys: []string{"goal", "goals", "value", "values"}},
+	{heading: "Projects", keys: []string{"project", "projects"}},
+}
+
+// ErrNoProfile is returned when no user profile has been built or written yet.
+var ErrNoProfile = errors.New("no user profile")
+
+// Profile returns the user profile document.
+func (s *Store) Profile(ctx context.Context) (*MemoryItem, error) {
+	id, err := s.profileID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetMemory(ctx, id)
+}
+
+// SetProfile replaces the user profile document, keeping the previous content in its
+// history. It creates the profile if there is none.
+func (s *Store) SetProfile(ctx context.Context, content string) (*MemoryItem, error) {
+	return s.writeProfile(ctx, content, map[string]interface{}{"updated_by": "user"})
+}
+
+// SynthesizeProfile rebuilds the user profile from the personal memories of every agent,
+// grouped into biographical details, preferences, goals and projects by memory type or
+// tag. Anything under the "## Notes" heading of the current profile is kept, as are
+// sections edited or added by hand since the last rebuild; a section removed by hand is
+// rebuilt. It returns the profile and whether it changed.
+func (s *Store) SynthesizeProfile(ctx context.Context) (*MemoryItem, bool, error) {
+	query := StatementBuilder().
+		Select(SelectMemoryItemsColumns()...).
+		From("memory_items").
+		Where(sq.Eq{"type": string(MemoryTypeProfile), "superseded_by": nil, "archived_at": nil}).
+		Where(sq.Or{sq.Eq{"memory_type": nil}, sq.NotEq{"memory_type": ProfileMemoryType}}).
+		OrderBy("importance DESC", "updated_at DESC", "id")
+	queryStr, args, err := query.ToSql()
+	if err != nil {
+		return nil, false, fmt.Errorf("build query: %w", err)
+	}
+	rows, err := s.db.QueryContext(ctx, queryStr, args...)
+	if err != nil {
+		return nil, false, fmt.Errorf("query personal memories: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck // No remedy for rows close errors
+
+	var memories []*MemoryItem
+	for rows.Next() {
+		item, err := loadMemoryItemFromRow(rows)
+		if err != nil {
+			return nil, false, err
+		}
+		memories = append(memories, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, err
+	}
+
+	current, err := s.Profile(ctx)
+	if err != nil && !errors.Is(err, ErrNoProfile) {
+		return nil, false, err
+	}
+	var (
+		notes  string
+		edited []profilePart
+	)
+	if current != nil {
+		notes = profileNotes(current.Content)
+		edited = editedProfileParts(current)
+	}
+
+	content, synthesized := buildProfile(memories, edited, notes)
+	if current != nil && current.Content == content && current.Metadata[profileSynthesizedKey] != nil {
+		return current, false, nil
+	}
+	if current == nil && len(memories) == 0 && notes == "" {
+		return nil, false, nil
+	}
+	item, err := s.writeProfile(ctx, content, map[string]interface{}{
+		"updated_by":          "synthesizer",
+		profileSynthesizedKey: synthesized,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return item, true, nil
+}
+
+// writeProfile stores content as the profile, merging meta into its metadata.
+func (s *Store) writeProfile(ctx context.Context, content string, meta map[string]interface{}) (*MemoryItem, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return nil, errors.New("profile is empty")
+	}
+
+	id, err := s.profileID(ctx)
+	if errors.Is(err, ErrNoProfile) {
+		nowUnix := now()
+		metaJSON, err := marshalOptional(meta)
+		if err != nil {
+			return nil, fmt.Errorf("marshal metadata: %w", err)
+		}
+		queryStr, args, err := StatementBuilder().
+			Insert("memory_items").
+			Columns("scope", "type", "memory_type", "content", "metadata", "importance", "created_at", "updated_at").
+			Values(string(ScopeGlobal), string(MemoryTypeProfile), ProfileMemoryType, content, metaJSON, 1.0, nowUnix, nowUnix).
+			ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("build insert: %w", err)
+		}
+		res, err := s.db.ExecContext(ctx, queryStr, args...)
+		if err != nil {
+			return nil, fmt.Errorf("insert profile: %w", err)
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return nil, fmt.Errorf("get profile id: %w", err)
+		}
+		return s.GetMemory(ctx, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.UpdateMemory(ctx, id, MemoryUpdate{Content: &content, Metadata: meta})
+}
+
+// profileID returns the ID of the profile document.
+func (s *Store) profileID(ctx context.Context) (int64, error) {
+	var id int64
+	err := s.db.QueryRowContext(ctx, `
+SELECT id FROM memory_items
+WHERE type = ? AND memory_type = ? AND superseded_by IS NULL
+ORDER BY id DESC
+LIMIT 1
+`, string(MemoryTypeProfile), ProfileMemoryType).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrNoProfile
+		}
+		return 0, fmt.Errorf("find profile: %w", err)
+	}
+	return id, nil
+}
+
+// buildProfile renders memories, most important first, as a markdown profile followed by
+// the hand-written notes. Edited sections replace the ones built from memories, and
+// edited sections with other headings follow them. It also returns the body of each
+// section it built, keyed by heading.
+func buildProfile(memories []*MemoryItem, edited []profilePart, notes string) (string, map[string]interface{}) {
+	editedBodies := make(map[string]string, len(edited))
+	for _, part := range edited {
+		editedBodies[part.heading] = part.body
+	}
+	synthesized := make(map[string]interface{})
+
+	var b strings.Builder
+	b.WriteString("# User profile\n")
+	writeSection := func(heading, body string) {
+		b.WriteString("\n## " + heading + "\n")
+		if body != "" {
+			b.WriteString(body + "\n")
+		}
+	}
+
+	for _, section := range profileSections {
+		if body, ok := editedBodies[section.heading]; ok {
+			writeSection(section.heading, body)
+			continue
+		}
+		seen := make(map[string]bool)
+		var entries []string
+		for _, m := range memories {
+			content := strings.Join(strings.Fields(m.Content), " ")
+			if !inProfileSection(m, section) || content == "" || seen[strings.ToLower(content)] {
+				continue
+			}
+			seen[strings.ToLower(content)] = true
+			entries = append(entries, content)
+			if len(entries) == maxProfileEntries {
+				break
+			}
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		body := "- " + strings.Join(entries, "\n- ")
+		writeSection(section.heading, body)
+		synthesized[section.heading] = body
+	}
+	for _, part := range edited {
+		if !isProfileSection(part.heading) {
+			writeSection(part.heading, part.body)
+		}
+	}
+
+	if notes != "" {
+		b.WriteString("\n" + profileNotesHeading + "\n" + notes + "\n")
+	}
+	return strings.TrimSpace(b.String()), synthesized
+}
+
+// editedProfileParts returns the sections of a profile that differ from what the
+// synthesizer last wrote under their heading. Profiles written before sections were
+// recorded are treated as unedited.
+func editedProfileParts(profile *MemoryItem) []profilePart {
+	written, ok := profile.Metadata[profileSynthesizedKey].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	var edited []profilePart
+	for _, part := range parseProfile(profile.Content) {
+		if body, _ := written[part.heading].(string); body != part.body {
+			edited = append(edited, part)
+		}
+	}
+	return edited
+}
+
+// parseProfile splits a profile into its "## " sections, in order, stopping at the notes.
+func parseProfile(content string) []profilePart {
+	if i := strings.Index(content, profileNotesHeading); i >= 0 {
+		content = content[:i]
+	}
+	var parts []profilePart
+	for _, line := range strings.Split(content, "\n") {
+		if heading, ok := strings.CutPrefix(line, "## "); ok {
+			parts = append(parts, profilePart{heading: strings.TrimSpace(heading)})
+			continue
+		}
+		if len(parts) > 0 {
+			parts[len(parts)-1].body += line + "\n"
+		}
+	}
+	for i := range parts {
+		parts[i].body = strings.TrimSpace(parts[i].body)
+	}
+	return parts
+}
+
+// isProfileSection reports whether heading is one of the sections built from memories.
+func isProfileSection(heading string) bool {
+	for _, section := range profileSections {
+		if section.heading == heading {
+			return true
+		}
+	}
+	return false
+}
+
+// inProfileSection reports whether a personal memory belongs in a profile section, by its
+// memory type or, for memories typed "other", by its tags.
+func inProfileSection(m *MemoryItem, section profileSection) bool {
+	for _, key := range section.keys {
+		if m.MemoryType == key {
+			return true
+		}
+	}
+	if m.MemoryType != "" && m.MemoryType != "other" {
+		return false
+	}
+	for _, tag := range m.Tags {
+		for _, key := range section.keys {
+			if strings.EqualFold(tag, key) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// profileNotes returns the text under the notes heading of a profile.
+func profileNotes(content string) string {
+	i := strings.Index(content, profileNotesHeading)
+	if i < 0 {
+		return ""
+	}
+	return strings.TrimSpace(content[i+len(profileNotesHeading):])
+}
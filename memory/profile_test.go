@@ -0,0 +1,222 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestSynthesizeProfile_GroupsMemoriesAndKeepsNotes(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	store, err := NewStore(db, newSemanticEmbedder(8), zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	store.SetDedupConfig(DedupConfig{Enabled: false})
+
+	if _, err := store.Profile(ctx); !errors.Is(err, ErrNoProfile) {
+		t.Fatalf("expected ErrNoProfile, got %v", err)
+	}
+
+	for _, m := range []struct {
+		agent, content, memoryType string
+		tags                       []string
+	}{
+		{"agent-1", "The user lives in Lisbon.", "biographical", nil},
+		{"agent-2", "The user prefers tea over coffee.", "preference", nil},
+		{"agent-1", "The user is training for a marathon.", "other", []string{"goal"}},
+		{"agent-2", "The user is building a garden shed.", "project", nil},
+		{"agent-1", "The user mentioned the weather.", "other", nil},
+	} {
+		if _, err := store.StorePersonalMemory(ctx, m.agent, m.content, m.content, m.memoryType, m.tags, nil, 0.5, nil); err != nil {
+			t.Fatalf("StorePersonalMemory: %v", err)
+		}
+	}
+
+	profile, changed, err := store.SynthesizeProfile(ctx)
+	if err != nil {
+		t.Fatalf("SynthesizeProfile: %v", err)
+	}
+	if !changed || profile == nil {
+		t.Fatalf("expected a new profile, got %v (changed %v)", profile, changed)
+	}
+	for _, want := range []string{
+		"## Biographical\n- The user lives in Lisbon.",
+		"## Preferences\n- The user prefers tea over coffee.",
+		"## Goals\n- The user is training for a marathon.",
+		"## Projects\n- The user is building a garden shed.",
+	} {
+		if !strings.Contains(profile.Content, want) {
+			t.Errorf("expected %q in profile:\n%s", want, profile.Content)
+		}
+	}
+	if strings.Contains(profile.Content, "weather") {
+		t.Errorf("untagged memory should not be in the profile:\n%s", profile.Content)
+	}
+
+	// Nothing new to add, so the profile is left alone
+	if _, changed, err := store.SynthesizeProfile(ctx); err != nil || changed {
+		t.Fatalf("expected an unchanged profile, got changed %v, err %v", changed, err)
+	}
+
+	// Hand-written notes survive a rebuild
+	edited := profile.Content + "\n\n## Notes\nCall me Sam."
+	if _, err := store.SetProfile(ctx, edited); err != nil {
+		t.Fatalf("SetProfile: %v", err)
+	}
+	if _, err := store.StorePersonalMemory(ctx, "agent-1", "The user has a dog.", "The user has a dog.", "biographical", nil, nil, 0.5, nil); err != nil {
+		t.Fatalf("StorePersonalMemory: %v", err)
+	}
+	rebuilt, changed, err := store.SynthesizeProfile(ctx)
+	if err != nil || !changed {
+		t.Fatalf("expected a rebuilt profile, got changed %v, err %v", changed, err)
+	}
+	if rebuilt.ID != profile.ID {
+		t.Errorf("expected the profile to be updated in place, got id %d, want %d", rebuilt.ID, profile.ID)
+	}
+	if !strings.Contains(rebuilt.Content, "The user has a dog.") || !strings.HasSuffix(rebuilt.Content, "## Notes\nCall me Sam.") {
+		t.Errorf("unexpected rebuilt profile:\n%s", rebuilt.Content)
+	}
+	if by, _ := rebuilt.Metadata["updated_by"].(string); by != "synthesizer" {
+		t.Errorf("expected updated_by synthesizer, got %q", by)
+	}
+
+	history, err := store.MemoryHistory(ctx, profile.ID)
+	if err != nil {
+		t.Fatalf("MemoryHistory: %v", err)
+	}
+	if len(history) < 2 {
+		t.Errorf("expected earlier profile versions in history, got %d", len(history))
+	}
+}
+
+func TestSynthesizeProfile_KeepsEditedSections(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	store, err := NewStore(db, newSemanticEmbedder(8), zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	store.SetDedupConfig(DedupConfig{Enabled: false})
+	remember := func(content, memoryType string) {
+		t.Helper()
+		if _, err := store.StorePersonalMemory(ctx, "agent-1", content, content, memoryType, nil, nil, 0.5, nil); err != nil {
+			t.Fatalf("StorePersonalMemory: %v", err)
+		}
+	}
+
+	remember("The user lives in Lisbon.", "biographical")
+	remember("The user prefers tea over coffee.", "preference")
+	profile, _, err := store.SynthesizeProfile(ctx)
+	if err != nil {
+		t.Fatalf("SynthesizeProfile: %v", err)
+	}
+
+	// Correct a generated section and add one of the user's own
+	edited := strings.Replace(profile.Content, "- The user prefers tea over coffee.", "- The user prefers green tea.", 1) +
+		"\n\n## Health\n- Allergic to peanuts."
+	if _, err := store.SetProfile(ctx, edited); err != nil {
+		t.Fatalf("SetProfile: %v", err)
+	}
+	remember("The user has a dog.", "biographical")
+	remember("The user likes early mornings.", "preference")
+
+	rebuilt, changed, err := store.SynthesizeProfile(ctx)
+	if err != nil || !changed {
+		t.Fatalf("expected a rebuilt profile, got changed %v, err %v", changed, err)
+	}
+	for _, want := range []string{
+		"## Biographical\n- The user lives in Lisbon.\n- The user has a dog.",
+		"## Preferences\n- The user prefers green tea.\n\n",
+		"## Health\n- Allergic to peanuts.",
+	} {
+		if !strings.Contains(rebuilt.Content, want) {
+			t.Errorf("expected %q in profile:\n%s", want, rebuilt.Content)
+		}
+	}
+	if strings.Contains(rebuilt.Content, "early mornings") || strings.Contains(rebuilt.Content, "tea over coffee") {
+		t.Errorf("expected the edited section left as written:\n%s", rebuilt.Content)
+	}
+
+	// Removing an edited section hands it back to the synthesizer
+	if _, err := store.SetProfile(ctx, strings.Replace(rebuilt.Content, "## Preferences\n- The user prefers green tea.\n", "", 1)); err != nil {
+		t.Fatalf("SetProfile: %v", err)
+	}
+	restored, _, err := store.SynthesizeProfile(ctx)
+	if err != nil {
+		t.Fatalf("SynthesizeProfile: %v", err)
+	}
+	if !strings.Contains(restored.Content, "- The user likes early mornings.") || !strings.Contains(restored.Content, "## Health") {
+		t.Errorf("expected preferences rebuilt and other edits kept:\n%s", restored.Content)
+	}
+}
+
+func TestProfile_NotSearchedOrConsolidated(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	embedder := newSemanticEmbedder(8)
+	store, err := NewStore(db, embedder, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	store.SetDedupConfig(DedupConfig{Enabled: false})
+
+	memory, err := store.StorePersonalMemory(ctx, "agent-1", "The user lives in Lisbon.", "The user lives in Lisbon.", "biographical", nil, nil, 0.5, nil)
+	if err != nil {
+		t.Fatalf("StorePersonalMemory: %v", err)
+	}
+	// Editing the profile embeds it
+	if _, err := store.SetProfile(ctx, "# User profile"); err != nil {
+		t.Fatalf("SetProfile: %v", err)
+	}
+	profile, err := store.SetProfile(ctx, "The user lives in Lisbon.")
+	if err != nil {
+		t.Fatalf("SetProfile: %v", err)
+	}
+
+	// Keyword, vector and time range searches find the memory but never the profile
+	agentID := "agent-1"
+	embedding, err := embedder.Embed(ctx, "The user lives in Lisbon.")
+	if err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	after := profile.CreatedAt.Add(-time.Hour)
+	for name, q := range map[string]*SearchQuery{
+		"keyword": {QueryText: "Lisbon", AgentID: &agentID, IncludeGlobal: true},
+		"vector":  {QueryEmbedding: embedding, AgentID: &agentID, IncludeGlobal: true, Types: []MemoryType{MemoryTypeProfile}},
+		"time":    {After: &after, AgentID: &agentID, IncludeGlobal: true},
+	} {
+		results, err := store.SearchMemory(ctx, q)
+		if err != nil {
+			t.Fatalf("%s search: %v", name, err)
+		}
+		if len(results) != 1 || results[0].Item.ID != memory.ID {
+			t.Errorf("%s search: expected only memory %d, got %+v", name, memory.ID, results)
+		}
+	}
+
+	// Consolidation leaves the profile alone
+	store.SetDedupConfig(DefaultDedupConfig())
+	result, err := store.ConsolidateMemories(ctx)
+	if err != nil {
+		t.Fatalf("ConsolidateMemories: %v", err)
+	}
+	if result.Scanned != 1 {
+		t.Errorf("expected only the personal memory to be scanned, got %+v", result)
+	}
+	current, err := store.Profile(ctx)
+	if err != nil || current.ID != profile.ID || current.SupersededBy != nil {
+		t.Errorf("expected the profile to be kept, got %+v, err %v", current, err)
+	}
+}
@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+const (
+	// ProvenanceReflection is the Provenance.Source of facts summarized by ReflectThread.
+	ProvenanceReflection = "reflection"
+	// ProvenanceExtraction is the Provenance.Source of facts stored by RememberExtractedFacts.
+	ProvenanceExtraction = "extraction"
+)
+
+// provenanceKey is the context key for the Provenance of memories stored with the context.
+type provenanceKey struct{}
+
+// WithProvenance records where memories stored with the returned context come from.
+func WithProvenance(ctx context.Context, p Provenance) context.Context {
+	return context.WithValue(ctx, provenanceKey{}, p)
+}
+
+// sourceColumnNames are the memory_items columns holding an item's Provenance.
+var sourceColumnNames = []string{
+	"source_agent_id", "source_thread_id", "source_message_id", "source_tool_id", "source_kind",
+}
+
+// sourceColumns scans the provenance columns of memory_items.
+type sourceColumns struct {
+	agentID, threadID, toolID, kind sql.NullString
+	messageID                       sql.NullInt64
+}
+
+// provenance returns the scanned provenance, or nil if none was recorded.
+func (c sourceColumns) provenance() *Provenance {
+	if !c.agentID.Valid && !c.threadID.Valid && !c.kind.Valid {
+		return nil
+	}
+	return &Provenance{
+		AgentID:   c.agentID.String,
+		ThreadID:  c.threadID.String,
+		MessageID: c.messageID.Int64,
+		ToolID:    c.toolID.String,
+		Source:    c.kind.String,
+	}
+}
+
+// provenanceFor returns the provenance recorded in ctx, or nil. If it names a thread but no
+// message, the thread's latest user message is taken as the one that led to the memory; tool
+// calls are written to the conversation only after they return, so that message is the
+// nearest one already stored.
+func (s *Store) provenanceFor(ctx context.Context) *Provenance {
+	p, ok := ctx.Value(provenanceKey{}).(Provenance)
+	if !ok {
+		return nil
+	}
+	if p.MessageID == 0 && p.AgentID != "" && p.ThreadID != "" {
+		err := s.db.QueryRowContext(ctx, `
+SELECT id FROM conversations
+WHERE agent_id = ? AND thread_id = ? AND role = 'user' AND tool_name IS NULL
+ORDER BY created_at DESC, id DESC
+LIMIT 1
+`, p.AgentID, p.ThreadID).Scan(&p.MessageID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			s.logger.Warn().Err(err).Str("thread_id", p.ThreadID).Msg("Failed to find source message for memory")
+		}
+	}
+	return &p
+}
+
+// sourceValues returns the provenance column values, in sourceColumnNames order.
+func sourceValues(p *Provenance) []interface{} {
+	if p == nil {
+		return []interface{}{nil, nil, nil, nil, nil}
+	}
+	var messageID interface{}
+	if p.MessageID != 0 {
+		messageID = p.MessageID
+	}
+	return []interface{}{
+		nullIfEmpty(p.AgentID), nullIfEmpty(p.ThreadID), messageID, nullIfEmpty(p.ToolID), nullIfEmpty(p.Source),
+	}
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestProvenance_RecordsSourceMessageAndTool(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	store, err := NewStore(db, &stubEmbedder{}, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	for _, content := range []string{"Hello", "Remember that I prefer tea."} {
+		if _, err := db.ExecContext(ctx, `
+INSERT INTO conversations (agent_id, thread_id, role, content, created_at) VALUES ('agent-1', 'thread-1', 'user', ?, 1)
+`, content); err != nil {
+			t.Fatalf("insert message: %v", err)
+		}
+	}
+	var sourceID int64
+	if err := db.QueryRowContext(ctx, `SELECT MAX(id) FROM conversations`).Scan(&sourceID); err != nil {
+		t.Fatalf("find message: %v", err)
+	}
+
+	withSource := WithProvenance(ctx, Provenance{
+		AgentID:  "agent-1",
+		ThreadID: "thread-1",
+		ToolID:   "toolu_1",
+		Source:   "memory_store_personal",
+	})
+	item, err := store.StorePersonalMemory(withSource, "agent-1", "I prefer tea.", "The user prefers tea.",
+		"preference", nil, nil, 0.5, nil)
+	if err != nil {
+		t.Fatalf("StorePersonalMemory: %v", err)
+	}
+
+	got, err := store.GetMemory(ctx, item.ID)
+	if err != nil {
+		t.Fatalf("GetMemory: %v", err)
+	}
+	want := Provenance{
+		AgentID:   "agent-1",
+		ThreadID:  "thread-1",
+		MessageID: sourceID,
+		ToolID:    "toolu_1",
+		Source:    "memory_store_personal",
+	}
+	if got.Provenance == nil || *got.Provenance != want {
+		t.Fatalf("expected provenance %+v, got %+v", want, got.Provenance)
+	}
+
+	// Memories stored outside a tool call or reflection have no provenance
+	plain, err := store.RememberGlobalFact(ctx, "The office closes at six.", 0.5, nil)
+	if err != nil {
+		t.Fatalf("RememberGlobalFact: %v", err)
+	}
+	if got, _ := store.GetMemory(ctx, plain.ID); got.Provenance != nil {
+		t.Errorf("expected no provenance, got %+v", got.Provenance)
+	}
+}
@@ -16,5 +16,9 @@ func SelectMemoryItemsColumns() []string {
 		"id", "agent_id", "thread_id", "scope", "type", "content",
 		"embedding", "metadata", "created_at", "updated_at", "importance",
 		"raw_content", "memory_type", "tags_json",
+		"embedding_model", "embedding_dim", "superseded_by",
+		"expires_at", "archived_at",
+		"source_agent_id", "source_thread_id", "source_message_id", "source_tool_id", "source_kind",
+		"group_name",
 	}
 }
@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Ranking controls how hybrid search combines its keyword, vector and tag results.
+//
+// Results are merged with reciprocal-rank fusion: each list adds weight/(K+rank) for every
+// item it returns, with rank starting at 1. Fusing ranks rather than raw scores avoids mixing
+// cosine similarities with keyword and tag scores that are on unrelated scales. The fused score
+// is then multiplied by the importance and recency boosts.
+type Ranking struct {
+	VectorWeight  float64
+	KeywordWeight float64
+	TagWeight     float64
+	K             float64 // Rank smoothing constant; larger values flatten the gap between ranks
+
+	// ImportanceWeight boosts items by 1 + ImportanceWeight*importance.
+	ImportanceWeight float64
+	// RecencyWeight boosts items by 1 + RecencyWeight*0.5^(age/RecencyHalfLife), so fresh
+	// items, such as corrections, outrank stale ones with the same relevance.
+	RecencyWeight   float64
+	RecencyHalfLife time.Duration
+}
+
+// DefaultRanking returns the ranking used when a SearchQuery doesn't set one.
+func DefaultRanking() Ranking {
+	return Ranking{
+		VectorWeight:     1.0,
+		KeywordWeight:    1.0,
+		TagWeight:        1.0,
+		K:                60,
+		ImportanceWeight: 0.25,
+		RecencyWeight:    0.5,
+		RecencyHalfLife:  30 * 24 * time.Hour,
+	}
+}
+
+// fuseResults merges ranked result lists into one list sorted by fused, boosted score.
+// Each input list must be sorted best first.
+func fuseResults(r Ranking, now time.Time, byVector, byKeyword, byTags []SearchResult) []SearchResult {
+	k := r.K
+	if k <= 0 {
+		k = DefaultRanking().K
+	}
+
+	fused := make(map[int64]*SearchResult)
+	var order []int64
+	add := func(list []SearchResult, weight float64) {
+		if weight == 0 {
+			return
+		}
+		for i, res := range list {
+			entry, ok := fused[res.Item.ID]
+			if !ok {
+				entry = &SearchResult{Item: res.Item}
+				fused[res.Item.ID] = entry
+				order = append(order, res.Item.ID)
+			}
+			if res.Match != nil {
+				entry.Match = res.Match
+			}
+			entry.Score += weight / (k + float64(i+1))
+		}
+	}
+	add(byVector, r.VectorWeight)
+	add(byKeyword, r.KeywordWeight)
+	add(byTags, r.TagWeight)
+
+	merged := make([]SearchResult, 0, len(order))
+	for _, id := range order {
+		res := fused[id]
+		res.Score *= r.boost(res.Item, now)
+		merged = append(merged, *res)
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Score > merged[j].Score
+	})
+	return merged
+}
+
+// boost returns the importance and recency multiplier for an item.
+func (r Ranking) boost(item *MemoryItem, now time.Time) float64 {
+	b := 1.0
+	if r.ImportanceWeight != 0 {
+		b *= 1 + r.ImportanceWeight*item.Importance
+	}
+	if r.RecencyWeight != 0 && r.RecencyHalfLife > 0 {
+		age := max(now.Sub(item.CreatedAt), 0)
+		decay := math.Pow(0.5, float64(age)/float64(r.RecencyHalfLife))
+		b *= 1 + r.RecencyWeight*decay
+	}
+	return b
+}
@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFuseResults_ReciprocalRank(t *testing.T) {
+	now := time.Now()
+	a := &MemoryItem{ID: 1, CreatedAt: now}
+	b := &MemoryItem{ID: 2, CreatedAt: now}
+	c := &MemoryItem{ID: 3, CreatedAt: now}
+
+	// Raw scores are on different scales; only ranks should matter
+	byVector := []SearchResult{{Item: a, Score: 0.91}, {Item: b, Score: 0.90}}
+	byKeyword := []SearchResult{{Item: b, Score: 1}, {Item: c, Score: 1}}
+
+	ranking := Ranking{VectorWeight: 1, KeywordWeight: 1, TagWeight: 1, K: 60}
+	got := fuseResults(ranking, now, byVector, byKeyword, nil)
+	if len(got) != 3 {
+		t.Fatalf("expected 3 fused results, got %d", len(got))
+	}
+	// b is ranked by both lists, so it comes first; a beats c on vector rank 1 vs keyword rank 2
+	wantOrder := []int64{2, 1, 3}
+	for i, id := range wantOrder {
+		if got[i].Item.ID != id {
+			t.Fatalf("position %d: expected item %d, got %d", i, id, got[i].Item.ID)
+		}
+	}
+	if want := 1/61.0 + 1/62.0; math.Abs(got[0].Score-want) > 1e-12 {
+		t.Errorf("expected fused score %v, got %v", want, got[0].Score)
+	}
+
+	// A zero weight drops a source entirely
+	ranking.KeywordWeight = 0
+	got = fuseResults(ranking, now, byVector, byKeyword, nil)
+	if len(got) != 2 || got[0].Item.ID != 1 {
+		t.Errorf("expected only vector results with keyword weight 0, got %d results", len(got))
+	}
+}
+
+func TestFuseResults_RecencyAndImportanceBoosts(t *testing.T) {
+	now := time.Now()
+	stale := &MemoryItem{ID: 1, CreatedAt: now.Add(-180 * 24 * time.Hour), Importance: 0.5}
+	fresh := &MemoryItem{ID: 2, CreatedAt: now.Add(-time.Hour), Importance: 0.5}
+
+	// The stale fact ranks slightly higher on relevance
+	byVector := []SearchResult{{Item: stale, Score: 0.9}, {Item: fresh, Score: 0.89}}
+
+	got := fuseResults(DefaultRanking(), now, byVector, nil, nil)
+	if got[0].Item.ID != fresh.ID {
+		t.Errorf("expected fresh correction to outrank stale fact with default ranking")
+	}
+
+	noBoost := DefaultRanking()
+	noBoost.RecencyWeight = 0
+	got = fuseResults(noBoost, now, byVector, nil, nil)
+	if got[0].Item.ID != stale.ID {
+		t.Errorf("expected relevance order without recency boost")
+	}
+
+	important := &MemoryItem{ID: 3, CreatedAt: stale.CreatedAt, Importance: 1}
+	noBoost.ImportanceWeight = 1
+	got = fuseResults(noBoost, now, []SearchResult{{Item: stale}, {Item: important}}, nil, nil)
+	if got[0].Item.ID != important.ID {
+		t.Errorf("expected importance boost to lift the more important item")
+	}
+}
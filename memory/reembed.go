@@ -0,0 +1,122 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+)
+
+// staleEmbeddingWhere selects active items without a vector from the current embedder.
+// Items stored before embedders were recorded count as stale, since their model is unknown.
+const staleEmbeddingWhere = `superseded_by IS NULL AND archived_at IS NULL
+  AND (embedding IS NULL OR embedding_model IS NULL OR embedding_model <> ?)`
+
+// ReembedBatchResult describes one batch of ReembedBatch.
+type ReembedBatchResult struct {
+	Reembedded int
+	LastID     int64 // Highest item ID in the batch; pass it as afterID to continue
+	Done       bool  // No stale items remain after afterID
+}
+
+// StaleEmbeddingCount returns how many active memory items need embedding with the
+// current embedder.
+func (s *Store) StaleEmbeddingCount(ctx context.Context) (int, error) {
+	if s.embedder == nil {
+		return 0, fmt.Errorf("no embedder configured")
+	}
+	var n int
+	if err := s.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM memory_items WHERE `+staleEmbeddingWhere,
+		s.embedder.Name(),
+	).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count stale embeddings: %w", err)
+	}
+	return n, nil
+}
+
+// ReembedBatch embeds up to limit stale items with IDs above afterID using the current
+// embedder, in one embedding request when the embedder supports batching. Re-embedded
+// items no longer count as stale, so an interrupted pass resumes where it stopped.
+func (s *Store) ReembedBatch(ctx context.Context, afterID int64, limit int) (ReembedBatchResult, error) {
+	result := ReembedBatchResult{LastID: afterID}
+	if s.embedder == nil {
+		return result, fmt.Errorf("no embedder configured")
+	}
+	if limit <= 0 {
+		limit = 32
+	}
+	model := s.embedder.Name()
+
+	rows, err := s.db.QueryContext(ctx, `
+SELECT id, content FROM memory_items
+WHERE `+staleEmbeddingWhere+` AND id > ?
+ORDER BY id
+LIMIT ?
+`, model, afterID, limit)
+	if err != nil {
+		return result, fmt.Errorf("query stale embeddings: %w", err)
+	}
+	var (
+		ids   []int64
+		texts []string
+	)
+	for rows.Next() {
+		var (
+			id      int64
+			content string
+		)
+		if err := rows.Scan(&id, &content); err != nil {
+			_ = rows.Close()
+			return result, err
+		}
+		ids = append(ids, id)
+		texts = append(texts, content)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return result, err
+	}
+	if len(ids) == 0 {
+		result.Done = true
+		return result, nil
+	}
+
+	vecs, err := EmbedBatch(ctx, s.embedder, texts)
+	if err != nil {
+		return result, fmt.Errorf("embed batch: %w", err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return result, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Only replace vectors that are still stale, in case the item was edited meanwhile
+	updated := make([]bool, len(ids))
+	for i, id := range ids {
+		embModel, embDim := s.embeddingInfo(vecs[i])
+		res, err := tx.ExecContext(ctx, `
+UPDATE memory_items SET embedding = ?, embedding_model = ?, embedding_dim = ?
+WHERE id = ? AND content = ? AND (embedding_model IS NULL OR embedding_model <> ?)
+`, EncodeEmbedding(vecs[i]), embModel, embDim, id, texts[i], model)
+		if err != nil {
+			return result, fmt.Errorf("update embedding: %w", err)
+		}
+		if n, _ := res.RowsAffected(); n > 0 {
+			updated[i] = true
+			result.Reembedded++
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return result, err
+	}
+
+	for i, id := range ids {
+		if updated[i] {
+			s.indexItem(id, vecs[i])
+		}
+	}
+	result.LastID = ids[len(ids)-1]
+	result.Done = len(ids) < limit
+	return result, nil
+}
@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestReembedBatch_ResumesUntilNoStaleItems(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	oldStore, err := NewStore(db, &namedEmbedder{semanticEmbedder: newSemanticEmbedder(8), name: "old"}, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	oldStore.SetDedupConfig(DedupConfig{Enabled: false})
+	for _, content := range []string{"The user likes hiking.", "The user works remotely.", "The user has a cat."} {
+		if _, err := oldStore.RememberGlobalFact(ctx, content, 0.5, nil); err != nil {
+			t.Fatalf("RememberGlobalFact: %v", err)
+		}
+	}
+
+	store, err := NewStore(db, newSemanticEmbedder(16), zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	stale, err := store.StaleEmbeddingCount(ctx)
+	if err != nil {
+		t.Fatalf("StaleEmbeddingCount: %v", err)
+	}
+	if stale != 3 {
+		t.Fatalf("expected 3 stale items, got %d", stale)
+	}
+
+	// A first batch covers part of the items; the rest are picked up after it
+	first, err := store.ReembedBatch(ctx, 0, 2)
+	if err != nil {
+		t.Fatalf("ReembedBatch: %v", err)
+	}
+	if first.Reembedded != 2 || first.Done {
+		t.Fatalf("unexpected first batch: %+v", first)
+	}
+	if stale, _ := store.StaleEmbeddingCount(ctx); stale != 1 {
+		t.Fatalf("expected 1 stale item after the first batch, got %d", stale)
+	}
+	second, err := store.ReembedBatch(ctx, first.LastID, 2)
+	if err != nil {
+		t.Fatalf("ReembedBatch: %v", err)
+	}
+	if second.Reembedded != 1 || !second.Done {
+		t.Fatalf("unexpected second batch: %+v", second)
+	}
+
+	results, err := store.SearchMemory(ctx, &SearchQuery{QueryText: "hiking", IncludeGlobal: true})
+	if err != nil {
+		t.Fatalf("SearchMemory: %v", err)
+	}
+	if len(results) == 0 {
+		t.Fatal("expected a search result")
+	}
+	if results[0].Item.EmbeddingModel != "semantic" || len(results[0].Item.Embedding) != 16 {
+		t.Errorf("expected a vector from the current embedder, got model %q with %d dimensions",
+			results[0].Item.EmbeddingModel, len(results[0].Item.Embedding))
+	}
+}
@@ -2,16 +2,36 @@ package memory
 
 import (
 	"context"
-	"database/sql"
-	"encoding/json"
 	"fmt"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/samber/lo"
 )
 
+// DefaultReflectionLookback is how far back reflection looks for episodes by default.
+const DefaultReflectionLookback = 7 * 24 * time.Hour
+
+// maxReflectionEpisodes caps how many episodes are summarized at once. Older ones are
+// summarized first; the rest are left for the next reflection.
+const maxReflectionEpisodes = 100
+
+// ReflectionPolicy controls how an agent's episodes are turned into durable facts.
+type ReflectionPolicy struct {
+	Interval time.Duration // How often to reflect; 0 disables scheduled reflection
+	Lookback time.Duration // Only episodes stored this recently are summarized
+	Scope    Scope         // Where the resulting facts are stored: ScopeAgent or ScopeGlobal
+}
+
+// ReflectionResult reports what a reflection summarized.
+type ReflectionResult struct {
+	Fact     *MemoryItem // Nil if there were no new episodes
+	Episodes int
+}
+
 // ReflectThread takes recent episode memories for a given agent + thread and
 // asks the Summarizer to produce a summary, which we store as a GLOBAL fact.
+// Episodes that were already reflected on are skipped.
 func (s *Store) ReflectThread(
 	ctx context.Context,
 	agentID string,
@@ -25,97 +45,139 @@ func (s *Store) ReflectThread(
 		return MemoryItem{}, fmt.Errorf("threadID is empty")
 	}
 
-	cutoff := time.Now().Add(-7 * 24 * time.Hour).Unix()
+	result, err := s.reflect(ctx, agentID, threadID, time.Now().Add(-DefaultReflectionLookback), ScopeGlobal, summarizer)
+	if err != nil {
+		return MemoryItem{}, err
+	}
+	if result.Fact == nil {
+		return MemoryItem{}, fmt.Errorf("no episodes found for agent %q thread %q", agentID, threadID)
+	}
+	return *result.Fact, nil
+}
+
+// ReflectAgent summarizes an agent's episodes from every thread within the policy's
+// lookback into one fact, stored in the policy's scope. Summarized episodes are marked
+// so later reflections skip them.
+func (s *Store) ReflectAgent(
+	ctx context.Context,
+	agentID string,
+	policy ReflectionPolicy,
+	summarizer Summarizer,
+) (ReflectionResult, error) {
+	if agentID == "" {
+		return ReflectionResult{}, fmt.Errorf("agentID is empty")
+	}
+	lookback := policy.Lookback
+	if lookback <= 0 {
+		lookback = DefaultReflectionLookback
+	}
+	return s.reflect(ctx, agentID, "", time.Now().Add(-lookback), policy.Scope, summarizer)
+}
+
+// reflect summarizes the agent's unreflected episodes since the cutoff, from one thread or
+// from all of them when threadID is empty.
+func (s *Store) reflect(
+	ctx context.Context,
+	agentID, threadID string,
+	since time.Time,
+	scope Scope,
+	summarizer Summarizer,
+) (ReflectionResult, error) {
+	if summarizer == nil {
+		return ReflectionResult{}, fmt.Errorf("no summarizer configured")
+	}
 
 	query := StatementBuilder().
-		Select("id", "agent_id", "thread_id", "scope", "type", "content",
-			"embedding", "metadata", "created_at", "updated_at", "importance").
+		Select(SelectMemoryItemsColumns()...).
 		From("memory_items").
-		Where(sq.Eq{"type": string(MemoryTypeEpisode)}).
-		Where(sq.Eq{"scope": string(ScopeAgent)}).
-		Where(sq.Eq{"agent_id": agentID}).
-		Where(sq.Eq{"thread_id": threadID}).
-		Where(sq.GtOrEq{"created_at": cutoff}).
-		OrderBy("created_at ASC")
+		Where(sq.Eq{
+			"type":          string(MemoryTypeEpisode),
+			"scope":         string(ScopeAgent),
+			"agent_id":      agentID,
+			"reflected_at":  nil,
+			"archived_at":   nil,
+			"superseded_by": nil,
+		}).
+		Where(sq.GtOrEq{"created_at": since.Unix()}).
+		OrderBy("created_at ASC", "id ASC").
+		Limit(maxReflectionEpisodes)
+	if threadID != "" {
+		query = query.Where(sq.Eq{"thread_id": threadID})
+	}
 
 	queryStr, args, err := query.ToSql()
 	if err != nil {
-		return MemoryItem{}, fmt.Errorf("build query: %w", err)
+		return ReflectionResult{}, fmt.Errorf("build query: %w", err)
 	}
 
 	rows, err := s.db.QueryContext(ctx, queryStr, args...)
 	if err != nil {
-		return MemoryItem{}, err
+		return ReflectionResult{}, err
 	}
 	defer rows.Close() //nolint:errcheck // No remedy for rows close errors
 
 	var episodes []MemoryItem
 	for rows.Next() {
-		var (
-			id          int64
-			agentIDStr  sql.NullString
-			threadIDStr sql.NullString
-			scopeStr    string
-			typStr      string
-			content     string
-			embBlob     []byte
-			metaJSON    sql.NullString
-			createdAt   int64
-			updatedAt   int64
-			importance  float64
-		)
-		if err := rows.Scan(&id, &agentIDStr, &threadIDStr, &scopeStr, &typStr, &content,
-			&embBlob, &metaJSON, &createdAt, &updatedAt, &importance); err != nil {
-			return MemoryItem{}, err
-		}
-		var meta map[string]interface{}
-		if metaJSON.Valid && metaJSON.String != "" {
-			_ = json.Unmarshal([]byte(metaJSON.String), &meta)
-		}
-		vec, _ := DecodeEmbedding(embBlob)
-
-		var agentPtr *string
-		if agentIDStr.Valid {
-			v := agentIDStr.String
-			agentPtr = &v
+		item, err := loadMemoryItemFromRow(rows)
+		if err != nil {
+			return ReflectionResult{}, err
 		}
-		var threadPtr *string
-		if threadIDStr.Valid {
-			v := threadIDStr.String
-			threadPtr = &v
-		}
-
-		episodes = append(episodes, MemoryItem{
-			ID:         id,
-			AgentID:    agentPtr,
-			ThreadID:   threadPtr,
-			Scope:      Scope(scopeStr),
-			Type:       MemoryType(typStr),
-			Content:    content,
-			Embedding:  vec,
-			Metadata:   meta,
-			CreatedAt:  time.Unix(createdAt, 0),
-			UpdatedAt:  time.Unix(updatedAt, 0),
-			Importance: importance,
-		})
+		episodes = append(episodes, *item)
 	}
 	if err := rows.Err(); err != nil {
-		return MemoryItem{}, err
+		return ReflectionResult{}, err
 	}
 	if len(episodes) == 0 {
-		return MemoryItem{}, fmt.Errorf("no episodes found for agent %q thread %q", agentID, threadID)
+		return ReflectionResult{}, nil
 	}
 
 	summary, err := summarizer.SummarizeEpisodes(episodes)
 	if err != nil {
-		return MemoryItem{}, fmt.Errorf("summarize episodes: %w", err)
+		return ReflectionResult{}, fmt.Errorf("summarize episodes: %w", err)
 	}
 
+	threadIDs := lo.Uniq(lo.FilterMap(episodes, func(ep MemoryItem, _ int) (string, bool) {
+		return derefString(ep.ThreadID), ep.ThreadID != nil
+	}))
 	meta := map[string]interface{}{
-		"thread_id": threadID,
-		"agent_id":  agentID,
-		"source":    "reflection",
+		"agent_id": agentID,
+		"source":   "reflection",
+		"episodes": len(episodes),
+	}
+	if threadID != "" {
+		meta["thread_id"] = threadID
+	} else {
+		meta["thread_ids"] = threadIDs
 	}
 
-	return s.RememberGlobalFact(ctx, summary, 0.7, meta)
+	ctx = WithProvenance(ctx, Provenance{AgentID: agentID, ThreadID: threadID, Source: ProvenanceReflection})
+	var fact MemoryItem
+	if scope == ScopeAgent {
+		fact, err = s.RememberAgentFact(ctx, agentID, summary, 0.7, meta)
+	} else {
+		fact, err = s.RememberGlobalFact(ctx, summary, 0.7, meta)
+	}
+	if err != nil {
+		return ReflectionResult{}, err
+	}
+
+	ids := lo.Map(episodes, func(ep MemoryItem, _ int) int64 { return ep.ID })
+	if err := s.markReflected(ctx, ids); err != nil {
+		return ReflectionResult{}, fmt.Errorf("mark episodes reflected: %w", err)
+	}
+	return ReflectionResult{Fact: &fact, Episodes: len(episodes)}, nil
+}
+
+// markReflected records that episodes have been summarized.
+func (s *Store) markReflected(ctx context.Context, ids []int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := execForIDs(ctx, tx, `UPDATE memory_items SET reflected_at = ? WHERE id`, ids, now()); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// recordingSummarizer joins the episodes it is given and remembers them.
+type recordingSummarizer struct {
+	calls [][]MemoryItem
+}
+
+func (r *recordingSummarizer) SummarizeEpisodes(episodes []MemoryItem) (string, error) {
+	r.calls = append(r.calls, episodes)
+	contents := make([]string, len(episodes))
+	for i, ep := range episodes {
+		contents[i] = ep.Content
+	}
+	return "Summary: " + strings.Join(contents, " "), nil
+}
+
+func TestReflectAgent_SummarizesAcrossThreadsOnce(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	store, err := NewStore(db, newSemanticEmbedder(8), zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	store.SetDedupConfig(DedupConfig{Enabled: false})
+
+	for _, ep := range []struct{ thread, content string }{
+		{"thread-1", "Drafted the quarterly report."},
+		{"thread-2", "Booked the team offsite."},
+		{"thread-2", "Long forgotten episode."},
+	} {
+		if _, err := store.RememberAgentEpisode(ctx, "agent-1", ep.thread, ep.content, 0.5, nil); err != nil {
+			t.Fatalf("RememberAgentEpisode: %v", err)
+		}
+	}
+	old := time.Now().Add(-30 * 24 * time.Hour).Unix()
+	if _, err := db.ExecContext(ctx, `UPDATE memory_items SET created_at = ? WHERE content = 'Long forgotten episode.'`, old); err != nil {
+		t.Fatalf("age episode: %v", err)
+	}
+
+	summarizer := &recordingSummarizer{}
+	policy := ReflectionPolicy{Interval: time.Hour, Lookback: 7 * 24 * time.Hour, Scope: ScopeAgent}
+	result, err := store.ReflectAgent(ctx, "agent-1", policy, summarizer)
+	if err != nil {
+		t.Fatalf("ReflectAgent: %v", err)
+	}
+	if result.Fact == nil || result.Episodes != 2 {
+		t.Fatalf("expected a fact from 2 episodes, got %+v", result)
+	}
+	if result.Fact.Scope != ScopeAgent || result.Fact.Type != MemoryTypeFact {
+		t.Errorf("expected an agent fact, got %s %s", result.Fact.Scope, result.Fact.Type)
+	}
+	if !strings.Contains(result.Fact.Content, "quarterly report") || !strings.Contains(result.Fact.Content, "offsite") {
+		t.Errorf("expected episodes from both threads in %q", result.Fact.Content)
+	}
+
+	// The episodes are marked, so reflecting again finds nothing new
+	again, err := store.ReflectAgent(ctx, "agent-1", policy, summarizer)
+	if err != nil {
+		t.Fatalf("ReflectAgent: %v", err)
+	}
+	if again.Fact != nil || len(summarizer.calls) != 1 {
+		t.Errorf("expected no second reflection, got %+v after %d summaries", again, len(summarizer.calls))
+	}
+}
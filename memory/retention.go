@@ -0,0 +1,278 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// RetentionPolicy controls how long one kind of memory is kept and how its importance fades.
+type RetentionPolicy struct {
+	TTL          time.Duration // Expire this long after the memory is stored (0 = never)
+	HalfLife     time.Duration // Importance halves over this period (0 = no decay)
+	ArchiveBelow float64       // Archive once decayed importance falls below this (0 = never)
+	Delete       bool          // Delete expired memories instead of archiving them
+}
+
+// RetentionConfig maps kinds of memory to retention policies. Keys are personal memory
+// types (preference, habit, ...) or MemoryType values (fact, episode, ...); a policy for an
+// item's personal memory type takes precedence over one for its MemoryType.
+type RetentionConfig map[string]RetentionPolicy
+
+// DefaultRetentionConfig returns the policies a new Store starts with. Episodes expire
+// after 30 days and fade with a two-week half-life so they don't crowd out durable facts;
+// everything else is kept as is.
+func DefaultRetentionConfig() RetentionConfig {
+	return RetentionConfig{
+		string(MemoryTypeEpisode): {
+			TTL:          30 * 24 * time.Hour,
+			HalfLife:     14 * 24 * time.Hour,
+			ArchiveBelow: 0.05,
+		},
+	}
+}
+
+// policyFor returns the retention policy for a kind of memory.
+func (c RetentionConfig) policyFor(typ MemoryType, memoryType string) (RetentionPolicy, bool) {
+	if memoryType != "" {
+		if p, ok := c[memoryType]; ok {
+			return p, true
+		}
+	}
+	p, ok := c[string(typ)]
+	return p, ok
+}
+
+// SetRetentionConfig changes the retention policies. Expiry times are assigned when
+// memories are stored, so a new TTL applies to memories stored afterwards.
+func (s *Store) SetRetentionConfig(cfg RetentionConfig) {
+	s.retention = cfg
+}
+
+// expiresAt returns the expires_at column value for a memory stored at nowUnix.
+func (s *Store) expiresAt(typ MemoryType, memoryType string, nowUnix int64) interface{} {
+	p, ok := s.retention.policyFor(typ, memoryType)
+	if !ok || p.TTL <= 0 {
+		return nil
+	}
+	return nowUnix + int64(p.TTL/time.Second)
+}
+
+// RetentionResult summarizes a retention pass.
+type RetentionResult struct {
+	Archived int // Expired or faded memories excluded from search
+	Deleted  int // Expired memories removed
+	Decayed  int // Memories whose importance was reduced
+}
+
+// ApplyRetention expires memories past their expires_at and decays importance, as of now.
+func (s *Store) ApplyRetention(ctx context.Context, now time.Time) (RetentionResult, error) {
+	var result RetentionResult
+	if err := s.expireMemories(ctx, now, &result); err != nil {
+		return result, fmt.Errorf("expire memories: %w", err)
+	}
+	if err := s.decayImportance(ctx, now, &result); err != nil {
+		return result, fmt.Errorf("decay importance: %w", err)
+	}
+	s.logger.Info().
+		Int("archived", result.Archived).
+		Int("deleted", result.Deleted).
+		Int("decayed", result.Decayed).
+		Msg("Memory retention applied")
+	return result, nil
+}
+
+// expireMemories archives or deletes memories whose expiry has passed.
+func (s *Store) expireMemories(ctx context.Context, now time.Time, result *RetentionResult) error {
+	rows, err := s.db.QueryContext(ctx, `
+SELECT id, type, memory_type
+FROM memory_items
+WHERE expires_at IS NOT NULL AND expires_at <= ? AND archived_at IS NULL
+`, now.Unix())
+	if err != nil {
+		return err
+	}
+	var archive, remove []int64
+	for rows.Next() {
+		var (
+			id         int64
+			typ        string
+			memoryType sql.NullString
+		)
+		if err := rows.Scan(&id, &typ, &memoryType); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		if p, _ := s.retention.policyFor(MemoryType(typ), memoryType.String); p.Delete {
+			remove = append(remove, id)
+		} else {
+			archive = append(archive, id)
+		}
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(archive) == 0 && len(remove) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := archiveItems(ctx, tx, archive, now.Unix()); err != nil {
+		return err
+	}
+	if err := deleteItems(ctx, tx, remove, now.Unix()); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	for _, id := range append(archive, remove...) {
+		s.unindexItem(id)
+	}
+	result.Archived += len(archive)
+	result.Deleted += len(remove)
+	return nil
+}
+
+// decayImportance reduces the importance of active memories with a half-life, archiving
+// those that fall below their policy's threshold.
+func (s *Store) decayImportance(ctx context.Context, now time.Time, result *RetentionResult) error {
+	var kinds []interface{}
+	for kind, p := range s.retention {
+		if p.HalfLife > 0 {
+			kinds = append(kinds, kind)
+		}
+	}
+	if len(kinds) == 0 {
+		return nil
+	}
+
+	query, args, err := StatementBuilder().
+		Select("id", "type", "memory_type", "importance", "created_at", "decayed_at").
+		From("memory_items").
+		Where(sq.Eq{"archived_at": nil, "superseded_by": nil}).
+		Where(sq.Or{sq.Eq{"type": kinds}, sq.Eq{"memory_type": kinds}}).
+		Where(sq.Gt{"importance": 0}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	type decayed struct {
+		id         int64
+		importance float64
+		archive    bool
+	}
+	var updates []decayed
+	for rows.Next() {
+		var (
+			id         int64
+			typ        string
+			memoryType sql.NullString
+			importance float64
+			createdAt  int64
+			decayedAt  sql.NullInt64
+		)
+		if err := rows.Scan(&id, &typ, &memoryType, &importance, &createdAt, &decayedAt); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		p, ok := s.retention.policyFor(MemoryType(typ), memoryType.String)
+		if !ok || p.HalfLife <= 0 {
+			continue
+		}
+		since := createdAt
+		if decayedAt.Valid {
+			since = decayedAt.Int64
+		}
+		elapsed := time.Duration(now.Unix()-since) * time.Second
+		if elapsed <= 0 {
+			continue
+		}
+		next := importance * math.Pow(0.5, float64(elapsed)/float64(p.HalfLife))
+		updates = append(updates, decayed{id: id, importance: next, archive: next < p.ArchiveBelow})
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.PrepareContext(ctx, `UPDATE memory_items SET importance = ?, decayed_at = ? WHERE id = ?`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close() //nolint:errcheck // No remedy for statement close errors
+
+	var archive []int64
+	for _, u := range updates {
+		if _, err := stmt.ExecContext(ctx, u.importance, now.Unix(), u.id); err != nil {
+			return fmt.Errorf("update importance: %w", err)
+		}
+		if u.archive {
+			archive = append(archive, u.id)
+		}
+	}
+	if err := archiveItems(ctx, tx, archive, now.Unix()); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	for _, id := range archive {
+		s.unindexItem(id)
+	}
+	result.Decayed += len(updates)
+	result.Archived += len(archive)
+	return nil
+}
+
+// archiveItems marks memories as archived.
+func archiveItems(ctx context.Context, tx *sql.Tx, ids []int64, nowUnix int64) error {
+	return execForIDs(ctx, tx, `UPDATE memory_items SET archived_at = ? WHERE id`, ids, nowUnix)
+}
+
+// execForIDs runs a statement ending in an ID column once per batch of ids, appending
+// " IN (...)". Leading args are bound before the IDs.
+func execForIDs(ctx context.Context, tx *sql.Tx, stmt string, ids []int64, args ...interface{}) error {
+	const batchSize = 500
+	for len(ids) > 0 {
+		batch := ids[:min(len(ids), batchSize)]
+		ids = ids[len(batch):]
+
+		query := stmt + " IN (" + strings.TrimSuffix(strings.Repeat("?,", len(batch)), ",") + ")"
+		batchArgs := append([]interface{}(nil), args...)
+		for _, id := range batch {
+			batchArgs = append(batchArgs, id)
+		}
+		if _, err := tx.ExecContext(ctx, query, batchArgs...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
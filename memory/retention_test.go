@@ -0,0 +1,149 @@
+package memory
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestApplyRetention_ExpiresEpisodes(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	store, err := NewStore(db, nil, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	store.SetRetentionConfig(RetentionConfig{
+		string(MemoryTypeEpisode): {TTL: time.Hour},
+		"habit":                   {TTL: time.Hour, Delete: true},
+	})
+
+	episode, err := store.RememberAgentEpisode(ctx, "agent", "thread", "Deployed the release", 0.5, nil)
+	if err != nil {
+		t.Fatalf("RememberAgentEpisode: %v", err)
+	}
+	if episode.ExpiresAt == nil {
+		t.Fatalf("expected episode to get an expiry")
+	}
+	habit, err := store.StorePersonalMemory(ctx, "agent", "", "The user runs daily.", "habit", nil, nil, 0, nil)
+	if err != nil {
+		t.Fatalf("StorePersonalMemory: %v", err)
+	}
+	fact, err := store.RememberAgentFact(ctx, "agent", "The release train leaves on Fridays", 0.5, nil)
+	if err != nil {
+		t.Fatalf("RememberAgentFact: %v", err)
+	}
+	if fact.ExpiresAt != nil {
+		t.Errorf("expected facts not to expire")
+	}
+
+	// Nothing has expired yet
+	result, err := store.ApplyRetention(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("ApplyRetention: %v", err)
+	}
+	if result.Archived != 0 || result.Deleted != 0 {
+		t.Fatalf("expected nothing expired yet, got %+v", result)
+	}
+
+	result, err = store.ApplyRetention(ctx, time.Now().Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("ApplyRetention: %v", err)
+	}
+	if result.Archived != 1 || result.Deleted != 1 {
+		t.Fatalf("expected one archived and one deleted memory, got %+v", result)
+	}
+
+	agentID := "agent"
+	results, err := store.SearchMemory(ctx, &SearchQuery{QueryText: "release", AgentID: &agentID})
+	if err != nil {
+		t.Fatalf("SearchMemory: %v", err)
+	}
+	if len(results) != 1 || results[0].Item.ID != fact.ID {
+		t.Fatalf("expected only the fact in search results, got %d results", len(results))
+	}
+
+	archived, err := store.SearchMemory(ctx, &SearchQuery{QueryText: "release", AgentID: &agentID, IncludeArchived: true})
+	if err != nil {
+		t.Fatalf("SearchMemory: %v", err)
+	}
+	if len(archived) != 2 {
+		t.Errorf("expected archived episode when requested, got %d results", len(archived))
+	}
+
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM memory_items WHERE id = ?`, habit.ID).Scan(&n); err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if n != 0 {
+		t.Errorf("expected habit memory deleted")
+	}
+}
+
+func TestApplyRetention_DecaysImportance(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	store, err := NewStore(db, nil, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	store.SetRetentionConfig(RetentionConfig{
+		string(MemoryTypeEpisode): {HalfLife: 24 * time.Hour, ArchiveBelow: 0.1},
+	})
+
+	episode, err := store.RememberAgentEpisode(ctx, "agent", "thread", "Reviewed the roadmap", 0.8, nil)
+	if err != nil {
+		t.Fatalf("RememberAgentEpisode: %v", err)
+	}
+	fact, err := store.RememberAgentFact(ctx, "agent", "The roadmap is public", 0.8, nil)
+	if err != nil {
+		t.Fatalf("RememberAgentFact: %v", err)
+	}
+
+	importance := func(id int64) float64 {
+		t.Helper()
+		var v float64
+		if err := db.QueryRow(`SELECT importance FROM memory_items WHERE id = ?`, id).Scan(&v); err != nil {
+			t.Fatalf("importance: %v", err)
+		}
+		return v
+	}
+
+	start := episode.CreatedAt
+	if _, err := store.ApplyRetention(ctx, start.Add(24*time.Hour)); err != nil {
+		t.Fatalf("ApplyRetention: %v", err)
+	}
+	if got := importance(episode.ID); math.Abs(got-0.4) > 1e-9 {
+		t.Errorf("expected importance halved to 0.4, got %v", got)
+	}
+	if got := importance(fact.ID); got != 0.8 {
+		t.Errorf("expected fact importance unchanged, got %v", got)
+	}
+
+	// Decay continues from the last run rather than compounding from creation
+	result, err := store.ApplyRetention(ctx, start.Add(72*time.Hour))
+	if err != nil {
+		t.Fatalf("ApplyRetention: %v", err)
+	}
+	if got := importance(episode.ID); math.Abs(got-0.1) > 1e-9 {
+		t.Errorf("expected importance 0.1 after three half-lives, got %v", got)
+	}
+	if result.Archived != 0 {
+		t.Errorf("expected no archive at the threshold, got %+v", result)
+	}
+
+	result, err = store.ApplyRetention(ctx, start.Add(96*time.Hour))
+	if err != nil {
+		t.Fatalf("ApplyRetention: %v", err)
+	}
+	if result.Archived != 1 {
+		t.Errorf("expected faded episode archived, got %+v", result)
+	}
+}
@@ -3,31 +3,75 @@ package memory
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/rs/zerolog"
+	"github.com/samber/lo"
 )
 
-// MemoryRouter handles routing of memories between agent-private and global.
+// MemoryRouter handles routing of memories between agent-private, group and global.
 type MemoryRouter struct {
 	store      *Store
 	summarizer Summarizer
+	groups     map[string][]string // Agent ID -> sharing groups it belongs to
+	location   *time.Location      // User's timezone for resolving relative times
 	logger     zerolog.Logger
 }
 
 // Config allows customizing MemoryRouter behavior.
 type Config struct {
 	Summarizer Summarizer
+	Groups     map[string][]string // Sharing group name -> member agent IDs
+	Location   *time.Location      // User's timezone (default: time.Local)
 }
 
 func NewMemoryRouter(store *Store, cfg Config, logger zerolog.Logger) *MemoryRouter {
+	groups := make(map[string][]string)
+	for group, members := range cfg.Groups {
+		for _, agentID := range lo.Uniq(members) {
+			groups[agentID] = append(groups[agentID], group)
+		}
+	}
+	for agentID := range groups {
+		sort.Strings(groups[agentID])
+	}
+	location := cfg.Location
+	if location == nil {
+		location = time.Local
+	}
 	return &MemoryRouter{
 		store:      store,
 		summarizer: cfg.Summarizer,
+		groups:     groups,
+		location:   location,
 		logger:     logger,
 	}
 }
 
+// Now returns the current time in the user's timezone, against which relative times such
+// as "yesterday" are resolved.
+func (r *MemoryRouter) Now() time.Time {
+	return time.Now().In(r.location)
+}
+
+// ErrNotGroupMember is returned when an agent uses a sharing group it does not belong to.
+var ErrNotGroupMember = errors.New("agent is not a member of the group")
+
+// Groups returns the sharing groups agentID belongs to, sorted by name.
+func (r *MemoryRouter) Groups(agentID string) []string {
+	return r.groups[agentID]
+}
+
+// checkMember verifies that agentID belongs to group.
+func (r *MemoryRouter) checkMember(agentID, group string) error {
+	if !lo.Contains(r.groups[agentID], group) {
+		return fmt.Errorf("group %q: %w", group, ErrNotGroupMember)
+	}
+	return nil
+}
+
 // StorePersonalMemory stores a normalized personal memory for a specific agent.
 // This is a thin wrapper around Store.StorePersonalMemory to keep tools decoupled
 // from the underlying storage implementation.
@@ -104,6 +148,26 @@ func (r *MemoryRouter) AddGlobalFact(
 	)
 }
 
+// AddGroupFact stores a fact shared with the other agents of one of agentID's groups.
+func (r *MemoryRouter) AddGroupFact(
+	ctx context.Context,
+	agentID string,
+	group string,
+	content string,
+	metadata map[string]interface{},
+) (MemoryItem, error) {
+	if err := r.checkMember(agentID, group); err != nil {
+		return MemoryItem{}, err
+	}
+	return r.store.RememberGroupFact(
+		ctx,
+		group,
+		content,
+		0.8,
+		metadata,
+	)
+}
+
 // AddArtifact stores a shared, durable document.
 func (r *MemoryRouter) AddArtifact(
 	ctx context.Context,
@@ -135,6 +199,15 @@ func (r *MemoryRouter) Reflect(
 	return r.store.ReflectThread(ctx, agentID, threadID, r.summarizer)
 }
 
+// ReflectAgent consolidates an agent's unreflected episodes from all threads into a fact.
+func (r *MemoryRouter) ReflectAgent(
+	ctx context.Context,
+	agentID string,
+	policy ReflectionPolicy,
+) (ReflectionResult, error) {
+	return r.store.ReflectAgent(ctx, agentID, policy, r.summarizer)
+}
+
 // AutoReflect performs time-based reflection.
 func (r *MemoryRouter) AutoReflect(
 	ctx context.Context,
@@ -157,35 +230,45 @@ func (r *MemoryRouter) AutoReflect(
 	return &item, nil
 }
 
-// QueryAgentMemory returns agent-private memory plus optional global.
-func (r *MemoryRouter) QueryAgentMemory(
-	ctx context.Context,
-	agentID string,
-	text string,
-	embedding []float32,
-	includeGlobal bool,
-	limit int,
-	types []MemoryType,
-) ([]SearchResult, error) {
+// AgentQueryOptions controls QueryAgentMemory.
+type AgentQueryOptions struct {
+	Text          string
+	Embedding     []float32
+	IncludeGlobal bool // Also search global memories
+	Limit         int
+	Types         []MemoryType
+	Ranking       *Ranking   // Nil uses DefaultRanking
+	TimeRange     *TimeRange // Limits results to memories created within it
+}
+
+// QueryAgentMemory returns agent-private memory and the memories of the agent's sharing
+// groups, plus optional global.
+func (r *MemoryRouter) QueryAgentMemory(ctx context.Context, agentID string, opts AgentQueryOptions) ([]SearchResult, error) {
 	r.logger.Info().
 		Str("method", "QueryAgentMemory").
 		Str("agentID", agentID).
-		Str("text", text).
-		Bool("hasEmbedding", embedding != nil).
-		Bool("includeGlobal", includeGlobal).
-		Int("limit", limit).
-		Interface("types", types).
+		Str("text", opts.Text).
+		Bool("hasEmbedding", opts.Embedding != nil).
+		Bool("includeGlobal", opts.IncludeGlobal).
+		Int("limit", opts.Limit).
+		Interface("types", opts.Types).
 		Msg("QueryAgentMemory started")
 
-	results, err := r.store.SearchMemory(ctx, &SearchQuery{
+	q := &SearchQuery{
 		AgentID:        &agentID,
-		IncludeGlobal:  includeGlobal,
-		QueryText:      text,
-		QueryEmbedding: embedding,
-		Limit:          limit,
+		IncludeGlobal:  opts.IncludeGlobal,
+		Groups:         r.Groups(agentID),
+		QueryText:      opts.Text,
+		QueryEmbedding: opts.Embedding,
+		Limit:          opts.Limit,
 		UseHybrid:      true,
-		Types:          types,
-	})
+		Types:          opts.Types,
+		Ranking:        opts.Ranking,
+	}
+	if opts.TimeRange != nil {
+		q.After, q.Before = opts.TimeRange.After, opts.TimeRange.Before
+	}
+	results, err := r.store.SearchMemory(ctx, q)
 	if err != nil {
 		r.logger.Error().
 			Str("method", "QueryAgentMemory").
@@ -275,3 +358,163 @@ func (r *MemoryRouter) QueryPersonalMemory(
 
 	return r.store.SearchMemory(ctx, query)
 }
+
+// ErrMemoryNotOwned is returned when an agent edits another agent's private memory.
+var ErrMemoryNotOwned = errors.New("memory belongs to another agent")
+
+// UpdateMemory edits a memory on behalf of an agent. Agents may edit global memories,
+// memories of their sharing groups and their own agent-scoped memories.
+func (r *MemoryRouter) UpdateMemory(ctx context.Context, agentID string, id int64, upd MemoryUpdate) (*MemoryItem, error) {
+	if err := r.checkOwner(ctx, agentID, id); err != nil {
+		return nil, err
+	}
+	return r.store.UpdateMemory(ctx, id, upd)
+}
+
+// ForgetMemory deletes a memory on behalf of an agent, with the same ownership rules as
+// UpdateMemory.
+func (r *MemoryRouter) ForgetMemory(ctx context.Context, agentID string, id int64) error {
+	if err := r.checkOwner(ctx, agentID, id); err != nil {
+		return err
+	}
+	return r.store.DeleteMemory(ctx, id)
+}
+
+// checkOwner verifies that agentID may change memory id.
+func (r *MemoryRouter) checkOwner(ctx context.Context, agentID string, id int64) error {
+	item, err := r.store.GetMemory(ctx, id)
+	if err != nil {
+		return err
+	}
+	if item.Scope == ScopeAgent && item.AgentID != nil && *item.AgentID != agentID {
+		return fmt.Errorf("memory %d: %w", id, ErrMemoryNotOwned)
+	}
+	if item.Scope == ScopeGroup {
+		if err := r.checkMember(agentID, item.Group); err != nil {
+			return fmt.Errorf("memory %d: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// AddAgentArtifact stores a durable document visible only to the agent that wrote it.
+func (r *MemoryRouter) AddAgentArtifact(
+	ctx context.Context,
+	agentID string,
+	title string,
+	body string,
+	metadata map[string]interface{},
+) (Artifact, error) {
+	return r.store.CreateArtifact(
+		ctx,
+		ScopeAgent,
+		&agentID,
+		nil,
+		title,
+		body,
+		metadata,
+	)
+}
+
+// SearchDocs searches the chunks of ingested workspace documents. Documents are shared by
+// all agents, so there is no agent to check.
+func (r *MemoryRouter) SearchDocs(ctx context.Context, query string, limit int) ([]DocResult, error) {
+	return r.store.SearchDocs(ctx, query, limit)
+}
+
+// LinkEntities records the entities a memory mentions and the relations it states between
+// them, as found by the normalizer.
+func (r *MemoryRouter) LinkEntities(ctx context.Context, memoryID int64, entities []EntityRef, relations []RelationRef) error {
+	return r.store.LinkEntities(ctx, memoryID, entities, relations)
+}
+
+// LookupEntities returns what the memories visible to agentID say about each entity named
+// name, optionally only of one kind. limit caps the memories returned for each entity.
+func (r *MemoryRouter) LookupEntities(ctx context.Context, agentID, name string, kind EntityKind, limit int) ([]EntityContext, error) {
+	q := r.entityQuery(agentID, limit)
+	entities, err := r.store.FindEntities(ctx, q, name, kind)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]EntityContext, 0, len(entities))
+	for _, e := range entities {
+		ec, err := r.store.EntityContext(ctx, q, []int64{e.ID})
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, *ec)
+	}
+	return results, nil
+}
+
+// RelatedMemories returns what the memories visible to agentID say about the named entities
+// together: the memories that mention all of them, and the relations and other entities
+// connected to them. A name matching several entities stands for any of them.
+func (r *MemoryRouter) RelatedMemories(ctx context.Context, agentID string, names []string, limit int) (*EntityContext, error) {
+	q := r.entityQuery(agentID, limit)
+	sets := make([][]int64, 0, len(names))
+	for _, name := range names {
+		entities, err := r.store.FindEntities(ctx, q, name, "")
+		if err != nil {
+			return nil, err
+		}
+		if len(entities) == 0 {
+			return nil, fmt.Errorf("%q: %w", name, ErrEntityNotFound)
+		}
+		sets = append(sets, lo.Map(entities, func(e Entity, _ int) int64 { return e.ID }))
+	}
+	return r.store.EntityContext(ctx, q, sets...)
+}
+
+// entityQuery selects the memories agentID can see: its own, its groups' and global ones.
+func (r *MemoryRouter) entityQuery(agentID string, limit int) *SearchQuery {
+	return &SearchQuery{
+		AgentID:       &agentID,
+		IncludeGlobal: true,
+		Groups:        r.Groups(agentID),
+		Limit:         limit,
+	}
+}
+
+// ReadArtifact returns an artifact visible to agentID. Other agents' private artifacts
+// are reported as not found.
+func (r *MemoryRouter) ReadArtifact(ctx context.Context, agentID string, id int64) (*Artifact, error) {
+	a, err := r.store.GetArtifact(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !artifactVisible(a, agentID) {
+		return nil, fmt.Errorf("artifact %d: %w", id, ErrArtifactNotFound)
+	}
+	return a, nil
+}
+
+// ReadArtifactVersion returns an earlier revision of an artifact visible to agentID.
+func (r *MemoryRouter) ReadArtifactVersion(ctx context.Context, agentID string, id int64, version int) (*ArtifactVersion, error) {
+	if _, err := r.ReadArtifact(ctx, agentID, id); err != nil {
+		return nil, err
+	}
+	return r.store.GetArtifactVersion(ctx, id, version)
+}
+
+// ListArtifacts returns artifacts visible to agentID whose title or body contains query.
+func (r *MemoryRouter) ListArtifacts(ctx context.Context, agentID, query string, limit int) ([]*Artifact, error) {
+	return r.store.ListArtifacts(ctx, ArtifactFilter{
+		AgentID: &agentID,
+		Query:   query,
+		Limit:   limit,
+	})
+}
+
+// UpdateArtifact records a new revision of an artifact visible to agentID.
+func (r *MemoryRouter) UpdateArtifact(ctx context.Context, agentID string, id int64, upd ArtifactUpdate) (*Artifact, error) {
+	if _, err := r.ReadArtifact(ctx, agentID, id); err != nil {
+		return nil, err
+	}
+	return r.store.UpdateArtifact(ctx, id, &agentID, upd)
+}
+
+// artifactVisible reports whether agentID may see a.
+func artifactVisible(a *Artifact, agentID string) bool {
+	return a.Scope == ScopeGlobal || (a.AgentID != nil && *a.AgentID == agentID)
+}
@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strings"
 	"time"
+	"unicode"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/rs/zerolog"
@@ -93,6 +94,31 @@ func (s *Store) SearchMemory(ctx context.Context, q *SearchQuery) ([]SearchResul
 		s.logger.Debug().Msg("SearchMemory: skipping tag search (no tags provided)")
 	}
 
+	ranking := DefaultRanking()
+	if q.Ranking != nil {
+		ranking = *q.Ranking
+	}
+
+	// A query that is only a time range, such as "what happened yesterday", lists the
+	// memories created in it, newest first.
+	if !useFTS && q.QueryEmbedding == nil && len(q.Tags) == 0 && (q.After != nil || q.Before != nil) {
+		s.logger.Debug().Msg("SearchMemory: executing time range search")
+		byTime, err := s.searchByTime(ctx, q, limit*3)
+		if err != nil {
+			s.logger.Error().Err(err).Msg("SearchMemory: time range search failed")
+			return nil, err
+		}
+		ranking.KeywordWeight = 1
+		merged := fuseResults(ranking, time.Now(), nil, byTime, nil)
+		if len(merged) > limit {
+			merged = merged[:limit]
+		}
+		s.logger.Info().
+			Int("returning", len(merged)).
+			Msg("SearchMemory: returning time range results")
+		return merged, nil
+	}
+
 	if !q.UseHybrid {
 		s.logger.Debug().Msg("SearchMemory: non-hybrid mode, selecting best result set")
 		if len(byVector) > 0 {
@@ -126,52 +152,13 @@ func (s *Store) SearchMemory(ctx context.Context, q *SearchQuery) ([]SearchResul
 		return nil, nil
 	}
 
-	results := make(map[int64]SearchResult)
-	const vectorWeight = 0.5
-	const tagWeight = 0.3
-	const ftsWeight = 0.2
-
-	for _, r := range byVector {
-		results[r.Item.ID] = SearchResult{
-			Item:  r.Item,
-			Score: r.Score * vectorWeight,
-		}
-	}
-	for _, r := range byTags {
-		if existing, ok := results[r.Item.ID]; ok {
-			existing.Score += r.Score * tagWeight
-			results[r.Item.ID] = existing
-		} else {
-			results[r.Item.ID] = SearchResult{
-				Item:  r.Item,
-				Score: r.Score * tagWeight,
-			}
-		}
-	}
-	for _, r := range byKeyword {
-		if existing, ok := results[r.Item.ID]; ok {
-			existing.Score += r.Score * ftsWeight
-			results[r.Item.ID] = existing
-		} else {
-			results[r.Item.ID] = SearchResult{
-				Item:  r.Item,
-				Score: r.Score * ftsWeight,
-			}
-		}
-	}
-
-	merged := make([]SearchResult, 0, len(results))
-	for _, r := range results {
-		merged = append(merged, r)
-	}
-	sort.Slice(merged, func(i, j int) bool {
-		return merged[i].Score > merged[j].Score
-	})
+	merged := fuseResults(ranking, time.Now(), byVector, byKeyword, byTags)
+	unique := len(merged)
 	if len(merged) > limit {
 		merged = merged[:limit]
 	}
 	s.logger.Info().
-		Int("uniqueResults", len(results)).
+		Int("uniqueResults", unique).
 		Int("vectorResults", len(byVector)).
 		Int("tagResults", len(byTags)).
 		Int("keywordResults", len(byKeyword)).
@@ -185,12 +172,29 @@ func (s *Store) searchByKeyword(ctx context.Context, q *SearchQuery, limit int)
 		Str("queryText", q.QueryText).
 		Int("limit", limit).
 		Msg("searchByKeyword: begin")
-	rows, err := s.db.QueryContext(ctx, `
-SELECT rowid
-FROM memory_items_fts
-WHERE memory_items_fts MATCH ?
-LIMIT ?
-`, q.QueryText, limit)
+	match := ftsQuery(q.QueryText)
+	if match == "" {
+		return nil, nil
+	}
+
+	// bm25() is lower for better matches; raw_content mostly restates content, so it counts for less.
+	// Filters are applied before the limit so other memory types can't crowd out the matches.
+	query := StatementBuilder().
+		Select("memory_items_fts.rowid").
+		Column("-bm25(memory_items_fts, 1.0, 0.5, 1.0) AS score").
+		Column(sq.Expr("snippet(memory_items_fts, -1, ?, ?, '…', 16)", MatchStart, MatchEnd)).
+		Column(sq.Expr("highlight(memory_items_fts, 0, ?, ?)", MatchStart, MatchEnd)).
+		From("memory_items_fts").
+		Join("memory_items ON memory_items.id = memory_items_fts.rowid").
+		Where("memory_items_fts MATCH ?", match).
+		Where(buildFilterWhere(q)).
+		OrderBy("score DESC").
+		Limit(uint64(limit))
+	queryStr, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+	rows, err := s.db.QueryContext(ctx, queryStr, args...)
 	if err != nil {
 		s.logger.Error().Err(err).Msg("searchByKeyword: FTS query failed")
 		return nil, fmt.Errorf("fts query: %w", err)
@@ -198,13 +202,20 @@ LIMIT ?
 	defer rows.Close() //nolint:errcheck // no remedy for rows close error
 
 	var ids []int64
+	matches := make(map[int64]*TextMatch)
 	for rows.Next() {
-		var id int64
-		if err := rows.Scan(&id); err != nil {
+		var (
+			id                 int64
+			m                  TextMatch
+			snippet, highlight sql.NullString
+		)
+		if err := rows.Scan(&id, &m.BM25, &snippet, &highlight); err != nil {
 			s.logger.Error().Err(err).Msg("searchByKeyword: failed to scan rowid")
 			return nil, err
 		}
+		m.Snippet, m.Highlight = snippet.String, highlight.String
 		ids = append(ids, id)
+		matches[id] = &m
 	}
 	if err := rows.Err(); err != nil {
 		s.logger.Error().Err(err).Msg("searchByKeyword: row iteration error")
@@ -228,6 +239,15 @@ LIMIT ?
 		Int("numLoadedItems", len(items)).
 		Msg("searchByKeyword: loaded items from DB")
 
+	// Restore FTS relevance order, which hybrid ranking depends on
+	position := make(map[int64]int, len(ids))
+	for i, id := range ids {
+		position[id] = i
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return position[items[i].ID] < position[items[j].ID]
+	})
+
 	results := lo.FilterMap(items, func(it *MemoryItem, _ int) (SearchResult, bool) {
 		if !applyFilters(it, q, s.logger) {
 			s.logger.Debug().
@@ -238,9 +258,11 @@ LIMIT ?
 				Msg("searchByKeyword: item filtered out")
 			return SearchResult{}, false
 		}
+		match := matches[it.ID]
 		return SearchResult{
 			Item:  it,
-			Score: 1.0,
+			Score: match.BM25,
+			Match: match,
 		}, true
 	})
 	filteredCount := len(items) - len(results)
@@ -252,6 +274,20 @@ LIMIT ?
 	return results, nil
 }
 
+// ftsQuery turns free text into an FTS5 query matching rows that contain all of its terms.
+// Each term is quoted, so punctuation such as "?", "'" and "-" is read as text instead of
+// query syntax. Terms without letters or digits are dropped.
+func ftsQuery(text string) string {
+	var terms []string
+	for _, term := range strings.Fields(text) {
+		if !strings.ContainsFunc(term, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) {
+			continue
+		}
+		terms = append(terms, `"`+strings.ReplaceAll(term, `"`, `""`)+`"`)
+	}
+	return strings.Join(terms, " ")
+}
+
 // loadMemoryItemFromRow has no usage of logger, so unchanged.
 func loadMemoryItemFromRow(rows *sql.Rows) (*MemoryItem, error) {
 	var (
@@ -269,10 +305,20 @@ func loadMemoryItemFromRow(rows *sql.Rows) (*MemoryItem, error) {
 		rawContent  sql.NullString
 		memoryType  sql.NullString
 		tagsJSON    sql.NullString
+		embModel    sql.NullString
+		embDim      sql.NullInt64
+		superseded  sql.NullInt64
+		expiresAt   sql.NullInt64
+		archivedAt  sql.NullInt64
+		source      sourceColumns
+		group       sql.NullString
 	)
 	if err := rows.Scan(&id, &agentIDStr, &threadIDStr, &scopeStr, &typStr, &content,
 		&embBlob, &metaJSON, &createdAt, &updatedAt, &importance,
-		&rawContent, &memoryType, &tagsJSON); err != nil {
+		&rawContent, &memoryType, &tagsJSON, &embModel, &embDim, &superseded,
+		&expiresAt, &archivedAt,
+		&source.agentID, &source.threadID, &source.messageID, &source.toolID, &source.kind,
+		&group); err != nil {
 		return nil, err
 	}
 
@@ -316,6 +362,7 @@ func loadMemoryItemFromRow(rows *sql.Rows) (*MemoryItem, error) {
 		CreatedAt:  time.Unix(createdAt, 0),
 		UpdatedAt:  time.Unix(updatedAt, 0),
 		Importance: importance,
+		Group:      group.String,
 	}
 	if rawContent.Valid {
 		item.RawContent = rawContent.String
@@ -326,17 +373,37 @@ func loadMemoryItemFromRow(rows *sql.Rows) (*MemoryItem, error) {
 	if tags != nil {
 		item.Tags = tags
 	}
+	item.EmbeddingModel = embModel.String
+	item.EmbeddingDim = int(embDim.Int64)
+	if superseded.Valid {
+		v := superseded.Int64
+		item.SupersededBy = &v
+	}
+	if expiresAt.Valid {
+		v := time.Unix(expiresAt.Int64, 0)
+		item.ExpiresAt = &v
+	}
+	if archivedAt.Valid {
+		v := time.Unix(archivedAt.Int64, 0)
+		item.ArchivedAt = &v
+	}
+	item.Provenance = source.provenance()
 
 	return item, nil
 }
 
 func (s *Store) searchByVector(ctx context.Context, q *SearchQuery, limit int) ([]SearchResult, error) {
+	if s.index != nil && s.index.graph.Dim() == len(q.QueryEmbedding) {
+		return s.searchByVectorIndex(ctx, q, limit)
+	}
+
+	// Without an index, only the most recent candidates are scored
 	const candidateLimit = 500
 
 	query := StatementBuilder().
 		Select(SelectMemoryItemsColumns()...).
 		From("memory_items").
-		Where(buildFilterWhere(q, s.logger)).
+		Where(buildFilterWhere(q)).
 		OrderBy("created_at DESC").
 		Limit(uint64(candidateLimit))
 
@@ -361,6 +428,7 @@ func (s *Store) searchByVector(ctx context.Context, q *SearchQuery, limit int) (
 	scannedCount := 0
 	zeroScoreCount := 0
 	filteredCount := 0
+	mismatchCount := 0
 	for rows.Next() {
 		item, err := loadMemoryItemFromRow(rows)
 		if err != nil {
@@ -375,6 +443,10 @@ func (s *Store) searchByVector(ctx context.Context, q *SearchQuery, limit int) (
 				Msg("searchByVector: item has no embedding, skipping")
 			continue
 		}
+		if !s.comparableEmbedding(item, q.QueryEmbedding) {
+			mismatchCount++
+			continue
+		}
 
 		score := CosineSimilarity(q.QueryEmbedding, item.Embedding)
 		if score <= 0 {
@@ -406,6 +478,7 @@ func (s *Store) searchByVector(ctx context.Context, q *SearchQuery, limit int) (
 	s.logger.Info().
 		Int("scanned", scannedCount).
 		Int("zeroOrNegativeScore", zeroScoreCount).
+		Int("embedderMismatch", mismatchCount).
 		Int("filtered", filteredCount).
 		Int("validResults", len(results)).
 		Msg("searchByVector: summary")
@@ -423,6 +496,16 @@ func (s *Store) searchByVector(ctx context.Context, q *SearchQuery, limit int) (
 	return results, nil
 }
 
+// comparableEmbedding reports whether an item's stored vector can be compared with a query
+// vector from the store's embedder. Vectors stored before embedders were recorded have no
+// model and are compared whenever the dimensions match.
+func (s *Store) comparableEmbedding(item *MemoryItem, query []float32) bool {
+	if len(item.Embedding) != len(query) {
+		return false
+	}
+	return item.EmbeddingModel == "" || s.embedder == nil || item.EmbeddingModel == s.embedder.Name()
+}
+
 func (s *Store) searchByTags(ctx context.Context, q *SearchQuery, limit int) ([]SearchResult, error) {
 	if len(q.Tags) == 0 {
 		return nil, nil
@@ -432,7 +515,7 @@ func (s *Store) searchByTags(ctx context.Context, q *SearchQuery, limit int) ([]
 	query := StatementBuilder().
 		Select(SelectMemoryItemsColumns()...).
 		From("memory_items").
-		Where(buildFilterWhere(q, s.logger)).
+		Where(buildFilterWhere(q)).
 		OrderBy("created_at DESC").
 		Limit(uint64(candidateLimit))
 
@@ -507,6 +590,42 @@ func (s *Store) searchByTags(ctx context.Context, q *SearchQuery, limit int) ([]
 	return results, nil
 }
 
+// searchByTime returns the newest memories matching q's filters, which include its time range.
+func (s *Store) searchByTime(ctx context.Context, q *SearchQuery, limit int) ([]SearchResult, error) {
+	query := StatementBuilder().
+		Select(SelectMemoryItemsColumns()...).
+		From("memory_items").
+		Where(buildFilterWhere(q)).
+		OrderBy("created_at DESC", "id DESC").
+		Limit(uint64(limit))
+
+	queryStr, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+	rows, err := s.db.QueryContext(ctx, queryStr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("time range query: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck // no remedy for rows close error
+
+	var results []SearchResult
+	for rows.Next() {
+		item, err := loadMemoryItemFromRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		if !applyFilters(item, q, s.logger) {
+			continue
+		}
+		results = append(results, SearchResult{Item: item, Score: 1})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
 func (s *Store) loadItemsByIDs(ctx context.Context, ids []int64) ([]*MemoryItem, error) {
 	if len(ids) == 0 {
 		s.logger.Debug().Msg("loadItemsByIDs: no IDs provided")
@@ -567,29 +686,35 @@ func (s *Store) loadItemsByIDs(ctx context.Context, ids []int64) ([]*MemoryItem,
 
 // buildFilterWhere builds Squirrel WHERE conditions based on SearchQuery filters.
 // Returns a sq.Sqlizer that can be used in Where() clauses.
-func buildFilterWhere(q *SearchQuery, logger zerolog.Logger) sq.Sqlizer {
+func buildFilterWhere(q *SearchQuery) sq.Sqlizer {
 	var conditions []sq.Sqlizer
 
-	// Build scope/agent_id filter
+	// Build scope filter: the agent's own memories, its groups' memories and global ones
+	var scopes sq.Or
 	if q.AgentID != nil {
-		if q.IncludeGlobal {
-			// (scope = 'agent' AND agent_id = ?) OR scope = 'global'
-			conditions = append(conditions, sq.Or{
-				sq.And{
-					sq.Eq{"scope": string(ScopeAgent)},
-					sq.Eq{"agent_id": *q.AgentID},
-				},
-				sq.Eq{"scope": string(ScopeGlobal)},
-			})
-		} else {
-			// scope = 'agent' AND agent_id = ?
-			conditions = append(conditions, sq.Eq{"scope": string(ScopeAgent)}, sq.Eq{"agent_id": *q.AgentID})
-		}
-	} else {
-		if q.IncludeGlobal {
-			// scope = 'global'
-			conditions = append(conditions, sq.Eq{"scope": string(ScopeGlobal)})
-		}
+		// scope = 'agent' AND agent_id = ?
+		scopes = append(scopes, sq.And{
+			sq.Eq{"scope": string(ScopeAgent)},
+			sq.Eq{"agent_id": *q.AgentID},
+		})
+	}
+	if len(q.Groups) > 0 {
+		// scope = 'group' AND group_name IN (...)
+		scopes = append(scopes, sq.And{
+			sq.Eq{"scope": string(ScopeGroup)},
+			sq.Eq{"group_name": q.Groups},
+		})
+	}
+	if q.IncludeGlobal {
+		// scope = 'global'
+		scopes = append(scopes, sq.Eq{"scope": string(ScopeGlobal)})
+	}
+	switch len(scopes) {
+	case 0:
+	case 1:
+		conditions = append(conditions, scopes[0])
+	default:
+		conditions = append(conditions, scopes)
 	}
 
 	// Type filter
@@ -600,6 +725,20 @@ func buildFilterWhere(q *SearchQuery, logger zerolog.Logger) sq.Sqlizer {
 		}
 		conditions = append(conditions, sq.Eq{"type": typeStrings})
 	}
+	if len(q.MemoryTypes) > 0 {
+		conditions = append(conditions, sq.Eq{"memory_type": q.MemoryTypes})
+	}
+
+	// Superseded items are only kept for history
+	if !q.IncludeSuperseded {
+		conditions = append(conditions, sq.Eq{"superseded_by": nil})
+	}
+	if !q.IncludeArchived {
+		conditions = append(conditions, sq.Eq{"archived_at": nil})
+	}
+
+	// The user profile document is read through Profile, never searched
+	conditions = append(conditions, sq.Or{sq.Eq{"memory_type": nil}, sq.NotEq{"memory_type": ProfileMemoryType}})
 
 	// Importance filter
 	if q.MinImportance > 0 {
@@ -614,12 +753,6 @@ func buildFilterWhere(q *SearchQuery, logger zerolog.Logger) sq.Sqlizer {
 		conditions = append(conditions, sq.LtOrEq{"created_at": q.Before.Unix()})
 	}
 
-	// If no filters were applied, return a condition that matches all rows
-	if len(conditions) == 0 {
-		logger.Debug().Msg("buildFilterWhere: no filters, query will match all rows")
-		return sq.Expr("1=1")
-	}
-
 	// Combine all conditions with AND
 	if len(conditions) == 1 {
 		return conditions[0]
@@ -627,57 +760,33 @@ func buildFilterWhere(q *SearchQuery, logger zerolog.Logger) sq.Sqlizer {
 	return sq.And(conditions)
 }
 
+// inScope reports whether item is one of the query agent's own memories, a memory of one of
+// the query groups, or, if the query includes them, a global memory.
+func inScope(item *MemoryItem, q *SearchQuery) bool {
+	switch item.Scope {
+	case ScopeAgent:
+		return q.AgentID != nil && item.AgentID != nil && *item.AgentID == *q.AgentID
+	case ScopeGroup:
+		return lo.Contains(q.Groups, item.Group)
+	case ScopeGlobal:
+		return q.IncludeGlobal
+	}
+	return false
+}
+
 func applyFilters(item *MemoryItem, q *SearchQuery, logger zerolog.Logger) bool {
-	if q.AgentID != nil {
-		if q.IncludeGlobal {
-			if item.Scope == ScopeAgent {
-				if item.AgentID == nil || *item.AgentID != *q.AgentID {
-					logger.Debug().
-						Str("reason", "agent scope but agentID mismatch").
-						Int64("item_id", item.ID).
-						Interface("item_agent_id", item.AgentID).
-						Interface("query_agent_id", q.AgentID).
-						Msg("applyFilters: item filtered")
-					return false
-				}
-			} else if item.Scope != ScopeGlobal {
-				logger.Debug().
-					Str("reason", "not agent or global scope").
-					Int64("item_id", item.ID).
-					Str("item_scope", string(item.Scope)).
-					Msg("applyFilters: item filtered")
-				return false
-			}
-		} else {
-			if item.Scope != ScopeAgent {
-				logger.Debug().
-					Str("reason", "not agent scope").
-					Int64("item_id", item.ID).
-					Str("item_scope", string(item.Scope)).
-					Msg("applyFilters: item filtered")
-				return false
-			}
-			if item.AgentID == nil || *item.AgentID != *q.AgentID {
-				logger.Debug().
-					Str("reason", "agentID mismatch").
-					Int64("item_id", item.ID).
-					Interface("item_agent_id", item.AgentID).
-					Interface("query_agent_id", q.AgentID).
-					Msg("applyFilters: item filtered")
-				return false
-			}
-		}
-	} else {
-		if q.IncludeGlobal {
-			if item.Scope != ScopeGlobal {
-				logger.Debug().
-					Str("reason", "not global scope").
-					Int64("item_id", item.ID).
-					Str("item_scope", string(item.Scope)).
-					Msg("applyFilters: item filtered")
-				return false
-			}
-		}
+	if (q.AgentID != nil || q.IncludeGlobal || len(q.Groups) > 0) && !inScope(item, q) {
+		logger.Debug().
+			Str("reason", "not visible in query scope").
+			Int64("item_id", item.ID).
+			Str("item_scope", string(item.Scope)).
+			Interface("item_agent_id", item.AgentID).
+			Str("item_group", item.Group).
+			Interface("query_agent_id", q.AgentID).
+			Bool("include_global", q.IncludeGlobal).
+			Interface("query_groups", q.Groups).
+			Msg("applyFilters: item filtered")
+		return false
 	}
 
 	if len(q.Types) > 0 {
@@ -718,6 +827,32 @@ func applyFilters(item *MemoryItem, q *SearchQuery, logger zerolog.Logger) bool
 		}
 	}
 
+	if item.MemoryType == ProfileMemoryType {
+		logger.Debug().
+			Str("reason", "user profile").
+			Int64("item_id", item.ID).
+			Msg("applyFilters: item filtered")
+		return false
+	}
+
+	if !q.IncludeSuperseded && item.SupersededBy != nil {
+		logger.Debug().
+			Str("reason", "superseded").
+			Int64("item_id", item.ID).
+			Int64("superseded_by", *item.SupersededBy).
+			Msg("applyFilters: item filtered")
+		return false
+	}
+
+	if !q.IncludeArchived && item.ArchivedAt != nil {
+		logger.Debug().
+			Str("reason", "archived").
+			Int64("item_id", item.ID).
+			Time("archived_at", *item.ArchivedAt).
+			Msg("applyFilters: item filtered")
+		return false
+	}
+
 	if q.MinImportance > 0 && item.Importance < q.MinImportance {
 		logger.Debug().
 			Str("reason", "importance too low").
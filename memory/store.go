@@ -14,16 +14,25 @@ import (
 
 // Store manages all memory & artifact persistence.
 type Store struct {
-	db       *sql.DB
-	embedder Embedder
-	logger   zerolog.Logger
+	db        *sql.DB
+	embedder  Embedder
+	logger    zerolog.Logger
+	index     *VectorIndex // Optional; see OpenVectorIndex
+	dedup     DedupConfig
+	retention RetentionConfig
 }
 
 // NewStore creates and returns a Store.
 func NewStore(db *sql.DB, embedder Embedder, logger zerolog.Logger) (*Store, error) {
 	logger = logger.With().Str("component", "memory_store").Logger()
 	logger.Info().Msg("Initializing new Store with DB and Embedder")
-	s := &Store{db: db, embedder: embedder, logger: logger}
+	s := &Store{
+		db:        db,
+		embedder:  embedder,
+		logger:    logger,
+		dedup:     DefaultDedupConfig(),
+		retention: DefaultRetentionConfig(),
+	}
 	return s, nil
 }
 
@@ -36,6 +45,23 @@ func (s *Store) EmbedText(ctx context.Context, text string) ([]float32, error) {
 	return s.embedder.Embed(ctx, text)
 }
 
+// EmbedderName returns the name of the store's embedder, or "" if it has none.
+func (s *Store) EmbedderName() string {
+	if s.embedder == nil {
+		return ""
+	}
+	return s.embedder.Name()
+}
+
+// embeddingInfo returns the embedding_model and embedding_dim column values for a vector
+// produced by the store's embedder, or nils when there is no vector.
+func (s *Store) embeddingInfo(embedding []float32) (model, dim interface{}) {
+	if len(embedding) == 0 || s.embedder == nil {
+		return nil, nil
+	}
+	return s.embedder.Name(), len(embedding)
+}
+
 func now() int64 { return time.Now().Unix() }
 
 // RememberGlobalFact stores a long-term shared fact.
@@ -51,7 +77,7 @@ func (s *Store) RememberGlobalFact(
 		Float64("importance", importance).
 		Interface("metadata", metadata).
 		Msg("called")
-	return s.remember(ctx, MemoryTypeFact, ScopeGlobal, nil, nil, content, importance, metadata)
+	return s.remember(ctx, MemoryTypeFact, ScopeGlobal, nil, "", nil, content, importance, metadata)
 }
 
 // RememberAgentFact stores a fact scoped to a specific agent.
@@ -69,7 +95,26 @@ func (s *Store) RememberAgentFact(
 		Float64("importance", importance).
 		Interface("metadata", metadata).
 		Msg("called")
-	return s.remember(ctx, MemoryTypeFact, ScopeAgent, &agentID, nil, content, importance, metadata)
+	return s.remember(ctx, MemoryTypeFact, ScopeAgent, &agentID, "", nil, content, importance, metadata)
+}
+
+// RememberGroupFact stores a fact shared with the agents of a sharing group. The agent that
+// stored it is recorded in the memory's provenance rather than its agent ID.
+func (s *Store) RememberGroupFact(
+	ctx context.Context,
+	group string,
+	content string,
+	importance float64,
+	metadata map[string]interface{},
+) (MemoryItem, error) {
+	s.logger.Debug().
+		Str("method", "RememberGroupFact").
+		Str("group", group).
+		Str("content", truncateString(content, 40)).
+		Float64("importance", importance).
+		Interface("metadata", metadata).
+		Msg("called")
+	return s.remember(ctx, MemoryTypeFact, ScopeGroup, nil, group, nil, content, importance, metadata)
 }
 
 // RememberAgentEpisode stores a short-term episode for a given agent and thread.
@@ -89,7 +134,7 @@ func (s *Store) RememberAgentEpisode(
 		Float64("importance", importance).
 		Interface("metadata", metadata).
 		Msg("called")
-	return s.remember(ctx, MemoryTypeEpisode, ScopeAgent, &agentID, &threadID, content, importance, metadata)
+	return s.remember(ctx, MemoryTypeEpisode, ScopeAgent, &agentID, "", &threadID, content, importance, metadata)
 }
 
 // RememberGeneric lets you choose any MemoryType/Scope/agent/thread.
@@ -113,7 +158,7 @@ func (s *Store) RememberGeneric(
 		Float64("importance", importance).
 		Interface("metadata", metadata).
 		Msg("called")
-	return s.remember(ctx, typ, scope, agentID, threadID, content, importance, metadata)
+	return s.remember(ctx, typ, scope, agentID, "", threadID, content, importance, metadata)
 }
 
 func (s *Store) remember(
@@ -121,6 +166,7 @@ func (s *Store) remember(
 	typ MemoryType,
 	scope Scope,
 	agentID *string,
+	group string,
 	threadID *string,
 	content string,
 	importance float64,
@@ -131,6 +177,7 @@ func (s *Store) remember(
 		Str("type", string(typ)).
 		Str("scope", string(scope)).
 		Str("agent_id", derefString(agentID)).
+		Str("group", group).
 		Str("thread_id", derefString(threadID)).
 		Str("content", truncateString(content, 40)).
 		Float64("importance", importance).
@@ -142,13 +189,16 @@ func (s *Store) remember(
 			Msg("Attempted to remember empty content")
 		return MemoryItem{}, errors.New("content is empty")
 	}
-	if scope != ScopeAgent && scope != ScopeGlobal {
+	if scope != ScopeAgent && scope != ScopeGlobal && scope != ScopeGroup {
 		s.logger.Error().
 			Str("method", "remember").
 			Str("invalid_scope", string(scope)).
 			Msg("Invalid scope provided")
 		return MemoryItem{}, fmt.Errorf("invalid scope: %q", scope)
 	}
+	if (scope == ScopeGroup) != (group != "") {
+		return MemoryItem{}, fmt.Errorf("group must be set exactly for %q scope", ScopeGroup)
+	}
 
 	var metaJSON []byte
 	var err error
@@ -175,6 +225,25 @@ func (s *Store) remember(
 		}
 	}
 
+	existing, action, err := s.findDuplicate(ctx, dedupCandidate{
+		typ:       typ,
+		scope:     scope,
+		agentID:   agentID,
+		group:     group,
+		embedding: embedding,
+	})
+	if err != nil {
+		s.logger.Warn().
+			Str("method", "remember").
+			Err(err).
+			Msg("Duplicate check failed. Storing as a new memory.")
+		action = dedupNone
+	}
+	if action == dedupMerge {
+		return s.mergeInto(ctx, existing, content, "", nil, importance, embedding, metadata, nil)
+	}
+
+	source := s.provenanceFor(ctx)
 	nowUnix := now()
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -195,12 +264,17 @@ func (s *Store) remember(
 		threadVal = *threadID
 	}
 
+	embModel, embDim := s.embeddingInfo(embedding)
+	expiresAt := s.expiresAt(typ, "", nowUnix)
 	query := StatementBuilder().
 		Insert("memory_items").
 		Columns("agent_id", "thread_id", "scope", "type", "content",
-			"embedding", "metadata", "created_at", "updated_at", "importance").
-		Values(agentVal, threadVal, string(scope), string(typ), content,
-			EncodeEmbedding(embedding), metaJSON, nowUnix, nowUnix, importance)
+			"embedding", "metadata", "created_at", "updated_at", "importance",
+			"embedding_model", "embedding_dim", "expires_at", "group_name").
+		Columns(sourceColumnNames...).
+		Values(append([]interface{}{agentVal, threadVal, string(scope), string(typ), content,
+			EncodeEmbedding(embedding), metaJSON, nowUnix, nowUnix, importance,
+			embModel, embDim, expiresAt, nullIfEmpty(group)}, sourceValues(source)...)...)
 
 	queryStr, args, err := query.ToSql()
 	if err != nil {
@@ -228,14 +302,14 @@ func (s *Store) remember(
 		return MemoryItem{}, err
 	}
 
-	if _, err := tx.ExecContext(ctx, `
-INSERT INTO memory_items_fts (rowid, content) VALUES (?, ?)
-`, id, content); err != nil {
-		s.logger.Error().
-			Str("method", "remember").
-			Err(err).
-			Msg("Failed to insert memory_items_fts row")
-		return MemoryItem{}, fmt.Errorf("insert fts: %w", err)
+	if action == dedupSupersede {
+		if err := supersede(ctx, tx, existing, id, versionSuperseded, nowUnix); err != nil {
+			s.logger.Error().
+				Str("method", "remember").
+				Err(err).
+				Msg("Failed to supersede existing memory_item")
+			return MemoryItem{}, err
+		}
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -245,6 +319,10 @@ INSERT INTO memory_items_fts (rowid, content) VALUES (?, ?)
 			Msg("Transaction commit failed for remembering memory_item")
 		return MemoryItem{}, err
 	}
+	s.indexItem(id, embedding)
+	if action == dedupSupersede {
+		s.unindexItem(existing.ID)
+	}
 
 	s.logger.Info().
 		Str("method", "remember").
@@ -268,6 +346,16 @@ INSERT INTO memory_items_fts (rowid, content) VALUES (?, ?)
 		CreatedAt:  time.Unix(nowUnix, 0),
 		UpdatedAt:  time.Unix(nowUnix, 0),
 		Importance: importance,
+		Group:      group,
+		Provenance: source,
+	}
+	if embModel != nil {
+		item.EmbeddingModel = s.embedder.Name()
+		item.EmbeddingDim = len(embedding)
+	}
+	if exp, ok := expiresAt.(int64); ok {
+		t := time.Unix(exp, 0)
+		item.ExpiresAt = &t
 	}
 	return item, nil
 }
@@ -284,6 +372,27 @@ func (s *Store) StorePersonalMemory(
 	threadID *string,
 	importance float64,
 	metadata map[string]interface{},
+) (MemoryItem, error) {
+	return s.storePersonalMemory(ctx, agentID, rawText, normalized, memoryType, tags, threadID, importance, metadata, nil)
+}
+
+// writeHook makes further changes in the transaction that stores or merges the memory
+// memoryID. Returning an error rolls the whole write back.
+type writeHook func(ctx context.Context, tx *sql.Tx, memoryID int64) error
+
+// storePersonalMemory is StorePersonalMemory with an optional hook that runs in the same
+// transaction as the write, so related changes are kept or rolled back with it.
+func (s *Store) storePersonalMemory(
+	ctx context.Context,
+	agentID string,
+	rawText string,
+	normalized string,
+	memoryType string,
+	tags []string,
+	threadID *string,
+	importance float64,
+	metadata map[string]interface{},
+	within writeHook,
 ) (MemoryItem, error) {
 	s.logger.Debug().
 		Str("method", "StorePersonalMemory").
@@ -351,6 +460,26 @@ func (s *Store) StorePersonalMemory(
 		}
 	}
 
+	existing, action, err := s.findDuplicate(ctx, dedupCandidate{
+		typ:        MemoryTypeProfile,
+		scope:      ScopeAgent,
+		agentID:    &agentID,
+		memoryType: memoryType,
+		tags:       tags,
+		embedding:  embedding,
+	})
+	if err != nil {
+		s.logger.Warn().
+			Str("method", "StorePersonalMemory").
+			Err(err).
+			Msg("duplicate check failed: storing as a new memory")
+		action = dedupNone
+	}
+	if action == dedupMerge {
+		return s.mergeInto(ctx, existing, normalized, rawText, tags, importance, embedding, metadata, within)
+	}
+
+	source := s.provenanceFor(ctx)
 	nowUnix := now()
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -368,14 +497,19 @@ func (s *Store) StorePersonalMemory(
 		threadVal = *threadID
 	}
 
+	embModel, embDim := s.embeddingInfo(embedding)
+	expiresAt := s.expiresAt(MemoryTypeProfile, memoryType, nowUnix)
 	query := StatementBuilder().
 		Insert("memory_items").
 		Columns("agent_id", "thread_id", "scope", "type", "content",
 			"embedding", "metadata", "created_at", "updated_at", "importance",
-			"raw_content", "memory_type", "tags_json").
-		Values(agentVal, threadVal, string(ScopeAgent), string(MemoryTypeProfile), normalized,
+			"raw_content", "memory_type", "tags_json",
+			"embedding_model", "embedding_dim", "expires_at").
+		Columns(sourceColumnNames...).
+		Values(append([]interface{}{agentVal, threadVal, string(ScopeAgent), string(MemoryTypeProfile), normalized,
 			EncodeEmbedding(embedding), metaJSON, nowUnix, nowUnix, importance,
-			rawText, memoryType, tagsJSON)
+			rawText, memoryType, tagsJSON,
+			embModel, embDim, expiresAt}, sourceValues(source)...)...)
 
 	queryStr, args, err := query.ToSql()
 	if err != nil {
@@ -403,15 +537,19 @@ func (s *Store) StorePersonalMemory(
 		return MemoryItem{}, err
 	}
 
-	// Index normalized text in the FTS table to support hybrid search.
-	if _, err := tx.ExecContext(ctx, `
-INSERT INTO memory_items_fts (rowid, content) VALUES (?, ?)
-`, id, normalized); err != nil {
-		s.logger.Error().
-			Str("method", "StorePersonalMemory").
-			Err(err).
-			Msg("failed to insert memory_items_fts row")
-		return MemoryItem{}, fmt.Errorf("insert personal fts: %w", err)
+	if action == dedupSupersede {
+		if err := supersede(ctx, tx, existing, id, versionSuperseded, nowUnix); err != nil {
+			s.logger.Error().
+				Str("method", "StorePersonalMemory").
+				Err(err).
+				Msg("failed to supersede existing memory_item")
+			return MemoryItem{}, err
+		}
+	}
+	if within != nil {
+		if err := within(ctx, tx, id); err != nil {
+			return MemoryItem{}, err
+		}
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -421,6 +559,10 @@ INSERT INTO memory_items_fts (rowid, content) VALUES (?, ?)
 			Msg("transaction commit failed")
 		return MemoryItem{}, err
 	}
+	s.indexItem(id, embedding)
+	if action == dedupSupersede {
+		s.unindexItem(existing.ID)
+	}
 
 	s.logger.Info().
 		Str("method", "StorePersonalMemory").
@@ -443,6 +585,15 @@ INSERT INTO memory_items_fts (rowid, content) VALUES (?, ?)
 		RawContent: rawText,
 		MemoryType: memoryType,
 		Tags:       append([]string(nil), tags...),
+		Provenance: source,
+	}
+	if embModel != nil {
+		item.EmbeddingModel = s.embedder.Name()
+		item.EmbeddingDim = len(embedding)
+	}
+	if exp, ok := expiresAt.(int64); ok {
+		t := time.Unix(exp, 0)
+		item.ExpiresAt = &t
 	}
 	return item, nil
 }
@@ -466,6 +617,9 @@ func (s *Store) CreateArtifact(
 		Interface("metadata", metadata).
 		Msg("called")
 
+	if strings.TrimSpace(title) == "" {
+		return Artifact{}, errors.New("title is empty")
+	}
 	if strings.TrimSpace(body) == "" {
 		s.logger.Warn().
 			Str("method", "CreateArtifact").
@@ -516,7 +670,13 @@ func (s *Store) CreateArtifact(
 		return Artifact{}, fmt.Errorf("build insert query: %w", err)
 	}
 
-	res, err := s.db.ExecContext(ctx, queryStr, args...)
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Artifact{}, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.ExecContext(ctx, queryStr, args...)
 	if err != nil {
 		s.logger.Error().
 			Str("method", "CreateArtifact").
@@ -532,6 +692,12 @@ func (s *Store) CreateArtifact(
 			Msg("Failed to retrieve LastInsertId for artifact")
 		return Artifact{}, err
 	}
+	if err := insertArtifactVersion(ctx, tx, id, 1, agentVal, title, body, metaJSON, nowUnix); err != nil {
+		return Artifact{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return Artifact{}, err
+	}
 	s.logger.Info().
 		Str("method", "CreateArtifact").
 		Int64("id", id).
@@ -548,6 +714,7 @@ func (s *Store) CreateArtifact(
 		Title:     title,
 		Body:      body,
 		Metadata:  metadata,
+		Version:   1,
 		CreatedAt: time.Unix(nowUnix, 0),
 		UpdatedAt: time.Unix(nowUnix, 0),
 	}, nil
@@ -3,6 +3,7 @@ package memory
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"hash/fnv"
 	"math"
 	"os"
@@ -22,6 +23,8 @@ func (stubEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
 	return []float32{float32(len(text)), 1.0}, nil
 }
 
+func (stubEmbedder) Name() string { return "stub" }
+
 // semanticEmbedder creates embeddings based on word content to simulate semantic similarity.
 // Documents with overlapping words will have similar embeddings (high cosine similarity).
 // This is deterministic and doesn't require external services, making it suitable for CI.
@@ -33,6 +36,8 @@ func newSemanticEmbedder(dimensions int) *semanticEmbedder {
 	return &semanticEmbedder{dimensions: dimensions}
 }
 
+func (e *semanticEmbedder) Name() string { return "semantic" }
+
 func (e *semanticEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
 	// Tokenize and normalize text
 	words := strings.Fields(strings.ToLower(text))
@@ -172,7 +177,7 @@ func TestRouter_AddEpisodeAndQuery(t *testing.T) {
 		t.Fatalf("AddEpisode: %v", err)
 	}
 
-	res, err := router.QueryAgentMemory(ctx, agentID, "ICE partnerships", nil, false, 5, nil)
+	res, err := router.QueryAgentMemory(ctx, agentID, AgentQueryOptions{Text: "ICE partnerships", Limit: 5})
 	if err != nil {
 		t.Fatalf("QueryAgentMemory: %v", err)
 	}
@@ -340,3 +345,200 @@ func TestSemanticEmbeddingSearch(t *testing.T) {
 		}
 	})
 }
+
+func TestSearchByVector_SkipsOtherEmbedders(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	oldStore, err := NewStore(db, newSemanticEmbedder(64), zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	old, err := oldStore.RememberGlobalFact(ctx, "Go programming with goroutines", 0.5, nil)
+	if err != nil {
+		t.Fatalf("RememberGlobalFact: %v", err)
+	}
+	if old.EmbeddingModel != "semantic" || old.EmbeddingDim != 64 {
+		t.Errorf("expected embedder recorded on item, got %q/%d", old.EmbeddingModel, old.EmbeddingDim)
+	}
+
+	// A store with a different embedder of the same dimension must not compare against the old vector
+	other := &namedEmbedder{semanticEmbedder: newSemanticEmbedder(64), name: "other"}
+	newStore, err := NewStore(db, other, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	fresh, err := newStore.RememberGlobalFact(ctx, "Go programming with channels", 0.5, nil)
+	if err != nil {
+		t.Fatalf("RememberGlobalFact: %v", err)
+	}
+
+	queryEmb, err := other.Embed(ctx, "Go programming")
+	if err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	results, err := newStore.SearchMemory(ctx, &SearchQuery{QueryEmbedding: queryEmb, IncludeGlobal: true})
+	if err != nil {
+		t.Fatalf("SearchMemory: %v", err)
+	}
+	if len(results) != 1 || results[0].Item.ID != fresh.ID {
+		t.Fatalf("expected only the item from the current embedder, got %d results", len(results))
+	}
+	if results[0].Item.EmbeddingModel != "other" {
+		t.Errorf("expected embedding model loaded from db, got %q", results[0].Item.EmbeddingModel)
+	}
+}
+
+// namedEmbedder wraps semanticEmbedder under a different name.
+type namedEmbedder struct {
+	*semanticEmbedder
+	name string
+}
+
+func (e *namedEmbedder) Name() string { return e.name }
+
+func TestSearchByKeyword_MatchesTagsAndFollowsDeletes(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	store, err := NewStore(db, nil, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	ctx := context.Background()
+
+	item, err := store.StorePersonalMemory(ctx, "agent", "I drink espresso every morning",
+		"The user drinks espresso every morning.", "habit", []string{"coffee"}, nil, 0.5, nil)
+	if err != nil {
+		t.Fatalf("StorePersonalMemory: %v", err)
+	}
+
+	agentID := "agent"
+	results, err := store.SearchMemory(ctx, &SearchQuery{QueryText: "espresso", AgentID: &agentID})
+	if err != nil {
+		t.Fatalf("SearchMemory: %v", err)
+	}
+	if len(results) != 1 || results[0].Match == nil {
+		t.Fatalf("expected one keyword match, got %d results", len(results))
+	}
+	match := results[0].Match
+	if match.BM25 <= 0 || results[0].Score != match.BM25 {
+		t.Errorf("expected a positive BM25 score as the result score, got %v (score %v)", match.BM25, results[0].Score)
+	}
+	if !strings.Contains(match.Snippet, MatchStart+"espresso"+MatchEnd) {
+		t.Errorf("expected the snippet to mark the matched term, got %q", match.Snippet)
+	}
+	if match.Highlight != "The user drinks "+MatchStart+"espresso"+MatchEnd+" every morning." {
+		t.Errorf("unexpected highlight %q", match.Highlight)
+	}
+
+	// Tags are indexed alongside content
+	results, err = store.SearchMemory(ctx, &SearchQuery{QueryText: "coffee", AgentID: &agentID})
+	if err != nil {
+		t.Fatalf("SearchMemory: %v", err)
+	}
+	if len(results) != 1 || results[0].Item.ID != item.ID {
+		t.Fatalf("expected the tag to match, got %d results", len(results))
+	}
+
+	if err := store.DeleteMemory(ctx, item.ID); err != nil {
+		t.Fatalf("DeleteMemory: %v", err)
+	}
+	var n int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM memory_items_fts`).Scan(&n); err != nil {
+		t.Fatalf("count fts rows: %v", err)
+	}
+	if n != 0 {
+		t.Errorf("expected the FTS row to be removed with the item, found %d rows", n)
+	}
+}
+
+func TestFTSQuery(t *testing.T) {
+	for text, want := range map[string]string{
+		"How do I configure the ingester?": `"How" "do" "I" "configure" "the" "ingester?"`,
+		"what's new":                       `"what's" "new"`,
+		"Q3-launch plan":                   `"Q3-launch" "plan"`,
+		`say "hi" - now`:                   `"say" """hi""" "now"`,
+		" ? - ":                            "",
+	} {
+		if got := ftsQuery(text); got != want {
+			t.Errorf("ftsQuery(%q) = %s, want %s", text, got, want)
+		}
+	}
+}
+
+func TestSearchByKeyword_QuestionsWithPunctuation(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	store, err := NewStore(db, nil, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	ctx := context.Background()
+
+	for _, fact := range []string{
+		"How do I configure the ingester? List directories under memory.docs.",
+		"Here's what's new: the Q3-launch plan moved to Friday.",
+		`The "backup" job runs nightly.`,
+	} {
+		if _, err := store.RememberGlobalFact(ctx, fact, 0.5, nil); err != nil {
+			t.Fatalf("RememberGlobalFact: %v", err)
+		}
+	}
+
+	for query, want := range map[string]string{
+		"How do I configure the ingester?": "How do I configure",
+		"what's new":                       "what's new",
+		"Q3-launch plan":                   "Q3-launch plan",
+		`"backup" job`:                     `"backup" job`,
+	} {
+		results, err := store.SearchMemory(ctx, &SearchQuery{QueryText: query, IncludeGlobal: true})
+		if err != nil {
+			t.Errorf("SearchMemory(%q): %v", query, err)
+			continue
+		}
+		if len(results) != 1 || !strings.Contains(results[0].Item.Content, want) {
+			t.Errorf("SearchMemory(%q): expected the fact containing %q, got %d results", query, want, len(results))
+		}
+	}
+
+	// A query of punctuation alone matches nothing instead of failing
+	if results, err := store.SearchMemory(ctx, &SearchQuery{QueryText: "?", IncludeGlobal: true}); err != nil || len(results) != 0 {
+		t.Errorf("expected no results for punctuation, got %d, err %v", len(results), err)
+	}
+}
+
+func TestSearchByKeyword_FiltersBeforeLimit(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	store, err := NewStore(db, nil, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	store.SetDedupConfig(DedupConfig{Enabled: false})
+	ctx := context.Background()
+
+	// Global facts that match better than the agent's memory fill the candidate list
+	for i := range 10 {
+		if _, err := store.RememberGlobalFact(ctx, fmt.Sprintf("launch launch launch %d", i), 0.5, nil); err != nil {
+			t.Fatalf("RememberGlobalFact: %v", err)
+		}
+	}
+	item, err := store.StorePersonalMemory(ctx, "agent", "The user is planning the launch party for the new product line.",
+		"The user is planning the launch party for the new product line.", "project", nil, nil, 0.5, nil)
+	if err != nil {
+		t.Fatalf("StorePersonalMemory: %v", err)
+	}
+
+	agentID := "agent"
+	results, err := store.SearchMemory(ctx, &SearchQuery{QueryText: "launch", AgentID: &agentID, Limit: 1})
+	if err != nil {
+		t.Fatalf("SearchMemory: %v", err)
+	}
+	if len(results) != 1 || results[0].Item.ID != item.ID {
+		t.Errorf("expected the agent's memory, got %d results", len(results))
+	}
+}
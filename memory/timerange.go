@@ -0,0 +1,352 @@
+package memory
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeRange is the period named by a time expression in a search query, such as
+// "last week" or "since March". Either bound may be open.
+type TimeRange struct {
+	Phrase string     // The expression as written in the query
+	After  *time.Time // Earliest creation time, inclusive
+	Before *time.Time // Latest creation time, inclusive
+}
+
+// timeRule recognizes one form of time expression. resolve returns the period it names,
+// as [start, end), relative to now. Rules whose words are common outside of time
+// expressions, such as month names, only apply after a preposition like "in" or "since"
+// unless the expression qualifies itself. A bare month or year is easily a number or a
+// name, so it also needs one of bareTimePrefixes and must not be followed by a word it
+// could describe, as in "1999 dollars".
+type timeRule struct {
+	re            *regexp.Regexp
+	needsPrefix   bool
+	bare          bool
+	selfQualified func(m []string) bool
+	resolve       func(m []string, now time.Time) (time.Time, time.Time, bool)
+}
+
+const timeCount = `(\d+|an?|one|two|three|four|five|six|seven|eight|nine|ten|eleven|twelve|(?:a\s+)?few|(?:a\s+)?couple(?:\s+of)?)`
+
+var (
+	// timePrefix is a preposition before a time expression, which decides how it bounds the search.
+	timePrefix = regexp.MustCompile(`(?i)\b(since|after|before|until|till|from|in|during|on|over|within|for)\s+(?:the\s+)?$`)
+
+	// bareTimePrefixes are the prefixes that make a bare month or year a time expression.
+	// "for", "over" and "on" are left out: "budget for 2027", "over 2000 users".
+	bareTimePrefixes = []string{"since", "after", "before", "until", "till", "from", "in", "during", "within"}
+
+	// bareTimeFollower matches a word right after a bare month or year, which it describes.
+	bareTimeFollower = regexp.MustCompile(`^\s+\w`)
+
+	timeRules = []timeRule{
+		{
+			re: regexp.MustCompile(`(?i)\b(today|yesterday)(?:\s+(morning|afternoon|evening|night))?\b`),
+			resolve: func(m []string, now time.Time) (time.Time, time.Time, bool) {
+				day := startOfDay(now)
+				if strings.EqualFold(m[1], "yesterday") {
+					day = day.AddDate(0, 0, -1)
+				}
+				if m[2] == "" {
+					return day, day.AddDate(0, 0, 1), true
+				}
+				start, end := partOfDay(day, m[2])
+				return start, end, true
+			},
+		},
+		{
+			re: regexp.MustCompile(`(?i)\b(this\s+(?:morning|afternoon|evening)|tonight|last\s+night)\b`),
+			resolve: func(m []string, now time.Time) (time.Time, time.Time, bool) {
+				phrase := strings.Fields(strings.ToLower(m[1]))
+				day := startOfDay(now)
+				switch phrase[0] {
+				case "tonight":
+					start, end := partOfDay(day, "night")
+					return start, end, true
+				case "last":
+					start, end := partOfDay(day.AddDate(0, 0, -1), "night")
+					return start, end, true
+				}
+				start, end := partOfDay(day, phrase[1])
+				return start, end, true
+			},
+		},
+		{
+			// "the last 3 days", "past two weeks": a rolling window ending now
+			re: regexp.MustCompile(`(?i)\b(?:the\s+)?(?:last|past)\s+` + timeCount + `\s+(hours?|days?|weeks?|months?|years?)\b`),
+			resolve: func(m []string, now time.Time) (time.Time, time.Time, bool) {
+				n, ok := parseTimeCount(m[1])
+				if !ok {
+					return time.Time{}, time.Time{}, false
+				}
+				return addUnits(now, strings.TrimSuffix(strings.ToLower(m[2]), "s"), -n), now, true
+			},
+		},
+		{
+			// "last week" is the previous calendar week, "the last week" and "past week" the last seven days
+			re: regexp.MustCompile(`(?i)\b(the\s+)?(last|past|previous|this|current)\s+(week|month|year|weekend)\b`),
+			resolve: func(m []string, now time.Time) (time.Time, time.Time, bool) {
+				which, unit := strings.ToLower(m[2]), strings.ToLower(m[3])
+				rolling := which == "past" || (m[1] != "" && which == "last")
+				if unit == "weekend" {
+					// The weekend of the current week if it has started, otherwise the one before
+					saturday := startOfWeek(now).AddDate(0, 0, 5)
+					if which != "this" && which != "current" || now.Before(saturday) {
+						saturday = saturday.AddDate(0, 0, -7)
+					}
+					return saturday, saturday.AddDate(0, 0, 2), true
+				}
+				if rolling {
+					return addUnits(now, unit, -1), now, true
+				}
+				start := startOfUnit(now, unit)
+				if which == "last" || which == "previous" {
+					return addUnits(start, unit, -1), start, true
+				}
+				return start, addUnits(start, unit, 1), true
+			},
+		},
+		{
+			// "3 days ago", "a month ago": the day, week, month or year containing that time
+			re: regexp.MustCompile(`(?i)\b` + timeCount + `\s+(days?|weeks?|months?|years?)\s+ago\b`),
+			resolve: func(m []string, now time.Time) (time.Time, time.Time, bool) {
+				n, ok := parseTimeCount(m[1])
+				if !ok {
+					return time.Time{}, time.Time{}, false
+				}
+				unit := strings.TrimSuffix(strings.ToLower(m[2]), "s")
+				start := addUnits(startOfUnit(now, unit), unit, -n)
+				return start, addUnits(start, unit, 1), true
+			},
+		},
+		{
+			// "last Monday", "on Friday": the most recent such day before today
+			re:            regexp.MustCompile(`(?i)\b(?:(last|this)\s+)?(monday|tuesday|wednesday|thursday|friday|saturday|sunday)\b`),
+			needsPrefix:   true,
+			selfQualified: func(m []string) bool { return m[1] != "" },
+			resolve: func(m []string, now time.Time) (time.Time, time.Time, bool) {
+				weekday := weekdays[strings.ToLower(m[2])]
+				day := startOfDay(now).AddDate(0, 0, -1)
+				for day.Weekday() != weekday {
+					day = day.AddDate(0, 0, -1)
+				}
+				return day, day.AddDate(0, 0, 1), true
+			},
+		},
+		{
+			// "in March", "since last May", "March 2024": the most recent such month that has begun
+			re:            regexp.MustCompile(`(?i)\b(?:(last|this)\s+)?(january|february|march|april|may|june|july|august|september|october|november|december|jan|feb|mar|apr|jun|jul|aug|sept?|oct|nov|dec)\.?(?:\s+(\d{4}))?\b`),
+			needsPrefix:   true,
+			bare:          true,
+			selfQualified: func(m []string) bool { return m[1] != "" || m[3] != "" },
+			resolve: func(m []string, now time.Time) (time.Time, time.Time, bool) {
+				month := months[strings.ToLower(m[2])[:3]]
+				year := now.Year()
+				if m[3] != "" {
+					year, _ = strconv.Atoi(m[3])
+					if year > now.Year() {
+						return time.Time{}, time.Time{}, false
+					}
+				} else if month > now.Month() || (strings.EqualFold(m[1], "last") && month == now.Month()) {
+					year--
+				}
+				start := time.Date(year, month, 1, 0, 0, 0, 0, now.Location())
+				return start, start.AddDate(0, 1, 0), true
+			},
+		},
+		{
+			// "in 2024": a past or the current year
+			re:          regexp.MustCompile(`\b((?:19|20)\d{2})\b`),
+			needsPrefix: true,
+			bare:        true,
+			resolve: func(m []string, now time.Time) (time.Time, time.Time, bool) {
+				year, _ := strconv.Atoi(m[1])
+				if year > now.Year() {
+					return time.Time{}, time.Time{}, false
+				}
+				start := time.Date(year, time.January, 1, 0, 0, 0, 0, now.Location())
+				return start, start.AddDate(1, 0, 0), true
+			},
+		},
+		{
+			re: regexp.MustCompile(`\b(\d{4})-(\d{2})-(\d{2})\b`),
+			resolve: func(m []string, now time.Time) (time.Time, time.Time, bool) {
+				day, err := time.ParseInLocation("2006-01-02", m[1]+"-"+m[2]+"-"+m[3], now.Location())
+				if err != nil {
+					return time.Time{}, time.Time{}, false
+				}
+				return day, day.AddDate(0, 0, 1), true
+			},
+		},
+	}
+
+	weekdays = map[string]time.Weekday{
+		"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+		"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+	}
+	months = map[string]time.Month{
+		"jan": time.January, "feb": time.February, "mar": time.March, "apr": time.April,
+		"may": time.May, "jun": time.June, "jul": time.July, "aug": time.August,
+		"sep": time.September, "oct": time.October, "nov": time.November, "dec": time.December,
+	}
+	timeCountWords = map[string]int{
+		"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+		"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+	}
+)
+
+// ParseTimeRange finds the first time expression in query, such as "yesterday morning",
+// "last week", "the past 3 days" or "since March", and returns the period it names along
+// with the query without it. Expressions are resolved in now's location, which should be
+// the user's timezone. "since" and "after" leave the end of the period open, and "before"
+// and "until" its start. A bare month or year, as in "taxes in 2024", may still be part of
+// what is searched for, so the query is returned whole when there is other text. It
+// returns nil and query unchanged if there is no expression.
+func ParseTimeRange(query string, now time.Time) (*TimeRange, string) {
+	var (
+		best               *TimeRange
+		bestStart, bestEnd = -1, -1
+		bestBare           bool
+	)
+	for _, rule := range timeRules {
+		for _, loc := range rule.re.FindAllStringSubmatchIndex(query, -1) {
+			m := submatches(query, loc)
+			start, end := loc[0], loc[1]
+
+			prefix := ""
+			if p := timePrefix.FindStringSubmatchIndex(query[:start]); p != nil {
+				prefix = strings.ToLower(query[p[2]:p[3]])
+				start = p[0]
+			}
+			qualified := rule.selfQualified != nil && rule.selfQualified(m)
+			if rule.needsPrefix && prefix == "" && !qualified {
+				continue
+			}
+			bare := rule.bare && !qualified
+			if bare && (!slices.Contains(bareTimePrefixes, prefix) || bareTimeFollower.MatchString(query[end:])) {
+				continue
+			}
+			if best != nil && (start > bestStart || (start == bestStart && end <= bestEnd)) {
+				continue
+			}
+
+			from, to, ok := rule.resolve(m, now)
+			if !ok {
+				continue
+			}
+			tr := &TimeRange{Phrase: query[start:end]}
+			last := to.Add(-time.Nanosecond)
+			switch prefix {
+			case "since":
+				tr.After = &from
+			case "after":
+				tr.After = &to
+			case "before":
+				last = from.Add(-time.Nanosecond)
+				tr.Before = &last
+			case "until", "till":
+				tr.Before = &last
+			default:
+				tr.After = &from
+				if to.Before(now) {
+					tr.Before = &last
+				}
+			}
+			best, bestStart, bestEnd, bestBare = tr, start, end, bare
+		}
+	}
+	if best == nil {
+		return nil, query
+	}
+	rest := strings.Join(strings.Fields(query[:bestStart]+" "+query[bestEnd:]), " ")
+	if bestBare && rest != "" {
+		return best, query
+	}
+	return best, rest
+}
+
+// submatches returns the text of each group in loc, with "" for groups that did not match.
+func submatches(s string, loc []int) []string {
+	m := make([]string, len(loc)/2)
+	for i := range m {
+		if loc[2*i] >= 0 {
+			m[i] = s[loc[2*i]:loc[2*i+1]]
+		}
+	}
+	return m
+}
+
+func parseTimeCount(s string) (int, bool) {
+	s = strings.ToLower(strings.Join(strings.Fields(s), " "))
+	switch {
+	case strings.Contains(s, "few"):
+		return 3, true
+	case strings.Contains(s, "couple"):
+		return 2, true
+	}
+	if n, ok := timeCountWords[s]; ok {
+		return n, true
+	}
+	n, err := strconv.Atoi(s)
+	return n, err == nil && n > 0
+}
+
+// partOfDay returns the morning, afternoon, evening or night of day. Night runs into the
+// early hours of the next day. Hours are wall-clock times, so they hold on DST changes.
+func partOfDay(day time.Time, part string) (time.Time, time.Time) {
+	at := func(days, hour int) time.Time {
+		return time.Date(day.Year(), day.Month(), day.Day()+days, hour, 0, 0, 0, day.Location())
+	}
+	switch strings.ToLower(part) {
+	case "morning":
+		return at(0, 5), at(0, 12)
+	case "afternoon":
+		return at(0, 12), at(0, 17)
+	case "evening":
+		return at(0, 17), at(1, 0)
+	default:
+		return at(0, 18), at(1, 6)
+	}
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// startOfWeek returns the Monday starting t's week.
+func startOfWeek(t time.Time) time.Time {
+	day := startOfDay(t)
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// startOfUnit returns the start of the day, week, month or year containing t.
+func startOfUnit(t time.Time, unit string) time.Time {
+	switch unit {
+	case "week":
+		return startOfWeek(t)
+	case "month":
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	case "year":
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+	}
+	return startOfDay(t)
+}
+
+// addUnits adds n hours, days, weeks, months or years to t.
+func addUnits(t time.Time, unit string, n int) time.Time {
+	switch unit {
+	case "hour":
+		return t.Add(time.Duration(n) * time.Hour)
+	case "week":
+		return t.AddDate(0, 0, 7*n)
+	case "month":
+		return t.AddDate(0, n, 0)
+	case "year":
+		return t.AddDate(n, 0, 0)
+	}
+	return t.AddDate(0, 0, n)
+}
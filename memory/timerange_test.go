@@ -0,0 +1,162 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestParseTimeRange(t *testing.T) {
+	zone := time.FixedZone("EST", -5*60*60)
+	at := func(year int, month time.Month, day, hour int) *time.Time {
+		t := time.Date(year, month, day, hour, 0, 0, 0, zone)
+		return &t
+	}
+	// endOf returns the last instant before the given hour
+	endOf := func(year int, month time.Month, day, hour int) *time.Time {
+		t := at(year, month, day, hour).Add(-time.Nanosecond)
+		return &t
+	}
+	now := time.Date(2026, time.October, 14, 15, 30, 0, 0, zone) // A Wednesday
+	threeDaysAgo := now.AddDate(0, 0, -3)
+
+	tests := []struct {
+		query  string
+		phrase string
+		rest   string
+		after  *time.Time
+		before *time.Time
+	}{
+		{"what did I do yesterday", "yesterday", "what did I do", at(2026, time.October, 13, 0), endOf(2026, time.October, 14, 0)},
+		{"notes from yesterday morning", "from yesterday morning", "notes", at(2026, time.October, 13, 5), endOf(2026, time.October, 13, 12)},
+		{"last night", "last night", "", at(2026, time.October, 13, 18), endOf(2026, time.October, 14, 6)},
+		{"Last week's standups", "Last week", "'s standups", at(2026, time.October, 5, 0), endOf(2026, time.October, 12, 0)},
+		{"meetings in the past 3 days", "in the past 3 days", "meetings", &threeDaysAgo, nil},
+		{"this month", "this month", "", at(2026, time.October, 1, 0), nil},
+		{"two weeks ago", "two weeks ago", "", at(2026, time.September, 28, 0), endOf(2026, time.October, 5, 0)},
+		{"calls on Monday", "on Monday", "calls", at(2026, time.October, 12, 0), endOf(2026, time.October, 13, 0)},
+		{"last Friday", "last Friday", "", at(2026, time.October, 9, 0), endOf(2026, time.October, 10, 0)},
+		{"hiring since March", "since March", "hiring since March", at(2026, time.March, 1, 0), nil},
+		{"trips in December", "in December", "trips in December", at(2025, time.December, 1, 0), endOf(2026, time.January, 1, 0)},
+		{"budget before June 2025", "before June 2025", "budget", nil, endOf(2025, time.June, 1, 0)},
+		{"launches until last month", "until last month", "launches", nil, endOf(2026, time.October, 1, 0)},
+		{"taxes in 2024", "in 2024", "taxes in 2024", at(2024, time.January, 1, 0), endOf(2025, time.January, 1, 0)},
+		{"in March", "in March", "", at(2026, time.March, 1, 0), endOf(2026, time.April, 1, 0)},
+		{"on 2026-10-01 standup", "on 2026-10-01", "standup", at(2026, time.October, 1, 0), endOf(2026, time.October, 2, 0)},
+	}
+	for _, tt := range tests {
+		got, rest := ParseTimeRange(tt.query, now)
+		if got == nil {
+			t.Errorf("%q: expected a time range", tt.query)
+			continue
+		}
+		if got.Phrase != tt.phrase || rest != tt.rest {
+			t.Errorf("%q: got phrase %q and rest %q, want %q and %q", tt.query, got.Phrase, rest, tt.phrase, tt.rest)
+		}
+		if !sameTime(got.After, tt.after) {
+			t.Errorf("%q: after = %v, want %v", tt.query, got.After, tt.after)
+		}
+		if !sameTime(got.Before, tt.before) {
+			t.Errorf("%q: before = %v, want %v", tt.query, got.Before, tt.before)
+		}
+	}
+}
+
+func TestParseTimeRange_IgnoresWordsThatAreNotTimes(t *testing.T) {
+	now := time.Date(2026, time.October, 14, 15, 30, 0, 0, time.UTC)
+	for _, query := range []string{
+		"may I see the march plan",
+		"2024 roadmap",
+		"casual Friday dress code",
+		"Q3 budget",
+		"budget for 2027",
+		"over 2000 users",
+		"notes on March madness",
+		"sales in 1999 dollars",
+		"plans in 2030",
+	} {
+		if got, rest := ParseTimeRange(query, now); got != nil || rest != query {
+			t.Errorf("%q: expected no time range, got %+v and rest %q", query, got, rest)
+		}
+	}
+}
+
+func TestParseTimeRange_PartOfDayAcrossDSTChange(t *testing.T) {
+	zone, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("no timezone data: %v", err)
+	}
+	// Clocks went forward at 2am on March 8, 2026
+	now := time.Date(2026, time.March, 9, 12, 0, 0, 0, zone)
+	got, _ := ParseTimeRange("yesterday morning", now)
+	if got == nil {
+		t.Fatal("expected a time range")
+	}
+	if want := time.Date(2026, time.March, 8, 5, 0, 0, 0, zone); !got.After.Equal(want) {
+		t.Errorf("after = %v, want %v", got.After, want)
+	}
+	if want := time.Date(2026, time.March, 8, 12, 0, 0, 0, zone).Add(-time.Nanosecond); !got.Before.Equal(want) {
+		t.Errorf("before = %v, want %v", got.Before, want)
+	}
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func TestQueryAgentMemory_TimeRangeOnly(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	store, err := NewStore(db, newSemanticEmbedder(8), zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	store.SetDedupConfig(DedupConfig{Enabled: false})
+	router := NewMemoryRouter(store, Config{}, zerolog.Nop())
+
+	for _, content := range []string{"Reviewed the vendor contract.", "Planned the product launch."} {
+		if _, err := router.AddAgentFact(ctx, "agent-1", content, nil); err != nil {
+			t.Fatalf("AddAgentFact: %v", err)
+		}
+	}
+	old := time.Now().AddDate(0, 0, -20).Unix()
+	if _, err := db.ExecContext(ctx, `UPDATE memory_items SET created_at = ? WHERE content = 'Reviewed the vendor contract.'`, old); err != nil {
+		t.Fatalf("age memory: %v", err)
+	}
+
+	timeRange, rest := ParseTimeRange("the past 3 days", router.Now())
+	if timeRange == nil || rest != "" {
+		t.Fatalf("expected a bare time range, got %+v and rest %q", timeRange, rest)
+	}
+	results, err := router.QueryAgentMemory(ctx, "agent-1", AgentQueryOptions{Text: rest, Limit: 10, TimeRange: timeRange})
+	if err != nil {
+		t.Fatalf("QueryAgentMemory: %v", err)
+	}
+	if len(results) != 1 || results[0].Item.Content != "Planned the product launch." {
+		t.Fatalf("expected only the recent memory, got %d results", len(results))
+	}
+
+	// The range also filters text matches
+	timeRange, rest = ParseTimeRange("vendor contract in the past 3 days", router.Now())
+	results, err = router.QueryAgentMemory(ctx, "agent-1", AgentQueryOptions{Text: rest, Limit: 10})
+	if err != nil {
+		t.Fatalf("QueryAgentMemory: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected %q to match the old memory without a range, got %d results", rest, len(results))
+	}
+	results, err = router.QueryAgentMemory(ctx, "agent-1", AgentQueryOptions{Text: rest, Limit: 10, TimeRange: timeRange})
+	if err != nil {
+		t.Fatalf("QueryAgentMemory: %v", err)
+	}
+	if len(results) != 0 {
+		t.Fatalf("expected the old memory to be filtered out, got %d results", len(results))
+	}
+}
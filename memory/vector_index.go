@@ -0,0 +1,353 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/aschepis/backscratcher/staff/memory/hnsw"
+)
+
+// vectorIndexVersion is bumped when the on-disk format changes; older files are rebuilt.
+const vectorIndexVersion = 2
+
+// vectorIndexSaveEvery is how many additions are buffered before the index is written.
+// Items added since the last save are recovered from the database when the index is opened.
+const vectorIndexSaveEvery = 100
+
+// vectorIndexLoadBatch is how many candidate items are loaded per query.
+const vectorIndexLoadBatch = 500
+
+// VectorIndex is an approximate nearest-neighbor index over memory item embeddings,
+// persisted to a file next to the database. The database stays the source of truth:
+// the file records the highest item ID it covers, and newer items are indexed on open.
+type VectorIndex struct {
+	path  string
+	model string // Embedder name the indexed vectors came from
+
+	mu      sync.Mutex
+	graph   *hnsw.Graph
+	lastID  int64 // Highest memory item ID the index has seen
+	pending int   // Additions since the last save
+}
+
+// vectorIndexHeader precedes the graph in the index file.
+type vectorIndexHeader struct {
+	Version int
+	Model   string
+	LastID  int64
+}
+
+// OpenVectorIndex loads the vector index at path and brings it up to date with the database.
+// The index is rebuilt from scratch if the file is missing, unreadable, or was built with a
+// different embedder. Once open, vector search uses the index instead of scanning recent items.
+func (s *Store) OpenVectorIndex(ctx context.Context, path string) error {
+	if s.embedder == nil {
+		return fmt.Errorf("vector index requires an embedder")
+	}
+	idx := &VectorIndex{path: path, model: s.embedder.Name()}
+
+	if err := idx.load(); err != nil {
+		s.logger.Warn().Err(err).Str("path", path).Msg("Vector index unavailable, rebuilding")
+		_, err := s.RebuildVectorIndex(ctx, path)
+		return err
+	}
+
+	added, err := s.indexNewItems(ctx, idx)
+	if err != nil {
+		return fmt.Errorf("update vector index: %w", err)
+	}
+	if added > 0 {
+		if err := idx.save(); err != nil {
+			return err
+		}
+	}
+	s.index = idx
+	s.logger.Info().
+		Str("path", path).
+		Int("vectors", idx.graph.Len()).
+		Int("caught_up", added).
+		Msg("Vector index opened")
+	return nil
+}
+
+// RebuildVectorIndex re-creates the vector index at path from every embedded memory item,
+// saves it and makes it the store's index. It returns the number of indexed items.
+func (s *Store) RebuildVectorIndex(ctx context.Context, path string) (int, error) {
+	if s.embedder == nil {
+		return 0, fmt.Errorf("vector index requires an embedder")
+	}
+	idx := &VectorIndex{path: path, model: s.embedder.Name(), graph: hnsw.New()}
+	added, err := s.indexNewItems(ctx, idx)
+	if err != nil {
+		return 0, fmt.Errorf("rebuild vector index: %w", err)
+	}
+	if err := idx.save(); err != nil {
+		return 0, err
+	}
+	s.index = idx
+	s.logger.Info().Str("path", path).Int("vectors", added).Msg("Vector index rebuilt")
+	return added, nil
+}
+
+// CloseVectorIndex saves any buffered additions to the vector index.
+func (s *Store) CloseVectorIndex() error {
+	if s.index == nil {
+		return nil
+	}
+	s.index.mu.Lock()
+	pending := s.index.pending
+	s.index.mu.Unlock()
+	if pending == 0 {
+		return nil
+	}
+	return s.index.save()
+}
+
+// indexItem adds a newly stored item to the vector index, if one is open.
+func (s *Store) indexItem(id int64, embedding []float32) {
+	if s.index == nil || len(embedding) == 0 {
+		return
+	}
+	if err := s.index.add(id, embedding); err != nil {
+		s.logger.Warn().Err(err).Int64("id", id).Msg("Failed to add memory item to vector index")
+		return
+	}
+	if err := s.index.maybeSave(); err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to save vector index")
+	}
+}
+
+// unindexItem removes an item from the vector index, if one is open.
+func (s *Store) unindexItem(id int64) {
+	if s.index == nil {
+		return
+	}
+	s.index.remove(id)
+	if err := s.index.maybeSave(); err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to save vector index")
+	}
+}
+
+// indexNewItems adds embedded items with IDs above idx.lastID to the index.
+// Vectors from other embedders are skipped. Items stored before embedders were recorded
+// are included when their dimension matches the index.
+func (s *Store) indexNewItems(ctx context.Context, idx *VectorIndex) (int, error) {
+	dim, err := s.indexDimension(ctx, idx)
+	if err != nil || dim == 0 {
+		return 0, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+SELECT id, embedding, embedding_model
+FROM memory_items
+WHERE embedding IS NOT NULL AND superseded_by IS NULL AND archived_at IS NULL AND id > ?
+ORDER BY id
+`, idx.lastID)
+	if err != nil {
+		return 0, fmt.Errorf("query embeddings: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck // No remedy for rows close errors
+
+	added := 0
+	for rows.Next() {
+		var (
+			id       int64
+			blob     []byte
+			embModel *string
+		)
+		if err := rows.Scan(&id, &blob, &embModel); err != nil {
+			return added, err
+		}
+		idx.lastID = id
+		if embModel != nil && *embModel != idx.model {
+			continue
+		}
+		vec, err := DecodeEmbedding(blob)
+		if err != nil || len(vec) != dim {
+			continue
+		}
+		if err := idx.graph.Add(id, vec); err != nil {
+			s.logger.Debug().Err(err).Int64("id", id).Msg("Skipping vector that cannot be indexed")
+			continue
+		}
+		added++
+	}
+	return added, rows.Err()
+}
+
+// indexDimension returns the vector dimension of the index, taking it from the stored
+// vectors if the index is empty. Returns 0 if there is nothing to index.
+func (s *Store) indexDimension(ctx context.Context, idx *VectorIndex) (int, error) {
+	if dim := idx.graph.Dim(); dim > 0 {
+		return dim, nil
+	}
+	var blobLen int
+	err := s.db.QueryRowContext(ctx, `
+SELECT length(embedding)
+FROM memory_items
+WHERE embedding IS NOT NULL AND (embedding_model = ? OR embedding_model IS NULL)
+ORDER BY embedding_model IS NULL, id
+LIMIT 1
+`, idx.model).Scan(&blobLen)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("query embedding dimension: %w", err)
+	}
+	return blobLen / 4, nil
+}
+
+// searchByVectorIndex answers a vector search from the index. Filters are applied after
+// the nearest-neighbor lookup, so the candidate pool grows until enough items pass them
+// or the index is exhausted.
+func (s *Store) searchByVectorIndex(ctx context.Context, q *SearchQuery, limit int) ([]SearchResult, error) {
+	k := max(limit*4, 50)
+	for {
+		hits := s.index.graph.Search(q.QueryEmbedding, k)
+		ids := make([]int64, len(hits))
+		for i, hit := range hits {
+			ids[i] = hit.ID
+		}
+		var results []SearchResult
+		for len(ids) > 0 {
+			// Load candidates in batches to stay under SQLite's bound parameter limit
+			batch := ids[:min(len(ids), vectorIndexLoadBatch)]
+			ids = ids[len(batch):]
+			items, err := s.loadItemsByIDs(ctx, batch)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, s.scoreCandidates(items, q)...)
+		}
+
+		if len(results) >= limit || len(hits) < k {
+			sort.Slice(results, func(i, j int) bool {
+				return results[i].Score > results[j].Score
+			})
+			if len(results) > limit {
+				results = results[:limit]
+			}
+			s.logger.Info().
+				Int("candidates", len(hits)).
+				Int("numResults", len(results)).
+				Msg("searchByVectorIndex: returning results")
+			return results, nil
+		}
+		k *= 4
+	}
+}
+
+// scoreCandidates filters index candidates and scores them exactly against the query.
+func (s *Store) scoreCandidates(items []*MemoryItem, q *SearchQuery) []SearchResult {
+	var results []SearchResult
+	for _, item := range items {
+		if !s.comparableEmbedding(item, q.QueryEmbedding) || !applyFilters(item, q, s.logger) {
+			continue
+		}
+		if score := CosineSimilarity(q.QueryEmbedding, item.Embedding); score > 0 {
+			results = append(results, SearchResult{Item: item, Score: score})
+		}
+	}
+	return results
+}
+
+// add indexes a vector.
+func (idx *VectorIndex) add(id int64, vec []float32) error {
+	if err := idx.graph.Add(id, vec); err != nil {
+		return err
+	}
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.lastID = max(idx.lastID, id)
+	idx.pending++
+	return nil
+}
+
+// remove drops a vector from the index.
+func (idx *VectorIndex) remove(id int64) {
+	idx.graph.Delete(id)
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.pending++
+}
+
+// maybeSave writes the index once enough additions have been buffered.
+func (idx *VectorIndex) maybeSave() error {
+	idx.mu.Lock()
+	due := idx.pending >= vectorIndexSaveEvery
+	idx.mu.Unlock()
+	if !due {
+		return nil
+	}
+	return idx.save()
+}
+
+// load reads the index file. It fails if the file was written for another embedder.
+func (idx *VectorIndex) load() error {
+	f, err := os.Open(idx.path) //#nosec 304 -- index path is derived from the database path
+	if err != nil {
+		return err
+	}
+	defer f.Close() //nolint:errcheck // No remedy for file close errors
+
+	var header vectorIndexHeader
+	dec := gob.NewDecoder(f)
+	if err := dec.Decode(&header); err != nil {
+		return fmt.Errorf("read vector index header: %w", err)
+	}
+	if header.Version != vectorIndexVersion {
+		return fmt.Errorf("vector index version %d is not supported", header.Version)
+	}
+	if header.Model != idx.model {
+		return fmt.Errorf("vector index was built with embedder %q, not %q", header.Model, idx.model)
+	}
+	// The header and graph share one gob stream, since the decoder reads ahead
+	graph, err := hnsw.Decode(dec)
+	if err != nil {
+		return err
+	}
+
+	idx.graph = graph
+	idx.lastID = header.LastID
+	return nil
+}
+
+// save writes the index to a temporary file and renames it into place, so a crash
+// mid-write leaves the previous index intact.
+func (idx *VectorIndex) save() error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	tmp, err := os.CreateTemp(filepath.Dir(idx.path), filepath.Base(idx.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create vector index file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // Already renamed on success
+
+	header := vectorIndexHeader{Version: vectorIndexVersion, Model: idx.model, LastID: idx.lastID}
+	enc := gob.NewEncoder(tmp)
+	if err := enc.Encode(header); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write vector index header: %w", err)
+	}
+	if err := idx.graph.Encode(enc); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close vector index file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), idx.path); err != nil {
+		return fmt.Errorf("replace vector index file: %w", err)
+	}
+	idx.pending = 0
+	return nil
+}
@@ -0,0 +1,190 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestVectorIndex_FindsItemsBeyondRecentCandidates(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	embedder := newSemanticEmbedder(64)
+	store, err := NewStore(db, embedder, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	// The filler items are near-duplicates of each other and must all be stored
+	store.SetDedupConfig(DedupConfig{})
+
+	target, err := store.RememberGlobalFact(ctx, "the lighthouse keeper prefers oolong tea", 0.5, nil)
+	if err != nil {
+		t.Fatalf("RememberGlobalFact: %v", err)
+	}
+	// Push the target out of the most recent candidates a linear scan would consider
+	for i := 0; i < 600; i++ {
+		if _, err := store.RememberGlobalFact(ctx, fmt.Sprintf("filler note %d about nothing", i), 0.1, nil); err != nil {
+			t.Fatalf("RememberGlobalFact: %v", err)
+		}
+	}
+
+	indexPath := filepath.Join(t.TempDir(), "memory.db.vectors")
+	if err := store.OpenVectorIndex(ctx, indexPath); err != nil {
+		t.Fatalf("OpenVectorIndex: %v", err)
+	}
+	if n := store.index.graph.Len(); n != 601 {
+		t.Fatalf("expected 601 indexed vectors, got %d", n)
+	}
+
+	queryEmb, err := embedder.Embed(ctx, "lighthouse keeper oolong tea")
+	if err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	results, err := store.SearchMemory(ctx, &SearchQuery{QueryEmbedding: queryEmb, IncludeGlobal: true, Limit: 5})
+	if err != nil {
+		t.Fatalf("SearchMemory: %v", err)
+	}
+	if len(results) == 0 || results[0].Item.ID != target.ID {
+		t.Fatalf("expected target item first, got %d results", len(results))
+	}
+
+	// New items are indexed as they are stored and survive a reopen
+	added, err := store.RememberGlobalFact(ctx, "the harbor master collects brass compasses", 0.5, nil)
+	if err != nil {
+		t.Fatalf("RememberGlobalFact: %v", err)
+	}
+	if err := store.CloseVectorIndex(); err != nil {
+		t.Fatalf("CloseVectorIndex: %v", err)
+	}
+	reopened, err := NewStore(db, embedder, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	if err := reopened.OpenVectorIndex(ctx, indexPath); err != nil {
+		t.Fatalf("OpenVectorIndex: %v", err)
+	}
+	if !reopened.index.graph.Contains(added.ID) {
+		t.Errorf("expected item %d in reopened index", added.ID)
+	}
+}
+
+func TestVectorIndex_CatchesUpAndRebuildsForNewEmbedder(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	store, err := NewStore(db, newSemanticEmbedder(32), zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	indexPath := filepath.Join(t.TempDir(), "memory.db.vectors")
+	if err := store.OpenVectorIndex(ctx, indexPath); err != nil {
+		t.Fatalf("OpenVectorIndex: %v", err)
+	}
+	first, err := store.RememberGlobalFact(ctx, "first fact", 0.5, nil)
+	if err != nil {
+		t.Fatalf("RememberGlobalFact: %v", err)
+	}
+	if err := store.CloseVectorIndex(); err != nil {
+		t.Fatalf("CloseVectorIndex: %v", err)
+	}
+
+	// Stored while the index was not open, e.g. by another process
+	offline, err := NewStore(db, newSemanticEmbedder(32), zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	missed, err := offline.RememberGlobalFact(ctx, "second fact", 0.5, nil)
+	if err != nil {
+		t.Fatalf("RememberGlobalFact: %v", err)
+	}
+
+	caughtUp, err := NewStore(db, newSemanticEmbedder(32), zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	if err := caughtUp.OpenVectorIndex(ctx, indexPath); err != nil {
+		t.Fatalf("OpenVectorIndex: %v", err)
+	}
+	for _, id := range []int64{first.ID, missed.ID} {
+		if !caughtUp.index.graph.Contains(id) {
+			t.Errorf("expected item %d in index", id)
+		}
+	}
+
+	// A different embedder discards the index and only indexes its own vectors
+	other := &namedEmbedder{semanticEmbedder: newSemanticEmbedder(32), name: "other"}
+	switched, err := NewStore(db, other, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	if err := switched.OpenVectorIndex(ctx, indexPath); err != nil {
+		t.Fatalf("OpenVectorIndex: %v", err)
+	}
+	if n := switched.index.graph.Len(); n != 0 {
+		t.Errorf("expected empty index for new embedder, got %d vectors", n)
+	}
+	own, err := switched.RememberGlobalFact(ctx, "third fact", 0.5, nil)
+	if err != nil {
+		t.Fatalf("RememberGlobalFact: %v", err)
+	}
+	if !switched.index.graph.Contains(own.ID) {
+		t.Errorf("expected item %d in index", own.ID)
+	}
+}
+
+func TestVectorIndex_LoadsSavedIndex(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck // Test cleanup
+
+	ctx := context.Background()
+	embedder := newSemanticEmbedder(32)
+	store, err := NewStore(db, embedder, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	store.SetDedupConfig(DedupConfig{})
+	var ids []int64
+	for _, fact := range []string{"first fact", "second fact", "third fact"} {
+		item, err := store.RememberGlobalFact(ctx, fact, 0.5, nil)
+		if err != nil {
+			t.Fatalf("RememberGlobalFact: %v", err)
+		}
+		ids = append(ids, item.ID)
+	}
+
+	indexPath := filepath.Join(t.TempDir(), "memory.db.vectors")
+	if _, err := store.RebuildVectorIndex(ctx, indexPath); err != nil {
+		t.Fatalf("RebuildVectorIndex: %v", err)
+	}
+
+	idx := &VectorIndex{path: indexPath, model: embedder.Name()}
+	if err := idx.load(); err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if idx.graph.Len() != len(ids) || idx.lastID != ids[len(ids)-1] {
+		t.Fatalf("expected %d vectors up to item %d, got %d up to %d", len(ids), ids[len(ids)-1], idx.graph.Len(), idx.lastID)
+	}
+
+	// Deleting a row behind the index's back shows whether the file was reused: a rebuild
+	// would drop the vector, loading the saved index keeps it
+	if _, err := db.ExecContext(ctx, `DELETE FROM memory_items WHERE id = ?`, ids[0]); err != nil {
+		t.Fatalf("delete item: %v", err)
+	}
+	reopened, err := NewStore(db, embedder, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	if err := reopened.OpenVectorIndex(ctx, indexPath); err != nil {
+		t.Fatalf("OpenVectorIndex: %v", err)
+	}
+	if !reopened.index.graph.Contains(ids[0]) {
+		t.Error("expected OpenVectorIndex to load the saved index instead of rebuilding it")
+	}
+}
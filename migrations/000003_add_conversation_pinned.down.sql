@@ -0,0 +1,2 @@
+-- Rollback migration to remove the pinned flag from conversations
+ALTER TABLE conversations DROP COLUMN pinned;
@@ -0,0 +1,3 @@
+-- Migration to let users pin conversation messages.
+-- Pinned messages are kept verbatim when the context is compressed.
+ALTER TABLE conversations ADD COLUMN pinned INTEGER NOT NULL DEFAULT 0;
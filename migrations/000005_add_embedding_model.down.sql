@@ -0,0 +1,3 @@
+-- Rollback migration to remove embedder tracking from memory_items
+ALTER TABLE memory_items DROP COLUMN embedding_dim;
+ALTER TABLE memory_items DROP COLUMN embedding_model;
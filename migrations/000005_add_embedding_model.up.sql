@@ -0,0 +1,4 @@
+-- Migration to record which embedder produced each stored vector.
+-- Vectors from different embedders are not comparable, so search skips mismatches.
+ALTER TABLE memory_items ADD COLUMN embedding_model TEXT;
+ALTER TABLE memory_items ADD COLUMN embedding_dim INTEGER;
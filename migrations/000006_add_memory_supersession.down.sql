@@ -0,0 +1,5 @@
+-- Rollback migration to remove memory supersession tracking
+DROP INDEX IF EXISTS idx_memory_item_versions_item;
+DROP TABLE IF EXISTS memory_item_versions;
+DROP INDEX IF EXISTS idx_memory_items_superseded_by;
+ALTER TABLE memory_items DROP COLUMN superseded_by;
@@ -0,0 +1,22 @@
+-- Migration to track memories replaced by newer ones and keep their earlier versions.
+-- Superseded items stay in memory_items for history but are excluded from search.
+ALTER TABLE memory_items ADD COLUMN superseded_by INTEGER REFERENCES memory_items(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_memory_items_superseded_by ON memory_items(superseded_by);
+
+-- Earlier versions of a memory, recorded when it is merged with a duplicate or supersedes
+-- another item. source_item_id is the item that held the content at the time.
+CREATE TABLE IF NOT EXISTS memory_item_versions (
+    id INTEGER PRIMARY KEY,
+    item_id INTEGER NOT NULL REFERENCES memory_items(id) ON DELETE CASCADE,
+    source_item_id INTEGER NOT NULL,
+    content TEXT NOT NULL,
+    raw_content TEXT,
+    tags_json TEXT,
+    importance REAL NOT NULL DEFAULT 0.0,
+    reason TEXT NOT NULL CHECK(reason IN ('merged','superseded')),
+    created_at INTEGER NOT NULL,  -- when this version became current
+    replaced_at INTEGER NOT NULL  -- when it was replaced
+);
+
+CREATE INDEX IF NOT EXISTS idx_memory_item_versions_item ON memory_item_versions(item_id, replaced_at);
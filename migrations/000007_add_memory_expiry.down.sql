@@ -0,0 +1,6 @@
+-- Rollback migration to remove memory expiry and decay
+DROP INDEX IF EXISTS idx_memory_items_archived_at;
+DROP INDEX IF EXISTS idx_memory_items_expires_at;
+ALTER TABLE memory_items DROP COLUMN decayed_at;
+ALTER TABLE memory_items DROP COLUMN archived_at;
+ALTER TABLE memory_items DROP COLUMN expires_at;
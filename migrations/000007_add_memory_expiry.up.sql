@@ -0,0 +1,10 @@
+-- Migration to let memories expire and fade.
+-- expires_at is set from the retention policy when a memory is stored; expired or faded
+-- memories are archived (excluded from search) or deleted by the retention job.
+ALTER TABLE memory_items ADD COLUMN expires_at INTEGER;
+ALTER TABLE memory_items ADD COLUMN archived_at INTEGER;
+-- When importance was last decayed (NULL = never, decay runs from created_at)
+ALTER TABLE memory_items ADD COLUMN decayed_at INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_memory_items_expires_at ON memory_items(expires_at) WHERE expires_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_memory_items_archived_at ON memory_items(archived_at);
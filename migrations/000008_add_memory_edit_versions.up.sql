@@ -0,0 +1,20 @@
+-- Allow memory versions recorded when a memory is edited directly.
+-- SQLite cannot alter a CHECK constraint, so the table is rebuilt.
+CREATE TABLE memory_item_versions_new (
+    id INTEGER PRIMARY KEY,
+    item_id INTEGER NOT NULL REFERENCES memory_items(id) ON DELETE CASCADE,
+    source_item_id INTEGER NOT NULL,
+    content TEXT NOT NULL,
+    raw_content TEXT,
+    tags_json TEXT,
+    importance REAL NOT NULL DEFAULT 0.0,
+    reason TEXT NOT NULL CHECK(reason IN ('merged','superseded','edited')),
+    created_at INTEGER NOT NULL,
+    replaced_at INTEGER NOT NULL
+);
+
+INSERT INTO memory_item_versions_new SELECT * FROM memory_item_versions;
+DROP TABLE memory_item_versions;
+ALTER TABLE memory_item_versions_new RENAME TO memory_item_versions;
+
+CREATE INDEX IF NOT EXISTS idx_memory_item_versions_item ON memory_item_versions(item_id, replaced_at);
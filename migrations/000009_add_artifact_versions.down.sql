@@ -0,0 +1,4 @@
+-- Rollback migration for artifact versions
+DROP INDEX IF EXISTS idx_artifacts_updated_at;
+DROP TABLE IF EXISTS artifact_versions;
+ALTER TABLE artifacts DROP COLUMN version;
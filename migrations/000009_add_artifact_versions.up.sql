@@ -0,0 +1,20 @@
+-- Migration to version artifacts. Every revision, including the current one, is kept
+-- in artifact_versions; artifacts.version is the current revision number.
+ALTER TABLE artifacts ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+CREATE TABLE IF NOT EXISTS artifact_versions (
+    id INTEGER PRIMARY KEY,
+    artifact_id INTEGER NOT NULL REFERENCES artifacts(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    agent_id TEXT,  -- who wrote this revision
+    title TEXT,
+    body TEXT NOT NULL,
+    metadata TEXT,
+    created_at INTEGER NOT NULL,
+    UNIQUE(artifact_id, version)
+);
+
+INSERT INTO artifact_versions (artifact_id, version, agent_id, title, body, metadata, created_at)
+SELECT id, 1, agent_id, title, body, metadata, updated_at FROM artifacts;
+
+CREATE INDEX IF NOT EXISTS idx_artifacts_updated_at ON artifacts(updated_at);
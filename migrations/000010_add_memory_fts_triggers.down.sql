@@ -0,0 +1,14 @@
+-- Rollback migration for memory FTS triggers
+DROP TRIGGER IF EXISTS memory_items_fts_update;
+DROP TRIGGER IF EXISTS memory_items_fts_delete;
+DROP TRIGGER IF EXISTS memory_items_fts_insert;
+
+DROP TABLE IF EXISTS memory_items_fts;
+
+CREATE VIRTUAL TABLE memory_items_fts USING fts5(
+    content,
+    content_rowid='id'
+);
+
+INSERT INTO memory_items_fts (rowid, content)
+SELECT id, content FROM memory_items;
@@ -0,0 +1,31 @@
+-- Keep memory_items_fts in sync with memory_items through triggers, and index
+-- raw_content and tags alongside content. The table is rebuilt with the new columns.
+DROP TABLE IF EXISTS memory_items_fts;
+
+CREATE VIRTUAL TABLE memory_items_fts USING fts5(
+    content,
+    raw_content,
+    tags
+);
+
+INSERT INTO memory_items_fts (rowid, content, raw_content, tags)
+SELECT id, content, raw_content,
+       (SELECT group_concat(value, ' ') FROM json_each(memory_items.tags_json))
+FROM memory_items;
+
+CREATE TRIGGER memory_items_fts_insert AFTER INSERT ON memory_items BEGIN
+    INSERT INTO memory_items_fts (rowid, content, raw_content, tags)
+    VALUES (new.id, new.content, new.raw_content,
+            (SELECT group_concat(value, ' ') FROM json_each(new.tags_json)));
+END;
+
+CREATE TRIGGER memory_items_fts_delete AFTER DELETE ON memory_items BEGIN
+    DELETE FROM memory_items_fts WHERE rowid = old.id;
+END;
+
+CREATE TRIGGER memory_items_fts_update AFTER UPDATE OF content, raw_content, tags_json ON memory_items BEGIN
+    DELETE FROM memory_items_fts WHERE rowid = old.id;
+    INSERT INTO memory_items_fts (rowid, content, raw_content, tags)
+    VALUES (new.id, new.content, new.raw_content,
+            (SELECT group_concat(value, ' ') FROM json_each(new.tags_json)));
+END;
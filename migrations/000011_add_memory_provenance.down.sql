@@ -0,0 +1,7 @@
+-- Rollback migration for memory provenance
+DROP INDEX IF EXISTS idx_memory_items_source_thread;
+ALTER TABLE memory_items DROP COLUMN source_kind;
+ALTER TABLE memory_items DROP COLUMN source_tool_id;
+ALTER TABLE memory_items DROP COLUMN source_message_id;
+ALTER TABLE memory_items DROP COLUMN source_thread_id;
+ALTER TABLE memory_items DROP COLUMN source_agent_id;
@@ -0,0 +1,9 @@
+-- Record where each memory came from: the agent and conversation that produced it,
+-- the user message that prompted it, and the tool call that stored it.
+ALTER TABLE memory_items ADD COLUMN source_agent_id TEXT;
+ALTER TABLE memory_items ADD COLUMN source_thread_id TEXT;
+ALTER TABLE memory_items ADD COLUMN source_message_id INTEGER; -- conversations.id
+ALTER TABLE memory_items ADD COLUMN source_tool_id TEXT;
+ALTER TABLE memory_items ADD COLUMN source_kind TEXT; -- tool name, or "reflection"
+
+CREATE INDEX IF NOT EXISTS idx_memory_items_source_thread ON memory_items(source_agent_id, source_thread_id);
@@ -0,0 +1,3 @@
+-- Rollback migration for episode reflection tracking
+DROP INDEX IF EXISTS idx_memory_items_unreflected;
+ALTER TABLE memory_items DROP COLUMN reflected_at;
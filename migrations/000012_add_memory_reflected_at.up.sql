@@ -0,0 +1,5 @@
+-- Mark episodes once reflection has summarized them, so they are not summarized again.
+ALTER TABLE memory_items ADD COLUMN reflected_at INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_memory_items_unreflected ON memory_items(agent_id, type, created_at)
+WHERE reflected_at IS NULL;
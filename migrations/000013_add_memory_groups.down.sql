@@ -0,0 +1,71 @@
+-- Rollback migration for memory sharing groups. Group memories have no place in the old
+-- scopes, so they are deleted.
+DELETE FROM memory_items WHERE scope = 'group';
+
+CREATE TABLE memory_items_old (
+    id INTEGER PRIMARY KEY,
+    agent_id TEXT,
+    thread_id TEXT,
+    scope TEXT NOT NULL CHECK(scope IN ('agent','global')),
+    type TEXT NOT NULL CHECK(type IN ('fact','episode','profile','doc_ref')),
+    content TEXT NOT NULL,
+    embedding BLOB,
+    metadata TEXT,
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL,
+    importance REAL NOT NULL DEFAULT 0.0,
+    raw_content TEXT,
+    memory_type TEXT,
+    tags_json TEXT,
+    embedding_model TEXT,
+    embedding_dim INTEGER,
+    superseded_by INTEGER REFERENCES memory_items(id) ON DELETE SET NULL,
+    expires_at INTEGER,
+    archived_at INTEGER,
+    decayed_at INTEGER,
+    source_agent_id TEXT,
+    source_thread_id TEXT,
+    source_message_id INTEGER,
+    source_tool_id TEXT,
+    source_kind TEXT,
+    reflected_at INTEGER
+);
+
+INSERT INTO memory_items_old (
+    id, agent_id, thread_id, scope, type, content, embedding, metadata, created_at,
+    updated_at, importance, raw_content, memory_type, tags_json, embedding_model,
+    embedding_dim, superseded_by, expires_at, archived_at, decayed_at, source_agent_id,
+    source_thread_id, source_message_id, source_tool_id, source_kind, reflected_at)
+SELECT
+    id, agent_id, thread_id, scope, type, content, embedding, metadata, created_at,
+    updated_at, importance, raw_content, memory_type, tags_json, embedding_model,
+    embedding_dim, superseded_by, expires_at, archived_at, decayed_at, source_agent_id,
+    source_thread_id, source_message_id, source_tool_id, source_kind, reflected_at
+FROM memory_items;
+
+DROP TABLE memory_items;
+ALTER TABLE memory_items_old RENAME TO memory_items;
+
+CREATE INDEX IF NOT EXISTS idx_memory_items_superseded_by ON memory_items(superseded_by);
+CREATE INDEX IF NOT EXISTS idx_memory_items_expires_at ON memory_items(expires_at) WHERE expires_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_memory_items_archived_at ON memory_items(archived_at);
+CREATE INDEX IF NOT EXISTS idx_memory_items_source_thread ON memory_items(source_agent_id, source_thread_id);
+CREATE INDEX IF NOT EXISTS idx_memory_items_unreflected ON memory_items(agent_id, type, created_at)
+WHERE reflected_at IS NULL;
+
+CREATE TRIGGER memory_items_fts_insert AFTER INSERT ON memory_items BEGIN
+    INSERT INTO memory_items_fts (rowid, content, raw_content, tags)
+    VALUES (new.id, new.content, new.raw_content,
+            (SELECT group_concat(value, ' ') FROM json_each(new.tags_json)));
+END;
+
+CREATE TRIGGER memory_items_fts_delete AFTER DELETE ON memory_items BEGIN
+    DELETE FROM memory_items_fts WHERE rowid = old.id;
+END;
+
+CREATE TRIGGER memory_items_fts_update AFTER UPDATE OF content, raw_content, tags_json ON memory_items BEGIN
+    DELETE FROM memory_items_fts WHERE rowid = old.id;
+    INSERT INTO memory_items_fts (rowid, content, raw_content, tags)
+    VALUES (new.id, new.content, new.raw_content,
+            (SELECT group_concat(value, ' ') FROM json_each(new.tags_json)));
+END;
@@ -0,0 +1,4 @@
+-- Rollback migration for document ingestion
+DROP INDEX IF EXISTS idx_memory_items_doc_path;
+DELETE FROM memory_items WHERE type = 'doc_ref';
+DROP TABLE IF EXISTS documents;
@@ -0,0 +1,12 @@
+-- Workspace documents ingested as doc_ref memories. The hash of the indexed content lets
+-- the ingester skip unchanged files and re-index changed ones.
+CREATE TABLE IF NOT EXISTS documents (
+    path TEXT PRIMARY KEY,
+    content_hash TEXT NOT NULL, -- sha256 of the file
+    chunks INTEGER NOT NULL,
+    indexed_at INTEGER NOT NULL
+);
+
+-- doc_ref chunks are found by the path in their metadata when a document is re-indexed.
+CREATE INDEX IF NOT EXISTS idx_memory_items_doc_path ON memory_items(json_extract(metadata, '$.path'))
+WHERE type = 'doc_ref';
@@ -0,0 +1,12 @@
+-- Rollback migration for the entity graph
+DROP TRIGGER IF EXISTS memory_items_entities_delete;
+
+DROP INDEX IF EXISTS idx_relations_memory;
+DROP INDEX IF EXISTS idx_relations_object;
+DROP INDEX IF EXISTS idx_relations_subject;
+DROP TABLE IF EXISTS relations;
+
+DROP INDEX IF EXISTS idx_entity_mentions_memory;
+DROP TABLE IF EXISTS entity_mentions;
+
+DROP TABLE IF EXISTS entities;
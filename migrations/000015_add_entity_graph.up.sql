@@ -0,0 +1,43 @@
+-- People, projects, organizations and places mentioned by memories, and the relations
+-- between them that memories state. Both are filled in when personal memories are
+-- normalized, so questions about an entity can be answered without text search.
+CREATE TABLE IF NOT EXISTS entities (
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL COLLATE NOCASE,
+    kind TEXT NOT NULL CHECK(kind IN ('person','project','organization','place')),
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL,
+    UNIQUE(kind, name)
+);
+
+CREATE TABLE IF NOT EXISTS entity_mentions (
+    entity_id INTEGER NOT NULL REFERENCES entities(id) ON DELETE CASCADE,
+    memory_id INTEGER NOT NULL REFERENCES memory_items(id) ON DELETE CASCADE,
+    PRIMARY KEY (entity_id, memory_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_entity_mentions_memory ON entity_mentions(memory_id);
+
+CREATE TABLE IF NOT EXISTS relations (
+    id INTEGER PRIMARY KEY,
+    subject_id INTEGER NOT NULL REFERENCES entities(id) ON DELETE CASCADE,
+    predicate TEXT NOT NULL, -- lowercase, e.g. works_on, manages, located_in
+    object_id INTEGER NOT NULL REFERENCES entities(id) ON DELETE CASCADE,
+    memory_id INTEGER NOT NULL REFERENCES memory_items(id) ON DELETE CASCADE, -- Memory stating the relation
+    created_at INTEGER NOT NULL,
+    UNIQUE(subject_id, predicate, object_id, memory_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_relations_subject ON relations(subject_id);
+CREATE INDEX IF NOT EXISTS idx_relations_object ON relations(object_id);
+CREATE INDEX IF NOT EXISTS idx_relations_memory ON relations(memory_id);
+
+-- Foreign keys are not enforced on every connection, so deleted memories take their
+-- mentions and relations with them here, along with entities nothing mentions any more.
+CREATE TRIGGER IF NOT EXISTS memory_items_entities_delete AFTER DELETE ON memory_items BEGIN
+    DELETE FROM relations WHERE memory_id = old.id;
+    DELETE FROM entities
+    WHERE id IN (SELECT entity_id FROM entity_mentions WHERE memory_id = old.id)
+      AND NOT EXISTS (SELECT 1 FROM entity_mentions m WHERE m.entity_id = entities.id AND m.memory_id != old.id);
+    DELETE FROM entity_mentions WHERE memory_id = old.id;
+END;
@@ -0,0 +1,3 @@
+-- Rollback migration for pending facts
+DROP TRIGGER IF EXISTS inbox_pending_facts_delete;
+DROP TABLE IF EXISTS pending_facts;
@@ -0,0 +1,15 @@
+-- Extracted facts waiting for the user to confirm them from the inbox. Each is stored as a
+-- personal memory when its inbox item is confirmed and dropped when it is declined.
+CREATE TABLE IF NOT EXISTS pending_facts (
+    inbox_id INTEGER PRIMARY KEY REFERENCES inbox(id) ON DELETE CASCADE,
+    agent_id TEXT NOT NULL,
+    thread_id TEXT,
+    fact TEXT NOT NULL, -- PendingFact as JSON
+    created_at INTEGER NOT NULL
+);
+
+-- Foreign keys are not enforced on every connection, so deleted inbox items take their
+-- pending facts with them here.
+CREATE TRIGGER IF NOT EXISTS inbox_pending_facts_delete AFTER DELETE ON inbox BEGIN
+    DELETE FROM pending_facts WHERE inbox_id = old.id;
+END;
@@ -0,0 +1,2 @@
+-- Rollback migration to remove attachments from conversations
+ALTER TABLE conversations DROP COLUMN attachments;
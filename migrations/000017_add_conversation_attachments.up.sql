@@ -0,0 +1,3 @@
+-- Migration to keep the images and documents attached to user messages.
+-- Attachments are stored as a JSON array so reloaded threads still carry them.
+ALTER TABLE conversations ADD COLUMN attachments TEXT;
@@ -0,0 +1,2 @@
+-- Rollback migration to remove reasoning signatures from conversations
+ALTER TABLE conversations DROP COLUMN signature;
@@ -0,0 +1,3 @@
+-- Migration to keep the provider signature of stored reasoning.
+-- Signed reasoning must be sent back with the tool calls it led to when a thread is reloaded.
+ALTER TABLE conversations ADD COLUMN signature TEXT;
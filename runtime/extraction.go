@@ -0,0 +1,152 @@
+package runtime
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/aschepis/backscratcher/staff/agent"
+	"github.com/aschepis/backscratcher/staff/memory"
+	"github.com/rs/zerolog"
+)
+
+// DefaultExtractionMinConfidence is the confidence at which extracted facts are stored
+// without asking the user.
+const DefaultExtractionMinConfidence = 0.8
+
+// extractionQueueSize bounds how many completed turns can wait for extraction.
+const extractionQueueSize = 64
+
+// FactExtractionJob reads completed chat turns in the background and remembers the
+// personal facts and preferences they reveal, so agents learn about the user without
+// having to call a memory tool. Facts below the confidence threshold are sent to the
+// inbox instead, and stored once the user confirms them there.
+type FactExtractionJob struct {
+	store         *memory.Store
+	extractor     memory.FactExtractor
+	normalizer    memory.PersonalNormalizer
+	db            *sql.DB
+	minConfidence float64
+	logger        zerolog.Logger
+	turns         chan agent.Turn
+}
+
+// NewFactExtractionJob creates a fact extraction job. Facts with at least minConfidence
+// are stored; the rest go to the inbox in db.
+func NewFactExtractionJob(
+	store *memory.Store,
+	extractor memory.FactExtractor,
+	normalizer memory.PersonalNormalizer,
+	db *sql.DB,
+	minConfidence float64,
+	logger zerolog.Logger,
+) (*FactExtractionJob, error) {
+	if store == nil {
+		return nil, fmt.Errorf("store cannot be nil")
+	}
+	if extractor == nil {
+		return nil, fmt.Errorf("extractor cannot be nil")
+	}
+	if normalizer == nil {
+		return nil, fmt.Errorf("normalizer cannot be nil")
+	}
+	if db == nil {
+		return nil, fmt.Errorf("db cannot be nil")
+	}
+	if minConfidence <= 0 {
+		minConfidence = DefaultExtractionMinConfidence
+	}
+	return &FactExtractionJob{
+		store:         store,
+		extractor:     extractor,
+		normalizer:    normalizer,
+		db:            db,
+		minConfidence: minConfidence,
+		logger:        logger.With().Str("component", "fact_extraction").Logger(),
+		turns:         make(chan agent.Turn, extractionQueueSize),
+	}, nil
+}
+
+// Hook queues a completed chat turn for extraction. It is an agent.TurnHook and never blocks;
+// turns are dropped while the queue is full.
+func (j *FactExtractionJob) Hook(_ context.Context, turn agent.Turn) {
+	select {
+	case j.turns <- turn:
+	default:
+		j.logger.Warn().Str("agent_id", turn.AgentID).Str("thread_id", turn.ThreadID).
+			Msg("Fact extraction queue is full, skipping turn")
+	}
+}
+
+// Start extracts facts from queued turns until ctx is cancelled.
+func (j *FactExtractionJob) Start(ctx context.Context) {
+	j.logger.Info().Float64("min_confidence", j.minConfidence).Msg("Starting fact extraction job")
+
+	for {
+		select {
+		case <-ctx.Done():
+			j.logger.Info().Msg("Fact extraction job stopped: context cancelled")
+			return
+		case turn := <-j.turns:
+			if err := j.extract(ctx, turn); err != nil && ctx.Err() == nil {
+				j.logger.Error().Err(err).Str("agent_id", turn.AgentID).Str("thread_id", turn.ThreadID).
+					Msg("Fact extraction failed")
+			}
+		}
+	}
+}
+
+// extract remembers the facts in one turn and asks the user about uncertain ones.
+func (j *FactExtractionJob) extract(ctx context.Context, turn agent.Turn) error {
+	transcript := fmt.Sprintf("User: %s\n\nAssistant: %s", strings.TrimSpace(turn.UserMessage), strings.TrimSpace(turn.Response))
+	facts, err := j.extractor.ExtractFacts(ctx, transcript)
+	if err != nil {
+		return fmt.Errorf("extract facts: %w", err)
+	}
+	if len(facts) == 0 {
+		return nil
+	}
+
+	result, err := j.store.RememberExtractedFacts(ctx, turn.AgentID, turn.ThreadID, facts, j.normalizer, j.minConfidence)
+	if err != nil {
+		return err
+	}
+	for _, pending := range result.Pending {
+		if err := j.askToConfirm(ctx, turn, pending); err != nil {
+			return err
+		}
+	}
+
+	j.logger.Info().Str("agent_id", turn.AgentID).Str("thread_id", turn.ThreadID).
+		Int("stored", len(result.Stored)).Int("pending", len(result.Pending)).
+		Msg("Extracted facts from conversation")
+	return nil
+}
+
+// askToConfirm adds an inbox item asking the user whether an uncertain fact is true, and
+// keeps the fact with it so confirming the item stores it.
+func (j *FactExtractionJob) askToConfirm(ctx context.Context, turn agent.Turn, pending memory.PendingFact) error {
+	message := fmt.Sprintf("I might have learned something about you: %q (confidence %.0f%%). "+
+		"Confirm it if you want me to remember it.", pending.Normalized, pending.Confidence*100)
+
+	now := time.Now().Unix()
+	queryStr, args, err := sq.Insert("inbox").
+		Columns("agent_id", "thread_id", "message", "requires_response", "created_at", "updated_at").
+		Values(turn.AgentID, turn.ThreadID, message, true, now, now).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build inbox insert: %w", err)
+	}
+	res, err := j.db.ExecContext(ctx, queryStr, args...)
+	if err != nil {
+		return fmt.Errorf("add fact to inbox: %w", err)
+	}
+	inboxID, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("get inbox item id: %w", err)
+	}
+	return j.store.AddPendingFact(ctx, inboxID, turn.AgentID, turn.ThreadID, pending)
+}
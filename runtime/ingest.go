@@ -0,0 +1,71 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aschepis/backscratcher/staff/ingest"
+	"github.com/rs/zerolog"
+)
+
+// DocIngestJob keeps the doc_ref index in step with the configured document directories.
+// It rescans them every interval; unchanged files cost only a hash.
+type DocIngestJob struct {
+	ingester *ingest.Ingester
+	interval time.Duration
+	logger   zerolog.Logger
+}
+
+// NewDocIngestJob creates a job that runs the ingester every interval.
+func NewDocIngestJob(ingester *ingest.Ingester, interval time.Duration, logger zerolog.Logger) (*DocIngestJob, error) {
+	if ingester == nil {
+		return nil, fmt.Errorf("ingester cannot be nil")
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("interval must be positive")
+	}
+	return &DocIngestJob{
+		ingester: ingester,
+		interval: interval,
+		logger:   logger.With().Str("component", "doc_ingest").Logger(),
+	}, nil
+}
+
+// Start runs the job immediately and then every interval until ctx is cancelled.
+func (j *DocIngestJob) Start(ctx context.Context) {
+	j.logger.Info().Dur("interval", j.interval).Msg("Starting document ingestion job")
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.run(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			j.logger.Info().Msg("Document ingestion job stopped: context cancelled")
+			return
+		case <-ticker.C:
+			j.run(ctx)
+		}
+	}
+}
+
+// run ingests once, logging rather than returning failures so the job keeps going.
+func (j *DocIngestJob) run(ctx context.Context) {
+	result, err := j.ingester.Run(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			j.logger.Error().Err(err).Msg("Document ingestion failed")
+		}
+		return
+	}
+	if result.Indexed > 0 || result.Removed > 0 || result.Failed > 0 {
+		j.logger.Info().
+			Int("indexed", result.Indexed).
+			Int("unchanged", result.Unchanged).
+			Int("removed", result.Removed).
+			Int("failed", result.Failed).
+			Msg("Ingested documents")
+	}
+}
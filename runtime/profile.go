@@ -0,0 +1,66 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aschepis/backscratcher/staff/memory"
+	"github.com/rs/zerolog"
+)
+
+// ProfileJob periodically rebuilds the user profile from the agents' personal memories,
+// so every agent that includes the profile starts from the same picture of the user.
+type ProfileJob struct {
+	store    *memory.Store
+	interval time.Duration
+	logger   zerolog.Logger
+}
+
+// NewProfileJob creates a profile job that runs every interval.
+func NewProfileJob(store *memory.Store, interval time.Duration, logger zerolog.Logger) (*ProfileJob, error) {
+	if store == nil {
+		return nil, fmt.Errorf("store cannot be nil")
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("interval must be positive")
+	}
+	return &ProfileJob{
+		store:    store,
+		interval: interval,
+		logger:   logger.With().Str("component", "memory_profile").Logger(),
+	}, nil
+}
+
+// Start runs the job immediately and then every interval until ctx is cancelled.
+func (j *ProfileJob) Start(ctx context.Context) {
+	j.logger.Info().Dur("interval", j.interval).Msg("Starting user profile job")
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.run(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			j.logger.Info().Msg("User profile job stopped: context cancelled")
+			return
+		case <-ticker.C:
+			j.run(ctx)
+		}
+	}
+}
+
+// run rebuilds the profile once, logging rather than returning failures so the job keeps going.
+func (j *ProfileJob) run(ctx context.Context) {
+	profile, changed, err := j.store.SynthesizeProfile(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			j.logger.Error().Err(err).Msg("User profile synthesis failed")
+		}
+		return
+	}
+	if changed {
+		j.logger.Info().Int64("id", profile.ID).Msg("Updated user profile")
+	}
+}
@@ -0,0 +1,156 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aschepis/backscratcher/staff/memory"
+	"github.com/rs/zerolog"
+)
+
+// DefaultReembedBatchSize is how many memories are embedded per request by default.
+const DefaultReembedBatchSize = 32
+
+// ReembedProgress reports the state of a ReembedJob.
+type ReembedProgress struct {
+	Running    bool
+	Total      int // Stale items when the current or last pass started
+	Done       int // Items re-embedded so far in that pass
+	StartedAt  time.Time
+	FinishedAt time.Time // Zero while running
+	Err        error     // Why the last pass stopped early, if it did
+}
+
+// ReembedJob re-embeds memories whose vectors came from a different embedder, so that
+// vector search never compares vectors from two models. It runs once at startup and
+// again whenever triggered. Progress lives in the database: re-embedded items are no
+// longer stale, so a pass interrupted by a restart picks up where it left off.
+type ReembedJob struct {
+	store     *memory.Store
+	batchSize int
+	logger    zerolog.Logger
+	trigger   chan struct{}
+
+	mu       sync.Mutex
+	progress ReembedProgress
+}
+
+// NewReembedJob creates a re-embedding job that embeds batchSize memories per request.
+func NewReembedJob(store *memory.Store, batchSize int, logger zerolog.Logger) (*ReembedJob, error) {
+	if store == nil {
+		return nil, fmt.Errorf("store cannot be nil")
+	}
+	if batchSize <= 0 {
+		batchSize = DefaultReembedBatchSize
+	}
+	return &ReembedJob{
+		store:     store,
+		batchSize: batchSize,
+		logger:    logger.With().Str("component", "memory_reembed").Logger(),
+		trigger:   make(chan struct{}, 1),
+	}, nil
+}
+
+// Start runs a pass immediately and then once per Trigger until ctx is cancelled.
+func (j *ReembedJob) Start(ctx context.Context) {
+	j.logger.Info().Int("batch_size", j.batchSize).Msg("Starting memory re-embedding job")
+
+	j.run(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			j.logger.Info().Msg("Memory re-embedding job stopped: context cancelled")
+			return
+		case <-j.trigger:
+			j.run(ctx)
+		}
+	}
+}
+
+// Trigger requests a pass. It returns false if a pass is already running or requested.
+func (j *ReembedJob) Trigger() bool {
+	j.mu.Lock()
+	running := j.progress.Running
+	j.mu.Unlock()
+	if running {
+		return false
+	}
+	select {
+	case j.trigger <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Progress returns the state of the current or last pass.
+func (j *ReembedJob) Progress() ReembedProgress {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.progress
+}
+
+// run re-embeds every stale memory in batches, logging rather than returning failures.
+func (j *ReembedJob) run(ctx context.Context) {
+	j.setProgress(func(p *ReembedProgress) {
+		*p = ReembedProgress{Running: true, StartedAt: time.Now()}
+	})
+
+	total, err := j.store.StaleEmbeddingCount(ctx)
+	if err != nil {
+		j.finish(err)
+		return
+	}
+	j.setProgress(func(p *ReembedProgress) { p.Total = total })
+	if total == 0 {
+		j.finish(nil)
+		return
+	}
+	j.logger.Info().Int("stale", total).Msg("Re-embedding memories from a different embedder")
+
+	var afterID int64
+	for {
+		result, err := j.store.ReembedBatch(ctx, afterID, j.batchSize)
+		if err != nil {
+			j.finish(err)
+			return
+		}
+		afterID = result.LastID
+		j.setProgress(func(p *ReembedProgress) { p.Done += result.Reembedded })
+		if result.Done {
+			break
+		}
+		if ctx.Err() != nil {
+			j.finish(ctx.Err())
+			return
+		}
+	}
+	j.finish(nil)
+}
+
+// setProgress updates the progress under the lock.
+func (j *ReembedJob) setProgress(update func(*ReembedProgress)) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	update(&j.progress)
+}
+
+// finish marks the current pass as stopped, with the error that stopped it early.
+func (j *ReembedJob) finish(err error) {
+	j.mu.Lock()
+	j.progress.Running = false
+	j.progress.FinishedAt = time.Now()
+	j.progress.Err = err
+	p := j.progress
+	j.mu.Unlock()
+
+	switch {
+	case err != nil && !errors.Is(err, context.Canceled):
+		j.logger.Error().Err(err).Int("done", p.Done).Int("total", p.Total).Msg("Memory re-embedding failed")
+	case err == nil && p.Total > 0:
+		j.logger.Info().Int("done", p.Done).Int("total", p.Total).Msg("Memory re-embedding finished")
+	}
+}
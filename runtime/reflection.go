@@ -0,0 +1,101 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aschepis/backscratcher/staff/memory"
+	"github.com/rs/zerolog"
+)
+
+// reflectionCheckInterval is how often the reflection scheduler looks for agents that are due.
+const reflectionCheckInterval = time.Minute
+
+// ReflectionScheduler periodically summarizes each agent's recent episodes, across all of
+// its threads, into durable facts. Every agent reflects on its own cadence and lookback.
+// Summarized episodes are marked in the store, so restarts and overlapping lookbacks
+// never summarize an episode twice.
+type ReflectionScheduler struct {
+	router   *memory.MemoryRouter
+	policies map[string]memory.ReflectionPolicy
+	logger   zerolog.Logger
+
+	lastRun map[string]time.Time
+}
+
+// NewReflectionScheduler creates a scheduler that reflects for each agent in policies.
+func NewReflectionScheduler(
+	router *memory.MemoryRouter,
+	policies map[string]memory.ReflectionPolicy,
+	logger zerolog.Logger,
+) (*ReflectionScheduler, error) {
+	if router == nil {
+		return nil, fmt.Errorf("router cannot be nil")
+	}
+	for agentID, p := range policies {
+		if p.Interval <= 0 {
+			return nil, fmt.Errorf("reflection interval for agent %s must be positive", agentID)
+		}
+	}
+	return &ReflectionScheduler{
+		router:   router,
+		policies: policies,
+		logger:   logger.With().Str("component", "memory_reflection").Logger(),
+		lastRun:  make(map[string]time.Time),
+	}, nil
+}
+
+// Start reflects for every agent immediately, and then whenever an agent's interval has
+// passed, until ctx is cancelled.
+func (s *ReflectionScheduler) Start(ctx context.Context) {
+	s.logger.Info().Int("agents", len(s.policies)).Msg("Starting memory reflection scheduler")
+
+	ticker := time.NewTicker(reflectionCheckInterval)
+	defer ticker.Stop()
+
+	s.runDue(ctx, time.Now())
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info().Msg("Memory reflection scheduler stopped: context cancelled")
+			return
+		case now := <-ticker.C:
+			s.runDue(ctx, now)
+		}
+	}
+}
+
+// runDue reflects for each agent whose interval has passed since its last reflection.
+func (s *ReflectionScheduler) runDue(ctx context.Context, now time.Time) {
+	agentIDs := make([]string, 0, len(s.policies))
+	for agentID := range s.policies {
+		agentIDs = append(agentIDs, agentID)
+	}
+	sort.Strings(agentIDs)
+
+	for _, agentID := range agentIDs {
+		if ctx.Err() != nil {
+			return
+		}
+		policy := s.policies[agentID]
+		if last, ok := s.lastRun[agentID]; ok && now.Sub(last) < policy.Interval {
+			continue
+		}
+		s.lastRun[agentID] = now
+
+		result, err := s.router.ReflectAgent(ctx, agentID, policy)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.logger.Error().Err(err).Str("agent_id", agentID).Msg("Memory reflection failed")
+			}
+			continue
+		}
+		if result.Fact != nil {
+			s.logger.Info().Str("agent_id", agentID).Int("episodes", result.Episodes).
+				Int64("fact_id", result.Fact.ID).Str("scope", string(result.Fact.Scope)).
+				Msg("Reflected on episodes")
+		}
+	}
+}
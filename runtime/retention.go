@@ -0,0 +1,70 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aschepis/backscratcher/staff/memory"
+	"github.com/rs/zerolog"
+)
+
+// RetentionJob periodically expires memories and decays their importance according to
+// the memory store's retention policies.
+type RetentionJob struct {
+	store    *memory.Store
+	interval time.Duration
+	logger   zerolog.Logger
+}
+
+// NewRetentionJob creates a retention job that runs every interval.
+func NewRetentionJob(store *memory.Store, interval time.Duration, logger zerolog.Logger) (*RetentionJob, error) {
+	if store == nil {
+		return nil, fmt.Errorf("store cannot be nil")
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("interval must be positive")
+	}
+	return &RetentionJob{
+		store:    store,
+		interval: interval,
+		logger:   logger.With().Str("component", "memory_retention").Logger(),
+	}, nil
+}
+
+// Start runs the job immediately and then every interval until ctx is cancelled.
+func (j *RetentionJob) Start(ctx context.Context) {
+	j.logger.Info().Dur("interval", j.interval).Msg("Starting memory retention job")
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.run(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			j.logger.Info().Msg("Memory retention job stopped: context cancelled")
+			return
+		case <-ticker.C:
+			j.run(ctx)
+		}
+	}
+}
+
+// run applies retention once, logging rather than returning failures so the job keeps going.
+func (j *RetentionJob) run(ctx context.Context) {
+	result, err := j.store.ApplyRetention(ctx, time.Now())
+	if err != nil {
+		if ctx.Err() == nil {
+			j.logger.Error().Err(err).Msg("Memory retention failed")
+		}
+		return
+	}
+	if result.Archived > 0 || result.Deleted > 0 {
+		j.logger.Info().
+			Int("archived", result.Archived).
+			Int("deleted", result.Deleted).
+			Int("decayed", result.Decayed).
+			Msg("Expired memories")
+	}
+}
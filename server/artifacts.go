@@ -0,0 +1,189 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aschepis/backscratcher/staff/api/staffpb"
+	"github.com/aschepis/backscratcher/staff/memory"
+	"github.com/samber/lo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ListArtifacts lists artifacts, most recently updated first.
+func (s *Server) ListArtifacts(ctx context.Context, req *staffpb.ListArtifactsRequest) (*staffpb.ListArtifactsResponse, error) {
+	filter := memory.ArtifactFilter{
+		Query: req.Query,
+		Limit: int(req.Limit),
+	}
+	if req.AgentId != "" {
+		filter.AgentID = &req.AgentId
+	}
+
+	artifacts, err := s.memoryStore.ListArtifacts(ctx, filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list artifacts: %v", err)
+	}
+
+	return &staffpb.ListArtifactsResponse{
+		Artifacts: lo.Map(artifacts, func(a *memory.Artifact, _ int) *staffpb.Artifact {
+			return convertArtifactToProto(a)
+		}),
+	}, nil
+}
+
+// GetArtifact returns an artifact, or one of its earlier versions.
+func (s *Server) GetArtifact(ctx context.Context, req *staffpb.GetArtifactRequest) (*staffpb.Artifact, error) {
+	if req.Id == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	a, err := s.memoryStore.GetArtifact(ctx, req.Id)
+	if err != nil {
+		return nil, artifactError("get", err)
+	}
+	if req.Version == 0 || int(req.Version) == a.Version {
+		return convertArtifactToProto(a), nil
+	}
+
+	v, err := s.memoryStore.GetArtifactVersion(ctx, req.Id, int(req.Version))
+	if err != nil {
+		return nil, artifactError("get", err)
+	}
+	return convertArtifactVersionToProto(a, v), nil
+}
+
+// CreateArtifact creates an artifact.
+func (s *Server) CreateArtifact(ctx context.Context, req *staffpb.CreateArtifactRequest) (*staffpb.Artifact, error) {
+	if req.Body == "" {
+		return nil, status.Error(codes.InvalidArgument, "body is required")
+	}
+
+	scope := memory.ScopeGlobal
+	if req.Scope != "" {
+		scope = memory.Scope(req.Scope)
+	}
+	var agentID *string
+	if req.AgentId != "" {
+		agentID = &req.AgentId
+	}
+	if scope == memory.ScopeAgent && agentID == nil {
+		return nil, status.Error(codes.InvalidArgument, "agent_id is required when scope is 'agent'")
+	}
+
+	var metadata map[string]interface{}
+	if req.Metadata != nil {
+		metadata = req.Metadata.AsMap()
+	}
+
+	a, err := s.memoryStore.CreateArtifact(ctx, scope, agentID, nil, req.Title, req.Body, metadata)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create artifact: %v", err)
+	}
+	return convertArtifactToProto(&a), nil
+}
+
+// UpdateArtifact revises an artifact on behalf of the user.
+func (s *Server) UpdateArtifact(ctx context.Context, req *staffpb.UpdateArtifactRequest) (*staffpb.Artifact, error) {
+	if req.Id == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if req.Body != nil && *req.Body == "" {
+		return nil, status.Error(codes.InvalidArgument, "body cannot be empty")
+	}
+
+	upd := memory.ArtifactUpdate{
+		Title: req.Title,
+		Body:  req.Body,
+	}
+	if req.Metadata != nil {
+		upd.Metadata = req.Metadata.AsMap()
+	}
+
+	a, err := s.memoryStore.UpdateArtifact(ctx, req.Id, nil, upd)
+	if err != nil {
+		return nil, artifactError("update", err)
+	}
+	return convertArtifactToProto(a), nil
+}
+
+// ListArtifactVersions lists every version of an artifact, oldest first.
+func (s *Server) ListArtifactVersions(ctx context.Context, req *staffpb.ListArtifactVersionsRequest) (*staffpb.ListArtifactVersionsResponse, error) {
+	if req.Id == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	a, err := s.memoryStore.GetArtifact(ctx, req.Id)
+	if err != nil {
+		return nil, artifactError("get", err)
+	}
+	versions, err := s.memoryStore.ArtifactVersions(ctx, req.Id)
+	if err != nil {
+		return nil, artifactError("list versions of", err)
+	}
+
+	return &staffpb.ListArtifactVersionsResponse{
+		Versions: lo.Map(versions, func(v memory.ArtifactVersion, _ int) *staffpb.Artifact {
+			return convertArtifactVersionToProto(a, &v)
+		}),
+	}, nil
+}
+
+// artifactError converts an artifact store error to a gRPC status.
+func artifactError(op string, err error) error {
+	if errors.Is(err, memory.ErrArtifactNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Errorf(codes.Internal, "failed to %s artifact: %v", op, err)
+}
+
+// convertArtifactToProto converts a memory.Artifact to protobuf format.
+func convertArtifactToProto(a *memory.Artifact) *staffpb.Artifact {
+	pb := &staffpb.Artifact{
+		Id:        a.ID,
+		Scope:     string(a.Scope),
+		Title:     a.Title,
+		Body:      a.Body,
+		Metadata:  metadataToProto(a.Metadata),
+		Version:   int32(a.Version), //nolint:gosec // Version counts stay small
+		CreatedAt: timestamppb.New(a.CreatedAt),
+		UpdatedAt: timestamppb.New(a.UpdatedAt),
+	}
+	if a.AgentID != nil {
+		pb.AgentId = *a.AgentID
+	}
+	if a.ThreadID != nil {
+		pb.ThreadId = *a.ThreadID
+	}
+	return pb
+}
+
+// convertArtifactVersionToProto converts a version of artifact a to protobuf format.
+func convertArtifactVersionToProto(a *memory.Artifact, v *memory.ArtifactVersion) *staffpb.Artifact {
+	pb := convertArtifactToProto(a)
+	pb.AgentId = ""
+	if v.AgentID != nil {
+		pb.AgentId = *v.AgentID
+	}
+	pb.Title = v.Title
+	pb.Body = v.Body
+	pb.Metadata = metadataToProto(v.Metadata)
+	pb.Version = int32(v.Version) //nolint:gosec // Version counts stay small
+	pb.UpdatedAt = timestamppb.New(v.CreatedAt)
+	return pb
+}
+
+// metadataToProto converts metadata to a structpb.Struct, dropping values it can't encode.
+func metadataToProto(metadata map[string]interface{}) *structpb.Struct {
+	if metadata == nil {
+		return nil
+	}
+	st, err := structpb.NewStruct(metadata)
+	if err != nil {
+		return nil
+	}
+	return st
+}
@@ -2,12 +2,15 @@ package server
 
 import (
 	"context"
+	"encoding/base64"
+	"fmt"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/samber/lo"
 
+	"github.com/aschepis/backscratcher/staff/agent"
 	"github.com/aschepis/backscratcher/staff/api/staffpb"
 	"github.com/aschepis/backscratcher/staff/llm"
 	"github.com/aschepis/backscratcher/staff/ui"
@@ -31,8 +34,17 @@ func (s *Server) Chat(req *staffpb.ChatRequest, stream staffpb.ChatService_ChatS
 		Str("agent_id", req.AgentId).
 		Str("thread_id", req.ThreadId).
 		Int("message_len", len(req.Message)).
+		Int("attachments", len(req.Attachments)).
 		Msg("Chat request received")
 
+	if len(req.Attachments) > 0 {
+		attachments, err := fromProtoAttachments(req.Attachments)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		ctx = agent.WithAttachments(ctx, attachments)
+	}
+
 	// Load conversation history from database
 	history, err := s.chatService.LoadThread(ctx, req.AgentId, req.ThreadId)
 	if err != nil {
@@ -49,6 +61,33 @@ func (s *Server) Chat(req *staffpb.ChatRequest, stream staffpb.ChatService_ChatS
 		})
 	}
 
+	// Reasoning callback sends extended thinking deltas as separate events
+	ctx = agent.WithReasoningCallback(ctx, func(text string) {
+		if err := stream.Send(&staffpb.ChatEvent{
+			Event: &staffpb.ChatEvent_ReasoningDelta{
+				ReasoningDelta: &staffpb.ReasoningDelta{Text: text},
+			},
+		}); err != nil {
+			s.logger.Warn().Err(err).Msg("Failed to send reasoning delta")
+		}
+	})
+
+	// Turn event callback sends tool activity and per-call usage. The stop reason of the
+	// last LLM call is the stop reason of the turn.
+	stopReason := "end_turn"
+	ctx = agent.WithTurnEventCallback(ctx, func(event agent.TurnEvent) {
+		if event.Type == agent.TurnEventUsage && event.StopReason != "" {
+			stopReason = event.StopReason
+		}
+		chatEvent := toProtoTurnEvent(event)
+		if chatEvent == nil {
+			return
+		}
+		if err := stream.Send(chatEvent); err != nil {
+			s.logger.Warn().Err(err).Str("event", string(event.Type)).Msg("Failed to send turn event")
+		}
+	})
+
 	// Execute the agent with streaming
 	response, err := s.chatService.SendMessageStream(ctx, req.AgentId, req.ThreadId, req.Message, history, streamCallback)
 	if err != nil {
@@ -70,7 +109,7 @@ func (s *Server) Chat(req *staffpb.ChatRequest, stream staffpb.ChatService_ChatS
 		Event: &staffpb.ChatEvent_Complete{
 			Complete: &staffpb.ChatComplete{
 				FullResponse: response,
-				StopReason:   "end_turn",
+				StopReason:   stopReason,
 			},
 		},
 	}); err != nil {
@@ -180,6 +219,23 @@ func (s *Server) CompressContext(ctx context.Context, req *staffpb.ContextReques
 	}, nil
 }
 
+// PinLastUserMessage pins the most recent user message so it survives context compression.
+func (s *Server) PinLastUserMessage(ctx context.Context, req *staffpb.ContextRequest) (*staffpb.PinMessageResponse, error) {
+	if req.AgentId == "" {
+		return nil, status.Error(codes.InvalidArgument, "agent_id is required")
+	}
+	if req.ThreadId == "" {
+		return nil, status.Error(codes.InvalidArgument, "thread_id is required")
+	}
+
+	content, err := s.chatService.PinLastUserMessage(ctx, req.AgentId, req.ThreadId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to pin message: %v", err)
+	}
+
+	return &staffpb.PinMessageResponse{Content: content}, nil
+}
+
 // convertMessageToProto converts an llm.Message to protobuf format.
 func convertMessageToProto(m llm.Message, timestamp int64) *staffpb.Message {
 	pb := &staffpb.Message{
@@ -195,6 +251,10 @@ func convertMessageToProto(m llm.Message, timestamp int64) *staffpb.Message {
 			switch block.Type {
 			case llm.ContentBlockTypeText:
 				textContent += block.Text
+			case llm.ContentBlockTypeReasoning:
+				if block.Reasoning != nil {
+					pb.Reasoning += block.Reasoning.Text
+				}
 			case llm.ContentBlockTypeToolUse:
 				if block.ToolUse != nil {
 					pb.ToolId = block.ToolUse.ID
@@ -212,3 +272,73 @@ func convertMessageToProto(m llm.Message, timestamp int64) *staffpb.Message {
 
 	return pb
 }
+
+// fromProtoAttachments converts chat request attachments to llm.MediaBlocks.
+func fromProtoAttachments(attachments []*staffpb.Attachment) ([]llm.MediaBlock, error) {
+	result := make([]llm.MediaBlock, 0, len(attachments))
+	for _, a := range attachments {
+		if a.MediaType == "" {
+			return nil, fmt.Errorf("attachment %q is missing media_type", a.Name)
+		}
+		if len(a.Data) > llm.MaxMediaBytes {
+			return nil, fmt.Errorf("attachment %q exceeds %d bytes", a.Name, llm.MaxMediaBytes)
+		}
+		result = append(result, llm.MediaBlock{
+			MediaType: a.MediaType,
+			Data:      base64.StdEncoding.EncodeToString(a.Data),
+			Name:      a.Name,
+		})
+	}
+	return result, nil
+}
+
+// toProtoTurnEvent converts a turn event to a chat event. Returns nil for events
+// with no protobuf representation.
+func toProtoTurnEvent(event agent.TurnEvent) *staffpb.ChatEvent {
+	switch event.Type {
+	case agent.TurnEventToolUse:
+		return &staffpb.ChatEvent{
+			Event: &staffpb.ChatEvent_ToolUse{
+				ToolUse: &staffpb.ToolUse{
+					ToolId:    event.ToolID,
+					ToolName:  event.ToolName,
+					InputJson: event.ToolInput,
+				},
+			},
+		}
+	case agent.TurnEventToolInput:
+		return &staffpb.ChatEvent{
+			Event: &staffpb.ChatEvent_ToolInputDelta{
+				ToolInputDelta: &staffpb.ToolInputDelta{
+					ToolId:      event.ToolID,
+					PartialJson: event.ToolInput,
+				},
+			},
+		}
+	case agent.TurnEventToolResult:
+		return &staffpb.ChatEvent{
+			Event: &staffpb.ChatEvent_ToolResult{
+				ToolResult: &staffpb.ToolResult{
+					ToolId:   event.ToolID,
+					ToolName: event.ToolName,
+					Result:   event.Result,
+					IsError:  event.IsError,
+				},
+			},
+		}
+	case agent.TurnEventUsage:
+		usage := &staffpb.Usage{
+			Iteration:  int32(event.Iteration), //nolint:gosec // Bounded by the tool loop's iteration limit
+			StopReason: event.StopReason,
+		}
+		if event.Usage != nil {
+			usage.InputTokens = event.Usage.InputTokens
+			usage.OutputTokens = event.Usage.OutputTokens
+			usage.CacheCreationInputTokens = event.Usage.CacheCreationInputTokens
+			usage.CacheReadInputTokens = event.Usage.CacheReadInputTokens
+		}
+		return &staffpb.ChatEvent{Event: &staffpb.ChatEvent_Usage{Usage: usage}}
+	default:
+		return nil
+	}
+}
@@ -2,8 +2,10 @@ package server
 
 import (
 	"context"
+	"errors"
 
 	"github.com/aschepis/backscratcher/staff/api/staffpb"
+	"github.com/aschepis/backscratcher/staff/memory"
 	"github.com/aschepis/backscratcher/staff/ui"
 	"github.com/samber/lo"
 	"google.golang.org/grpc/codes"
@@ -38,6 +40,34 @@ func (s *Server) Archive(ctx context.Context, req *staffpb.ArchiveRequest) (*sta
 	return &staffpb.ArchiveResponse{Success: true}, nil
 }
 
+// ConfirmFact stores or declines the fact an inbox item asks about, then archives the item.
+func (s *Server) ConfirmFact(ctx context.Context, req *staffpb.ConfirmFactRequest) (*staffpb.ConfirmFactResponse, error) {
+	if req.InboxId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "inbox_id is required")
+	}
+
+	resp := &staffpb.ConfirmFactResponse{}
+	var err error
+	if req.Accept {
+		var item memory.MemoryItem
+		item, err = s.memoryStore.ConfirmPendingFact(ctx, req.InboxId)
+		resp.MemoryId = item.ID
+	} else {
+		err = s.memoryStore.DiscardPendingFact(ctx, req.InboxId)
+	}
+	if errors.Is(err, memory.ErrNoPendingFact) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to confirm fact: %v", err)
+	}
+
+	if err := s.chatService.ArchiveInboxItem(ctx, req.InboxId); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to archive item: %v", err)
+	}
+	return resp, nil
+}
+
 // Watch streams new inbox items as they arrive.
 func (s *Server) Watch(req *staffpb.WatchInboxRequest, stream staffpb.InboxService_WatchServer) error {
 	// Subscribe to inbox notifications
@@ -74,6 +104,7 @@ func convertInboxItemToProto(item *ui.InboxItem) *staffpb.InboxItem {
 		Response:         item.Response,
 		CreatedAt:        timestamppb.New(item.CreatedAt),
 		UpdatedAt:        timestamppb.New(item.UpdatedAt),
+		PendingFact:      item.PendingFact,
 	}
 
 	if item.ResponseAt != nil {
@@ -2,8 +2,11 @@ package server
 
 import (
 	"context"
+	"errors"
+	"strings"
 
 	"github.com/aschepis/backscratcher/staff/api/staffpb"
+	"github.com/aschepis/backscratcher/staff/conversations"
 	"github.com/aschepis/backscratcher/staff/memory"
 	"github.com/samber/lo"
 	"google.golang.org/grpc/codes"
@@ -25,11 +28,18 @@ func (s *Server) Search(ctx context.Context, req *staffpb.SearchMemoryRequest) (
 		IncludeGlobal: req.Scope == "global",
 	}
 
-	if req.Scope == "agent" {
+	switch req.Scope {
+	case "agent":
 		if req.AgentId == "" {
 			return nil, status.Error(codes.InvalidArgument, "agent_id is required when scope is 'agent'")
 		}
 		query.AgentID = &req.AgentId
+		query.Groups = s.memoryRouter.Groups(req.AgentId)
+	case "group":
+		if req.Group == "" {
+			return nil, status.Error(codes.InvalidArgument, "group is required when scope is 'group'")
+		}
+		query.Groups = []string{req.Group}
 	}
 
 	// Convert types
@@ -47,7 +57,16 @@ func (s *Server) Search(ctx context.Context, req *staffpb.SearchMemoryRequest) (
 
 	// Convert results to protobuf
 	pbItems := lo.Map(results, func(result memory.SearchResult, _ int) *staffpb.MemoryItem {
-		return convertMemoryItemToProto(result.Item)
+		pb := convertMemoryItemToProto(result.Item)
+		pb.Score = result.Score
+		if result.Match != nil {
+			pb.Match = &staffpb.MemoryMatch{
+				Bm25:      result.Match.BM25,
+				Snippet:   result.Match.Snippet,
+				Highlight: result.Match.Highlight,
+			}
+		}
+		return pb
 	})
 
 	return &staffpb.SearchMemoryResponse{Items: pbItems}, nil
@@ -75,10 +94,19 @@ func (s *Server) Store(ctx context.Context, req *staffpb.StoreMemoryRequest) (*s
 	var err error
 
 	// Route based on scope and type
-	if req.Scope == "global" {
+	switch req.Scope {
+	case "global":
 		// Store as global fact
 		item, err = s.memoryRouter.AddGlobalFact(ctx, req.Content, metadata)
-	} else {
+	case "group":
+		if req.Group == "" {
+			return nil, status.Error(codes.InvalidArgument, "group is required when scope is 'group'")
+		}
+		item, err = s.memoryRouter.AddGroupFact(ctx, req.AgentId, req.Group, req.Content, metadata)
+		if errors.Is(err, memory.ErrNotGroupMember) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+	default:
 		// Store as agent-specific memory
 		importance := req.Importance
 		if importance == 0 {
@@ -111,6 +139,244 @@ func (s *Server) Store(ctx context.Context, req *staffpb.StoreMemoryRequest) (*s
 	return &staffpb.StoreMemoryResponse{Id: item.ID}, nil
 }
 
+// Get returns a memory item by ID.
+func (s *Server) Get(ctx context.Context, req *staffpb.GetMemoryRequest) (*staffpb.MemoryItem, error) {
+	if req.Id == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	item, err := s.memoryStore.GetMemory(ctx, req.Id)
+	if err != nil {
+		return nil, memoryError("get", err)
+	}
+
+	return convertMemoryItemToProto(item), nil
+}
+
+// Update edits a memory item. Only fields set in the request are changed.
+func (s *Server) Update(ctx context.Context, req *staffpb.UpdateMemoryRequest) (*staffpb.MemoryItem, error) {
+	if req.Id == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	upd := memory.MemoryUpdate{
+		Content:    req.Content,
+		Importance: req.Importance,
+		MemoryType: req.MemoryType,
+	}
+	if req.Content != nil && strings.TrimSpace(*req.Content) == "" {
+		return nil, status.Error(codes.InvalidArgument, "content cannot be empty")
+	}
+	if len(req.Tags) > 0 {
+		upd.Tags = req.Tags
+	} else if req.ClearTags {
+		upd.Tags = []string{}
+	}
+	if req.Metadata != nil {
+		upd.Metadata = req.Metadata.AsMap()
+	}
+
+	item, err := s.memoryStore.UpdateMemory(ctx, req.Id, upd)
+	if err != nil {
+		return nil, memoryError("update", err)
+	}
+
+	return convertMemoryItemToProto(item), nil
+}
+
+// Delete removes a memory item.
+func (s *Server) Delete(ctx context.Context, req *staffpb.DeleteMemoryRequest) (*staffpb.DeleteMemoryResponse, error) {
+	if req.Id == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	if err := s.memoryStore.DeleteMemory(ctx, req.Id); err != nil {
+		return nil, memoryError("delete", err)
+	}
+
+	return &staffpb.DeleteMemoryResponse{Success: true}, nil
+}
+
+// defaultProvenanceContext is how many messages GetProvenance returns on either side of
+// the source message by default.
+const defaultProvenanceContext = 5
+
+// GetProvenance returns where a memory came from, with the conversation around the message
+// that led to it.
+func (s *Server) GetProvenance(ctx context.Context, req *staffpb.GetMemoryProvenanceRequest) (*staffpb.MemoryProvenance, error) {
+	if req.Id == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	item, err := s.memoryStore.GetMemory(ctx, req.Id)
+	if err != nil {
+		return nil, memoryError("get", err)
+	}
+
+	pb := &staffpb.MemoryProvenance{MemoryId: item.ID}
+	p := item.Provenance
+	if p == nil {
+		return pb, nil
+	}
+	pb.AgentId = p.AgentID
+	pb.ThreadId = p.ThreadID
+	pb.MessageId = p.MessageID
+	pb.ToolId = p.ToolID
+	pb.Source = p.Source
+	if p.MessageID == 0 || p.ThreadID == "" {
+		return pb, nil
+	}
+
+	n := int(req.ContextMessages)
+	if n <= 0 {
+		n = defaultProvenanceContext
+	}
+	messages, err := conversations.NewStore(s.db).MessagesAround(ctx, p.AgentID, p.ThreadID, p.MessageID, n)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to load source conversation: %v", err)
+	}
+	for _, m := range messages {
+		pb.Messages = append(pb.Messages, &staffpb.SourceMessage{
+			Id:        m.ID,
+			Role:      m.Role,
+			Content:   m.Content,
+			ToolName:  m.ToolName,
+			ToolId:    m.ToolID,
+			Timestamp: timestamppb.New(m.CreatedAt),
+			IsSource:  m.ID == p.MessageID || (p.ToolID != "" && m.ToolID == p.ToolID),
+		})
+	}
+	return pb, nil
+}
+
+// GetProfile returns the user profile.
+func (s *Server) GetProfile(ctx context.Context, req *staffpb.GetProfileRequest) (*staffpb.UserProfile, error) {
+	profile, err := s.memoryStore.Profile(ctx)
+	if err != nil {
+		return nil, profileError("get", err)
+	}
+	return convertProfileToProto(profile), nil
+}
+
+// UpdateProfile replaces the user profile.
+func (s *Server) UpdateProfile(ctx context.Context, req *staffpb.UpdateProfileRequest) (*staffpb.UserProfile, error) {
+	if strings.TrimSpace(req.Content) == "" {
+		return nil, status.Error(codes.InvalidArgument, "content is required")
+	}
+	profile, err := s.memoryStore.SetProfile(ctx, req.Content)
+	if err != nil {
+		return nil, profileError("update", err)
+	}
+	return convertProfileToProto(profile), nil
+}
+
+// profileError converts a profile error to a gRPC status.
+func profileError(op string, err error) error {
+	if errors.Is(err, memory.ErrNoProfile) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Errorf(codes.Internal, "failed to %s profile: %v", op, err)
+}
+
+// convertProfileToProto converts the memory item holding the user profile to protobuf format.
+func convertProfileToProto(item *memory.MemoryItem) *staffpb.UserProfile {
+	pb := &staffpb.UserProfile{
+		Id:        item.ID,
+		Content:   item.Content,
+		UpdatedAt: timestamppb.New(item.UpdatedAt),
+	}
+	if by, ok := item.Metadata["updated_by"].(string); ok {
+		pb.UpdatedBy = by
+	}
+	return pb
+}
+
+// defaultEntityMemories is how many memories GetEntity returns by default.
+const defaultEntityMemories = 20
+
+// GetEntity returns an entity, by ID or name, with the memories that mention it, its
+// relations and the entities connected to it.
+func (s *Server) GetEntity(ctx context.Context, req *staffpb.GetEntityRequest) (*staffpb.Entity, error) {
+	id := req.Id
+	if id == 0 {
+		name := strings.TrimSpace(req.Name)
+		if name == "" {
+			return nil, status.Error(codes.InvalidArgument, "id or name is required")
+		}
+		kind := memory.EntityKind(strings.ToLower(req.Kind))
+		if kind != "" && !memory.ValidEntityKind(kind) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid kind %q", req.Kind)
+		}
+		entities, err := s.memoryStore.FindEntities(ctx, nil, name, kind)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to find entity: %v", err)
+		}
+		switch len(entities) {
+		case 0:
+			return nil, status.Errorf(codes.NotFound, "no entity named %q", name)
+		case 1:
+			id = entities[0].ID
+		default:
+			names := lo.Map(entities, func(e memory.Entity, _ int) string { return e.Name + " (" + string(e.Kind) + ")" })
+			return nil, status.Errorf(codes.InvalidArgument, "%q matches several entities: %s", name, strings.Join(names, ", "))
+		}
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultEntityMemories
+	}
+	ec, err := s.memoryStore.EntityContext(ctx, &memory.SearchQuery{Limit: limit}, []int64{id})
+	if errors.Is(err, memory.ErrEntityNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get entity: %v", err)
+	}
+	return convertEntityToProto(ec), nil
+}
+
+// convertEntityToProto converts what memories say about a single entity to protobuf format.
+func convertEntityToProto(ec *memory.EntityContext) *staffpb.Entity {
+	e := ec.Entities[0]
+	return &staffpb.Entity{
+		Id:        e.ID,
+		Name:      e.Name,
+		Kind:      string(e.Kind),
+		CreatedAt: timestamppb.New(e.CreatedAt),
+		UpdatedAt: timestamppb.New(e.UpdatedAt),
+		Memories: lo.Map(ec.Memories, func(item memory.MemoryItem, _ int) *staffpb.MemoryItem {
+			return convertMemoryItemToProto(&item)
+		}),
+		Relations: lo.Map(ec.Relations, func(r memory.Relation, _ int) *staffpb.EntityRelation {
+			return &staffpb.EntityRelation{
+				SubjectId: r.Subject.ID,
+				Subject:   r.Subject.Name,
+				Predicate: r.Predicate,
+				ObjectId:  r.Object.ID,
+				Object:    r.Object.Name,
+				MemoryId:  r.MemoryID,
+			}
+		}),
+		Related: lo.Map(ec.Related, func(r memory.RelatedEntity, _ int) *staffpb.RelatedEntity {
+			return &staffpb.RelatedEntity{
+				Id:    r.ID,
+				Name:  r.Name,
+				Kind:  string(r.Kind),
+				Links: int32(r.Links),
+			}
+		}),
+	}
+}
+
+// memoryError converts a memory store error to a gRPC status.
+func memoryError(op string, err error) error {
+	if errors.Is(err, memory.ErrMemoryNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Errorf(codes.Internal, "failed to %s memory: %v", op, err)
+}
+
 // Dump writes all memory items to a file.
 func (s *Server) Dump(ctx context.Context, req *staffpb.DumpMemoryRequest) (*staffpb.DumpMemoryResponse, error) {
 	if req.FilePath == "" {
@@ -139,6 +405,46 @@ func (s *Server) Clear(ctx context.Context, req *staffpb.ClearMemoryRequest) (*s
 	}, nil
 }
 
+// Reembed starts a re-embedding pass, unless one is already running.
+func (s *Server) Reembed(ctx context.Context, req *staffpb.ReembedMemoryRequest) (*staffpb.ReembedStatus, error) {
+	if s.reembedJob == nil {
+		return nil, status.Error(codes.Unavailable, "re-embedding is not enabled")
+	}
+	started := s.reembedJob.Trigger()
+	pb := s.reembedStatus()
+	pb.Started = started
+	return pb, nil
+}
+
+// GetReembedStatus reports the progress of the current or last re-embedding pass.
+func (s *Server) GetReembedStatus(ctx context.Context, req *staffpb.GetReembedStatusRequest) (*staffpb.ReembedStatus, error) {
+	if s.reembedJob == nil {
+		return nil, status.Error(codes.Unavailable, "re-embedding is not enabled")
+	}
+	return s.reembedStatus(), nil
+}
+
+// reembedStatus converts the re-embedding job's progress to protobuf format.
+func (s *Server) reembedStatus() *staffpb.ReembedStatus {
+	p := s.reembedJob.Progress()
+	pb := &staffpb.ReembedStatus{
+		Running:  p.Running,
+		Total:    int64(p.Total),
+		Done:     int64(p.Done),
+		Embedder: s.memoryStore.EmbedderName(),
+	}
+	if !p.StartedAt.IsZero() {
+		pb.StartedAt = timestamppb.New(p.StartedAt)
+	}
+	if !p.FinishedAt.IsZero() {
+		pb.FinishedAt = timestamppb.New(p.FinishedAt)
+	}
+	if p.Err != nil {
+		pb.Error = p.Err.Error()
+	}
+	return pb
+}
+
 // convertMemoryItemToProto converts a memory.MemoryItem to protobuf format.
 func convertMemoryItemToProto(item *memory.MemoryItem) *staffpb.MemoryItem {
 	pb := &staffpb.MemoryItem{
@@ -148,6 +454,10 @@ func convertMemoryItemToProto(item *memory.MemoryItem) *staffpb.MemoryItem {
 		Content:    item.Content,
 		Importance: item.Importance,
 		CreatedAt:  timestamppb.New(item.CreatedAt),
+		UpdatedAt:  timestamppb.New(item.UpdatedAt),
+		MemoryType: item.MemoryType,
+		Tags:       item.Tags,
+		Group:      item.Group,
 	}
 
 	// Handle optional AgentID pointer
@@ -11,6 +11,7 @@ import (
 	"github.com/aschepis/backscratcher/staff/agent"
 	"github.com/aschepis/backscratcher/staff/api/staffpb"
 	"github.com/aschepis/backscratcher/staff/memory"
+	"github.com/aschepis/backscratcher/staff/runtime"
 	"github.com/aschepis/backscratcher/staff/ui"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
@@ -23,6 +24,7 @@ type Server struct {
 	staffpb.UnimplementedAgentServiceServer
 	staffpb.UnimplementedInboxServiceServer
 	staffpb.UnimplementedMemoryServiceServer
+	staffpb.UnimplementedArtifactServiceServer
 	staffpb.UnimplementedSystemServiceServer
 
 	grpcServer   *grpc.Server
@@ -31,6 +33,7 @@ type Server struct {
 	memoryRouter *memory.MemoryRouter
 	memoryStore  *memory.Store
 	chatService  ui.ChatService
+	reembedJob   *runtime.ReembedJob
 	logger       zerolog.Logger
 
 	// Server state
@@ -54,6 +57,7 @@ type Server struct {
 type Config struct {
 	SocketPath string
 	Logger     zerolog.Logger
+	ReembedJob *runtime.ReembedJob // Optional; enables MemoryService.Reembed
 }
 
 // New creates a new gRPC server.
@@ -64,6 +68,7 @@ func New(cfg Config, crew *agent.Crew, db *sql.DB, memoryRouter *memory.MemoryRo
 		memoryRouter:  memoryRouter,
 		memoryStore:   memoryStore,
 		chatService:   chatService,
+		reembedJob:    cfg.ReembedJob,
 		logger:        cfg.Logger.With().Str("component", "grpc-server").Logger(),
 		socketPath:    cfg.SocketPath,
 		clients:       make(map[string]struct{}),
@@ -82,6 +87,7 @@ func New(cfg Config, crew *agent.Crew, db *sql.DB, memoryRouter *memory.MemoryRo
 	staffpb.RegisterAgentServiceServer(s.grpcServer, s)
 	staffpb.RegisterInboxServiceServer(s.grpcServer, s)
 	staffpb.RegisterMemoryServiceServer(s.grpcServer, s)
+	staffpb.RegisterArtifactServiceServer(s.grpcServer, s)
 	staffpb.RegisterSystemServiceServer(s.grpcServer, s)
 
 	// Enable reflection for debugging tools like grpcurl
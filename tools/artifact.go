@@ -0,0 +1,183 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aschepis/backscratcher/staff/memory"
+)
+
+// artifactPreviewLen is how much of each body artifact_list returns.
+const artifactPreviewLen = 200
+
+// RegisterArtifactTools registers tools for reading and writing artifacts: durable
+// documents such as reports and handoff notes that agents leave for each other and the user.
+func (r *Registry) RegisterArtifactTools(router *memory.MemoryRouter) {
+	r.logger.Info().Msg("Registering artifact tools in registry")
+
+	r.Register("artifact_create", func(ctx context.Context, agentID string, args json.RawMessage) (any, error) {
+		var payload struct {
+			Title    string                 `json:"title"`
+			Body     string                 `json:"body"`
+			Private  bool                   `json:"private"`
+			Metadata map[string]interface{} `json:"metadata"`
+		}
+		if err := json.Unmarshal(args, &payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal arguments: %w", err)
+		}
+		if strings.TrimSpace(payload.Title) == "" {
+			return nil, fmt.Errorf("title cannot be empty")
+		}
+		if strings.TrimSpace(payload.Body) == "" {
+			return nil, fmt.Errorf("body cannot be empty")
+		}
+
+		var (
+			artifact memory.Artifact
+			err      error
+		)
+		if payload.Private {
+			artifact, err = router.AddAgentArtifact(ctx, agentID, payload.Title, payload.Body, payload.Metadata)
+		} else {
+			artifact, err = router.AddArtifact(ctx, &agentID, payload.Title, payload.Body, payload.Metadata)
+		}
+		if err != nil {
+			r.logger.Error().Str("agentID", agentID).Err(err).Msg("artifact_create failed")
+			return nil, err
+		}
+
+		return map[string]any{
+			"id":      artifact.ID,
+			"title":   artifact.Title,
+			"scope":   artifact.Scope,
+			"version": artifact.Version,
+		}, nil
+	})
+
+	r.Register("artifact_read", func(ctx context.Context, agentID string, args json.RawMessage) (any, error) {
+		var payload struct {
+			ID      int64 `json:"id"`
+			Version int   `json:"version"`
+		}
+		if err := json.Unmarshal(args, &payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal arguments: %w", err)
+		}
+		if payload.ID == 0 {
+			return nil, fmt.Errorf("id is required")
+		}
+
+		artifact, err := router.ReadArtifact(ctx, agentID, payload.ID)
+		if err != nil {
+			return nil, err
+		}
+		if payload.Version == 0 || payload.Version == artifact.Version {
+			return artifactResult(artifact), nil
+		}
+
+		v, err := router.ReadArtifactVersion(ctx, agentID, payload.ID, payload.Version)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{
+			"id":              v.ArtifactID,
+			"title":           v.Title,
+			"body":            v.Body,
+			"metadata":        v.Metadata,
+			"version":         v.Version,
+			"current_version": artifact.Version,
+			"author":          v.AgentID,
+			"created":         v.CreatedAt,
+		}, nil
+	})
+
+	r.Register("artifact_list", func(ctx context.Context, agentID string, args json.RawMessage) (any, error) {
+		var payload struct {
+			Query string `json:"query"`
+			Limit int    `json:"limit"`
+		}
+		if err := json.Unmarshal(args, &payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal arguments: %w", err)
+		}
+		if payload.Limit == 0 {
+			payload.Limit = 20
+		}
+
+		artifacts, err := router.ListArtifacts(ctx, agentID, payload.Query, payload.Limit)
+		if err != nil {
+			r.logger.Error().Str("agentID", agentID).Err(err).Msg("artifact_list failed")
+			return nil, err
+		}
+
+		out := make([]map[string]any, 0, len(artifacts))
+		for _, a := range artifacts {
+			out = append(out, map[string]any{
+				"id":      a.ID,
+				"title":   a.Title,
+				"scope":   a.Scope,
+				"author":  a.AgentID,
+				"version": a.Version,
+				"updated": a.UpdatedAt,
+				"preview": artifactPreview(a.Body),
+			})
+		}
+		return out, nil
+	})
+
+	r.Register("artifact_update", func(ctx context.Context, agentID string, args json.RawMessage) (any, error) {
+		var payload struct {
+			ID       int64                  `json:"id"`
+			Title    *string                `json:"title"`
+			Body     *string                `json:"body"`
+			Metadata map[string]interface{} `json:"metadata"`
+		}
+		if err := json.Unmarshal(args, &payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal arguments: %w", err)
+		}
+		if payload.ID == 0 {
+			return nil, fmt.Errorf("id is required")
+		}
+
+		artifact, err := router.UpdateArtifact(ctx, agentID, payload.ID, memory.ArtifactUpdate{
+			Title:    payload.Title,
+			Body:     payload.Body,
+			Metadata: payload.Metadata,
+		})
+		if err != nil {
+			r.logger.Error().Str("agentID", agentID).Int64("id", payload.ID).Err(err).Msg("artifact_update failed")
+			return nil, err
+		}
+
+		return map[string]any{
+			"id":      artifact.ID,
+			"title":   artifact.Title,
+			"version": artifact.Version,
+		}, nil
+	})
+}
+
+// artifactPreview shortens a body to artifactPreviewLen characters, cutting between runes.
+func artifactPreview(body string) string {
+	runes := []rune(body)
+	if len(runes) <= artifactPreviewLen {
+		return body
+	}
+	return string(runes[:artifactPreviewLen]) + "..."
+}
+
+// artifactResult formats the current revision of an artifact for a tool result.
+func artifactResult(a *memory.Artifact) map[string]any {
+	return map[string]any{
+		"id":       a.ID,
+		"title":    a.Title,
+		"body":     a.Body,
+		"metadata": a.Metadata,
+		"scope":    a.Scope,
+		"author":   a.AgentID,
+		"thread":   a.ThreadID,
+		"version":  a.Version,
+		"created":  a.CreatedAt,
+		"updated":  a.UpdatedAt,
+	}
+}
@@ -0,0 +1,75 @@
+package tools
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/aschepis/backscratcher/staff/memory"
+	"github.com/aschepis/backscratcher/staff/migrations"
+	"github.com/rs/zerolog"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestArtifactTools_ValidateAndPreview(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open in-memory sqlite: %v", err)
+	}
+	defer db.Close() //nolint:errcheck // Test cleanup
+	if err := migrations.RunMigrations(db, filepath.Join("..", "migrations"), zerolog.Nop()); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+	store, err := memory.NewStore(db, nil, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	reg := NewRegistry(zerolog.Nop())
+	reg.RegisterArtifactTools(memory.NewMemoryRouter(store, memory.Config{}, zerolog.Nop()))
+
+	ctx := context.Background()
+	call := func(tool string, args map[string]any) (any, error) {
+		t.Helper()
+		argsBytes, err := json.Marshal(args)
+		if err != nil {
+			t.Fatalf("failed to marshal args: %v", err)
+		}
+		return reg.Handle(ctx, tool, "agent-test", argsBytes)
+	}
+
+	if _, err := call("artifact_create", map[string]any{"title": "Notes", "body": "  "}); err == nil {
+		t.Error("expected artifact_create to reject an empty body")
+	}
+
+	// Multi-byte characters straddle the preview cutoff
+	body := strings.Repeat("é", artifactPreviewLen+10)
+	created, err := call("artifact_create", map[string]any{"title": "Accents", "body": body})
+	if err != nil {
+		t.Fatalf("artifact_create: %v", err)
+	}
+	id := created.(map[string]any)["id"]
+
+	for _, upd := range []map[string]any{{"id": id, "body": ""}, {"id": id, "title": " "}} {
+		if _, err := call("artifact_update", upd); err == nil {
+			t.Errorf("expected artifact_update to reject %v", upd)
+		}
+	}
+
+	listed, err := call("artifact_list", map[string]any{})
+	if err != nil {
+		t.Fatalf("artifact_list: %v", err)
+	}
+	artifacts := listed.([]map[string]any)
+	if len(artifacts) != 1 {
+		t.Fatalf("expected 1 artifact, got %d", len(artifacts))
+	}
+	preview := artifacts[0]["preview"].(string)
+	if !utf8.ValidString(preview) || preview != strings.Repeat("é", artifactPreviewLen)+"..." {
+		t.Errorf("expected preview cut between characters, got %q", preview)
+	}
+}
@@ -0,0 +1,56 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aschepis/backscratcher/staff/memory"
+)
+
+// RegisterDocsTools registers tools for searching ingested workspace documents.
+func (r *Registry) RegisterDocsTools(router *memory.MemoryRouter) {
+	r.logger.Info().Msg("Registering docs tools in registry")
+
+	r.Register("docs_search", func(ctx context.Context, agentID string, args json.RawMessage) (any, error) {
+		var payload struct {
+			Query string `json:"query"`
+			Limit int    `json:"limit"`
+		}
+		if err := json.Unmarshal(args, &payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal arguments: %w", err)
+		}
+		if strings.TrimSpace(payload.Query) == "" {
+			return nil, fmt.Errorf("query cannot be empty")
+		}
+		if payload.Limit <= 0 {
+			payload.Limit = 5
+		}
+
+		results, err := router.SearchDocs(ctx, payload.Query, payload.Limit)
+		if err != nil {
+			r.logger.Error().Str("agentID", agentID).Err(err).Msg("docs_search failed")
+			return nil, err
+		}
+
+		out := make([]map[string]any, 0, len(results))
+		for _, res := range results {
+			result := map[string]any{
+				"path":     res.Path,
+				"chunk":    res.Chunk,
+				"content":  res.Text,
+				"score":    res.Score,
+				"citation": res.Citation(),
+			}
+			if !res.Extracted {
+				result["offset"] = res.Offset
+			}
+			if res.Match != nil {
+				result["matched"] = res.Match.Snippet
+			}
+			out = append(out, result)
+		}
+		return out, nil
+	})
+}
@@ -9,6 +9,8 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+
+	"github.com/aschepis/backscratcher/staff/llm"
 )
 
 // validateWorkspacePath ensures the given path is within the workspace directory
@@ -45,6 +47,21 @@ func validateWorkspacePath(workspacePath, targetPath string) (string, error) {
 	return absTarget, nil
 }
 
+// mediaFileResult is returned by read_file for images and PDFs.
+// The file itself is attached to the tool result as a media block; only the metadata
+// below is serialized (and persisted) as the textual result.
+type mediaFileResult struct {
+	Path      string `json:"path"`
+	Size      int64  `json:"size"`
+	MediaType string `json:"media_type"`
+	media     llm.MediaBlock
+}
+
+// ToolResultMedia implements llm.MediaToolResult.
+func (r *mediaFileResult) ToolResultMedia() []llm.MediaBlock {
+	return []llm.MediaBlock{r.media}
+}
+
 // RegisterFilesystemTools registers all filesystem-related tools
 func (r *Registry) RegisterFilesystemTools(workspacePath string) {
 	r.logger.Info().Msg("Registering filesystem tools in registry")
@@ -76,6 +93,23 @@ func (r *Registry) RegisterFilesystemTools(workspacePath string) {
 			return nil, fmt.Errorf("path is a directory, not a file: %s", payload.Path)
 		}
 
+		// Images and PDFs are handed to the model as media blocks instead of text
+		if mediaType := llm.MediaTypeForPath(validPath); mediaType != "" {
+			if info.Size() > llm.MaxMediaBytes {
+				return nil, fmt.Errorf("file too large to attach: %d bytes (limit %d)", info.Size(), llm.MaxMediaBytes)
+			}
+			return &mediaFileResult{
+				Path:      payload.Path,
+				Size:      info.Size(),
+				MediaType: mediaType,
+				media: llm.MediaBlock{
+					MediaType: mediaType,
+					Path:      validPath,
+					Name:      filepath.Base(validPath),
+				},
+			}, nil
+		}
+
 		file, err := os.Open(validPath) //#nosec 304 -- validated above
 		if err != nil {
 			return nil, fmt.Errorf("failed to open file: %w", err)
@@ -8,6 +8,8 @@ import (
 	"testing"
 
 	"github.com/rs/zerolog"
+
+	"github.com/aschepis/backscratcher/staff/llm"
 )
 
 func TestValidateWorkspacePath(t *testing.T) {
@@ -107,6 +109,51 @@ func TestReadFile(t *testing.T) {
 	}
 }
 
+func TestReadFileImage(t *testing.T) {
+	tmpDir := t.TempDir()
+	workspacePath, _ := filepath.Abs(tmpDir)
+
+	// A PNG signature is enough; the tool does not decode the image
+	pngBytes := []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}
+	if err := os.WriteFile(filepath.Join(workspacePath, "receipt.png"), pngBytes, 0o600); err != nil {
+		t.Fatalf("Failed to create test image: %v", err)
+	}
+
+	reg := NewRegistry(zerolog.Nop())
+	reg.RegisterFilesystemTools(workspacePath)
+
+	result, err := reg.Handle(context.Background(), "read_file", "test-agent", json.RawMessage(`{"path": "receipt.png"}`))
+	if err != nil {
+		t.Fatalf("read_file failed: %v", err)
+	}
+
+	mediaResult, ok := result.(llm.MediaToolResult)
+	if !ok {
+		t.Fatalf("Expected llm.MediaToolResult, got %T", result)
+	}
+	media := mediaResult.ToolResultMedia()
+	if len(media) != 1 || media[0].MediaType != "image/png" {
+		t.Fatalf("Expected one image/png media block, got %+v", media)
+	}
+
+	raw, err := media[0].Bytes()
+	if err != nil {
+		t.Fatalf("Failed to load media bytes: %v", err)
+	}
+	if string(raw) != string(pngBytes) {
+		t.Errorf("Media bytes do not match the file")
+	}
+
+	// The serialized result carries metadata only, never the image data
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Failed to marshal result: %v", err)
+	}
+	if string(encoded) != `{"path":"receipt.png","size":8,"media_type":"image/png"}` {
+		t.Errorf("Unexpected serialized result: %s", encoded)
+	}
+}
+
 func TestWriteFile(t *testing.T) {
 	tmpDir := t.TempDir()
 	workspacePath, _ := filepath.Abs(tmpDir)
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	ctxpkg "github.com/aschepis/backscratcher/staff/context"
 	"github.com/aschepis/backscratcher/staff/memory"
@@ -106,6 +107,12 @@ func (r *Registry) Handle(ctx context.Context, toolName, agentID string, argsStr
 	return result, err
 }
 
+// memorySearchTypes are the memory types memory_search returns. Ingested document chunks
+// are left to docs_search so they don't crowd out what agents have remembered. Personal
+// memories are included, but the user profile built from them never is: search skips it,
+// and agents see it in their system prompt.
+var memorySearchTypes = []memory.MemoryType{memory.MemoryTypeFact, memory.MemoryTypeEpisode, memory.MemoryTypeProfile}
+
 // RegisterMemoryTools registers memory-related tools backed by a MemoryRouter.
 // Note: Tool names must match pattern ^[a-zA-Z0-9_-]{1,128}$ (no dots allowed)
 // apiKey is used for the normalizer and must be provided from config.
@@ -113,7 +120,7 @@ func (r *Registry) RegisterMemoryTools(router *memory.MemoryRouter, apiKey strin
 	r.logger.Info().Msg("Registering memory tools in registry")
 
 	// Normalizer instance shared by memory tools that only transform text.
-	normalizer := memory.NewNormalizer("claude-3.5-haiku-latest", apiKey, 256, r.logger)
+	normalizer := memory.NewNormalizer("claude-3.5-haiku-latest", apiKey, 512, r.logger)
 
 	r.Register("memory_remember_episode", func(ctx context.Context, agentID string, args json.RawMessage) (any, error) {
 		var payload struct {
@@ -165,7 +172,7 @@ func (r *Registry) RegisterMemoryTools(router *memory.MemoryRouter, apiKey strin
 		}
 
 		r.logger.Info().Str("fact", payload.Fact).Msg("Adding global fact")
-		item, err := router.AddGlobalFact(ctx, payload.Fact, payload.Metadata)
+		item, err := router.AddGlobalFact(withToolProvenance(ctx), payload.Fact, payload.Metadata)
 		if err != nil {
 			r.logger.Error().Str("agentID", agentID).Err(err).Msg("Failed to save global fact")
 			return nil, fmt.Errorf("failed to save fact to database: %w", err)
@@ -199,7 +206,7 @@ func (r *Registry) RegisterMemoryTools(router *memory.MemoryRouter, apiKey strin
 		}
 
 		r.logger.Info().Str("agentID", agentID).Str("fact", payload.Fact).Msg("Adding agent-specific fact")
-		item, err := router.AddAgentFact(ctx, agentID, payload.Fact, payload.Metadata)
+		item, err := router.AddAgentFact(withToolProvenance(ctx), agentID, payload.Fact, payload.Metadata)
 		if err != nil {
 			r.logger.Error().Str("agentID", agentID).Err(err).Msg("Failed to save agent fact")
 			return nil, fmt.Errorf("failed to save agent fact to database: %w", err)
@@ -214,11 +221,49 @@ func (r *Registry) RegisterMemoryTools(router *memory.MemoryRouter, apiKey strin
 		}, nil
 	})
 
+	r.Register("memory_remember_group_fact", func(ctx context.Context, agentID string, args json.RawMessage) (any, error) {
+		var payload struct {
+			Group    string                 `json:"group"`
+			Fact     string                 `json:"fact"`
+			Metadata map[string]interface{} `json:"metadata"`
+		}
+		r.logger.Debug().Str("agentID", agentID).Msg("Received call to memory_remember_group_fact")
+		if err := json.Unmarshal(args, &payload); err != nil {
+			r.logger.Warn().Err(err).Msg("Failed to decode arguments for memory_remember_group_fact")
+			return nil, fmt.Errorf("failed to unmarshal arguments: %w", err)
+		}
+
+		if strings.TrimSpace(payload.Fact) == "" {
+			r.logger.Warn().Str("agentID", agentID).Msg("Empty fact passed to memory_remember_group_fact")
+			return nil, fmt.Errorf("fact cannot be empty")
+		}
+		if payload.Group == "" {
+			return nil, fmt.Errorf("group is required; this agent belongs to: %s", strings.Join(router.Groups(agentID), ", "))
+		}
+
+		r.logger.Info().Str("agentID", agentID).Str("group", payload.Group).Str("fact", payload.Fact).Msg("Adding group fact")
+		item, err := router.AddGroupFact(withToolProvenance(ctx), agentID, payload.Group, payload.Fact, payload.Metadata)
+		if err != nil {
+			r.logger.Error().Str("agentID", agentID).Str("group", payload.Group).Err(err).Msg("Failed to save group fact")
+			return nil, fmt.Errorf("failed to save group fact: %w", err)
+		}
+
+		r.logger.Debug().Int64("id", item.ID).Msg("memory_remember_group_fact succeeded")
+		return map[string]any{
+			"id":      item.ID,
+			"scope":   item.Scope,
+			"group":   item.Group,
+			"type":    item.Type,
+			"created": item.CreatedAt,
+		}, nil
+	})
+
 	r.Register("memory_search", func(ctx context.Context, agentID string, args json.RawMessage) (any, error) {
 		var payload struct {
-			Query         string `json:"query"`
-			IncludeGlobal bool   `json:"include_global"`
-			Limit         int    `json:"limit"`
+			Query         string       `json:"query"`
+			IncludeGlobal bool         `json:"include_global"`
+			Limit         int          `json:"limit"`
+			Ranking       *rankingArgs `json:"ranking"`
 		}
 		r.logger.Debug().Str("agentID", agentID).Msg("Received call to memory_search")
 		if err := json.Unmarshal(args, &payload); err != nil {
@@ -229,13 +274,23 @@ func (r *Registry) RegisterMemoryTools(router *memory.MemoryRouter, apiKey strin
 			payload.Limit = 10
 			r.logger.Debug().Str("agentID", agentID).Msg("Defaulting memory_search limit to 10")
 		}
+		// Time expressions such as "last week" filter by creation time instead of matching text
+		timeRange, query := memory.ParseTimeRange(payload.Query, router.Now())
 		r.logger.Info().
 			Str("agentID", agentID).
-			Str("query", payload.Query).
+			Str("query", query).
+			Interface("timeRange", timeRange).
 			Bool("includeGlobal", payload.IncludeGlobal).
 			Int("limit", payload.Limit).
 			Msg("memory_search: Querying memory")
-		results, err := router.QueryAgentMemory(ctx, agentID, payload.Query, nil, payload.IncludeGlobal, payload.Limit, nil)
+		results, err := router.QueryAgentMemory(ctx, agentID, memory.AgentQueryOptions{
+			Text:          query,
+			IncludeGlobal: payload.IncludeGlobal,
+			Limit:         payload.Limit,
+			Types:         memorySearchTypes,
+			Ranking:       payload.Ranking.toRanking(),
+			TimeRange:     timeRange,
+		})
 		if err != nil {
 			r.logger.Error().Err(err).Str("agentID", agentID).Msg("memory_search failed for agent")
 			return nil, err
@@ -246,16 +301,34 @@ func (r *Registry) RegisterMemoryTools(router *memory.MemoryRouter, apiKey strin
 		}
 		out := make([]map[string]any, 0, len(results))
 		for _, r := range results {
-			out = append(out, map[string]any{
+			resultMap := map[string]any{
 				"id":       r.Item.ID,
 				"scope":    r.Item.Scope,
 				"type":     r.Item.Type,
 				"content":  r.Item.Content,
 				"metadata": r.Item.Metadata,
 				"score":    r.Score,
-			})
+			}
+			if r.Item.Group != "" {
+				resultMap["group"] = r.Item.Group
+			}
+			if r.Match != nil {
+				resultMap["matched"] = r.Match.Snippet
+			}
+			out = append(out, resultMap)
 		}
-		return out, nil
+		response := map[string]any{"results": out}
+		if timeRange != nil {
+			applied := map[string]any{"phrase": timeRange.Phrase}
+			if timeRange.After != nil {
+				applied["after"] = timeRange.After.Format(time.RFC3339)
+			}
+			if timeRange.Before != nil {
+				applied["before"] = timeRange.Before.Format(time.RFC3339)
+			}
+			response["time_range"] = applied
+		}
+		return response, nil
 	})
 
 	r.Register("memory_search_personal", func(ctx context.Context, agentID string, args json.RawMessage) (any, error) {
@@ -304,6 +377,9 @@ func (r *Registry) RegisterMemoryTools(router *memory.MemoryRouter, apiKey strin
 			if len(r.Item.Tags) > 0 {
 				resultMap["tags"] = r.Item.Tags
 			}
+			if r.Match != nil {
+				resultMap["matched"] = r.Match.Snippet
+			}
 			out = append(out, resultMap)
 		}
 		return out, nil
@@ -319,6 +395,8 @@ func (r *Registry) RegisterMemoryTools(router *memory.MemoryRouter, apiKey strin
 			ThreadID   string                 `json:"thread_id"`  // optional conversation/thread id
 			Importance float64                `json:"importance"` // optional importance; default handled by store
 			Metadata   map[string]interface{} `json:"metadata"`   // optional extra metadata
+			Entities   []memory.EntityRef     `json:"entities"`   // entities from memory_normalize
+			Relations  []memory.RelationRef   `json:"relations"`  // relations from memory_normalize
 		}
 
 		r.logger.Debug().Str("agentID", agentID).Msg("Received call to memory_store_personal")
@@ -346,7 +424,7 @@ func (r *Registry) RegisterMemoryTools(router *memory.MemoryRouter, apiKey strin
 		}
 
 		item, err := router.StorePersonalMemory(
-			ctx,
+			withToolProvenance(ctx),
 			effectiveAgentID,
 			rawText,
 			normalized,
@@ -360,6 +438,10 @@ func (r *Registry) RegisterMemoryTools(router *memory.MemoryRouter, apiKey strin
 			r.logger.Error().Str("agentID", effectiveAgentID).Err(err).Msg("memory_store_personal failed")
 			return nil, err
 		}
+		if err := router.LinkEntities(ctx, item.ID, payload.Entities, payload.Relations); err != nil {
+			r.logger.Error().Str("agentID", effectiveAgentID).Int64("id", item.ID).Err(err).Msg("memory_store_personal: linking entities failed")
+			return nil, err
+		}
 
 		return map[string]any{
 			"id":              item.ID,
@@ -373,6 +455,72 @@ func (r *Registry) RegisterMemoryTools(router *memory.MemoryRouter, apiKey strin
 		}, nil
 	})
 
+	r.Register("memory_update", func(ctx context.Context, agentID string, args json.RawMessage) (any, error) {
+		var payload struct {
+			ID         int64                  `json:"id"`
+			Content    *string                `json:"content"`     // corrected normalized text
+			Importance *float64               `json:"importance"`  // optional new importance
+			MemoryType *string                `json:"memory_type"` // optional new normalized memory type
+			Tags       []string               `json:"tags"`        // optional replacement tags
+			Metadata   map[string]interface{} `json:"metadata"`    // optional metadata to merge
+		}
+		r.logger.Debug().Str("agentID", agentID).Msg("Received call to memory_update")
+		if err := json.Unmarshal(args, &payload); err != nil {
+			r.logger.Warn().Err(err).Msg("Failed to decode arguments for memory_update")
+			return nil, fmt.Errorf("failed to unmarshal arguments: %w", err)
+		}
+		if payload.ID == 0 {
+			return nil, fmt.Errorf("id is required")
+		}
+
+		item, err := router.UpdateMemory(ctx, agentID, payload.ID, memory.MemoryUpdate{
+			Content:    payload.Content,
+			Importance: payload.Importance,
+			MemoryType: payload.MemoryType,
+			Tags:       payload.Tags,
+			Metadata:   payload.Metadata,
+		})
+		if err != nil {
+			r.logger.Error().Str("agentID", agentID).Int64("id", payload.ID).Err(err).Msg("memory_update failed")
+			return nil, err
+		}
+
+		return map[string]any{
+			"id":          item.ID,
+			"scope":       item.Scope,
+			"type":        item.Type,
+			"memory_type": item.MemoryType,
+			"content":     item.Content,
+			"importance":  item.Importance,
+			"tags":        item.Tags,
+			"updated":     item.UpdatedAt,
+		}, nil
+	})
+
+	r.Register("memory_forget", func(ctx context.Context, agentID string, args json.RawMessage) (any, error) {
+		var payload struct {
+			ID int64 `json:"id"`
+		}
+		r.logger.Debug().Str("agentID", agentID).Msg("Received call to memory_forget")
+		if err := json.Unmarshal(args, &payload); err != nil {
+			r.logger.Warn().Err(err).Msg("Failed to decode arguments for memory_forget")
+			return nil, fmt.Errorf("failed to unmarshal arguments: %w", err)
+		}
+		if payload.ID == 0 {
+			return nil, fmt.Errorf("id is required")
+		}
+
+		if err := router.ForgetMemory(ctx, agentID, payload.ID); err != nil {
+			r.logger.Error().Str("agentID", agentID).Int64("id", payload.ID).Err(err).Msg("memory_forget failed")
+			return nil, err
+		}
+
+		return map[string]any{
+			"id":        payload.ID,
+			"forgotten": true,
+		}, nil
+	})
+
 	r.Register("memory_normalize", func(ctx context.Context, agentID string, args json.RawMessage) (any, error) {
 		var payload struct {
 			Text string `json:"text"`
@@ -388,18 +536,158 @@ func (r *Registry) RegisterMemoryTools(router *memory.MemoryRouter, apiKey strin
 			return nil, fmt.Errorf("text cannot be empty")
 		}
 
-		normalized, memType, tags, err := normalizer.Normalize(ctx, payload.Text)
+		normalized, err := normalizer.NormalizeMemory(ctx, payload.Text)
 		if err != nil {
 			r.logger.Error().Str("agentID", agentID).Err(err).Msg("memory_normalize failed")
 			return nil, err
 		}
 
 		return map[string]any{
-			"normalized": normalized,
-			"type":       memType,
-			"tags":       tags,
+			"normalized": normalized.Text,
+			"type":       normalized.Type,
+			"tags":       normalized.Tags,
+			"entities":   normalized.Entities,
+			"relations":  normalized.Relations,
 		}, nil
 	})
+
+	r.Register("memory_entity_lookup", func(ctx context.Context, agentID string, args json.RawMessage) (any, error) {
+		var payload struct {
+			Name  string `json:"name"`
+			Kind  string `json:"kind"`
+			Limit int    `json:"limit"`
+		}
+		if err := json.Unmarshal(args, &payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal arguments: %w", err)
+		}
+		if strings.TrimSpace(payload.Name) == "" {
+			return nil, fmt.Errorf("name cannot be empty")
+		}
+		kind := memory.EntityKind(strings.ToLower(strings.TrimSpace(payload.Kind)))
+		if kind != "" && !memory.ValidEntityKind(kind) {
+			return nil, fmt.Errorf("kind must be person, project, organization or place")
+		}
+		if payload.Limit <= 0 {
+			payload.Limit = 5
+		}
+
+		results, err := router.LookupEntities(ctx, agentID, payload.Name, kind, payload.Limit)
+		if err != nil {
+			r.logger.Error().Str("agentID", agentID).Err(err).Msg("memory_entity_lookup failed")
+			return nil, err
+		}
+		out := make([]map[string]any, 0, len(results))
+		for _, ec := range results {
+			result := entityContextResult(ec)
+			e := ec.Entities[0]
+			result["id"], result["name"], result["kind"] = e.ID, e.Name, e.Kind
+			delete(result, "entities")
+			out = append(out, result)
+		}
+		return out, nil
+	})
+
+	r.Register("memory_related", func(ctx context.Context, agentID string, args json.RawMessage) (any, error) {
+		var payload struct {
+			Entities []string `json:"entities"`
+			Limit    int      `json:"limit"`
+		}
+		if err := json.Unmarshal(args, &payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal arguments: %w", err)
+		}
+		var names []string
+		for _, name := range payload.Entities {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 {
+			return nil, fmt.Errorf("entities cannot be empty")
+		}
+		if payload.Limit <= 0 {
+			payload.Limit = 10
+		}
+
+		ec, err := router.RelatedMemories(ctx, agentID, names, payload.Limit)
+		if err != nil {
+			r.logger.Error().Str("agentID", agentID).Err(err).Msg("memory_related failed")
+			return nil, err
+		}
+		return entityContextResult(*ec), nil
+	})
+}
+
+// entityContextResult formats what memories say about entities as a tool result.
+func entityContextResult(ec memory.EntityContext) map[string]any {
+	entity := func(e memory.Entity) map[string]any {
+		return map[string]any{"id": e.ID, "name": e.Name, "kind": e.Kind}
+	}
+	memories := make([]map[string]any, 0, len(ec.Memories))
+	for _, m := range ec.Memories {
+		memories = append(memories, map[string]any{
+			"id":      m.ID,
+			"scope":   m.Scope,
+			"content": m.Content,
+			"updated": m.UpdatedAt,
+		})
+	}
+	relations := make([]map[string]any, 0, len(ec.Relations))
+	for _, rel := range ec.Relations {
+		relations = append(relations, map[string]any{
+			"subject":   rel.Subject.Name,
+			"predicate": rel.Predicate,
+			"object":    rel.Object.Name,
+			"memory_id": rel.MemoryID,
+		})
+	}
+	entities := make([]map[string]any, 0, len(ec.Entities))
+	for _, e := range ec.Entities {
+		entities = append(entities, entity(e))
+	}
+	related := make([]map[string]any, 0, len(ec.Related))
+	for _, e := range ec.Related {
+		result := entity(e.Entity)
+		result["links"] = e.Links
+		related = append(related, result)
+	}
+	return map[string]any{
+		"entities":  entities,
+		"memories":  memories,
+		"relations": relations,
+		"related":   related,
+	}
+}
+
+// rankingArgs overrides parts of memory.DefaultRanking from tool arguments.
+type rankingArgs struct {
+	VectorWeight        *float64 `json:"vector_weight"`
+	KeywordWeight       *float64 `json:"keyword_weight"`
+	TagWeight           *float64 `json:"tag_weight"`
+	ImportanceWeight    *float64 `json:"importance_weight"`
+	RecencyWeight       *float64 `json:"recency_weight"`
+	RecencyHalfLifeDays *float64 `json:"recency_half_life_days"`
+}
+
+// toRanking returns the ranking to search with, or nil for the default.
+func (a *rankingArgs) toRanking() *memory.Ranking {
+	if a == nil {
+		return nil
+	}
+	ranking := memory.DefaultRanking()
+	set := func(dst, src *float64) {
+		if src != nil {
+			*dst = *src
+		}
+	}
+	set(&ranking.VectorWeight, a.VectorWeight)
+	set(&ranking.KeywordWeight, a.KeywordWeight)
+	set(&ranking.TagWeight, a.TagWeight)
+	set(&ranking.ImportanceWeight, a.ImportanceWeight)
+	set(&ranking.RecencyWeight, a.RecencyWeight)
+	if a.RecencyHalfLifeDays != nil {
+		ranking.RecencyHalfLife = time.Duration(*a.RecencyHalfLifeDays * float64(24*time.Hour))
+	}
+	return &ranking
 }
 
 // RemoteCaller represents something that can call a remote tool backend.
@@ -407,6 +695,21 @@ type RemoteCaller interface {
 	Call(ctx context.Context, toolName string, args json.RawMessage) (json.RawMessage, error)
 }
 
+// withToolProvenance records the tool call in ctx, if any, as the source of the memories
+// stored with the returned context.
+func withToolProvenance(ctx context.Context) context.Context {
+	call, ok := ctxpkg.GetToolCall(ctx)
+	if !ok {
+		return ctx
+	}
+	return memory.WithProvenance(ctx, memory.Provenance{
+		AgentID:  call.AgentID,
+		ThreadID: call.ThreadID,
+		ToolID:   call.ToolID,
+		Source:   call.ToolName,
+	})
+}
+
 // RegisterRemoteTool registers a tool whose implementation is provided by a RemoteCaller.
 func (r *Registry) RegisterRemoteTool(name string, caller RemoteCaller) {
 	r.logger.Info().Str("name", name).Msg("Registering remote tool")
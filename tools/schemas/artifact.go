@@ -0,0 +1,90 @@
+package schemas
+
+// ArtifactSchemas returns schemas for artifact tools.
+func ArtifactSchemas() map[string]ToolSchema {
+	return map[string]ToolSchema{
+		"artifact_create": {
+			Description: "Create an artifact: a durable document such as a report, plan or handoff note. Artifacts are shared with other agents and the user unless private is set. Use memory tools for short facts instead.",
+			Schema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"title": map[string]any{
+						"type":        "string",
+						"description": "Short descriptive title.",
+					},
+					"body": map[string]any{
+						"type":        "string",
+						"description": "Document content, usually Markdown.",
+					},
+					"private": map[string]any{
+						"type":        "boolean",
+						"description": "Only you can see the artifact (default false: shared).",
+					},
+					"metadata": map[string]any{
+						"type":        "object",
+						"description": "Optional metadata, e.g. {\"for\": \"agent-id\"} for a handoff.",
+					},
+				},
+				"required": []string{"title", "body"},
+			},
+		},
+		"artifact_read": {
+			Description: "Read an artifact by ID. Returns the current version unless an earlier version is requested.",
+			Schema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"id": map[string]any{
+						"type":        "number",
+						"description": "Artifact ID from artifact_list or artifact_create.",
+					},
+					"version": map[string]any{
+						"type":        "number",
+						"description": "Optional version number to read (default: current).",
+					},
+				},
+				"required": []string{"id"},
+			},
+		},
+		"artifact_list": {
+			Description: "List artifacts you can see, most recently updated first, with a preview of each body.",
+			Schema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"query": map[string]any{
+						"type":        "string",
+						"description": "Optional text that must appear in the title or body.",
+					},
+					"limit": map[string]any{
+						"type":        "number",
+						"description": "Maximum number of artifacts to return (default: 20).",
+					},
+				},
+			},
+		},
+		"artifact_update": {
+			Description: "Revise an artifact, creating a new version. Earlier versions remain readable with artifact_read.",
+			Schema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"id": map[string]any{
+						"type":        "number",
+						"description": "Artifact ID to revise.",
+					},
+					"title": map[string]any{
+						"type":        "string",
+						"description": "New title (default: unchanged).",
+					},
+					"body": map[string]any{
+						"type":        "string",
+						"description": "Full new body, replacing the previous one (default: unchanged).",
+					},
+					"metadata": map[string]any{
+						"type":        "object",
+						"description": "Metadata to merge into the artifact's metadata. A null value removes a key.",
+					},
+				},
+				"required": []string{"id"},
+			},
+		},
+	}
+}
@@ -0,0 +1,24 @@
+package schemas
+
+// DocsSchemas returns schemas for document search tools.
+func DocsSchemas() map[string]ToolSchema {
+	return map[string]ToolSchema{
+		"docs_search": {
+			Description: "Search the workspace documents (Markdown, text, HTML and PDF files) indexed from the configured directories. Returns matching passages with a citation to quote when you use them: path:offset for Markdown and text files, path#chunk-N for HTML and PDF.",
+			Schema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"query": map[string]any{
+						"type":        "string",
+						"description": "What to look for, in keywords or a natural language question.",
+					},
+					"limit": map[string]any{
+						"type":        "number",
+						"description": "Maximum number of passages to return (default 5).",
+					},
+				},
+				"required": []string{"query"},
+			},
+		},
+	}
+}
@@ -4,7 +4,7 @@ package schemas
 func FilesystemSchemas() map[string]ToolSchema {
 	return map[string]ToolSchema{
 		"read_file": {
-			Description: "Read the contents of a file. Returns the file content, size, and path.",
+			Description: "Read the contents of a file. Returns the file content, size, and path. Image files (png, jpeg, gif, webp) and PDFs are attached so you can see them directly.",
 			Schema: map[string]any{
 				"type": "object",
 				"properties": map[string]any{
@@ -4,13 +4,37 @@ package schemas
 func MemorySchemas() map[string]ToolSchema {
 	return map[string]ToolSchema{
 		"memory_search": {
-			Description: "Search the agent or global memory store.",
+			Description: "Search the agent's own memories, the memories of its sharing groups, and optionally global memory. Results that matched the query text include a matched snippet with the matching words in **bold**. Time expressions in the query, such as \"yesterday morning\", \"last week\", \"the past 3 days\" or \"since March\", limit results to memories created in that period in the user's timezone; the applied range is returned as time_range. Use docs_search for workspace documents.",
 			Schema: map[string]any{
 				"type": "object",
 				"properties": map[string]any{
-					"query":          map[string]any{"type": "string"},
+					"query": map[string]any{
+						"type":        "string",
+						"description": "Text to search for, optionally with a time expression. A query that is only a time expression lists the memories from that period, newest first.",
+					},
 					"include_global": map[string]any{"type": "boolean"},
 					"limit":          map[string]any{"type": "number"},
+					"ranking": map[string]any{
+						"type":        "object",
+						"description": "Optional ranking overrides. Keyword, vector and tag results are merged by reciprocal rank, weighted per source (default 1; 0 ignores a source), then boosted by importance and recency.",
+						"properties": map[string]any{
+							"vector_weight":  map[string]any{"type": "number", "description": "Weight of semantic similarity results."},
+							"keyword_weight": map[string]any{"type": "number", "description": "Weight of full-text keyword results."},
+							"tag_weight":     map[string]any{"type": "number", "description": "Weight of tag match results."},
+							"importance_weight": map[string]any{
+								"type":        "number",
+								"description": "Boost for important memories (default 0.25; 0 disables).",
+							},
+							"recency_weight": map[string]any{
+								"type":        "number",
+								"description": "Boost for recent memories, so newer corrections outrank stale facts (default 0.5; 0 disables).",
+							},
+							"recency_half_life_days": map[string]any{
+								"type":        "number",
+								"description": "Age in days at which the recency boost halves (default 30).",
+							},
+						},
+					},
 				},
 				"required": []string{"query"},
 			},
@@ -93,8 +117,29 @@ func MemorySchemas() map[string]ToolSchema {
 				"required": []string{"fact"},
 			},
 		},
+		"memory_remember_group_fact": {
+			Description: "Store a fact shared with the other agents of one of this agent's sharing groups. Group facts are visible to every member of the group, and to no other agents.",
+			Schema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"group": map[string]any{
+						"type":        "string",
+						"description": "Name of the sharing group, e.g. \"finance-team\". The agent must be a member.",
+					},
+					"fact": map[string]any{
+						"type":        "string",
+						"description": "The factual information to share with the group.",
+					},
+					"metadata": map[string]any{
+						"type":        "object",
+						"description": "Optional additional metadata to associate with this fact.",
+					},
+				},
+				"required": []string{"group", "fact"},
+			},
+		},
 		"memory_normalize": {
-			Description: "Normalize a raw user or agent statement into a structured personal memory triple: normalized text, type, and tags.",
+			Description: "Normalize a raw user or agent statement into a structured personal memory: normalized text, type, tags, and the people, projects, organizations and places it mentions with the relations between them.",
 			Schema: map[string]any{
 				"type": "object",
 				"properties": map[string]any{
@@ -144,9 +189,105 @@ func MemorySchemas() map[string]ToolSchema {
 						"type":        "object",
 						"description": "Optional additional metadata to associate with this memory.",
 					},
+					"entities": map[string]any{
+						"type":        "array",
+						"description": "Entities returned by memory_normalize, as {name, kind} objects.",
+						"items":       map[string]any{"type": "object"},
+					},
+					"relations": map[string]any{
+						"type":        "array",
+						"description": "Relations returned by memory_normalize, as {subject, predicate, object} objects.",
+						"items":       map[string]any{"type": "object"},
+					},
 				},
 				"required": []string{"normalized", "type", "tags"},
 			},
 		},
+		"memory_entity_lookup": {
+			Description: "Look up a person, project, organization or place by name. Returns each matching entity with the memories that mention it, its relations to other entities, and the entities most often connected to it.",
+			Schema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"name": map[string]any{
+						"type":        "string",
+						"description": "Entity name; exact matches are preferred, otherwise names containing it match.",
+					},
+					"kind": map[string]any{
+						"type":        "string",
+						"description": "Optional kind: person, project, organization or place.",
+					},
+					"limit": map[string]any{
+						"type":        "number",
+						"description": "Maximum number of memories per entity (default 5).",
+					},
+				},
+				"required": []string{"name"},
+			},
+		},
+		"memory_related": {
+			Description: "Find what is known about several entities together, e.g. [\"Sam\", \"Q3 launch\"]. Returns the memories that mention all of them, the relations involving them, and other connected entities.",
+			Schema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"entities": map[string]any{
+						"type":        "array",
+						"description": "Names of the people, projects, organizations or places to relate.",
+						"items":       map[string]any{"type": "string"},
+					},
+					"limit": map[string]any{
+						"type":        "number",
+						"description": "Maximum number of memories to return (default 10).",
+					},
+				},
+				"required": []string{"entities"},
+			},
+		},
+		"memory_update": {
+			Description: "Correct an existing memory by ID, e.g. when the user says something you remembered is wrong. Use an ID returned by memory_search or memory_search_personal. Only the given fields change; the previous content is kept in the memory's history.",
+			Schema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"id": map[string]any{
+						"type":        "number",
+						"description": "ID of the memory to update.",
+					},
+					"content": map[string]any{
+						"type":        "string",
+						"description": "Corrected memory text, normalized in the third person like memory_normalize output.",
+					},
+					"importance": map[string]any{
+						"type":        "number",
+						"description": "New importance score between 0 and 1.",
+					},
+					"memory_type": map[string]any{
+						"type":        "string",
+						"description": "New normalized memory type (preference, biographical, habit, goal, value, project, other).",
+					},
+					"tags": map[string]any{
+						"type":        "array",
+						"description": "Replacement tags. Pass an empty array to remove all tags.",
+						"items":       map[string]any{"type": "string"},
+					},
+					"metadata": map[string]any{
+						"type":        "object",
+						"description": "Metadata to merge into the memory's metadata. A null value removes a key.",
+					},
+				},
+				"required": []string{"id"},
+			},
+		},
+		"memory_forget": {
+			Description: "Permanently delete a memory by ID, e.g. when the user asks you to forget something. Use an ID returned by memory_search or memory_search_personal.",
+			Schema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"id": map[string]any{
+						"type":        "number",
+						"description": "ID of the memory to delete.",
+					},
+				},
+				"required": []string{"id"},
+			},
+		},
 	}
 }
@@ -17,6 +17,12 @@ func All() map[string]ToolSchema {
 	for name, schema := range MemorySchemas() {
 		schemas[name] = schema
 	}
+	for name, schema := range ArtifactSchemas() {
+		schemas[name] = schema
+	}
+	for name, schema := range DocsSchemas() {
+		schemas[name] = schema
+	}
 	for name, schema := range FilesystemSchemas() {
 		schemas[name] = schema
 	}
@@ -37,6 +37,10 @@ type ChatService interface {
 	// ArchiveInboxItem marks an inbox item as archived.
 	ArchiveInboxItem(ctx context.Context, inboxID int64) error
 
+	// ConfirmInboxFact stores (accept) or declines the fact an inbox item asks about, then
+	// archives the item.
+	ConfirmInboxFact(ctx context.Context, inboxID int64, accept bool) error
+
 	// GetOrCreateThreadID gets an existing thread ID for an agent, or creates a new one if none exists.
 	GetOrCreateThreadID(ctx context.Context, agentID string) (string, error)
 
@@ -75,6 +79,10 @@ type ChatService interface {
 	// CompressContext summarizes the context and inserts a system message marking the compression.
 	CompressContext(ctx context.Context, agentID, threadID string) error
 
+	// PinLastUserMessage pins the most recent user message in a thread so it is kept verbatim
+	// when the context is compressed. Returns the pinned message content.
+	PinLastUserMessage(ctx context.Context, agentID, threadID string) (string, error)
+
 	// LoadSystemMessages loads system messages (context breaks) for a given agent and thread ID.
 	LoadSystemMessages(ctx context.Context, agentID, threadID string) ([]map[string]interface{}, error)
 
@@ -110,6 +118,24 @@ type ChatService interface {
 	ListAllTools(ctx context.Context) ([]string, error)
 	// DumpToolSchemas writes all tool schemas to a file as JSON
 	DumpToolSchemas(ctx context.Context, filePath string) error
+
+	// Artifact operations for the artifacts UI page
+	// ListArtifacts returns artifacts whose title or body contains query, most recently updated first
+	ListArtifacts(ctx context.Context, query string) ([]*Artifact, error)
+	// GetArtifact returns an artifact, or one of its earlier versions if version is non-zero
+	GetArtifact(ctx context.Context, id int64, version int) (*Artifact, error)
+	// ListArtifactVersions returns every version of an artifact, oldest first
+	ListArtifactVersions(ctx context.Context, id int64) ([]*Artifact, error)
+
+	// Memory operations for the memory UI page
+	// SearchMemory returns the memories of every agent that match query, best first
+	SearchMemory(ctx context.Context, query string) ([]*MemoryResult, error)
+	// GetMemoryProvenance returns where a memory came from, with the conversation around its source message
+	GetMemoryProvenance(ctx context.Context, id int64) (*MemoryProvenance, error)
+	// GetUserProfile returns the user profile, or nil if none has been built yet
+	GetUserProfile(ctx context.Context) (*UserProfile, error)
+	// UpdateUserProfile replaces the user profile
+	UpdateUserProfile(ctx context.Context, content string) (*UserProfile, error)
 }
 
 // MessageWithTimestamp represents a message with its database timestamp.
@@ -123,7 +149,7 @@ type MessageWithTimestamp struct {
 type AgentInfo struct {
 	ID       string
 	Name     string
-	Provider string // e.g., llm.ProviderAnthropic, llm.ProviderOllama, llm.ProviderOpenAI
+	Provider string // e.g., llm.ProviderAnthropic, llm.ProviderOllama, llm.ProviderOpenAI, llm.ProviderGemini
 	Model    string // e.g., "claude-sonnet-4-20250514"
 }
 
@@ -139,6 +165,62 @@ type InboxItem struct {
 	ArchivedAt       *time.Time
 	CreatedAt        time.Time
 	UpdatedAt        time.Time
+	PendingFact      string // Extracted fact waiting for confirmation, if any
+}
+
+// Artifact represents a version of a durable document written by an agent or the user.
+type Artifact struct {
+	ID        int64
+	AgentID   string // Creator, or the author of an earlier version; empty for the user
+	ThreadID  string
+	Scope     string
+	Title     string
+	Body      string
+	Version   int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// MemoryResult represents a memory found by a search.
+type MemoryResult struct {
+	ID        int64
+	AgentID   string // Empty for global and group memories
+	Group     string // Sharing group of group memories
+	Scope     string
+	Type      string
+	Content   string
+	Snippet   string // Matching fragment, with matched terms wrapped in "**"
+	CreatedAt time.Time
+}
+
+// MemoryProvenance describes where a memory came from.
+// All fields but MemoryID are empty for memories stored without a known source.
+type MemoryProvenance struct {
+	MemoryID  int64
+	AgentID   string
+	ThreadID  string
+	MessageID int64
+	ToolID    string
+	Source    string // Tool name, or "reflection"
+	Messages  []SourceMessage
+}
+
+// SourceMessage is a conversation message around the source of a memory.
+type SourceMessage struct {
+	ID        int64
+	Role      string
+	Content   string
+	ToolName  string
+	Timestamp time.Time
+	IsSource  bool // The message the memory came from, or its tool call
+}
+
+// UserProfile is the markdown profile of the user built from personal memories.
+type UserProfile struct {
+	ID        int64
+	Content   string
+	UpdatedAt time.Time
+	UpdatedBy string // "synthesizer" or "user"
 }
 
 // SystemInfo provides information about the system configuration.
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -19,6 +20,7 @@ import (
 	"github.com/aschepis/backscratcher/staff/config"
 	"github.com/aschepis/backscratcher/staff/conversations"
 	"github.com/aschepis/backscratcher/staff/llm"
+	"github.com/aschepis/backscratcher/staff/memory"
 )
 
 const (
@@ -26,6 +28,7 @@ const (
 	roleUser      = "user"
 	roleTool      = "tool"
 	roleSystem    = "system"
+	roleReasoning = "reasoning"
 )
 
 // chatService implements ChatService by wrapping an agent.Crew
@@ -33,6 +36,7 @@ type chatService struct {
 	crew              *agent.Crew
 	db                *sql.DB
 	conversationStore *conversations.Store
+	artifactStore     *memory.Store // Artifacts only, so no embedder
 	timeout           time.Duration // Timeout for chat operations
 	config            *config.ServerConfig
 	logger            zerolog.Logger
@@ -44,26 +48,45 @@ func NewChatService(logger zerolog.Logger, crew *agent.Crew, db *sql.DB, convers
 	if timeoutSeconds <= 0 {
 		timeoutSeconds = 60 // Default timeout
 	}
+	logger = logger.With().Str("component", "chatService").Logger()
+	artifactStore, _ := memory.NewStore(db, nil, logger) // NewStore never fails
 	return &chatService{
 		crew:              crew,
 		db:                db,
 		conversationStore: conversationStore,
+		artifactStore:     artifactStore,
 		timeout:           time.Duration(timeoutSeconds) * time.Second,
 		config:            appConfig,
-		logger:            logger.With().Str("component", "chatService").Logger(),
+		logger:            logger,
 	}
 }
 
 // SendMessage sends a message to an agent and returns the response.
 // History is provided as provider-neutral llm.Message types.
 func (s *chatService) SendMessage(ctx context.Context, agentID, threadID, message string, history []llm.Message) (string, error) {
-	return s.crew.Run(ctx, agentID, threadID, message, history)
+	if err := s.saveUserMessage(ctx, agentID, threadID, message); err != nil {
+		return "", err
+	}
+	return s.crew.Run(agent.WithChatTurn(ctx), agentID, threadID, message, history)
 }
 
 // SendMessageStream sends a message to an agent with streaming support.
 // History is provided as provider-neutral llm.Message types.
 func (s *chatService) SendMessageStream(ctx context.Context, agentID, threadID, message string, history []llm.Message, streamCallback StreamCallback) (string, error) {
-	return s.crew.RunStream(ctx, agentID, threadID, message, history, agent.StreamCallback(streamCallback))
+	if err := s.saveUserMessage(ctx, agentID, threadID, message); err != nil {
+		return "", err
+	}
+	return s.crew.RunStream(agent.WithChatTurn(ctx), agentID, threadID, message, history, agent.StreamCallback(streamCallback))
+}
+
+// saveUserMessage records the user's message, with any attachments queued on the context,
+// before the agent runs so that reloading the thread replays both.
+func (s *chatService) saveUserMessage(ctx context.Context, agentID, threadID, message string) error {
+	attachments, _ := agent.GetAttachments(ctx)
+	if err := s.conversationStore.AppendUserMessageWithAttachments(ctx, agentID, threadID, message, attachments); err != nil {
+		return fmt.Errorf("save user message: %w", err)
+	}
+	return nil
 }
 
 // ListAgents returns a list of available agents.
@@ -85,15 +108,16 @@ func (s *chatService) ListAgents() []AgentInfo {
 
 // ListInboxItems returns a list of inbox items, optionally filtered by archived status.
 func (s *chatService) ListInboxItems(ctx context.Context, includeArchived bool) ([]*InboxItem, error) {
-	query := sq.Select("id", "agent_id", "thread_id", "message", "requires_response", "response",
-		"response_at", "archived_at", "created_at", "updated_at").
-		From("inbox")
+	query := sq.Select("i.id", "i.agent_id", "i.thread_id", "i.message", "i.requires_response", "i.response",
+		"i.response_at", "i.archived_at", "i.created_at", "i.updated_at", "json_extract(p.fact, '$.normalized')").
+		From("inbox i").
+		LeftJoin("pending_facts p ON p.inbox_id = i.id")
 
 	if !includeArchived {
-		query = query.Where(sq.Eq{"archived_at": nil})
+		query = query.Where(sq.Eq{"i.archived_at": nil})
 	}
 
-	query = query.OrderBy("created_at DESC")
+	query = query.OrderBy("i.created_at DESC")
 
 	queryStr, args, err := query.ToSql()
 	if err != nil {
@@ -110,7 +134,7 @@ func (s *chatService) ListInboxItems(ctx context.Context, includeArchived bool)
 	for rows.Next() {
 		var item InboxItem
 		var agentID, threadID sql.NullString
-		var response sql.NullString
+		var response, pendingFact sql.NullString
 		var responseAt, archivedAt, createdAt, updatedAt sql.NullInt64
 
 		err := rows.Scan(
@@ -124,6 +148,7 @@ func (s *chatService) ListInboxItems(ctx context.Context, includeArchived bool)
 			&archivedAt,
 			&createdAt,
 			&updatedAt,
+			&pendingFact,
 		)
 		if err != nil {
 			return nil, err
@@ -152,6 +177,7 @@ func (s *chatService) ListInboxItems(ctx context.Context, includeArchived bool)
 		if updatedAt.Valid {
 			item.UpdatedAt = time.Unix(updatedAt.Int64, 0)
 		}
+		item.PendingFact = pendingFact.String
 
 		items = append(items, &item)
 	}
@@ -180,6 +206,22 @@ func (s *chatService) ArchiveInboxItem(ctx context.Context, inboxID int64) error
 	return err
 }
 
+// ConfirmInboxFact stores or declines the fact an inbox item asks about and archives the
+// item. This service has no embedder, so a confirmed fact is stored without a vector until
+// the re-embedding job picks it up.
+func (s *chatService) ConfirmInboxFact(ctx context.Context, inboxID int64, accept bool) error {
+	var err error
+	if accept {
+		_, err = s.artifactStore.ConfirmPendingFact(ctx, inboxID)
+	} else {
+		err = s.artifactStore.DiscardPendingFact(ctx, inboxID)
+	}
+	if err != nil {
+		return err
+	}
+	return s.ArchiveInboxItem(ctx, inboxID)
+}
+
 // GetOrCreateThreadID gets an existing thread ID for an agent, or creates a new one if none exists.
 func (s *chatService) GetOrCreateThreadID(ctx context.Context, agentID string) (string, error) {
 	// Check if there's an existing thread for this agent
@@ -353,6 +395,31 @@ func (s *chatService) LoadAllMessagesWithTimestamps(ctx context.Context, agentID
 				}
 			}
 
+		case roleReasoning:
+			// Reasoning is shown as its own message ahead of the answer it led to.
+			// A bare signature has nothing to show.
+			if content == "" {
+				continue
+			}
+			s.commitPendingMessagesWithTimestamp(&messages, currentUserTextBlocks, currentAssistantTextBlocks,
+				currentAssistantToolBlocks, currentToolResultBlocks, currentUserTimestamp, currentAssistantTimestamp, currentToolTimestamp)
+			messages = append(messages, MessageWithTimestamp{
+				Message: llm.Message{
+					Role:    llm.RoleAssistant,
+					Content: []llm.ContentBlock{llm.NewReasoningContentBlock(llm.ReasoningBlock{Text: content})},
+				},
+				Timestamp: createdAt,
+			})
+			currentUserTextBlocks = nil
+			currentAssistantTextBlocks = nil
+			currentAssistantToolBlocks = nil
+			currentToolResultBlocks = nil
+			currentUserTimestamp = 0
+			currentAssistantTimestamp = 0
+			currentToolTimestamp = 0
+			seenToolUseIDs = make(map[string]bool)
+			seenToolResultIDs = make(map[string]bool)
+
 		case roleTool:
 			if toolName.Valid && toolName.String != "" {
 				var toolResultData map[string]interface{}
@@ -423,13 +490,31 @@ func (s *chatService) LoadAllMessagesWithTimestamps(ctx context.Context, agentID
 	return messages, nil
 }
 
-// LoadMessagesWithTimestamps loads regular (non-system) messages with their timestamps.
-// Only loads messages after the most recent reset or compression break (if any).
-// This is used for LLM context - only messages after the break are sent to the model.
-// Returns provider-neutral llm.Message types.
-func (s *chatService) LoadMessagesWithTimestamps(ctx context.Context, agentID, threadID string) ([]MessageWithTimestamp, error) {
-	// First, find the most recent context break (system message with type="reset" or "compress")
-	var breakTimestamp sql.NullInt64
+// contextWindow describes which conversation rows make up the active LLM context.
+type contextWindow struct {
+	after       int64  // rows created after this timestamp are in the window
+	pinnedAfter int64  // pinned rows created after this timestamp are always included
+	summary     string // summary of the compressed history, prepended to the context
+}
+
+// filter returns the WHERE clause selecting the rows in the window.
+func (w contextWindow) filter() sq.Sqlizer {
+	return sq.Or{
+		sq.Gt{"created_at": w.after},
+		sq.And{
+			sq.Eq{"pinned": 1},
+			sq.Gt{"created_at": w.pinnedAfter},
+		},
+	}
+}
+
+// loadContextWindow finds the most recent context break (system message with type="reset" or
+// "compress") and resolves the window of messages that follow it. A sliding-window compression
+// records how many turns it retained; the window then reaches back to the start of the oldest
+// retained turn. Pinned messages survive compressions but not resets.
+func (s *chatService) loadContextWindow(ctx context.Context, agentID, threadID string) contextWindow {
+	var window contextWindow
+
 	breakQuery := sq.Select("content", "created_at").
 		From("conversations").
 		Where(sq.Eq{"agent_id": agentID}).
@@ -438,40 +523,182 @@ func (s *chatService) LoadMessagesWithTimestamps(ctx context.Context, agentID, t
 		OrderBy("created_at DESC")
 
 	breakQueryStr, breakArgs, err := breakQuery.ToSql()
-	if err == nil {
-		rows, err := s.db.QueryContext(ctx, breakQueryStr, breakArgs...)
+	if err != nil {
+		return window
+	}
+	rows, err := s.db.QueryContext(ctx, breakQueryStr, breakArgs...)
+	if err != nil {
+		return window
+	}
+
+	var compressAt int64
+	var retainedTurns int
+	foundBreak := false
+	for rows.Next() {
+		var content string
+		var createdAt int64
+		if err := rows.Scan(&content, &createdAt); err != nil {
+			continue
+		}
+		// Parse JSON to check if it's a reset or compress message
+		var msgData map[string]interface{}
+		if err := json.Unmarshal([]byte(content), &msgData); err != nil {
+			continue
+		}
+		msgType, _ := msgData["type"].(string)
+		if msgType == "reset" {
+			window.pinnedAfter = createdAt
+			if !foundBreak {
+				window.after = createdAt
+			}
+			break
+		}
+		if msgType == "compress" && !foundBreak {
+			foundBreak = true
+			window.after = createdAt
+			compressAt = createdAt
+			window.summary, _ = msgData["summary"].(string)
+			if turns, ok := msgData["retained_turns"].(float64); ok {
+				retainedTurns = int(turns)
+			}
+		}
+	}
+	_ = rows.Close()
+
+	if retainedTurns > 0 {
+		// The retained window starts at the Nth most recent user text message before the break
+		startQuery := sq.Select("created_at").
+			From("conversations").
+			Where(sq.Eq{"agent_id": agentID}).
+			Where(sq.Eq{"thread_id": threadID}).
+			Where(sq.Eq{"role": roleUser}).
+			Where(sq.Eq{"tool_name": nil}).
+			Where(sq.LtOrEq{"created_at": compressAt}).
+			Where(sq.Gt{"created_at": window.pinnedAfter}).
+			OrderBy("created_at DESC").
+			Limit(1).
+			Offset(uint64(retainedTurns - 1))
+
+		startQueryStr, startArgs, err := startQuery.ToSql()
 		if err == nil {
-			for rows.Next() {
-				var content string
-				var createdAt int64
-				if err := rows.Scan(&content, &createdAt); err == nil {
-					// Parse JSON to check if it's a reset or compress message
-					var msgData map[string]interface{}
-					if err := json.Unmarshal([]byte(content), &msgData); err == nil {
-						if msgType, ok := msgData["type"].(string); ok && (msgType == "reset" || msgType == "compress") {
-							breakTimestamp = sql.NullInt64{Int64: createdAt, Valid: true}
-							break
-						}
-					}
+			var start int64
+			switch err := s.db.QueryRowContext(ctx, startQueryStr, startArgs...).Scan(&start); err {
+			case nil:
+				window.after = s.keepToolPairs(ctx, agentID, threadID, window.pinnedAfter, compressAt, start-1)
+			case sql.ErrNoRows:
+				window.after = window.pinnedAfter
+			}
+		}
+	}
+
+	return window
+}
+
+// keepToolPairs moves a window start earlier so that no tool_use row is separated from its
+// tool_result, and tool_use rows that were never answered stay in the window, the same way
+// the agent widens its sliding window. The start moves to the beginning of the assistant
+// message holding the tool_use. Only rows between pinnedAfter and compressAt are considered.
+func (s *chatService) keepToolPairs(ctx context.Context, agentID, threadID string, pinnedAfter, compressAt, after int64) int64 {
+	query := sq.Select("role", "content", "tool_name", "created_at").
+		From("conversations").
+		Where(sq.Eq{"agent_id": agentID}).
+		Where(sq.Eq{"thread_id": threadID}).
+		Where(sq.NotEq{"role": roleSystem}).
+		Where(sq.Gt{"created_at": pinnedAfter}).
+		Where(sq.LtOrEq{"created_at": compressAt}).
+		OrderBy("created_at ASC")
+
+	queryStr, args, err := query.ToSql()
+	if err != nil {
+		return after
+	}
+	rows, err := s.db.QueryContext(ctx, queryStr, args...)
+	if err != nil {
+		return after
+	}
+	defer rows.Close() //nolint:errcheck // No remedy for rows close errors
+
+	type row struct {
+		role      string
+		toolUseID string
+		createdAt int64
+	}
+	var loaded []row
+	answered := make(map[string]bool)
+	resultAt := make(map[string]int64)
+	for rows.Next() {
+		var r row
+		var content string
+		var toolName sql.NullString
+		if err := rows.Scan(&r.role, &content, &toolName, &r.createdAt); err != nil {
+			return after
+		}
+		if toolName.Valid && toolName.String != "" {
+			var data struct {
+				ID string `json:"id"`
+			}
+			if err := json.Unmarshal([]byte(content), &data); err == nil && data.ID != "" {
+				switch r.role {
+				case roleAssistant:
+					r.toolUseID = data.ID
+				case roleTool:
+					answered[data.ID] = true
+					resultAt[data.ID] = r.createdAt
 				}
 			}
-			_ = rows.Close()
 		}
+		loaded = append(loaded, r)
+	}
+	if rows.Err() != nil {
+		return after
 	}
 
+	for {
+		newAfter := after
+		for i, r := range loaded {
+			if r.createdAt > after {
+				break
+			}
+			if r.toolUseID == "" {
+				continue
+			}
+			if answered[r.toolUseID] && resultAt[r.toolUseID] <= after {
+				continue
+			}
+			// Start the window at the assistant message that holds this tool_use
+			start := i
+			for start > 0 && (loaded[start-1].role == roleAssistant || loaded[start-1].role == roleReasoning) {
+				start--
+			}
+			if loaded[start].createdAt-1 < newAfter {
+				newAfter = loaded[start].createdAt - 1
+			}
+			break
+		}
+		if newAfter == after {
+			return after
+		}
+		after = newAfter
+	}
+}
+
+// LoadMessagesWithTimestamps loads regular (non-system) messages with their timestamps.
+// Only loads messages after the most recent reset or compression break (if any).
+// This is used for LLM context - only messages after the break are sent to the model.
+// Returns provider-neutral llm.Message types.
+func (s *chatService) LoadMessagesWithTimestamps(ctx context.Context, agentID, threadID string) ([]MessageWithTimestamp, error) {
+	// Find the active context window (after the most recent reset or compression break)
+	window := s.loadContextWindow(ctx, agentID, threadID)
+
 	// Build main query - only load messages after the break (if any)
 	query := sq.Select("role", "content", "tool_name", "created_at").
 		From("conversations").
 		Where(sq.Eq{"agent_id": agentID}).
 		Where(sq.Eq{"thread_id": threadID}).
 		Where(sq.NotEq{"role": "system"}).
+		Where(window.filter()).
 		OrderBy("created_at ASC")
 
-	// If we found a break, only load messages after it
-	if breakTimestamp.Valid {
-		query = query.Where(sq.Gt{"created_at": breakTimestamp.Int64})
-	}
-
 	queryStr, args, err := query.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("build query: %w", err)
@@ -601,6 +828,31 @@ func (s *chatService) LoadMessagesWithTimestamps(ctx context.Context, agentID, t
 				}
 			}
 
+		case roleReasoning:
+			// Reasoning is shown as its own message ahead of the answer it led to.
+			// A bare signature has nothing to show.
+			if content == "" {
+				continue
+			}
+			s.commitPendingMessagesWithTimestamp(&messages, currentUserTextBlocks, currentAssistantTextBlocks,
+				currentAssistantToolBlocks, currentToolResultBlocks, currentUserTimestamp, currentAssistantTimestamp, currentToolTimestamp)
+			messages = append(messages, MessageWithTimestamp{
+				Message: llm.Message{
+					Role:    llm.RoleAssistant,
+					Content: []llm.ContentBlock{llm.NewReasoningContentBlock(llm.ReasoningBlock{Text: content})},
+				},
+				Timestamp: createdAt,
+			})
+			currentUserTextBlocks = nil
+			currentAssistantTextBlocks = nil
+			currentAssistantToolBlocks = nil
+			currentToolResultBlocks = nil
+			currentUserTimestamp = 0
+			currentAssistantTimestamp = 0
+			currentToolTimestamp = 0
+			seenToolUseIDs = make(map[string]bool)
+			seenToolResultIDs = make(map[string]bool)
+
 		case roleTool:
 			if toolName.Valid && toolName.String != "" {
 				var toolResultData map[string]interface{}
@@ -721,49 +973,23 @@ func (s *chatService) commitPendingMessagesWithTimestamp(
 // Only loads messages after the most recent reset or compression break (if any).
 // Returns provider-neutral llm.Message types.
 func (s *chatService) LoadThread(ctx context.Context, agentID, threadID string) ([]llm.Message, error) {
-	// First, find the most recent context break (system message with type="reset" or "compress")
-	var breakTimestamp sql.NullInt64
-	breakQuery := sq.Select("content", "created_at").
-		From("conversations").
-		Where(sq.Eq{"agent_id": agentID}).
-		Where(sq.Eq{"thread_id": threadID}).
-		Where(sq.Eq{"role": roleSystem}).
-		OrderBy("created_at DESC")
-
-	breakQueryStr, breakArgs, err := breakQuery.ToSql()
-	if err == nil {
-		rows, err := s.db.QueryContext(ctx, breakQueryStr, breakArgs...)
-		if err == nil {
-			for rows.Next() {
-				var content string
-				var createdAt int64
-				if err := rows.Scan(&content, &createdAt); err == nil {
-					// Parse JSON to check if it's a reset or compress message
-					var msgData map[string]interface{}
-					if err := json.Unmarshal([]byte(content), &msgData); err == nil {
-						if msgType, ok := msgData["type"].(string); ok && (msgType == "reset" || msgType == "compress") {
-							breakTimestamp = sql.NullInt64{Int64: createdAt, Valid: true}
-							break
-						}
-					}
-				}
-			}
-			_ = rows.Close()
-		}
-	}
+	// Find the active context window (after the most recent reset or compression break)
+	window := s.loadContextWindow(ctx, agentID, threadID)
 
-	// Build main query - only load messages after the break (if any)
-	query := sq.Select("role", "content", "tool_name", "created_at").
+	// Build main query - only load messages inside the window (plus pinned messages)
+	// Unsigned reasoning is for display only; signed reasoning is replayed with the
+	// tool calls it led to, as providers that sign their reasoning require
+	query := sq.Select("role", "content", "tool_name", "created_at", "pinned", "attachments", "signature").
 		From("conversations").
 		Where(sq.Eq{"agent_id": agentID}).
 		Where(sq.Eq{"thread_id": threadID}).
+		Where(sq.Or{
+			sq.NotEq{"role": roleReasoning},
+			sq.NotEq{"signature": nil},
+		}).
+		Where(window.filter()).
 		OrderBy("created_at ASC")
 
-	// If we found a break, only load messages after it
-	if breakTimestamp.Valid {
-		query = query.Where(sq.Gt{"created_at": breakTimestamp.Int64})
-	}
-
 	queryStr, args, err := query.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("build query: %w", err)
@@ -777,6 +1003,9 @@ func (s *chatService) LoadThread(ctx context.Context, agentID, threadID string)
 
 	var messages []llm.Message
 	var currentUserTextBlocks []string
+	var currentUserPinned bool
+	var currentUserMedia []llm.MediaBlock
+	var currentAssistantReasoning []llm.ContentBlock
 	var currentAssistantTextBlocks []string
 	var currentAssistantToolBlocks []llm.ContentBlock
 	var currentToolResultBlocks []llm.ContentBlock
@@ -785,28 +1014,46 @@ func (s *chatService) LoadThread(ctx context.Context, agentID, threadID string)
 	seenToolUseIDs := make(map[string]bool)
 	seenToolResultIDs := make(map[string]bool)
 
+	// A compressed context starts with the summary of everything before the window
+	if window.summary != "" {
+		currentUserTextBlocks = []string{fmt.Sprintf("Previous conversation summary: %s", window.summary)}
+		lastRole = roleUser
+	}
+
 	for rows.Next() {
 		var role string
 		var content string
 		var toolName sql.NullString
 		var createdAt int64
+		var pinned bool
+		var attachmentsJSON sql.NullString
+		var signature sql.NullString
 
-		if err := rows.Scan(&role, &content, &toolName, &createdAt); err != nil {
+		if err := rows.Scan(&role, &content, &toolName, &createdAt, &pinned, &attachmentsJSON, &signature); err != nil {
 			return nil, err
 		}
 
 		// Handle different message types
 		switch role {
 		case roleUser:
-			// User text message
+			// User text message, with any images or documents that were sent with it
+			attachments, err := conversations.DecodeAttachments(attachmentsJSON.String)
+			if err != nil {
+				s.logger.Warn().Err(err).Str("thread_id", threadID).Msg("Skipping unreadable message attachments")
+			}
 			if lastRole == roleUser {
 				currentUserTextBlocks = append(currentUserTextBlocks, content)
+				currentUserPinned = currentUserPinned || pinned
+				currentUserMedia = append(currentUserMedia, attachments...)
 			} else {
 				// Role changed, commit previous messages
-				s.commitPendingMessages(&messages, currentUserTextBlocks, currentAssistantTextBlocks,
+				s.commitPendingMessages(&messages, currentUserTextBlocks, currentUserPinned, currentUserMedia, currentAssistantReasoning, currentAssistantTextBlocks,
 					currentAssistantToolBlocks, currentToolResultBlocks)
 
 				currentUserTextBlocks = []string{content}
+				currentUserPinned = pinned
+				currentUserMedia = attachments
+				currentAssistantReasoning = nil
 				currentAssistantTextBlocks = nil
 				currentAssistantToolBlocks = nil
 				currentToolResultBlocks = nil
@@ -858,9 +1105,12 @@ func (s *chatService) LoadThread(ctx context.Context, agentID, threadID string)
 
 				// Commit if role changed
 				if lastRole != roleAssistant && lastRole != "" {
-					s.commitPendingMessages(&messages, currentUserTextBlocks, currentAssistantTextBlocks,
+					s.commitPendingMessages(&messages, currentUserTextBlocks, currentUserPinned, currentUserMedia, currentAssistantReasoning, currentAssistantTextBlocks,
 						currentAssistantToolBlocks, currentToolResultBlocks)
 					currentUserTextBlocks = nil
+					currentUserPinned = false
+					currentUserMedia = nil
+					currentAssistantReasoning = nil
 					currentAssistantTextBlocks = nil
 					currentAssistantToolBlocks = nil
 					currentToolResultBlocks = nil
@@ -874,10 +1124,13 @@ func (s *chatService) LoadThread(ctx context.Context, agentID, threadID string)
 					currentAssistantTextBlocks = append(currentAssistantTextBlocks, content)
 				} else {
 					// Role changed or we have tool blocks, commit previous messages
-					s.commitPendingMessages(&messages, currentUserTextBlocks, currentAssistantTextBlocks,
+					s.commitPendingMessages(&messages, currentUserTextBlocks, currentUserPinned, currentUserMedia, currentAssistantReasoning, currentAssistantTextBlocks,
 						currentAssistantToolBlocks, currentToolResultBlocks)
 
 					currentUserTextBlocks = nil
+					currentUserPinned = false
+					currentUserMedia = nil
+					currentAssistantReasoning = nil
 					currentAssistantTextBlocks = []string{content}
 					currentAssistantToolBlocks = nil
 					currentToolResultBlocks = nil
@@ -887,6 +1140,28 @@ func (s *chatService) LoadThread(ctx context.Context, agentID, threadID string)
 				}
 			}
 
+		case roleReasoning:
+			// Signed reasoning opens the assistant message holding the text or tool calls it led to
+			if lastRole != roleAssistant || len(currentAssistantTextBlocks) > 0 || len(currentAssistantToolBlocks) > 0 {
+				s.commitPendingMessages(&messages, currentUserTextBlocks, currentUserPinned, currentUserMedia, currentAssistantReasoning,
+					currentAssistantTextBlocks, currentAssistantToolBlocks, currentToolResultBlocks)
+				currentUserTextBlocks = nil
+				currentUserPinned = false
+				currentUserMedia = nil
+				currentAssistantReasoning = nil
+				currentAssistantTextBlocks = nil
+				currentAssistantToolBlocks = nil
+				currentToolResultBlocks = nil
+				seenToolUseIDs = make(map[string]bool)
+				seenToolResultIDs = make(map[string]bool)
+			}
+			currentAssistantReasoning = append(currentAssistantReasoning, llm.NewReasoningContentBlock(llm.ReasoningBlock{
+				Text:      content,
+				Signature: signature.String,
+			}))
+			lastRole = roleAssistant
+			continue
+
 		case roleSystem:
 			// System messages (context breaks) are not sent to LLM API
 			// They are stored for UI display purposes only
@@ -940,9 +1215,12 @@ func (s *chatService) LoadThread(ctx context.Context, agentID, threadID string)
 
 				// Commit if role changed
 				if lastRole != roleTool && lastRole != "" {
-					s.commitPendingMessages(&messages, currentUserTextBlocks, currentAssistantTextBlocks,
+					s.commitPendingMessages(&messages, currentUserTextBlocks, currentUserPinned, currentUserMedia, currentAssistantReasoning, currentAssistantTextBlocks,
 						currentAssistantToolBlocks, currentToolResultBlocks)
 					currentUserTextBlocks = nil
+					currentUserPinned = false
+					currentUserMedia = nil
+					currentAssistantReasoning = nil
 					currentAssistantTextBlocks = nil
 					currentAssistantToolBlocks = nil
 					currentToolResultBlocks = nil
@@ -957,7 +1235,7 @@ func (s *chatService) LoadThread(ctx context.Context, agentID, threadID string)
 	}
 
 	// Commit any remaining messages
-	s.commitPendingMessages(&messages, currentUserTextBlocks, currentAssistantTextBlocks,
+	s.commitPendingMessages(&messages, currentUserTextBlocks, currentUserPinned, currentUserMedia, currentAssistantReasoning, currentAssistantTextBlocks,
 		currentAssistantToolBlocks, currentToolResultBlocks)
 
 	if err := rows.Err(); err != nil {
@@ -972,23 +1250,36 @@ func (s *chatService) LoadThread(ctx context.Context, agentID, threadID string)
 func (s *chatService) commitPendingMessages(
 	messages *[]llm.Message,
 	userTextBlocks []string,
+	userPinned bool,
+	userMedia []llm.MediaBlock,
+	assistantReasoning []llm.ContentBlock,
 	assistantTextBlocks []string,
 	assistantToolBlocks []llm.ContentBlock,
 	toolResultBlocks []llm.ContentBlock,
 ) {
 	// Commit user text messages
 	if len(userTextBlocks) > 0 {
-		*messages = append(*messages, llm.NewTextMessage(llm.RoleUser, strings.Join(userTextBlocks, "\n")))
+		userMsg := llm.NewTextMessage(llm.RoleUser, strings.Join(userTextBlocks, "\n"))
+		userMsg.Pinned = userPinned
+		for _, media := range userMedia {
+			userMsg.Content = append(userMsg.Content, llm.NewMediaBlock(media))
+		}
+		*messages = append(*messages, userMsg)
 	}
 
-	// Commit assistant messages (text or tool calls)
+	// Commit assistant messages (text or tool calls). Reasoning goes ahead of the tool calls
+	// it led to, or ahead of the text when there were none.
 	if len(assistantTextBlocks) > 0 {
-		*messages = append(*messages, llm.NewTextMessage(llm.RoleAssistant, strings.Join(assistantTextBlocks, "\n")))
+		textMsg := llm.NewTextMessage(llm.RoleAssistant, strings.Join(assistantTextBlocks, "\n"))
+		if len(assistantToolBlocks) == 0 {
+			textMsg.Content = append(append([]llm.ContentBlock{}, assistantReasoning...), textMsg.Content...)
+		}
+		*messages = append(*messages, textMsg)
 	}
 	if len(assistantToolBlocks) > 0 {
 		*messages = append(*messages, llm.Message{
 			Role:    llm.RoleAssistant,
-			Content: assistantToolBlocks,
+			Content: append(append([]llm.ContentBlock{}, assistantReasoning...), assistantToolBlocks...),
 		})
 	}
 
@@ -1032,6 +1323,11 @@ func (s *chatService) AppendAssistantMessage(ctx context.Context, agentID, threa
 	return s.conversationStore.AppendAssistantMessage(ctx, agentID, threadID, content)
 }
 
+// AppendReasoning saves the assistant's reasoning to the conversation history.
+func (s *chatService) AppendReasoning(ctx context.Context, agentID, threadID, content, signature string) error {
+	return s.conversationStore.AppendReasoning(ctx, agentID, threadID, content, signature)
+}
+
 // AppendToolCall saves an assistant message with tool use blocks to the conversation history.
 func (s *chatService) AppendToolCall(ctx context.Context, agentID, threadID, toolID, toolName string, toolInput any) error {
 	return s.conversationStore.AppendToolCall(ctx, agentID, threadID, toolID, toolName, toolInput)
@@ -1151,12 +1447,22 @@ func (s *chatService) CompressContext(ctx context.Context, agentID, threadID str
 		return fmt.Errorf("summarizer not available for agent %s", agentID)
 	}
 
-	// Use ContextManager to compress context
+	// Use ContextManager to compress context with the agent's configured strategy
 	cm := agent.NewContextManager(s.logger, s)
+	strategy, keepTurns := agent.CompressionSettings(agentConfig)
+	if strategy == agent.CompressionStrategySlidingWindow {
+		_, err = cm.CompressContextWindow(ctx, agentID, threadID, agentConfig.System, history, keepTurns, summarizer)
+		return err
+	}
 	_, err = cm.CompressContext(ctx, agentID, threadID, agentConfig.System, history, summarizer)
 	return err
 }
 
+// PinLastUserMessage pins the most recent user message in a thread so it survives context compression.
+func (s *chatService) PinLastUserMessage(ctx context.Context, agentID, threadID string) (string, error) {
+	return s.conversationStore.PinLastUserMessage(ctx, agentID, threadID)
+}
+
 // GetSystemInfo returns information about the system configuration.
 func (s *chatService) GetSystemInfo(ctx context.Context) (*SystemInfo, error) {
 	info := &SystemInfo{
@@ -1328,14 +1634,7 @@ func (s *chatService) ClearMemory(ctx context.Context) error {
 	}
 	defer tx.Rollback() //nolint:errcheck // No remedy for rollback errors
 
-	// Delete from FTS table first (if it exists)
-	_, err = tx.ExecContext(ctx, "DELETE FROM memory_items_fts")
-	if err != nil {
-		// FTS table might not exist, continue
-		s.logger.Warn().Err(err).Msg("FTS table might not exist, continuing")
-	}
-
-	// Delete from main table
+	// Triggers remove the matching memory_items_fts rows
 	_, err = tx.ExecContext(ctx, "DELETE FROM memory_items")
 	if err != nil {
 		return fmt.Errorf("delete memory items: %w", err)
@@ -1625,3 +1924,169 @@ func (s *chatService) DumpToolSchemas(ctx context.Context, filePath string) erro
 
 	return os.WriteFile(filePath, data, 0o600)
 }
+
+// ListArtifacts returns artifacts whose title or body contains query.
+func (s *chatService) ListArtifacts(ctx context.Context, query string) ([]*Artifact, error) {
+	artifacts, err := s.artifactStore.ListArtifacts(ctx, memory.ArtifactFilter{Query: query})
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(artifacts, func(a *memory.Artifact, _ int) *Artifact {
+		return artifactFromMemory(a)
+	}), nil
+}
+
+// GetArtifact returns an artifact, or one of its earlier versions.
+func (s *chatService) GetArtifact(ctx context.Context, id int64, version int) (*Artifact, error) {
+	a, err := s.artifactStore.GetArtifact(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if version == 0 || version == a.Version {
+		return artifactFromMemory(a), nil
+	}
+	v, err := s.artifactStore.GetArtifactVersion(ctx, id, version)
+	if err != nil {
+		return nil, err
+	}
+	return artifactVersionFromMemory(a, v), nil
+}
+
+// ListArtifactVersions returns every version of an artifact, oldest first.
+func (s *chatService) ListArtifactVersions(ctx context.Context, id int64) ([]*Artifact, error) {
+	a, err := s.artifactStore.GetArtifact(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := s.artifactStore.ArtifactVersions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(versions, func(v memory.ArtifactVersion, _ int) *Artifact {
+		return artifactVersionFromMemory(a, &v)
+	}), nil
+}
+
+// artifactFromMemory converts the current version of an artifact for display.
+func artifactFromMemory(a *memory.Artifact) *Artifact {
+	return &Artifact{
+		ID:        a.ID,
+		AgentID:   lo.FromPtr(a.AgentID),
+		ThreadID:  lo.FromPtr(a.ThreadID),
+		Scope:     string(a.Scope),
+		Title:     a.Title,
+		Body:      a.Body,
+		Version:   a.Version,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+	}
+}
+
+// artifactVersionFromMemory converts an earlier version of artifact a for display.
+func artifactVersionFromMemory(a *memory.Artifact, v *memory.ArtifactVersion) *Artifact {
+	out := artifactFromMemory(a)
+	out.AgentID = lo.FromPtr(v.AgentID)
+	out.Title = v.Title
+	out.Body = v.Body
+	out.Version = v.Version
+	out.UpdatedAt = v.CreatedAt
+	return out
+}
+
+// provenanceContextMessages is how many messages are shown on either side of a memory's
+// source message.
+const provenanceContextMessages = 5
+
+// SearchMemory returns the memories of every agent that match query. Only keyword search
+// is available here, since the service has no embedder.
+func (s *chatService) SearchMemory(ctx context.Context, query string) ([]*MemoryResult, error) {
+	results, err := s.artifactStore.SearchMemory(ctx, &memory.SearchQuery{QueryText: query})
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(results, func(r memory.SearchResult, _ int) *MemoryResult {
+		out := &MemoryResult{
+			ID:        r.Item.ID,
+			AgentID:   lo.FromPtr(r.Item.AgentID),
+			Group:     r.Item.Group,
+			Scope:     string(r.Item.Scope),
+			Type:      string(r.Item.Type),
+			Content:   r.Item.Content,
+			CreatedAt: r.Item.CreatedAt,
+		}
+		if r.Match != nil {
+			out.Snippet = r.Match.Snippet
+		}
+		return out
+	}), nil
+}
+
+// GetMemoryProvenance returns where a memory came from, with the conversation around its
+// source message.
+func (s *chatService) GetMemoryProvenance(ctx context.Context, id int64) (*MemoryProvenance, error) {
+	item, err := s.artifactStore.GetMemory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	out := &MemoryProvenance{MemoryID: item.ID}
+	p := item.Provenance
+	if p == nil {
+		return out, nil
+	}
+	out.AgentID = p.AgentID
+	out.ThreadID = p.ThreadID
+	out.MessageID = p.MessageID
+	out.ToolID = p.ToolID
+	out.Source = p.Source
+	if p.MessageID == 0 || p.ThreadID == "" {
+		return out, nil
+	}
+
+	messages, err := s.conversationStore.MessagesAround(ctx, p.AgentID, p.ThreadID, p.MessageID, provenanceContextMessages)
+	if err != nil {
+		return nil, fmt.Errorf("load source conversation: %w", err)
+	}
+	out.Messages = lo.Map(messages, func(m conversations.Message, _ int) SourceMessage {
+		return SourceMessage{
+			ID:        m.ID,
+			Role:      m.Role,
+			Content:   m.Content,
+			ToolName:  m.ToolName,
+			Timestamp: m.CreatedAt,
+			IsSource:  m.ID == p.MessageID || (p.ToolID != "" && m.ToolID == p.ToolID),
+		}
+	})
+	return out, nil
+}
+
+// GetUserProfile returns the user profile, or nil if none has been built yet.
+func (s *chatService) GetUserProfile(ctx context.Context) (*UserProfile, error) {
+	item, err := s.artifactStore.Profile(ctx)
+	if errors.Is(err, memory.ErrNoProfile) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return userProfileFromMemory(item), nil
+}
+
+// UpdateUserProfile replaces the user profile.
+func (s *chatService) UpdateUserProfile(ctx context.Context, content string) (*UserProfile, error) {
+	item, err := s.artifactStore.SetProfile(ctx, content)
+	if err != nil {
+		return nil, err
+	}
+	return userProfileFromMemory(item), nil
+}
+
+// userProfileFromMemory converts the memory item holding the user profile for display.
+func userProfileFromMemory(item *memory.MemoryItem) *UserProfile {
+	out := &UserProfile{
+		ID:        item.ID,
+		Content:   item.Content,
+		UpdatedAt: item.UpdatedAt,
+	}
+	out.UpdatedBy, _ = item.Metadata["updated_by"].(string)
+	return out
+}
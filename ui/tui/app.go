@@ -28,8 +28,10 @@ type App struct {
 	chatService ui.ChatService
 
 	// Chat-related fields
-	chatHistory map[string][]llm.Message // agentID -> conversation history
-	chatMutex   sync.RWMutex             // protects chatHistory
+	chatHistory        map[string][]llm.Message    // agentID -> conversation history
+	pendingAttachments map[string][]llm.MediaBlock // agentID -> media queued for the next message
+	lastReasoning      map[string]string           // agentID -> reasoning behind the latest response
+	chatMutex          sync.RWMutex                // protects chatHistory, pendingAttachments and lastReasoning
 
 	// Config-related fields
 	configPath string
@@ -60,12 +62,14 @@ func NewAppWithTheme(logger zerolog.Logger, configPath string, chatService ui.Ch
 	}
 
 	return &App{
-		app:         tviewApp,
-		pages:       tview.NewPages(),
-		chatService: chatService,
-		chatHistory: make(map[string][]llm.Message),
-		logger:      logger,
-		configPath:  configPath,
+		app:                tviewApp,
+		pages:              tview.NewPages(),
+		chatService:        chatService,
+		chatHistory:        make(map[string][]llm.Message),
+		pendingAttachments: make(map[string][]llm.MediaBlock),
+		lastReasoning:      make(map[string]string),
+		logger:             logger,
+		configPath:         configPath,
 	}
 }
 
@@ -86,6 +90,15 @@ func (a *App) setupUI() {
 		AddItem("Crew Members", "View all agents", '2', func() {
 			a.showCrewMembers()
 		}).
+		AddItem("Artifacts", "Read reports and handoff documents", '5', func() {
+			a.showArtifacts()
+		}).
+		AddItem("Memory", "Search memories and jump to their source", '6', func() {
+			a.showMemory()
+		}).
+		AddItem("Profile", "Edit what agents know about you", '7', func() {
+			a.showProfile()
+		}).
 		AddItem("Settings", "Configure settings", '3', func() {
 			a.showSettings()
 		}).
@@ -0,0 +1,179 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/aschepis/backscratcher/staff/ui"
+)
+
+// showArtifacts lists artifacts written by agents, most recently updated first.
+func (a *App) showArtifacts() {
+	artifactList := tview.NewList()
+	artifactList.SetBorder(true).SetTitle("Artifacts - Select to Read (r: Refresh)")
+
+	refreshArtifacts := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		artifacts, err := a.chatService.ListArtifacts(ctx, "")
+		a.app.QueueUpdateDraw(func() {
+			artifactList.Clear()
+			switch {
+			case err != nil:
+				artifactList.AddItem("Error", fmt.Sprintf("Failed to load artifacts: %v", err), ' ', nil)
+			case len(artifacts) == 0:
+				artifactList.AddItem("No artifacts", "Agents haven't written any artifacts yet", ' ', nil)
+			default:
+				for _, artifact := range artifacts {
+					artifactCopy := artifact
+					artifactList.AddItem(artifactTitle(artifact), artifactSummary(artifact), 0, func() {
+						a.showArtifactDetail(artifactCopy, "artifacts")
+					})
+				}
+			}
+			artifactList.AddItem("Back", "Return to main menu", 'b', func() {
+				a.pages.SwitchToPage("main")
+				a.app.SetFocus(a.sidebar)
+			})
+		})
+	}
+
+	// Load asynchronously so the UI stays responsive
+	go refreshArtifacts()
+
+	artifactList.SetInputCapture(func(ev *tcell.EventKey) *tcell.EventKey {
+		switch ev.Key() {
+		case tcell.KeyEsc:
+			a.pages.SwitchToPage("main")
+			a.app.SetFocus(a.sidebar)
+			return nil
+		case tcell.KeyRune:
+			if ev.Rune() == 'r' || ev.Rune() == 'R' {
+				go refreshArtifacts()
+				return nil
+			}
+		}
+		return ev
+	})
+
+	a.pages.AddPage("artifacts", artifactList, true, false)
+	a.pages.SwitchToPage("artifacts")
+	a.app.SetFocus(artifactList)
+}
+
+// showArtifactDetail shows one version of an artifact. Esc returns to backPage.
+func (a *App) showArtifactDetail(artifact *ui.Artifact, backPage string) {
+	detailView := tview.NewTextView()
+	detailView.SetDynamicColors(true).
+		SetWordWrap(true).
+		SetScrollable(true).
+		SetBorder(true).
+		SetTitle(fmt.Sprintf("%s (v%d) - h: History, Esc: Back", artifactTitle(artifact), artifact.Version))
+
+	var content strings.Builder
+	author := artifact.AgentID
+	if author == "" {
+		author = "You"
+	}
+	content.WriteString(fmt.Sprintf("[yellow]Author[white]: %s\n", author))
+	content.WriteString(fmt.Sprintf("[yellow]Scope[white]: %s\n", artifact.Scope))
+	if artifact.ThreadID != "" {
+		content.WriteString(fmt.Sprintf("[yellow]Thread[white]: %s\n", artifact.ThreadID))
+	}
+	content.WriteString(fmt.Sprintf("[yellow]Version[white]: %d\n", artifact.Version))
+	content.WriteString(fmt.Sprintf("[yellow]Updated[white]: %s\n\n", artifact.UpdatedAt.Format("2006-01-02 15:04:05")))
+	content.WriteString(tview.Escape(artifact.Body))
+	detailView.SetText(content.String())
+
+	pageName := fmt.Sprintf("%s/artifact_%d_v%d", backPage, artifact.ID, artifact.Version)
+	detailView.SetInputCapture(func(ev *tcell.EventKey) *tcell.EventKey {
+		switch ev.Key() {
+		case tcell.KeyEsc:
+			a.pages.RemovePage(pageName)
+			a.returnToPage(backPage)
+			return nil
+		case tcell.KeyRune:
+			if ev.Rune() == 'h' || ev.Rune() == 'H' {
+				a.showArtifactVersions(artifact.ID, pageName)
+				return nil
+			}
+		}
+		return ev
+	})
+
+	a.pages.AddPage(pageName, detailView, true, false)
+	a.pages.SwitchToPage(pageName)
+	a.app.SetFocus(detailView)
+}
+
+// showArtifactVersions lists every version of an artifact, newest first.
+func (a *App) showArtifactVersions(id int64, backPage string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	versions, err := a.chatService.ListArtifactVersions(ctx, id)
+	if err != nil {
+		a.showErrorModal("Artifact History", fmt.Sprintf("Failed to load versions: %v", err))
+		return
+	}
+
+	pageName := fmt.Sprintf("%s/artifact_%d_versions", backPage, id)
+	versionList := tview.NewList()
+	versionList.SetBorder(true).SetTitle(fmt.Sprintf("Artifact #%d History - Select a Version", id))
+	for i := len(versions) - 1; i >= 0; i-- {
+		v := versions[i]
+		author := v.AgentID
+		if author == "" {
+			author = "You"
+		}
+		label := fmt.Sprintf("v%d: %s", v.Version, artifactTitle(v))
+		secondary := fmt.Sprintf("By %s, %s", author, v.UpdatedAt.Format("Jan 2, 15:04"))
+		versionList.AddItem(label, secondary, 0, func() {
+			a.showArtifactDetail(v, pageName)
+		})
+	}
+	versionList.SetInputCapture(func(ev *tcell.EventKey) *tcell.EventKey {
+		if ev.Key() == tcell.KeyEsc {
+			a.pages.RemovePage(pageName)
+			a.returnToPage(backPage)
+			return nil
+		}
+		return ev
+	})
+
+	a.pages.AddPage(pageName, versionList, true, false)
+	a.pages.SwitchToPage(pageName)
+	a.app.SetFocus(versionList)
+}
+
+// artifactTitle returns an artifact's title, or a placeholder for untitled ones.
+func artifactTitle(artifact *ui.Artifact) string {
+	if artifact.Title == "" {
+		return fmt.Sprintf("Untitled #%d", artifact.ID)
+	}
+	return artifact.Title
+}
+
+// artifactSummary describes an artifact's author, version, scope and last update.
+func artifactSummary(artifact *ui.Artifact) string {
+	author := artifact.AgentID
+	if author == "" {
+		author = "You"
+	}
+	return fmt.Sprintf("By %s | v%d | %s | %s", author, artifact.Version, artifact.Scope,
+		artifact.UpdatedAt.Format("Jan 2, 15:04"))
+}
+
+// returnToPage switches back to a page and focuses it.
+func (a *App) returnToPage(name string) {
+	a.pages.SwitchToPage(name)
+	if _, page := a.pages.GetFrontPage(); page != nil {
+		a.app.SetFocus(page)
+	}
+}
@@ -82,7 +82,7 @@ func (a *App) showChat(agentID string) {
 	if provider != "" && model != "" {
 		title += fmt.Sprintf(" (%s/%s)", provider, model)
 	}
-	title += " (Esc: back, Tab: focus input, Alt+Enter: send, /reset: reset context, /compress: compress context, exit: leave)"
+	title += " (Esc: back, Tab: focus input, Alt+Enter: send, /reset: reset context, /compress: compress context, /pin: pin last message, /attach <file>: attach image or PDF, /reasoning: show reasoning, exit: leave)"
 	chatDisplay.SetDynamicColors(true).
 		SetWordWrap(true).
 		SetBorder(true).
@@ -96,7 +96,7 @@ func (a *App) showChat(agentID string) {
 	textArea := tview.NewTextArea()
 	textArea.SetLabel("You: ").
 		SetBorder(true).
-		SetTitle("Message (Alt+Enter: send, Enter: new line, Tab: scroll chat, /reset: reset context, /compress: compress context, exit: leave, Esc: back)")
+		SetTitle("Message (Alt+Enter: send, Enter: new line, Tab: scroll chat, /reset: reset context, /compress: compress context, /pin: pin last message, /attach <file>: attach image or PDF, /reasoning: show reasoning, exit: leave, Esc: back)")
 
 	// Add input capture to chat display for arrow key scrolling
 	// Must be after textArea is declared so we can reference it
@@ -174,7 +174,8 @@ func (a *App) showChat(agentID string) {
 		if len(lines) == 1 || (len(lines) > 0 && strings.HasPrefix(strings.TrimSpace(lines[0]), "/")) {
 			firstLine := strings.TrimSpace(lines[0])
 			if strings.HasPrefix(firstLine, "/") {
-				command := strings.ToLower(firstLine)
+				command, arg, _ := strings.Cut(firstLine, " ")
+				command = strings.ToLower(command)
 				textArea.SetText("", true)
 
 				switch command {
@@ -184,11 +185,20 @@ func (a *App) showChat(agentID string) {
 				case "/compress":
 					go a.handleCompressContext(agentID, agentName, threadID, chatDisplay)
 					return
+				case "/pin":
+					go a.handlePinMessage(agentID, threadID, chatDisplay)
+					return
+				case "/attach":
+					a.handleAttachFile(agentID, strings.TrimSpace(arg), chatDisplay)
+					return
+				case "/reasoning":
+					a.handleShowReasoning(agentID, chatDisplay)
+					return
 				default:
 					// Unknown command - show error and don't send
 					a.app.QueueUpdateDraw(func() {
 						_, _ = fmt.Fprintf(chatDisplay, "[red]Unknown command: %s[white]\n", firstLine)
-						_, _ = fmt.Fprintf(chatDisplay, "[gray]Available commands: /reset, /compress, exit[white]\n\n")
+						_, _ = fmt.Fprintf(chatDisplay, "[gray]Available commands: /reset, /compress, /pin, /attach <file>, /reasoning, exit[white]\n\n")
 						chatDisplay.ScrollToEnd()
 					})
 					return
@@ -370,14 +380,28 @@ func (a *App) updateChatDisplay(chatDisplay *tview.TextView, agentID, agentName,
 				// Render regular message
 				var textBuilder strings.Builder
 
-				// Extract text from message content blocks
+				var reasoningBuilder strings.Builder
+
+				// Extract text and reasoning from message content blocks
 				for _, block := range item.msg.Content {
-					// Check if this is a text block
-					if block.Type == llm.ContentBlockTypeText {
+					switch block.Type {
+					case llm.ContentBlockTypeText:
 						textBuilder.WriteString(block.Text)
+					case llm.ContentBlockTypeReasoning:
+						if block.Reasoning != nil {
+							reasoningBuilder.WriteString(block.Reasoning.Text)
+						}
 					}
 				}
 
+				// Reasoning is shown collapsed; /reasoning expands the most recent one
+				if reasoning := strings.TrimSpace(reasoningBuilder.String()); reasoning != "" {
+					a.chatMutex.Lock()
+					a.lastReasoning[agentID] = reasoning
+					a.chatMutex.Unlock()
+					_, _ = fmt.Fprintf(chatDisplay, "[gray]  💭 Reasoning (%d words)[white]\n", len(strings.Fields(reasoning)))
+				}
+
 				text := strings.TrimSpace(textBuilder.String())
 				if text == "" {
 					continue // Skip empty messages
@@ -503,8 +527,56 @@ func (a *App) handleChatMessage(agentID, agentName, message string, chatDisplay
 	}
 	ctx = agent.WithDebugCallback(ctx, debugCallback)
 
+	// Create reasoning callback. Reasoning is shown collapsed while streaming and can be
+	// expanded with /reasoning once the response is complete.
+	var reasoning strings.Builder
+	reasoningCallback := func(text string) {
+		if reasoning.Len() == 0 {
+			a.app.QueueUpdateDraw(func() {
+				_, _ = fmt.Fprintf(chatDisplay, "[gray]  💭 Reasoning... (/reasoning to expand)[white]\n")
+				chatDisplay.ScrollToEnd()
+			})
+		}
+		reasoning.WriteString(text)
+	}
+	ctx = agent.WithReasoningCallback(ctx, reasoningCallback)
+
+	// Create turn event callback to show tool activity as it happens
+	turnEventCallback := func(event agent.TurnEvent) {
+		var line string
+		switch {
+		case event.Type == agent.TurnEventToolUse:
+			line = fmt.Sprintf("[gray]  🔧 %s...[white]\n", event.ToolName)
+		case event.Type == agent.TurnEventToolResult && event.IsError:
+			line = fmt.Sprintf("[gray]  ❌ %s failed[white]\n", event.ToolName)
+		case event.Type == agent.TurnEventToolResult:
+			line = fmt.Sprintf("[gray]  ✅ %s done[white]\n", event.ToolName)
+		default:
+			return
+		}
+		a.app.QueueUpdateDraw(func() {
+			_, _ = fmt.Fprint(chatDisplay, line)
+			chatDisplay.ScrollToEnd()
+		})
+	}
+	ctx = agent.WithTurnEventCallback(ctx, turnEventCallback)
+
+	// Attach any files queued with /attach to this message
+	a.chatMutex.Lock()
+	attachments := a.pendingAttachments[agentID]
+	delete(a.pendingAttachments, agentID)
+	a.chatMutex.Unlock()
+	if len(attachments) > 0 {
+		ctx = agent.WithAttachments(ctx, attachments)
+	}
+
 	// Run agent with streaming using the chat service
 	response, err := a.chatService.SendMessageStream(ctx, agentID, threadID, message, history, streamCallback)
+	if reasoning.Len() > 0 {
+		a.chatMutex.Lock()
+		a.lastReasoning[agentID] = strings.TrimSpace(reasoning.String())
+		a.chatMutex.Unlock()
+	}
 
 	// Update UI in main thread
 	a.app.QueueUpdateDraw(func() {
@@ -637,3 +709,77 @@ func (a *App) handleCompressContext(agentID, agentName, threadID string, chatDis
 		chatDisplay.ScrollToEnd()
 	})
 }
+
+// handlePinMessage handles the /pin command
+func (a *App) handlePinMessage(agentID, threadID string, chatDisplay *tview.TextView) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	content, err := a.chatService.PinLastUserMessage(ctx, agentID, threadID)
+	a.app.QueueUpdateDraw(func() {
+		if err != nil {
+			_, _ = fmt.Fprintf(chatDisplay, "[red]Error pinning message: %v[white]\n\n", err)
+		} else {
+			_, _ = fmt.Fprintf(chatDisplay, "[magenta]  📌 Pinned: %s[white]\n\n", tview.Escape(truncateForPin(content)))
+		}
+		chatDisplay.ScrollToEnd()
+	})
+}
+
+// handleAttachFile handles the /attach command by queueing an image or PDF
+// to be sent with the next message to the agent.
+func (a *App) handleAttachFile(agentID, path string, chatDisplay *tview.TextView) {
+	if path == "" {
+		_, _ = fmt.Fprintf(chatDisplay, "[red]Usage: /attach <path to image or PDF>[white]\n\n")
+		chatDisplay.ScrollToEnd()
+		return
+	}
+
+	block, err := llm.NewMediaBlockFromFile(path)
+	if err == nil {
+		// Fail now rather than when the message is sent if the file is missing or too large
+		var info os.FileInfo
+		if info, err = os.Stat(path); err == nil && info.Size() > llm.MaxMediaBytes {
+			err = fmt.Errorf("file is %d bytes, exceeds limit of %d", info.Size(), llm.MaxMediaBytes)
+		}
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(chatDisplay, "[red]Error attaching file: %v[white]\n\n", err)
+		chatDisplay.ScrollToEnd()
+		return
+	}
+
+	a.chatMutex.Lock()
+	a.pendingAttachments[agentID] = append(a.pendingAttachments[agentID], *block.Media)
+	count := len(a.pendingAttachments[agentID])
+	a.chatMutex.Unlock()
+
+	_, _ = fmt.Fprintf(chatDisplay, "[magenta]  📎 Attached %s (%d pending, sent with your next message)[white]\n\n",
+		tview.Escape(block.Media.DisplayName()), count)
+	chatDisplay.ScrollToEnd()
+}
+
+// handleShowReasoning handles the /reasoning command by expanding the reasoning
+// behind the agent's most recent response.
+func (a *App) handleShowReasoning(agentID string, chatDisplay *tview.TextView) {
+	a.chatMutex.RLock()
+	reasoning := a.lastReasoning[agentID]
+	a.chatMutex.RUnlock()
+
+	if reasoning == "" {
+		_, _ = fmt.Fprintf(chatDisplay, "[gray]No reasoning recorded for the latest response[white]\n\n")
+	} else {
+		_, _ = fmt.Fprintf(chatDisplay, "[gray]💭 Reasoning:\n%s[white]\n\n", tview.Escape(reasoning))
+	}
+	chatDisplay.ScrollToEnd()
+}
+
+// truncateForPin shortens a pinned message for the confirmation line, cutting between runes.
+func truncateForPin(content string) string {
+	const maxLen = 80
+	runes := []rune(strings.ReplaceAll(content, "\n", " "))
+	if len(runes) <= maxLen {
+		return string(runes)
+	}
+	return string(runes[:maxLen]) + "..."
+}
@@ -0,0 +1,199 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/aschepis/backscratcher/staff/ui"
+)
+
+// showMemory searches the memories of every agent. Selecting a result jumps to the
+// conversation it came from.
+func (a *App) showMemory() {
+	resultList := tview.NewList()
+	resultList.SetBorder(true).SetTitle("Memories - Select to View Source (/: Search)")
+	resultList.AddItem("Type a query and press Enter", "", ' ', nil)
+
+	searchField := tview.NewInputField().SetLabel("Search: ")
+	searchField.SetBorder(true)
+
+	goBack := func() {
+		a.pages.RemovePage("memory")
+		a.pages.SwitchToPage("main")
+		a.app.SetFocus(a.sidebar)
+	}
+
+	search := func(query string) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		results, err := a.chatService.SearchMemory(ctx, query)
+		a.app.QueueUpdateDraw(func() {
+			resultList.Clear()
+			switch {
+			case err != nil:
+				resultList.AddItem("Error", fmt.Sprintf("Failed to search memory: %v", err), ' ', nil)
+			case len(results) == 0:
+				resultList.AddItem("No memories", fmt.Sprintf("Nothing matches %q", query), ' ', nil)
+			default:
+				for _, result := range results {
+					id := result.ID
+					resultList.AddItem(tview.Escape(memoryLabel(result)), memorySummary(result), 0, func() {
+						a.showMemorySource(id, "memory")
+					})
+				}
+				a.app.SetFocus(resultList)
+			}
+		})
+	}
+
+	searchField.SetDoneFunc(func(key tcell.Key) {
+		switch key {
+		case tcell.KeyEnter:
+			if query := strings.TrimSpace(searchField.GetText()); query != "" {
+				go search(query)
+			}
+		case tcell.KeyTab, tcell.KeyDown:
+			a.app.SetFocus(resultList)
+		case tcell.KeyEsc:
+			goBack()
+		}
+	})
+
+	resultList.SetInputCapture(func(ev *tcell.EventKey) *tcell.EventKey {
+		switch ev.Key() {
+		case tcell.KeyEsc:
+			goBack()
+			return nil
+		case tcell.KeyRune:
+			if ev.Rune() == '/' {
+				a.app.SetFocus(searchField)
+				return nil
+			}
+		}
+		return ev
+	})
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(searchField, 3, 0, true).
+		AddItem(resultList, 0, 1, false)
+
+	a.pages.AddPage("memory", layout, true, false)
+	a.pages.SwitchToPage("memory")
+	a.app.SetFocus(searchField)
+}
+
+// showMemorySource shows where a memory came from, with the conversation around its source
+// message highlighted. Esc returns to backPage.
+func (a *App) showMemorySource(id int64, backPage string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	provenance, err := a.chatService.GetMemoryProvenance(ctx, id)
+	if err != nil {
+		a.showErrorModal("Memory Source", fmt.Sprintf("Failed to load source: %v", err))
+		return
+	}
+
+	sourceView := tview.NewTextView()
+	sourceView.SetDynamicColors(true).
+		SetWordWrap(true).
+		SetScrollable(true).
+		SetBorder(true).
+		SetTitle(fmt.Sprintf("Memory #%d Source - Esc: Back", id))
+	sourceView.SetText(formatProvenance(provenance))
+
+	pageName := fmt.Sprintf("%s/memory_%d_source", backPage, id)
+	sourceView.SetInputCapture(func(ev *tcell.EventKey) *tcell.EventKey {
+		if ev.Key() == tcell.KeyEsc {
+			a.pages.RemovePage(pageName)
+			a.returnToPage(backPage)
+			return nil
+		}
+		return ev
+	})
+
+	a.pages.AddPage(pageName, sourceView, true, false)
+	a.pages.SwitchToPage(pageName)
+	a.app.SetFocus(sourceView)
+}
+
+// formatProvenance renders a memory's provenance and source conversation for a text view.
+func formatProvenance(p *ui.MemoryProvenance) string {
+	var content strings.Builder
+	if p.Source == "" && p.ThreadID == "" {
+		content.WriteString("[gray]This memory was stored without a recorded source.[white]\n")
+		return content.String()
+	}
+
+	content.WriteString(fmt.Sprintf("[yellow]Agent[white]: %s\n", tview.Escape(p.AgentID)))
+	content.WriteString(fmt.Sprintf("[yellow]Thread[white]: %s\n", tview.Escape(p.ThreadID)))
+	content.WriteString(fmt.Sprintf("[yellow]Stored by[white]: %s\n", tview.Escape(p.Source)))
+	if p.ToolID != "" {
+		content.WriteString(fmt.Sprintf("[yellow]Tool call[white]: %s\n", tview.Escape(p.ToolID)))
+	}
+	content.WriteString("\n")
+
+	if len(p.Messages) == 0 {
+		content.WriteString("[gray]The source conversation is no longer available.[white]\n")
+		return content.String()
+	}
+	for _, m := range p.Messages {
+		role := m.Role
+		if m.ToolName != "" {
+			role = fmt.Sprintf("%s (%s)", m.Role, m.ToolName)
+		}
+		header := fmt.Sprintf("%s  %s", m.Timestamp.Format("2006-01-02 15:04:05"), tview.Escape(role))
+		if m.IsSource {
+			content.WriteString(fmt.Sprintf("[black:yellow] %s [-:-]\n", header))
+			content.WriteString(fmt.Sprintf("[yellow]%s[white]\n\n", tview.Escape(m.Content)))
+			continue
+		}
+		content.WriteString(fmt.Sprintf("[gray]%s[white]\n%s\n\n", header, tview.Escape(m.Content)))
+	}
+	return content.String()
+}
+
+// memoryLabel returns a memory's content, shortened to fit on one line.
+func memoryLabel(result *ui.MemoryResult) string {
+	label := strings.Join(strings.Fields(result.Content), " ")
+	if len(label) > 100 {
+		label = label[:97] + "..."
+	}
+	return label
+}
+
+// memorySummary describes a memory's owner and type, and why it matched.
+func memorySummary(result *ui.MemoryResult) string {
+	owner := result.AgentID
+	switch {
+	case result.Group != "":
+		owner = "group " + result.Group
+	case owner == "":
+		owner = "global"
+	}
+	summary := fmt.Sprintf("#%d | %s | %s | %s", result.ID, owner, result.Type, result.CreatedAt.Format("Jan 2, 15:04"))
+	if result.Snippet != "" {
+		summary += " | " + highlightMatches(result.Snippet)
+	}
+	return summary
+}
+
+// highlightMatches escapes a search snippet and colors the terms wrapped in "**".
+func highlightMatches(snippet string) string {
+	parts := strings.Split(strings.Join(strings.Fields(snippet), " "), "**")
+	var out strings.Builder
+	for i, part := range parts {
+		if i%2 == 1 {
+			out.WriteString("[yellow]" + tview.Escape(part) + "[-]")
+			continue
+		}
+		out.WriteString(tview.Escape(part))
+	}
+	return out.String()
+}
@@ -16,7 +16,7 @@ import (
 func (a *App) showInbox() {
 	// Create a list for inbox items
 	inboxList := tview.NewList()
-	inboxList.SetBorder(true).SetTitle("Inbox - Select to View Details (a: Archive, r: Refresh)")
+	inboxList.SetBorder(true).SetTitle("Inbox - Select to View Details (a: Archive, c: Confirm fact, x: Decline fact, r: Refresh)")
 
 	// Store current items for archiving - this will be updated on each refresh
 	var currentItems []*ui.InboxItem
@@ -67,6 +67,9 @@ func (a *App) showInbox() {
 					if item.RequiresResponse {
 						secondaryText += " [red](Response Required)[white]"
 					}
+					if item.PendingFact != "" {
+						secondaryText += " [yellow](Fact to Confirm)[white]"
+					}
 
 					// Capture item in closure
 					itemCopy := item
@@ -85,6 +88,44 @@ func (a *App) showInbox() {
 	// Initial load - run asynchronously so it doesn't block UI startup
 	go refreshInbox()
 
+	// confirmSelected stores or declines the fact the selected item asks about
+	confirmSelected := func(accept bool) {
+		currentItemIndex := inboxList.GetCurrentItem()
+
+		itemsMutex.RLock()
+		items := currentItems
+		itemsMutex.RUnlock()
+
+		if currentItemIndex < 0 || currentItemIndex >= len(items) || items[currentItemIndex].PendingFact == "" {
+			return
+		}
+		item := items[currentItemIndex]
+		originalTitle := inboxList.GetTitle()
+
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			err := a.chatService.ConfirmInboxFact(ctx, item.ID, accept)
+
+			a.app.QueueUpdateDraw(func() {
+				if err != nil {
+					inboxList.SetTitle(fmt.Sprintf("Inbox - Confirm failed: %v", err))
+					go func() {
+						time.Sleep(2 * time.Second)
+						a.app.QueueUpdateDraw(func() {
+							inboxList.SetTitle(originalTitle)
+						})
+					}()
+				}
+			})
+
+			if err == nil {
+				go refreshInbox()
+			}
+		}()
+	}
+
 	// Handle input
 	inboxList.SetInputCapture(func(ev *tcell.EventKey) *tcell.EventKey {
 		switch ev.Key() {
@@ -98,6 +139,12 @@ func (a *App) showInbox() {
 				// Refresh inbox
 				go refreshInbox()
 				return nil
+			case 'c', 'C':
+				confirmSelected(true)
+				return nil
+			case 'x', 'X':
+				confirmSelected(false)
+				return nil
 			case 'a', 'A':
 				// Archive selected item
 				currentItemIndex := inboxList.GetCurrentItem()
@@ -188,6 +235,10 @@ func (a *App) showInboxItemDetail(item *ui.InboxItem) {
 		content.WriteString("[red]⚠ Response Required[white]\n\n")
 	}
 
+	if item.PendingFact != "" {
+		content.WriteString(fmt.Sprintf("[yellow]Fact to confirm[white]: %s\n(c: Confirm, x: Decline from the inbox list)\n\n", item.PendingFact))
+	}
+
 	if item.Response != "" {
 		responseTime := ""
 		if item.ResponseAt != nil {
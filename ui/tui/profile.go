@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/aschepis/backscratcher/staff/ui"
+)
+
+// profileTitle is the title of the profile editor.
+const profileTitle = "User Profile - Ctrl+S: Save, Esc: Back"
+
+// showProfile opens the user profile in an editor. Notes and edited or added sections are
+// kept when the profile is rebuilt from memories; untouched sections are regenerated.
+func (a *App) showProfile() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	profile, err := a.chatService.GetUserProfile(ctx)
+	if err != nil {
+		a.showErrorModal("User Profile", fmt.Sprintf("Failed to load profile: %v", err))
+		return
+	}
+
+	editor := tview.NewTextArea()
+	editor.SetBorder(true).SetTitle(profileTitle)
+	editor.SetPlaceholder("No profile yet. It is built from personal memories, or write one here.")
+	if profile != nil {
+		editor.SetText(profile.Content, false)
+	}
+
+	status := tview.NewTextView().SetDynamicColors(true)
+	status.SetText(profileStatus(profile))
+
+	goBack := func() {
+		a.pages.RemovePage("profile")
+		a.pages.SwitchToPage("main")
+		a.app.SetFocus(a.sidebar)
+	}
+
+	save := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		updated, err := a.chatService.UpdateUserProfile(ctx, editor.GetText())
+		if err != nil {
+			status.SetText(fmt.Sprintf("[red]Failed to save profile: %v[white]", tview.Escape(err.Error())))
+			return
+		}
+		status.SetText(profileStatus(updated))
+	}
+
+	editor.SetInputCapture(func(ev *tcell.EventKey) *tcell.EventKey {
+		switch ev.Key() {
+		case tcell.KeyCtrlS:
+			save()
+			return nil
+		case tcell.KeyEsc:
+			goBack()
+			return nil
+		}
+		return ev
+	})
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(editor, 0, 1, true).
+		AddItem(status, 1, 0, false)
+
+	a.pages.AddPage("profile", layout, true, false)
+	a.pages.SwitchToPage("profile")
+	a.app.SetFocus(editor)
+}
+
+// profileStatus describes who last updated the profile and when.
+func profileStatus(p *ui.UserProfile) string {
+	if p == nil {
+		return "[gray]Not created yet[white]"
+	}
+	by := p.UpdatedBy
+	if by == "" {
+		by = "unknown"
+	}
+	return fmt.Sprintf("[gray]Last updated %s by %s[white]", p.UpdatedAt.Local().Format("2006-01-02 15:04"), by)
+}